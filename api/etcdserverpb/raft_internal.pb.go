@@ -73,18 +73,26 @@ var xxx_messageInfo_RequestHeader proto.InternalMessageInfo
 // An InternalRaftRequest is the union of all requests which can be
 // sent via raft.
 type InternalRaftRequest struct {
-	Header                   *RequestHeader                            `protobuf:"bytes,100,opt,name=header,proto3" json:"header,omitempty"`
-	ID                       uint64                                    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	V2                       *Request                                  `protobuf:"bytes,2,opt,name=v2,proto3" json:"v2,omitempty"`
-	Range                    *RangeRequest                             `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
-	Put                      *PutRequest                               `protobuf:"bytes,4,opt,name=put,proto3" json:"put,omitempty"`
-	DeleteRange              *DeleteRangeRequest                       `protobuf:"bytes,5,opt,name=delete_range,json=deleteRange,proto3" json:"delete_range,omitempty"`
-	Txn                      *TxnRequest                               `protobuf:"bytes,6,opt,name=txn,proto3" json:"txn,omitempty"`
-	Compaction               *CompactionRequest                        `protobuf:"bytes,7,opt,name=compaction,proto3" json:"compaction,omitempty"`
-	LeaseGrant               *LeaseGrantRequest                        `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant,proto3" json:"lease_grant,omitempty"`
-	LeaseRevoke              *LeaseRevokeRequest                       `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke,proto3" json:"lease_revoke,omitempty"`
-	Alarm                    *AlarmRequest                             `protobuf:"bytes,10,opt,name=alarm,proto3" json:"alarm,omitempty"`
-	LeaseCheckpoint          *LeaseCheckpointRequest                   `protobuf:"bytes,11,opt,name=lease_checkpoint,json=leaseCheckpoint,proto3" json:"lease_checkpoint,omitempty"`
+	Header           *RequestHeader           `protobuf:"bytes,100,opt,name=header,proto3" json:"header,omitempty"`
+	ID               uint64                   `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	V2               *Request                 `protobuf:"bytes,2,opt,name=v2,proto3" json:"v2,omitempty"`
+	Range            *RangeRequest            `protobuf:"bytes,3,opt,name=range,proto3" json:"range,omitempty"`
+	Put              *PutRequest              `protobuf:"bytes,4,opt,name=put,proto3" json:"put,omitempty"`
+	DeleteRange      *DeleteRangeRequest      `protobuf:"bytes,5,opt,name=delete_range,json=deleteRange,proto3" json:"delete_range,omitempty"`
+	Txn              *TxnRequest              `protobuf:"bytes,6,opt,name=txn,proto3" json:"txn,omitempty"`
+	Compaction       *CompactionRequest       `protobuf:"bytes,7,opt,name=compaction,proto3" json:"compaction,omitempty"`
+	LeaseGrant       *LeaseGrantRequest       `protobuf:"bytes,8,opt,name=lease_grant,json=leaseGrant,proto3" json:"lease_grant,omitempty"`
+	LeaseRevoke      *LeaseRevokeRequest      `protobuf:"bytes,9,opt,name=lease_revoke,json=leaseRevoke,proto3" json:"lease_revoke,omitempty"`
+	Alarm            *AlarmRequest            `protobuf:"bytes,10,opt,name=alarm,proto3" json:"alarm,omitempty"`
+	LeaseCheckpoint  *LeaseCheckpointRequest  `protobuf:"bytes,11,opt,name=lease_checkpoint,json=leaseCheckpoint,proto3" json:"lease_checkpoint,omitempty"`
+	CompactionPolicy *CompactionPolicyRequest `protobuf:"bytes,12,opt,name=compaction_policy,json=compactionPolicy,proto3" json:"compaction_policy,omitempty"`
+	LeaseGroupGrant  *LeaseGroupGrantRequest  `protobuf:"bytes,13,opt,name=lease_group_grant,json=leaseGroupGrant,proto3" json:"lease_group_grant,omitempty"`
+	LeaseGroupRevoke *LeaseGroupRevokeRequest `protobuf:"bytes,14,opt,name=lease_group_revoke,json=leaseGroupRevoke,proto3" json:"lease_group_revoke,omitempty"`
+	LeaseGroupAttach *LeaseGroupAttachRequest `protobuf:"bytes,15,opt,name=lease_group_attach,json=leaseGroupAttach,proto3" json:"lease_group_attach,omitempty"`
+	LeaseGroupDetach *LeaseGroupDetachRequest `protobuf:"bytes,16,opt,name=lease_group_detach,json=leaseGroupDetach,proto3" json:"lease_group_detach,omitempty"`
+	// lease_expire revokes a lease the leader found expired.
+	LeaseExpire              *LeaseRevokeRequest                       `protobuf:"bytes,17,opt,name=lease_expire,json=leaseExpire,proto3" json:"lease_expire,omitempty"`
+	RevisionTime             *RevisionTimeRequest                      `protobuf:"bytes,18,opt,name=revision_time,json=revisionTime,proto3" json:"revision_time,omitempty"`
 	AuthEnable               *AuthEnableRequest                        `protobuf:"bytes,1000,opt,name=auth_enable,json=authEnable,proto3" json:"auth_enable,omitempty"`
 	AuthDisable              *AuthDisableRequest                       `protobuf:"bytes,1011,opt,name=auth_disable,json=authDisable,proto3" json:"auth_disable,omitempty"`
 	AuthStatus               *AuthStatusRequest                        `protobuf:"bytes,1013,opt,name=auth_status,json=authStatus,proto3" json:"auth_status,omitempty"`
@@ -144,6 +152,51 @@ func (m *InternalRaftRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_InternalRaftRequest proto.InternalMessageInfo
 
+// RevisionTimeRequest records the time the leader proposed it at for the
+// revision of the key-value store it is applied at, so that every member
+// resolves a time to the same revision.
+type RevisionTimeRequest struct {
+	// timestamp is the wall-clock time of the leader, in nanoseconds since the
+	// Unix epoch.
+	Timestamp            int64    `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RevisionTimeRequest) Reset()         { *m = RevisionTimeRequest{} }
+func (m *RevisionTimeRequest) String() string { return proto.CompactTextString(m) }
+func (*RevisionTimeRequest) ProtoMessage()    {}
+func (*RevisionTimeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b4c9a9be0cfca103, []int{2}
+}
+func (m *RevisionTimeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *RevisionTimeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_RevisionTimeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *RevisionTimeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RevisionTimeRequest.Merge(m, src)
+}
+func (m *RevisionTimeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *RevisionTimeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RevisionTimeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RevisionTimeRequest proto.InternalMessageInfo
+
 type EmptyResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -154,7 +207,7 @@ func (m *EmptyResponse) Reset()         { *m = EmptyResponse{} }
 func (m *EmptyResponse) String() string { return proto.CompactTextString(m) }
 func (*EmptyResponse) ProtoMessage()    {}
 func (*EmptyResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b4c9a9be0cfca103, []int{2}
+	return fileDescriptor_b4c9a9be0cfca103, []int{3}
 }
 func (m *EmptyResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -200,7 +253,7 @@ func (m *InternalAuthenticateRequest) Reset()         { *m = InternalAuthenticat
 func (m *InternalAuthenticateRequest) String() string { return proto.CompactTextString(m) }
 func (*InternalAuthenticateRequest) ProtoMessage()    {}
 func (*InternalAuthenticateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b4c9a9be0cfca103, []int{3}
+	return fileDescriptor_b4c9a9be0cfca103, []int{4}
 }
 func (m *InternalAuthenticateRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -232,6 +285,7 @@ var xxx_messageInfo_InternalAuthenticateRequest proto.InternalMessageInfo
 func init() {
 	proto.RegisterType((*RequestHeader)(nil), "etcdserverpb.RequestHeader")
 	proto.RegisterType((*InternalRaftRequest)(nil), "etcdserverpb.InternalRaftRequest")
+	proto.RegisterType((*RevisionTimeRequest)(nil), "etcdserverpb.RevisionTimeRequest")
 	proto.RegisterType((*EmptyResponse)(nil), "etcdserverpb.EmptyResponse")
 	proto.RegisterType((*InternalAuthenticateRequest)(nil), "etcdserverpb.InternalAuthenticateRequest")
 }
@@ -239,76 +293,87 @@ func init() {
 func init() { proto.RegisterFile("raft_internal.proto", fileDescriptor_b4c9a9be0cfca103) }
 
 var fileDescriptor_b4c9a9be0cfca103 = []byte{
-	// 1101 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x56, 0xcb, 0x72, 0x1b, 0x45,
-	0x14, 0x8d, 0x6c, 0xc7, 0xb6, 0x5a, 0xb6, 0xe3, 0xb4, 0x9d, 0xa4, 0xb1, 0xab, 0x8c, 0xe3, 0x90,
-	0x60, 0x20, 0xc8, 0xc1, 0x06, 0xaa, 0x60, 0x03, 0x8a, 0xe5, 0x72, 0x4c, 0x25, 0x29, 0xd7, 0xc4,
-	0x50, 0x29, 0x28, 0x6a, 0x68, 0xcd, 0x5c, 0x4b, 0x13, 0x8f, 0x66, 0x86, 0xee, 0x96, 0xe2, 0x6c,
-	0x59, 0xb2, 0x06, 0x8a, 0x8f, 0x60, 0xc1, 0x2b, 0xff, 0x90, 0x05, 0x8f, 0x00, 0x3f, 0x00, 0x66,
-	0xc3, 0x1e, 0xd8, 0xa7, 0xfa, 0x31, 0x2f, 0xa9, 0xe5, 0xdd, 0xe8, 0xde, 0x73, 0xcf, 0x39, 0xdd,
-	0x7d, 0xbb, 0x75, 0xd1, 0x02, 0xa3, 0x87, 0xc2, 0x0d, 0x22, 0x01, 0x2c, 0xa2, 0x61, 0x3d, 0x61,
-	0xb1, 0x88, 0xf1, 0x0c, 0x08, 0xcf, 0xe7, 0xc0, 0xfa, 0xc0, 0x92, 0xd6, 0xd2, 0x62, 0x3b, 0x6e,
-	0xc7, 0x2a, 0xb1, 0x21, 0xbf, 0x34, 0x66, 0x69, 0x3e, 0xc7, 0x98, 0x48, 0x95, 0x25, 0x9e, 0xf9,
-	0x5c, 0x95, 0xc9, 0x0d, 0x9a, 0x04, 0x1b, 0x7d, 0x60, 0x3c, 0x88, 0xa3, 0xa4, 0x95, 0x7e, 0x19,
-	0xc4, 0xb5, 0x0c, 0xd1, 0x85, 0x6e, 0x0b, 0x18, 0xef, 0x04, 0x49, 0xd2, 0x2a, 0xfc, 0xd0, 0xb8,
-	0x35, 0x86, 0x66, 0x1d, 0xf8, 0xb4, 0x07, 0x5c, 0xdc, 0x02, 0xea, 0x03, 0xc3, 0x73, 0x68, 0x6c,
-	0xaf, 0x49, 0x2a, 0xab, 0x95, 0xf5, 0x09, 0x67, 0x6c, 0xaf, 0x89, 0x97, 0xd0, 0x74, 0x8f, 0x4b,
-	0xf3, 0x5d, 0x20, 0x63, 0xab, 0x95, 0xf5, 0xaa, 0x93, 0xfd, 0xc6, 0xd7, 0xd1, 0x2c, 0xed, 0x89,
-	0x8e, 0xcb, 0xa0, 0x1f, 0x48, 0x6d, 0x32, 0x2e, 0xcb, 0x6e, 0x4e, 0x7d, 0xfe, 0x98, 0x8c, 0x6f,
-	0xd5, 0x5f, 0x73, 0x66, 0x64, 0xd6, 0x31, 0xc9, 0xb7, 0xa7, 0x3e, 0x53, 0xe1, 0x1b, 0x6b, 0x8f,
-	0x17, 0xd0, 0xc2, 0x9e, 0xd9, 0x11, 0x87, 0x1e, 0x0a, 0x63, 0x00, 0x6f, 0xa1, 0xc9, 0x8e, 0x32,
-	0x41, 0xfc, 0xd5, 0xca, 0x7a, 0x6d, 0x73, 0xb9, 0x5e, 0xdc, 0xa7, 0x7a, 0xc9, 0xa7, 0x63, 0xa0,
-	0x43, 0x7e, 0xaf, 0xa2, 0xb1, 0xfe, 0xa6, 0x72, 0x5a, 0xdb, 0xbc, 0x60, 0x25, 0x70, 0xc6, 0xfa,
-	0x9b, 0xf8, 0x06, 0x3a, 0xcb, 0x68, 0xd4, 0x06, 0x65, 0xb9, 0xb6, 0xb9, 0x34, 0x80, 0x94, 0xa9,
-	0x14, 0xae, 0x81, 0xf8, 0x65, 0x34, 0x9e, 0xf4, 0x04, 0x99, 0x50, 0x78, 0x52, 0xc6, 0xef, 0xf7,
-	0xd2, 0x45, 0x38, 0x12, 0x84, 0xb7, 0xd1, 0x8c, 0x0f, 0x21, 0x08, 0x70, 0xb5, 0xc8, 0x59, 0x55,
-	0xb4, 0x5a, 0x2e, 0x6a, 0x2a, 0x44, 0x49, 0xaa, 0xe6, 0xe7, 0x31, 0x29, 0x28, 0x8e, 0x23, 0x32,
-	0x69, 0x13, 0x3c, 0x38, 0x8e, 0x32, 0x41, 0x71, 0x1c, 0xe1, 0x77, 0x10, 0xf2, 0xe2, 0x6e, 0x42,
-	0x3d, 0x21, 0x8f, 0x61, 0x4a, 0x95, 0x3c, 0x5f, 0x2e, 0xd9, 0xce, 0xf2, 0x69, 0x65, 0xa1, 0x04,
-	0xbf, 0x8b, 0x6a, 0x21, 0x50, 0x0e, 0x6e, 0x9b, 0xd1, 0x48, 0x90, 0x69, 0x1b, 0xc3, 0x6d, 0x09,
-	0xd8, 0x95, 0xf9, 0x8c, 0x21, 0xcc, 0x42, 0x72, 0xcd, 0x9a, 0x81, 0x41, 0x3f, 0x3e, 0x02, 0x52,
-	0xb5, 0xad, 0x59, 0x51, 0x38, 0x0a, 0x90, 0xad, 0x39, 0xcc, 0x63, 0xf2, 0x58, 0x68, 0x48, 0x59,
-	0x97, 0x20, 0xdb, 0xb1, 0x34, 0x64, 0x2a, 0x3b, 0x16, 0x05, 0xc4, 0xf7, 0xd1, 0xbc, 0x96, 0xf5,
-	0x3a, 0xe0, 0x1d, 0x25, 0x71, 0x10, 0x09, 0x52, 0x53, 0xc5, 0x2f, 0x58, 0xa4, 0xb7, 0x33, 0x90,
-	0xa1, 0x49, 0x9b, 0xf5, 0x75, 0xe7, 0x5c, 0x58, 0x06, 0xe0, 0x06, 0xaa, 0xa9, 0xee, 0x86, 0x88,
-	0xb6, 0x42, 0x20, 0xff, 0x58, 0x77, 0xb5, 0xd1, 0x13, 0x9d, 0x1d, 0x05, 0xc8, 0xf6, 0x84, 0x66,
-	0x21, 0xdc, 0x44, 0xea, 0x0a, 0xb8, 0x7e, 0xc0, 0x15, 0xc7, 0xbf, 0x53, 0xb6, 0x4d, 0x91, 0x1c,
-	0x4d, 0x8d, 0xc8, 0x36, 0x85, 0xe6, 0x31, 0xfc, 0x9e, 0x31, 0xc2, 0x05, 0x15, 0x3d, 0x4e, 0xfe,
-	0x1f, 0x69, 0xe4, 0x9e, 0x02, 0x0c, 0xac, 0xec, 0x0d, 0xed, 0x48, 0xe7, 0xf0, 0x5d, 0xed, 0x08,
-	0x22, 0x11, 0x78, 0x54, 0x00, 0xf9, 0x4f, 0x93, 0xbd, 0x54, 0x26, 0x4b, 0x6f, 0x67, 0xa3, 0x00,
-	0x4d, 0xad, 0x95, 0xea, 0xf1, 0x8e, 0x79, 0x02, 0xe4, 0x9b, 0xe0, 0x52, 0xdf, 0x27, 0x3f, 0x4d,
-	0x8f, 0x5a, 0xe2, 0xfb, 0x1c, 0x58, 0xc3, 0xf7, 0x4b, 0x4b, 0x34, 0x31, 0x7c, 0x17, 0xcd, 0xe7,
-	0x34, 0xfa, 0x12, 0x90, 0x9f, 0x35, 0xd3, 0x15, 0x3b, 0x93, 0xb9, 0x3d, 0x86, 0x6c, 0x8e, 0x96,
-	0xc2, 0x65, 0x5b, 0x6d, 0x10, 0xe4, 0x97, 0x53, 0x6d, 0xed, 0x82, 0x18, 0xb2, 0xb5, 0x0b, 0x02,
-	0xb7, 0xd1, 0x73, 0x39, 0x8d, 0xd7, 0x91, 0xd7, 0xd2, 0x4d, 0x28, 0xe7, 0x0f, 0x63, 0xe6, 0x93,
-	0x5f, 0x35, 0xe5, 0x2b, 0x76, 0xca, 0x6d, 0x85, 0xde, 0x37, 0xe0, 0x94, 0xfd, 0x22, 0xb5, 0xa6,
-	0xf1, 0x7d, 0xb4, 0x58, 0xf0, 0x2b, 0xef, 0x93, 0xcb, 0xe2, 0x10, 0xc8, 0x53, 0xad, 0x71, 0x6d,
-	0x84, 0x6d, 0x75, 0x17, 0xe3, 0xbc, 0x6d, 0xce, 0xd3, 0xc1, 0x0c, 0xfe, 0x08, 0x5d, 0xc8, 0x99,
-	0xf5, 0xd5, 0xd4, 0xd4, 0xbf, 0x69, 0xea, 0x17, 0xed, 0xd4, 0xe6, 0x8e, 0x16, 0xb8, 0x31, 0x1d,
-	0x4a, 0xe1, 0x5b, 0x68, 0x2e, 0x27, 0x0f, 0x03, 0x2e, 0xc8, 0xef, 0x9a, 0xf5, 0xb2, 0x9d, 0xf5,
-	0x76, 0xc0, 0x45, 0xa9, 0x8f, 0xd2, 0x60, 0xc6, 0x24, 0xad, 0x69, 0xa6, 0x3f, 0x46, 0x32, 0x49,
-	0xe9, 0x21, 0xa6, 0x34, 0x98, 0x1d, 0xbd, 0x62, 0x92, 0x1d, 0xf9, 0x6d, 0x75, 0xd4, 0xd1, 0xcb,
-	0x9a, 0xc1, 0x8e, 0x34, 0xb1, 0xac, 0x23, 0x15, 0x8d, 0xe9, 0xc8, 0xef, 0xaa, 0xa3, 0x3a, 0x52,
-	0x56, 0x59, 0x3a, 0x32, 0x0f, 0x97, 0x6d, 0xc9, 0x8e, 0xfc, 0xfe, 0x54, 0x5b, 0x83, 0x1d, 0x69,
-	0x62, 0xf8, 0x01, 0x5a, 0x2a, 0xd0, 0xa8, 0x46, 0x49, 0x80, 0x75, 0x03, 0xae, 0xfe, 0x7f, 0x7f,
-	0xd0, 0x9c, 0xd7, 0x47, 0x70, 0x4a, 0xf8, 0x7e, 0x86, 0x4e, 0xf9, 0x2f, 0x51, 0x7b, 0x1e, 0x77,
-	0xd1, 0x72, 0xae, 0x65, 0x5a, 0xa7, 0x20, 0xf6, 0xa3, 0x16, 0x7b, 0xd5, 0x2e, 0xa6, 0xbb, 0x64,
-	0x58, 0x8d, 0xd0, 0x11, 0x00, 0xfc, 0x09, 0x5a, 0xf0, 0xc2, 0x1e, 0x17, 0xc0, 0x5c, 0x33, 0xcb,
-	0xb8, 0x1c, 0x04, 0xf9, 0x02, 0x99, 0x2b, 0x50, 0x1c, 0x64, 0xea, 0xdb, 0x1a, 0xf9, 0x81, 0x06,
-	0xde, 0x03, 0x31, 0xf4, 0xea, 0x9d, 0xf7, 0x06, 0x21, 0xf8, 0x01, 0xba, 0x94, 0x2a, 0x68, 0x32,
-	0x97, 0x0a, 0xc1, 0x94, 0xca, 0x97, 0xc8, 0xbc, 0x83, 0x36, 0x95, 0x3b, 0x2a, 0xd6, 0x10, 0x82,
-	0xd9, 0x84, 0x16, 0x3d, 0x0b, 0x0a, 0x7f, 0x8c, 0xb0, 0x1f, 0x3f, 0x8c, 0xda, 0x8c, 0xfa, 0xe0,
-	0x06, 0xd1, 0x61, 0xac, 0x64, 0xbe, 0xd2, 0x32, 0x57, 0xcb, 0x32, 0xcd, 0x14, 0xb8, 0x17, 0x1d,
-	0xc6, 0x36, 0x89, 0x79, 0x7f, 0x00, 0x81, 0x03, 0x74, 0x31, 0xa7, 0x4f, 0xb7, 0x4b, 0x00, 0x17,
-	0xe4, 0x9b, 0x3b, 0xb6, 0x17, 0x3d, 0x93, 0x30, 0xdb, 0x71, 0x00, 0x7c, 0x50, 0xe6, 0x4d, 0x67,
-	0xd1, 0xb7, 0xa0, 0xf2, 0xb9, 0xed, 0x1c, 0x9a, 0xdd, 0xe9, 0x26, 0xe2, 0x91, 0x03, 0x3c, 0x89,
-	0x23, 0x0e, 0x6b, 0x8f, 0xd0, 0xf2, 0x29, 0xff, 0x14, 0x18, 0xa3, 0x09, 0x35, 0x36, 0x56, 0xd4,
-	0xd8, 0xa8, 0xbe, 0xe5, 0x38, 0x99, 0x3d, 0xa0, 0x66, 0x9c, 0x4c, 0x7f, 0xe3, 0xcb, 0x68, 0x86,
-	0x07, 0xdd, 0x24, 0x04, 0x57, 0xc4, 0x47, 0xa0, 0xa7, 0xc9, 0xaa, 0x53, 0xd3, 0xb1, 0x03, 0x19,
-	0xca, 0xbc, 0xdc, 0x7c, 0xeb, 0xc9, 0x5f, 0x2b, 0x67, 0x9e, 0x9c, 0xac, 0x54, 0x9e, 0x9e, 0xac,
-	0x54, 0xfe, 0x3c, 0x59, 0xa9, 0x7c, 0xfd, 0xf7, 0xca, 0x99, 0x0f, 0xaf, 0xb4, 0x63, 0xb5, 0xec,
-	0x7a, 0x10, 0x6f, 0xe4, 0x23, 0xf2, 0xd6, 0x46, 0x71, 0x2b, 0x5a, 0x93, 0x6a, 0xf2, 0xdd, 0x7a,
-	0x16, 0x00, 0x00, 0xff, 0xff, 0xb2, 0xa0, 0x15, 0x1f, 0x9b, 0x0b, 0x00, 0x00,
+	// 1278 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x97, 0xcb, 0x72, 0x1c, 0x35,
+	0x17, 0xc7, 0x33, 0x76, 0x12, 0x7b, 0x34, 0xbe, 0xca, 0x4e, 0xa2, 0xcf, 0xf9, 0xca, 0x38, 0x0e,
+	0x09, 0x01, 0x82, 0x1d, 0x6c, 0x20, 0x05, 0x45, 0x15, 0x4c, 0x3c, 0x2e, 0xc7, 0x54, 0x12, 0x5c,
+	0x1d, 0x43, 0x05, 0x02, 0xd5, 0x68, 0xba, 0x8f, 0x67, 0x3a, 0xe9, 0x1b, 0x6a, 0xcd, 0xc4, 0xd9,
+	0xb2, 0x64, 0x0d, 0x14, 0x4f, 0xc0, 0x8a, 0x05, 0xd7, 0x77, 0xc8, 0x82, 0x4b, 0x80, 0x17, 0x80,
+	0xb0, 0x61, 0x0f, 0xec, 0x29, 0x5d, 0xfa, 0x3a, 0x9a, 0x81, 0x5d, 0xf7, 0x39, 0x7f, 0xfd, 0xce,
+	0x91, 0x74, 0xfa, 0xa8, 0x85, 0x16, 0x18, 0x3d, 0xe0, 0xb6, 0x17, 0x72, 0x60, 0x21, 0xf5, 0xd7,
+	0x62, 0x16, 0xf1, 0x08, 0x4f, 0x01, 0x77, 0xdc, 0x04, 0x58, 0x1f, 0x58, 0xdc, 0x5e, 0x5a, 0xec,
+	0x44, 0x9d, 0x48, 0x3a, 0xd6, 0xc5, 0x93, 0xd2, 0x2c, 0xcd, 0xe5, 0x1a, 0x6d, 0xa9, 0xb3, 0xd8,
+	0xd1, 0x8f, 0x2b, 0xc2, 0xb9, 0x4e, 0x63, 0x6f, 0xbd, 0x0f, 0x2c, 0xf1, 0xa2, 0x30, 0x6e, 0xa7,
+	0x4f, 0x5a, 0x71, 0x3e, 0x53, 0x04, 0x10, 0xb4, 0x81, 0x25, 0x5d, 0x2f, 0x8e, 0xdb, 0x85, 0x17,
+	0xa5, 0x5b, 0x65, 0x68, 0xda, 0x82, 0xf7, 0x7b, 0x90, 0xf0, 0xab, 0x40, 0x5d, 0x60, 0x78, 0x06,
+	0x8d, 0xed, 0xb6, 0x48, 0x6d, 0xa5, 0x76, 0xe1, 0xa8, 0x35, 0xb6, 0xdb, 0xc2, 0x4b, 0x68, 0xb2,
+	0x97, 0x88, 0xe4, 0x03, 0x20, 0x63, 0x2b, 0xb5, 0x0b, 0x75, 0x2b, 0x7b, 0xc7, 0x17, 0xd1, 0x34,
+	0xed, 0xf1, 0xae, 0xcd, 0xa0, 0xef, 0x89, 0xd8, 0x64, 0x5c, 0x0c, 0xbb, 0x32, 0xf1, 0xe1, 0xb7,
+	0x64, 0x7c, 0x73, 0xed, 0x59, 0x6b, 0x4a, 0x78, 0x2d, 0xed, 0x7c, 0x69, 0xe2, 0x03, 0x69, 0xbe,
+	0xb4, 0xfa, 0x19, 0x41, 0x0b, 0xbb, 0x7a, 0x45, 0x2c, 0x7a, 0xc0, 0x75, 0x02, 0x78, 0x13, 0x1d,
+	0xef, 0xca, 0x24, 0x88, 0xbb, 0x52, 0xbb, 0xd0, 0xd8, 0x38, 0xbd, 0x56, 0x5c, 0xa7, 0xb5, 0x52,
+	0x9e, 0x96, 0x96, 0x0e, 0xe4, 0x7b, 0x0e, 0x8d, 0xf5, 0x37, 0x64, 0xa6, 0x8d, 0x8d, 0x13, 0x46,
+	0x80, 0x35, 0xd6, 0xdf, 0xc0, 0x97, 0xd0, 0x31, 0x46, 0xc3, 0x0e, 0xc8, 0x94, 0x1b, 0x1b, 0x4b,
+	0x15, 0xa5, 0x70, 0xa5, 0x72, 0x25, 0xc4, 0x4f, 0xa1, 0xf1, 0xb8, 0xc7, 0xc9, 0x51, 0xa9, 0x27,
+	0x65, 0xfd, 0x5e, 0x2f, 0x9d, 0x84, 0x25, 0x44, 0x78, 0x0b, 0x4d, 0xb9, 0xe0, 0x03, 0x07, 0x5b,
+	0x05, 0x39, 0x26, 0x07, 0xad, 0x94, 0x07, 0xb5, 0xa4, 0xa2, 0x14, 0xaa, 0xe1, 0xe6, 0x36, 0x11,
+	0x90, 0x1f, 0x86, 0xe4, 0xb8, 0x29, 0xe0, 0xfe, 0x61, 0x98, 0x05, 0xe4, 0x87, 0x21, 0x7e, 0x05,
+	0x21, 0x27, 0x0a, 0x62, 0xea, 0x70, 0xb1, 0x0d, 0x13, 0x72, 0xc8, 0x63, 0xe5, 0x21, 0x5b, 0x99,
+	0x3f, 0x1d, 0x59, 0x18, 0x82, 0x5f, 0x45, 0x0d, 0x1f, 0x68, 0x02, 0x76, 0x87, 0xd1, 0x90, 0x93,
+	0x49, 0x13, 0xe1, 0x9a, 0x10, 0xec, 0x08, 0x7f, 0x46, 0xf0, 0x33, 0x93, 0x98, 0xb3, 0x22, 0x30,
+	0xe8, 0x47, 0x77, 0x81, 0xd4, 0x4d, 0x73, 0x96, 0x08, 0x4b, 0x0a, 0xb2, 0x39, 0xfb, 0xb9, 0x4d,
+	0x6c, 0x0b, 0xf5, 0x29, 0x0b, 0x08, 0x32, 0x6d, 0x4b, 0x53, 0xb8, 0xb2, 0x6d, 0x91, 0x42, 0x7c,
+	0x0b, 0xcd, 0xa9, 0xb0, 0x4e, 0x17, 0x9c, 0xbb, 0x71, 0xe4, 0x85, 0x9c, 0x34, 0xe4, 0xe0, 0xc7,
+	0x0d, 0xa1, 0xb7, 0x32, 0x91, 0xc6, 0xa4, 0xc5, 0xfa, 0x9c, 0x35, 0xeb, 0x97, 0x05, 0xf8, 0x36,
+	0x9a, 0xcf, 0x17, 0xc8, 0x8e, 0x23, 0xdf, 0x73, 0xee, 0x93, 0x29, 0x89, 0x3e, 0x37, 0x6c, 0x69,
+	0xf7, 0xa4, 0xaa, 0xc2, 0xbe, 0x6c, 0xcd, 0x39, 0x15, 0x05, 0x7e, 0x0b, 0xcd, 0xa7, 0xeb, 0x1d,
+	0xf5, 0x62, 0xbd, 0xea, 0xd3, 0x43, 0xf3, 0xde, 0x11, 0xaa, 0xe2, 0xd2, 0xe7, 0xec, 0x59, 0xbf,
+	0x2c, 0xc0, 0xef, 0x20, 0x5c, 0x44, 0xeb, 0xed, 0x98, 0x31, 0x25, 0x9e, 0xb3, 0x4b, 0x7b, 0x52,
+	0x48, 0xdc, 0xaf, 0x28, 0xaa, 0x74, 0xca, 0x39, 0x75, 0xba, 0x64, 0x76, 0x34, 0xbd, 0x29, 0x55,
+	0xa3, 0xe8, 0x4a, 0x51, 0xa5, 0xbb, 0x20, 0xe9, 0x73, 0xa3, 0xe9, 0x2d, 0xf8, 0x37, 0xba, 0x52,
+	0xe0, 0x6b, 0x69, 0x89, 0xc2, 0x61, 0xec, 0x31, 0x20, 0xf3, 0xff, 0xad, 0x44, 0x73, 0xa4, 0xaa,
+	0xd5, 0x6d, 0x39, 0x1a, 0xbf, 0x8e, 0xa6, 0xd3, 0xc6, 0x67, 0x73, 0x2f, 0x00, 0x82, 0x25, 0xee,
+	0x4c, 0xb5, 0xe9, 0x28, 0xc9, 0xbe, 0x17, 0x0c, 0xf2, 0xa6, 0x58, 0xc1, 0x8b, 0x9b, 0xa8, 0x21,
+	0xdb, 0x29, 0x84, 0xb4, 0xed, 0x03, 0xf9, 0xc3, 0xf8, 0x19, 0x37, 0x7b, 0xbc, 0xbb, 0x2d, 0x05,
+	0xd9, 0x47, 0x48, 0x33, 0x13, 0x6e, 0x21, 0xd9, 0x73, 0x6d, 0xd7, 0x4b, 0x24, 0xe3, 0xcf, 0x09,
+	0xd3, 0x14, 0x05, 0xa3, 0xa5, 0x14, 0xd9, 0x57, 0x48, 0x73, 0x1b, 0x7e, 0x4d, 0x27, 0x92, 0x70,
+	0xca, 0x7b, 0x09, 0xf9, 0x7b, 0x68, 0x22, 0x37, 0xa5, 0xa0, 0x32, 0xad, 0xe7, 0x55, 0x46, 0xca,
+	0x87, 0x6f, 0xa8, 0x8c, 0x20, 0xe4, 0x9e, 0x43, 0x39, 0x90, 0xbf, 0x14, 0xec, 0xc9, 0x32, 0x2c,
+	0x3d, 0x0e, 0x9a, 0x05, 0x69, 0x9a, 0x5a, 0x69, 0x3c, 0xde, 0xd6, 0x67, 0x8e, 0x38, 0x84, 0x6c,
+	0xea, 0xba, 0xe4, 0xbb, 0xc9, 0x61, 0x53, 0x7c, 0x23, 0x01, 0xd6, 0x74, 0xdd, 0xd2, 0x14, 0xb5,
+	0x0d, 0xdf, 0x40, 0x73, 0x39, 0x46, 0x75, 0x5d, 0xf2, 0xbd, 0x22, 0x9d, 0x35, 0x93, 0x74, 0xbb,
+	0xd6, 0xb0, 0x19, 0x5a, 0x32, 0x97, 0xd3, 0xea, 0x00, 0x27, 0x3f, 0x8c, 0x4c, 0x6b, 0x07, 0xf8,
+	0x40, 0x5a, 0x3b, 0xc0, 0x71, 0x07, 0xfd, 0x2f, 0xc7, 0x38, 0x5d, 0x71, 0x0e, 0xd8, 0x31, 0x4d,
+	0x92, 0x7b, 0x11, 0x73, 0xc9, 0x8f, 0x0a, 0xf9, 0xb4, 0x19, 0xb9, 0x25, 0xd5, 0x7b, 0x5a, 0x9c,
+	0xd2, 0x4f, 0x52, 0xa3, 0x1b, 0xdf, 0x42, 0x8b, 0x85, 0x7c, 0x45, 0xdf, 0xb0, 0x59, 0xe4, 0x03,
+	0x79, 0xa8, 0x62, 0x9c, 0x1f, 0x92, 0xb6, 0xec, 0x40, 0x51, 0x5e, 0x36, 0xf3, 0xb4, 0xea, 0xc1,
+	0xb7, 0xd1, 0x89, 0x9c, 0xac, 0x9a, 0x8f, 0x42, 0xff, 0xa4, 0xd0, 0x4f, 0x98, 0xd1, 0xfa, 0x8b,
+	0x2b, 0xb0, 0x31, 0x1d, 0x70, 0xe1, 0xab, 0x68, 0x26, 0x87, 0xfb, 0x5e, 0xc2, 0xc9, 0xcf, 0x93,
+	0xa6, 0xaf, 0x2e, 0xa5, 0x5e, 0xf3, 0x12, 0x5e, 0xaa, 0xa3, 0xd4, 0x98, 0x91, 0x44, 0x6a, 0x8a,
+	0xf4, 0xcb, 0x50, 0x92, 0x08, 0x3d, 0x40, 0x4a, 0x8d, 0xd9, 0xd6, 0x4b, 0x92, 0xa8, 0xc8, 0x2f,
+	0xea, 0xc3, 0xb6, 0x5e, 0x8c, 0xa9, 0x56, 0xa4, 0xb6, 0x65, 0x15, 0x29, 0x31, 0xba, 0x22, 0xbf,
+	0xac, 0x0f, 0xab, 0x48, 0x31, 0xca, 0x50, 0x91, 0xb9, 0xb9, 0x9c, 0x96, 0xa8, 0xc8, 0xaf, 0x46,
+	0xa6, 0x55, 0xad, 0x48, 0x6d, 0xc3, 0x77, 0xd0, 0x52, 0x01, 0x23, 0x0b, 0x25, 0x06, 0x16, 0x78,
+	0x89, 0xfc, 0xe1, 0xfb, 0x5a, 0x31, 0x2f, 0x0e, 0x61, 0x0a, 0xf9, 0x5e, 0xa6, 0x4e, 0xf9, 0xa7,
+	0xa8, 0xd9, 0x8f, 0x03, 0x74, 0x3a, 0x8f, 0xa5, 0x4b, 0xa7, 0x10, 0xec, 0x1b, 0x15, 0xec, 0x19,
+	0x73, 0x30, 0x55, 0x25, 0x83, 0xd1, 0x08, 0x1d, 0x22, 0xc0, 0xef, 0xa1, 0x05, 0xc7, 0xef, 0x25,
+	0x1c, 0x98, 0xad, 0x7f, 0x9e, 0xed, 0x04, 0x38, 0xf9, 0x08, 0xe9, 0x4f, 0xa0, 0xf8, 0xe7, 0xbc,
+	0xb6, 0xa5, 0x94, 0x6f, 0x2a, 0xe1, 0x4d, 0xe0, 0x03, 0x5d, 0x6f, 0xde, 0xa9, 0x4a, 0xf0, 0x1d,
+	0x74, 0x2a, 0x8d, 0xa0, 0x60, 0xe2, 0xbc, 0x64, 0x32, 0xca, 0xc7, 0x48, 0xf7, 0x41, 0x53, 0x94,
+	0xeb, 0xd2, 0xd6, 0xe4, 0x9c, 0x99, 0x02, 0x2d, 0x3a, 0x06, 0x15, 0x7e, 0x17, 0x61, 0x37, 0xba,
+	0x17, 0x76, 0x18, 0x75, 0xc1, 0xf6, 0xc2, 0x83, 0x48, 0x86, 0xf9, 0x04, 0xe9, 0xb3, 0xb3, 0x14,
+	0xa6, 0x95, 0x0a, 0x77, 0xc3, 0x83, 0xc8, 0x14, 0x62, 0xce, 0xad, 0x28, 0xb0, 0x87, 0x4e, 0xe6,
+	0xf8, 0x74, 0xb9, 0x38, 0x24, 0x9c, 0x7c, 0x7e, 0xdd, 0xd4, 0xd1, 0xb3, 0x10, 0x7a, 0x39, 0xf6,
+	0x21, 0xa9, 0x86, 0x79, 0xc1, 0x5a, 0x74, 0x0d, 0xaa, 0xfc, 0xa2, 0xf0, 0x32, 0x5a, 0x30, 0x1c,
+	0x9f, 0xf8, 0xff, 0xa8, 0x2e, 0xce, 0xdb, 0x84, 0xd3, 0x20, 0x96, 0x7f, 0xfe, 0xe3, 0x56, 0x6e,
+	0x48, 0x47, 0x5f, 0x5e, 0x9d, 0x45, 0xd3, 0xdb, 0x41, 0xcc, 0xef, 0x5b, 0x90, 0xc4, 0x51, 0x98,
+	0xc0, 0xea, 0x7d, 0x74, 0x7a, 0xc4, 0x39, 0x83, 0x31, 0x3a, 0x2a, 0x6f, 0x39, 0x35, 0x79, 0xcb,
+	0x91, 0xcf, 0xe2, 0xf6, 0x93, 0xb5, 0x5f, 0x7d, 0xfb, 0x49, 0xdf, 0xf1, 0x19, 0x34, 0x95, 0x78,
+	0x41, 0xec, 0x83, 0xcd, 0xa3, 0xbb, 0xa0, 0x2e, 0x3f, 0x75, 0xab, 0xa1, 0x6c, 0xfb, 0xc2, 0x94,
+	0xcd, 0xe4, 0xca, 0x8b, 0x0f, 0x7e, 0x5b, 0x3e, 0xf2, 0xe0, 0xd1, 0x72, 0xed, 0xe1, 0xa3, 0xe5,
+	0xda, 0xaf, 0x8f, 0x96, 0x6b, 0x9f, 0xfe, 0xbe, 0x7c, 0xe4, 0xed, 0xb3, 0x9d, 0x48, 0x2e, 0xda,
+	0x9a, 0x17, 0xad, 0xe7, 0x37, 0xba, 0xcd, 0xf5, 0xe2, 0x42, 0xb6, 0x8f, 0xcb, 0x8b, 0xda, 0xe6,
+	0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0x0c, 0xdd, 0xf7, 0x73, 0x4a, 0x0e, 0x00, 0x00,
 }
 
 func (m *RequestHeader) Marshal() (dAtA []byte, err error) {
@@ -689,6 +754,96 @@ func (m *InternalRaftRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i--
 		dAtA[i] = 0xa2
 	}
+	if m.RevisionTime != nil {
+		{
+			size, err := m.RevisionTime.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x92
+	}
+	if m.LeaseExpire != nil {
+		{
+			size, err := m.LeaseExpire.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
+	}
+	if m.LeaseGroupDetach != nil {
+		{
+			size, err := m.LeaseGroupDetach.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	if m.LeaseGroupAttach != nil {
+		{
+			size, err := m.LeaseGroupAttach.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x7a
+	}
+	if m.LeaseGroupRevoke != nil {
+		{
+			size, err := m.LeaseGroupRevoke.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.LeaseGroupGrant != nil {
+		{
+			size, err := m.LeaseGroupGrant.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.CompactionPolicy != nil {
+		{
+			size, err := m.CompactionPolicy.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintRaftInternal(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x62
+	}
 	if m.LeaseCheckpoint != nil {
 		{
 			size, err := m.LeaseCheckpoint.MarshalToSizedBuffer(dAtA[:i])
@@ -817,6 +972,38 @@ func (m *InternalRaftRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *RevisionTimeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RevisionTimeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RevisionTimeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Timestamp != 0 {
+		i = encodeVarintRaftInternal(dAtA, i, uint64(m.Timestamp))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *EmptyResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -974,6 +1161,34 @@ func (m *InternalRaftRequest) Size() (n int) {
 		l = m.LeaseCheckpoint.Size()
 		n += 1 + l + sovRaftInternal(uint64(l))
 	}
+	if m.CompactionPolicy != nil {
+		l = m.CompactionPolicy.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseGroupGrant != nil {
+		l = m.LeaseGroupGrant.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseGroupRevoke != nil {
+		l = m.LeaseGroupRevoke.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseGroupAttach != nil {
+		l = m.LeaseGroupAttach.Size()
+		n += 1 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseGroupDetach != nil {
+		l = m.LeaseGroupDetach.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
+	if m.LeaseExpire != nil {
+		l = m.LeaseExpire.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
+	if m.RevisionTime != nil {
+		l = m.RevisionTime.Size()
+		n += 2 + l + sovRaftInternal(uint64(l))
+	}
 	if m.Header != nil {
 		l = m.Header.Size()
 		n += 2 + l + sovRaftInternal(uint64(l))
@@ -1068,6 +1283,21 @@ func (m *InternalRaftRequest) Size() (n int) {
 	return n
 }
 
+func (m *RevisionTimeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Timestamp != 0 {
+		n += 1 + sovRaftInternal(uint64(m.Timestamp))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *EmptyResponse) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1639,6 +1869,258 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompactionPolicy", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.CompactionPolicy == nil {
+				m.CompactionPolicy = &CompactionPolicyRequest{}
+			}
+			if err := m.CompactionPolicy.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseGroupGrant", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseGroupGrant == nil {
+				m.LeaseGroupGrant = &LeaseGroupGrantRequest{}
+			}
+			if err := m.LeaseGroupGrant.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseGroupRevoke", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseGroupRevoke == nil {
+				m.LeaseGroupRevoke = &LeaseGroupRevokeRequest{}
+			}
+			if err := m.LeaseGroupRevoke.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseGroupAttach", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseGroupAttach == nil {
+				m.LeaseGroupAttach = &LeaseGroupAttachRequest{}
+			}
+			if err := m.LeaseGroupAttach.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 16:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseGroupDetach", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseGroupDetach == nil {
+				m.LeaseGroupDetach = &LeaseGroupDetachRequest{}
+			}
+			if err := m.LeaseGroupDetach.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseExpire", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LeaseExpire == nil {
+				m.LeaseExpire = &LeaseRevokeRequest{}
+			}
+			if err := m.LeaseExpire.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 18:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevisionTime", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.RevisionTime == nil {
+				m.RevisionTime = &RevisionTimeRequest{}
+			}
+			if err := m.RevisionTime.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		case 100:
 			if wireType != 2 {
 				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
@@ -2453,6 +2935,76 @@ func (m *InternalRaftRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *RevisionTimeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRaftInternal
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: RevisionTimeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: RevisionTimeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Timestamp", wireType)
+			}
+			m.Timestamp = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRaftInternal
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Timestamp |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRaftInternal(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRaftInternal
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func (m *EmptyResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
@@ -71,8 +71,12 @@ var xxx_messageInfo_RaftAttributes proto.InternalMessageInfo
 
 // Attributes represents all the non-raft related attributes of an etcd member.
 type Attributes struct {
-	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	ClientUrls           []string `protobuf:"bytes,2,rep,name=client_urls,json=clientUrls,proto3" json:"client_urls,omitempty"`
+	Name       string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ClientUrls []string `protobuf:"bytes,2,rep,name=client_urls,json=clientUrls,proto3" json:"client_urls,omitempty"`
+	// labels are key/value pairs describing the member, such as its zone or rack.
+	Labels map[string]string `protobuf:"bytes,3,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// draining indicates if the member is being drained for maintenance.
+	Draining             bool     `protobuf:"varint,4,opt,name=draining,proto3" json:"draining,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -278,6 +282,7 @@ var xxx_messageInfo_DowngradeInfoSetRequest proto.InternalMessageInfo
 func init() {
 	proto.RegisterType((*RaftAttributes)(nil), "membershippb.RaftAttributes")
 	proto.RegisterType((*Attributes)(nil), "membershippb.Attributes")
+	proto.RegisterMapType((map[string]string)(nil), "membershippb.Attributes.LabelsEntry")
 	proto.RegisterType((*Member)(nil), "membershippb.Member")
 	proto.RegisterType((*ClusterVersionSetRequest)(nil), "membershippb.ClusterVersionSetRequest")
 	proto.RegisterType((*ClusterMemberAttrSetRequest)(nil), "membershippb.ClusterMemberAttrSetRequest")
@@ -287,34 +292,39 @@ func init() {
 func init() { proto.RegisterFile("membership.proto", fileDescriptor_949fe0d019050ef5) }
 
 var fileDescriptor_949fe0d019050ef5 = []byte{
-	// 422 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x52, 0xc1, 0x6e, 0xd3, 0x40,
-	0x10, 0xed, 0x3a, 0x55, 0x6b, 0x4f, 0x51, 0x28, 0x2b, 0x24, 0xac, 0x06, 0x8c, 0x55, 0x2e, 0x39,
-	0xd9, 0x12, 0x51, 0x0f, 0x70, 0x03, 0xd2, 0x43, 0x10, 0xe5, 0xb0, 0xa8, 0x1c, 0xb8, 0x44, 0xeb,
-	0x66, 0x62, 0x56, 0x72, 0xbc, 0x66, 0x77, 0x5d, 0xee, 0x1c, 0xf9, 0x02, 0xfe, 0x82, 0x13, 0xff,
-	0xd0, 0x23, 0x9f, 0x00, 0xe1, 0x47, 0x90, 0x77, 0x9d, 0xd8, 0x11, 0x9c, 0x7a, 0x1b, 0x3f, 0xcf,
-	0xbc, 0x79, 0xef, 0xed, 0xc0, 0xf1, 0x0a, 0x57, 0x19, 0x2a, 0xfd, 0x51, 0x54, 0x49, 0xa5, 0xa4,
-	0x91, 0xf4, 0x4e, 0x87, 0x54, 0xd9, 0xc9, 0xfd, 0x5c, 0xe6, 0xd2, 0xfe, 0x48, 0x9b, 0xca, 0xf5,
-	0x9c, 0xc4, 0x68, 0xae, 0x16, 0x29, 0xaf, 0x44, 0x7a, 0x8d, 0x4a, 0x0b, 0x59, 0x56, 0xd9, 0xa6,
-	0x72, 0x1d, 0xa7, 0x97, 0x30, 0x64, 0x7c, 0x69, 0x5e, 0x18, 0xa3, 0x44, 0x56, 0x1b, 0xd4, 0x74,
-	0x04, 0x41, 0x85, 0xa8, 0xe6, 0xb5, 0x2a, 0x74, 0x48, 0xe2, 0xc1, 0x38, 0x60, 0x7e, 0x03, 0x5c,
-	0xaa, 0x42, 0xd3, 0x47, 0x00, 0x42, 0xcf, 0x0b, 0xe4, 0xaa, 0x44, 0x15, 0x7a, 0x31, 0x19, 0xfb,
-	0x2c, 0x10, 0xfa, 0x8d, 0x03, 0x9e, 0x1f, 0x7e, 0xf9, 0x11, 0x0e, 0x26, 0xc9, 0xd9, 0xe9, 0x6b,
-	0x80, 0x1e, 0x25, 0x85, 0xfd, 0x92, 0xaf, 0x30, 0x24, 0x31, 0x19, 0x07, 0xcc, 0xd6, 0xf4, 0x31,
-	0x1c, 0x5d, 0x15, 0x02, 0x4b, 0xe3, 0x16, 0x79, 0x76, 0x11, 0x38, 0xa8, 0x59, 0xd5, 0x71, 0x7d,
-	0x27, 0x70, 0x70, 0x61, 0xbd, 0xd2, 0x21, 0x78, 0xb3, 0xa9, 0xa5, 0xd9, 0x67, 0xde, 0x6c, 0x4a,
-	0xcf, 0xe1, 0xae, 0xe2, 0x4b, 0x33, 0xe7, 0xdb, 0x5d, 0x56, 0xd3, 0xd1, 0xd3, 0x87, 0x49, 0x3f,
-	0x9d, 0x64, 0xd7, 0x22, 0x1b, 0xaa, 0x5d, 0xcb, 0xe7, 0x70, 0xcf, 0xb5, 0xf7, 0x89, 0x06, 0x96,
-	0x28, 0xdc, 0x25, 0xea, 0x91, 0xb4, 0x2f, 0xd2, 0x21, 0x9d, 0xe2, 0x33, 0x08, 0x5f, 0x15, 0xb5,
-	0x36, 0xa8, 0xde, 0xbb, 0xb0, 0xdf, 0xa1, 0x61, 0xf8, 0xa9, 0x46, 0x6d, 0xe8, 0x31, 0x0c, 0xae,
-	0x51, 0xb5, 0x51, 0x34, 0x65, 0x37, 0xf6, 0x95, 0xc0, 0xa8, 0x9d, 0xbb, 0xd8, 0x72, 0xf7, 0x46,
-	0x47, 0x10, 0xb4, 0x32, 0xb7, 0x21, 0xf8, 0x0e, 0xb0, 0x51, 0xfc, 0xc7, 0x83, 0x77, 0x7b, 0x0f,
-	0x6f, 0xe1, 0xc1, 0x54, 0x7e, 0x2e, 0x73, 0xc5, 0x17, 0x38, 0x2b, 0x97, 0xb2, 0xa7, 0x23, 0x84,
-	0x43, 0x2c, 0x79, 0x56, 0xe0, 0xc2, 0xaa, 0xf0, 0xd9, 0xe6, 0x73, 0x63, 0xce, 0xfb, 0xd7, 0xdc,
-	0xcb, 0x67, 0x37, 0xbf, 0xa3, 0xbd, 0x9b, 0x75, 0x44, 0x7e, 0xae, 0x23, 0xf2, 0x6b, 0x1d, 0x91,
-	0x6f, 0x7f, 0xa2, 0xbd, 0x0f, 0x4f, 0x72, 0x99, 0x34, 0x37, 0x9a, 0x08, 0x99, 0x76, 0xb7, 0x3a,
-	0x49, 0xfb, 0x82, 0xb3, 0x03, 0x7b, 0xaa, 0x93, 0xbf, 0x01, 0x00, 0x00, 0xff, 0xff, 0xcf, 0x56,
-	0x21, 0x97, 0x04, 0x03, 0x00, 0x00,
+	// 507 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x53, 0xc1, 0x6e, 0xd3, 0x4c,
+	0x10, 0xee, 0xda, 0xf9, 0x93, 0x78, 0xf2, 0x2b, 0x94, 0x55, 0x25, 0xac, 0x04, 0x42, 0xd4, 0x72,
+	0xc8, 0xc9, 0x91, 0x1a, 0x55, 0xd0, 0xde, 0x28, 0xc9, 0x21, 0x52, 0xcb, 0x61, 0x51, 0x39, 0x70,
+	0x89, 0xd6, 0xcd, 0x24, 0xac, 0x70, 0xd6, 0x66, 0x77, 0x13, 0xd4, 0x2b, 0xc7, 0x3e, 0x01, 0x6f,
+	0xc1, 0x89, 0x77, 0xe8, 0x91, 0x47, 0x80, 0xf0, 0x04, 0xbc, 0x01, 0xf2, 0xda, 0x89, 0x1d, 0x01,
+	0x17, 0x6e, 0xb3, 0xdf, 0xce, 0x7c, 0xfb, 0x7d, 0x33, 0x3b, 0xb0, 0xbf, 0xc0, 0x45, 0x88, 0x4a,
+	0xbf, 0x15, 0x49, 0x90, 0xa8, 0xd8, 0xc4, 0xf4, 0xff, 0x02, 0x49, 0xc2, 0xd6, 0xc1, 0x3c, 0x9e,
+	0xc7, 0xf6, 0xa2, 0x9f, 0x46, 0x59, 0x4e, 0xab, 0x8b, 0xe6, 0x7a, 0xda, 0xe7, 0x89, 0xe8, 0xaf,
+	0x50, 0x69, 0x11, 0xcb, 0x24, 0xdc, 0x44, 0x59, 0xc6, 0xe1, 0x15, 0x34, 0x19, 0x9f, 0x99, 0xe7,
+	0xc6, 0x28, 0x11, 0x2e, 0x0d, 0x6a, 0xda, 0x06, 0x2f, 0x41, 0x54, 0x93, 0xa5, 0x8a, 0xb4, 0x4f,
+	0xba, 0x6e, 0xcf, 0x63, 0xf5, 0x14, 0xb8, 0x52, 0x91, 0xa6, 0x8f, 0x00, 0x84, 0x9e, 0x44, 0xc8,
+	0x95, 0x44, 0xe5, 0x3b, 0x5d, 0xd2, 0xab, 0x33, 0x4f, 0xe8, 0x8b, 0x0c, 0x38, 0xab, 0x7d, 0xfc,
+	0xe2, 0xbb, 0x83, 0xe0, 0xe4, 0xf0, 0x27, 0x01, 0x28, 0x71, 0x52, 0xa8, 0x48, 0xbe, 0x40, 0x9f,
+	0x74, 0x49, 0xcf, 0x63, 0x36, 0xa6, 0x8f, 0xa1, 0x71, 0x1d, 0x09, 0x94, 0x26, 0x7b, 0xc9, 0xb1,
+	0x2f, 0x41, 0x06, 0xd9, 0xb7, 0x46, 0x50, 0x8d, 0x78, 0x88, 0x91, 0xf6, 0xdd, 0xae, 0xdb, 0x6b,
+	0x1c, 0x3f, 0x09, 0xca, 0x8e, 0x83, 0x82, 0x3e, 0xb8, 0xb0, 0x69, 0x23, 0x69, 0xd4, 0xcd, 0x79,
+	0xed, 0xd6, 0x4a, 0x78, 0xca, 0xf2, 0x62, 0x7a, 0x04, 0xf5, 0xa9, 0xe2, 0x42, 0x0a, 0x39, 0xf7,
+	0x2b, 0xa9, 0xe0, 0x22, 0x65, 0x7b, 0xd1, 0x3a, 0x85, 0x46, 0x89, 0x84, 0xee, 0x83, 0xfb, 0x0e,
+	0x6f, 0x72, 0xb9, 0x69, 0x48, 0x0f, 0xe0, 0xbf, 0x15, 0x8f, 0x96, 0x68, 0x3d, 0x7b, 0x2c, 0x3b,
+	0x9c, 0x39, 0xcf, 0x48, 0xe1, 0xf9, 0x33, 0x81, 0xea, 0xa5, 0x55, 0x48, 0x9b, 0xe0, 0x8c, 0x87,
+	0xb6, 0xbc, 0xc2, 0x9c, 0xf1, 0x90, 0x8e, 0xe0, 0x9e, 0xe2, 0x33, 0x33, 0xe1, 0x5b, 0xcd, 0x96,
+	0xa7, 0x71, 0xfc, 0x70, 0xd7, 0xd3, 0xee, 0x28, 0x58, 0x53, 0xed, 0x8e, 0x66, 0x04, 0xf7, 0xb3,
+	0xf4, 0x32, 0x91, 0x6b, 0x89, 0xfc, 0xbf, 0x35, 0x87, 0xe5, 0x3f, 0xa7, 0x40, 0x0a, 0xc5, 0x27,
+	0xe0, 0xbf, 0x88, 0x96, 0xda, 0xa0, 0x7a, 0x9d, 0x7d, 0x8a, 0x57, 0x68, 0x18, 0xbe, 0x5f, 0xa2,
+	0x36, 0x69, 0x0b, 0x56, 0xa8, 0x36, 0x2d, 0x58, 0x95, 0x87, 0x7b, 0x4b, 0xa0, 0x9d, 0xd7, 0x5d,
+	0x6e, 0xb9, 0x4b, 0xa5, 0x6d, 0xf0, 0x72, 0x99, 0xdb, 0x26, 0xd4, 0x33, 0xc0, 0xb6, 0xe2, 0x0f,
+	0x1e, 0x9c, 0x7f, 0xf7, 0xf0, 0x12, 0x1e, 0x0c, 0xe3, 0x0f, 0x72, 0xae, 0xf8, 0x14, 0xc7, 0x72,
+	0x16, 0x97, 0x74, 0xf8, 0x50, 0x43, 0xc9, 0xc3, 0x08, 0xa7, 0x56, 0x45, 0x9d, 0x6d, 0x8e, 0x1b,
+	0x73, 0xce, 0xef, 0xe6, 0xce, 0x4f, 0xef, 0xbe, 0x77, 0xf6, 0xee, 0xd6, 0x1d, 0xf2, 0x75, 0xdd,
+	0x21, 0xdf, 0xd6, 0x1d, 0xf2, 0xe9, 0x47, 0x67, 0xef, 0xcd, 0xd1, 0x3c, 0x0e, 0xd2, 0x5d, 0x0a,
+	0x44, 0xdc, 0x2f, 0x76, 0x6a, 0xd0, 0x2f, 0x0b, 0x0e, 0xab, 0x76, 0xa5, 0x06, 0xbf, 0x02, 0x00,
+	0x00, 0xff, 0xff, 0xba, 0x0d, 0x2c, 0x3e, 0xac, 0x03, 0x00, 0x00,
 }
 
 func (m *RaftAttributes) Marshal() (dAtA []byte, err error) {
@@ -387,6 +397,35 @@ func (m *Attributes) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.Draining {
+		i--
+		if m.Draining {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Labels) > 0 {
+		for k := range m.Labels {
+			v := m.Labels[k]
+			baseI := i
+			i -= len(v)
+			copy(dAtA[i:], v)
+			i = encodeVarintMembership(dAtA, i, uint64(len(v)))
+			i--
+			dAtA[i] = 0x12
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintMembership(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintMembership(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
 	if len(m.ClientUrls) > 0 {
 		for iNdEx := len(m.ClientUrls) - 1; iNdEx >= 0; iNdEx-- {
 			i -= len(m.ClientUrls[iNdEx])
@@ -632,6 +671,17 @@ func (m *Attributes) Size() (n int) {
 			n += 1 + l + sovMembership(uint64(l))
 		}
 	}
+	if len(m.Labels) > 0 {
+		for k, v := range m.Labels {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovMembership(uint64(len(k))) + 1 + len(v) + sovMembership(uint64(len(v)))
+			n += mapEntrySize + 1 + sovMembership(uint64(mapEntrySize))
+		}
+	}
+	if m.Draining {
+		n += 2
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -917,6 +967,153 @@ func (m *Attributes) Unmarshal(dAtA []byte) error {
 			}
 			m.ClientUrls = append(m.ClientUrls, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthMembership
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthMembership
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Labels == nil {
+				m.Labels = make(map[string]string)
+			}
+			var mapkey string
+			var mapvalue string
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowMembership
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMembership
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthMembership
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthMembership
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var stringLenmapvalue uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowMembership
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapvalue := int(stringLenmapvalue)
+					if intStringLenmapvalue < 0 {
+						return ErrInvalidLengthMembership
+					}
+					postStringIndexmapvalue := iNdEx + intStringLenmapvalue
+					if postStringIndexmapvalue < 0 {
+						return ErrInvalidLengthMembership
+					}
+					if postStringIndexmapvalue > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = string(dAtA[iNdEx:postStringIndexmapvalue])
+					iNdEx = postStringIndexmapvalue
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipMembership(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if (skippy < 0) || (iNdEx+skippy) < 0 {
+						return ErrInvalidLengthMembership
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.Labels[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Draining", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowMembership
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Draining = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipMembership(dAtA[iNdEx:])
@@ -24,6 +24,34 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// LeaseMode tells when a key attached to several leases is deleted.
+type LeaseMode int32
+
+const (
+	// ANY deletes the key as soon as any of its leases is revoked.
+	ANY LeaseMode = 0
+	// ALL deletes the key once all of its leases are revoked.
+	ALL LeaseMode = 1
+)
+
+var LeaseMode_name = map[int32]string{
+	0: "ANY",
+	1: "ALL",
+}
+
+var LeaseMode_value = map[string]int32{
+	"ANY": 0,
+	"ALL": 1,
+}
+
+func (x LeaseMode) String() string {
+	return proto.EnumName(LeaseMode_name, int32(x))
+}
+
+func (LeaseMode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2216fe83c9c12408, []int{0}
+}
+
 type Event_EventType int32
 
 const (
@@ -49,6 +77,42 @@ func (Event_EventType) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_2216fe83c9c12408, []int{1, 0}
 }
 
+type Event_RevokeReason int32
+
+const (
+	// NOT_REVOKED is set on events for keys that were not attached to a lease.
+	NOT_REVOKED Event_RevokeReason = 0
+	// EXPIRED indicates the lease of the key expired.
+	EXPIRED Event_RevokeReason = 1
+	// REVOKED indicates the lease of the key was revoked.
+	REVOKED Event_RevokeReason = 2
+	// DELETED indicates the key was deleted while attached to its lease,
+	// typically by the owner of the lease.
+	DELETED Event_RevokeReason = 3
+)
+
+var Event_RevokeReason_name = map[int32]string{
+	0: "NOT_REVOKED",
+	1: "EXPIRED",
+	2: "REVOKED",
+	3: "DELETED",
+}
+
+var Event_RevokeReason_value = map[string]int32{
+	"NOT_REVOKED": 0,
+	"EXPIRED":     1,
+	"REVOKED":     2,
+	"DELETED":     3,
+}
+
+func (x Event_RevokeReason) String() string {
+	return proto.EnumName(Event_RevokeReason_name, int32(x))
+}
+
+func (Event_RevokeReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_2216fe83c9c12408, []int{1, 1}
+}
+
 type KeyValue struct {
 	// key is the key in bytes. An empty key is not allowed.
 	Key []byte `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
@@ -65,10 +129,14 @@ type KeyValue struct {
 	// lease is the ID of the lease that attached to key.
 	// When the attached lease expires, the key will be deleted.
 	// If lease is 0, then no lease is attached to the key.
-	Lease                int64    `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Lease int64 `protobuf:"varint,6,opt,name=lease,proto3" json:"lease,omitempty"`
+	// extra_leases are the IDs of the other leases attached to the key, if any.
+	ExtraLeases []int64 `protobuf:"varint,7,rep,packed,name=extra_leases,json=extraLeases,proto3" json:"extra_leases,omitempty"`
+	// lease_mode tells when a key attached to several leases is deleted.
+	LeaseMode            LeaseMode `protobuf:"varint,8,opt,name=lease_mode,json=leaseMode,proto3,enum=mvccpb.LeaseMode" json:"lease_mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
 }
 
 func (m *KeyValue) Reset()         { *m = KeyValue{} }
@@ -116,10 +184,17 @@ type Event struct {
 	// its modification revision set to the revision of deletion.
 	Kv *KeyValue `protobuf:"bytes,2,opt,name=kv,proto3" json:"kv,omitempty"`
 	// prev_kv holds the key-value pair before the event happens.
-	PrevKv               *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+	PrevKv *KeyValue `protobuf:"bytes,3,opt,name=prev_kv,json=prevKv,proto3" json:"prev_kv,omitempty"`
+	// lease is the ID of the lease the key was attached to when it was deleted.
+	// It is only set on DELETE events.
+	Lease int64 `protobuf:"varint,4,opt,name=lease,proto3" json:"lease,omitempty"`
+	// revoke_reason tells why a key attached to a lease was deleted. It is only
+	// set on DELETE events sent to watchers as the deletion happens; events
+	// replayed from the history do not carry it.
+	RevokeReason         Event_RevokeReason `protobuf:"varint,5,opt,name=revoke_reason,json=revokeReason,proto3,enum=mvccpb.Event_RevokeReason" json:"revoke_reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
 }
 
 func (m *Event) Reset()         { *m = Event{} }
@@ -156,7 +231,9 @@ func (m *Event) XXX_DiscardUnknown() {
 var xxx_messageInfo_Event proto.InternalMessageInfo
 
 func init() {
+	proto.RegisterEnum("mvccpb.LeaseMode", LeaseMode_name, LeaseMode_value)
 	proto.RegisterEnum("mvccpb.Event_EventType", Event_EventType_name, Event_EventType_value)
+	proto.RegisterEnum("mvccpb.Event_RevokeReason", Event_RevokeReason_name, Event_RevokeReason_value)
 	proto.RegisterType((*KeyValue)(nil), "mvccpb.KeyValue")
 	proto.RegisterType((*Event)(nil), "mvccpb.Event")
 }
@@ -164,28 +241,37 @@ func init() {
 func init() { proto.RegisterFile("kv.proto", fileDescriptor_2216fe83c9c12408) }
 
 var fileDescriptor_2216fe83c9c12408 = []byte{
-	// 327 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x91, 0xc1, 0x6a, 0xfa, 0x40,
-	0x10, 0xc6, 0xb3, 0x46, 0xa3, 0xff, 0x51, 0xfc, 0x87, 0x45, 0x68, 0x28, 0x34, 0xa4, 0x5e, 0x6a,
-	0x29, 0x24, 0xa0, 0x87, 0xde, 0x4b, 0x73, 0xb2, 0x87, 0x12, 0x6c, 0x0f, 0xbd, 0x48, 0x8c, 0x83,
-	0x84, 0xa8, 0x1b, 0x62, 0xba, 0x90, 0x37, 0xe9, 0xbd, 0xf7, 0x3e, 0x87, 0x47, 0x1f, 0xa1, 0xda,
-	0x17, 0x29, 0x3b, 0x5b, 0xed, 0xa5, 0x97, 0xdd, 0x99, 0xef, 0xfb, 0xb1, 0xf3, 0x0d, 0x0b, 0xad,
-	0x4c, 0xfa, 0x79, 0x21, 0x4a, 0xc1, 0xad, 0x95, 0x4c, 0x92, 0x7c, 0x76, 0xde, 0x5b, 0x88, 0x85,
-	0x20, 0x29, 0x50, 0x95, 0x76, 0xfb, 0x1f, 0x0c, 0x5a, 0x63, 0xac, 0x9e, 0xe3, 0xe5, 0x2b, 0x72,
-	0x1b, 0xcc, 0x0c, 0x2b, 0x87, 0x79, 0x6c, 0xd0, 0x89, 0x54, 0xc9, 0xaf, 0xe0, 0x7f, 0x52, 0x60,
-	0x5c, 0xe2, 0xb4, 0x40, 0x99, 0x6e, 0x52, 0xb1, 0x76, 0x6a, 0x1e, 0x1b, 0x98, 0x51, 0x57, 0xcb,
-	0xd1, 0x8f, 0xca, 0x2f, 0xa1, 0xb3, 0x12, 0xf3, 0x5f, 0xca, 0x24, 0xaa, 0xbd, 0x12, 0xf3, 0x13,
-	0xe2, 0x40, 0x53, 0x62, 0x41, 0x6e, 0x9d, 0xdc, 0x63, 0xcb, 0x7b, 0xd0, 0x90, 0x2a, 0x80, 0xd3,
-	0xa0, 0xc9, 0xba, 0x51, 0xea, 0x12, 0xe3, 0x0d, 0x3a, 0x16, 0xd1, 0xba, 0xe9, 0xbf, 0x33, 0x68,
-	0x84, 0x12, 0xd7, 0x25, 0xbf, 0x81, 0x7a, 0x59, 0xe5, 0x48, 0x71, 0xbb, 0xc3, 0x33, 0x5f, 0xef,
-	0xe9, 0x93, 0xa9, 0xcf, 0x49, 0x95, 0x63, 0x44, 0x10, 0xf7, 0xa0, 0x96, 0x49, 0xca, 0xde, 0x1e,
-	0xda, 0x47, 0xf4, 0xb8, 0x78, 0x54, 0xcb, 0x24, 0xbf, 0x86, 0x66, 0x5e, 0xa0, 0x9c, 0x66, 0x92,
-	0xc2, 0xff, 0x85, 0x59, 0x0a, 0x18, 0xcb, 0xbe, 0x07, 0xff, 0x4e, 0xef, 0xf3, 0x26, 0x98, 0x8f,
-	0x4f, 0x13, 0xdb, 0xe0, 0x00, 0xd6, 0x7d, 0xf8, 0x10, 0x4e, 0x42, 0x9b, 0xdd, 0xdd, 0x6e, 0xf7,
-	0xae, 0xb1, 0xdb, 0xbb, 0xc6, 0xf6, 0xe0, 0xb2, 0xdd, 0xc1, 0x65, 0x9f, 0x07, 0x97, 0xbd, 0x7d,
-	0xb9, 0xc6, 0xcb, 0xc5, 0x42, 0xf8, 0x58, 0x26, 0x73, 0x3f, 0x15, 0x81, 0xba, 0x83, 0x38, 0x4f,
-	0x03, 0x39, 0x0a, 0xf4, 0xac, 0x99, 0x45, 0xdf, 0x32, 0xfa, 0x0e, 0x00, 0x00, 0xff, 0xff, 0x78,
-	0x06, 0x46, 0xf5, 0xc0, 0x01, 0x00, 0x00,
+	// 465 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x6c, 0x52, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xf6, 0xda, 0x89, 0x9d, 0x4c, 0x42, 0xba, 0xac, 0x2a, 0x61, 0x55, 0xaa, 0x65, 0x72, 0x21,
+	0x80, 0x64, 0xa3, 0xf4, 0xc0, 0x11, 0x81, 0x62, 0x24, 0x94, 0xd0, 0x56, 0xab, 0x50, 0x01, 0x17,
+	0xcb, 0x8d, 0x57, 0x51, 0xe4, 0x24, 0x6b, 0x39, 0x66, 0x45, 0xce, 0xbc, 0x04, 0xcf, 0xc3, 0xa9,
+	0xc7, 0x3e, 0x02, 0x0d, 0x2f, 0x82, 0x76, 0x8c, 0xd3, 0x20, 0xf5, 0x62, 0xcf, 0xf7, 0xb3, 0x9e,
+	0x99, 0xcf, 0x0b, 0xad, 0x4c, 0x05, 0x79, 0x21, 0x4b, 0xc9, 0xec, 0x95, 0x9a, 0xcd, 0xf2, 0xeb,
+	0x93, 0xe3, 0xb9, 0x9c, 0x4b, 0xa4, 0x42, 0x5d, 0x55, 0x6a, 0xff, 0x87, 0x09, 0xad, 0xb1, 0xd8,
+	0x5e, 0x25, 0xcb, 0x6f, 0x82, 0x51, 0xb0, 0x32, 0xb1, 0x75, 0x89, 0x4f, 0x06, 0x5d, 0xae, 0x4b,
+	0xf6, 0x0c, 0x8e, 0x66, 0x85, 0x48, 0x4a, 0x11, 0x17, 0x42, 0x2d, 0x36, 0x0b, 0xb9, 0x76, 0x4d,
+	0x9f, 0x0c, 0x2c, 0xde, 0xab, 0x68, 0xfe, 0x8f, 0x65, 0x4f, 0xa1, 0xbb, 0x92, 0xe9, 0xbd, 0xcb,
+	0x42, 0x57, 0x67, 0x25, 0xd3, 0xbd, 0xc5, 0x05, 0x47, 0x89, 0x02, 0xd5, 0x06, 0xaa, 0x35, 0x64,
+	0xc7, 0xd0, 0x54, 0x7a, 0x00, 0xb7, 0x89, 0x9d, 0x2b, 0xa0, 0xd9, 0xa5, 0x48, 0x36, 0xc2, 0xb5,
+	0xd1, 0x5d, 0x01, 0xdd, 0x48, 0x7c, 0x2f, 0x8b, 0x24, 0x46, 0xb8, 0x71, 0x1d, 0xdf, 0xd2, 0x8d,
+	0x90, 0x9b, 0x20, 0xc5, 0x5e, 0x01, 0xa0, 0x18, 0xaf, 0x64, 0x2a, 0xdc, 0x96, 0x4f, 0x06, 0xbd,
+	0xe1, 0xe3, 0xa0, 0x8a, 0x21, 0x40, 0xcf, 0x47, 0x99, 0x0a, 0xde, 0x5e, 0xd6, 0x65, 0xff, 0x97,
+	0x09, 0xcd, 0x48, 0x89, 0x75, 0xc9, 0x5e, 0x42, 0xa3, 0xdc, 0xe6, 0x02, 0x33, 0xe8, 0x0d, 0x9f,
+	0xd4, 0xa7, 0x50, 0xac, 0x9e, 0xd3, 0x6d, 0x2e, 0x38, 0x9a, 0x98, 0x0f, 0x66, 0xa6, 0x30, 0x90,
+	0xce, 0x90, 0xd6, 0xd6, 0x3a, 0x4d, 0x6e, 0x66, 0x8a, 0x3d, 0x07, 0x27, 0x2f, 0x84, 0x8a, 0x33,
+	0x85, 0x89, 0x3c, 0x64, 0xb3, 0xb5, 0x61, 0xac, 0xee, 0xd7, 0x6d, 0x1c, 0xae, 0xfb, 0x06, 0x1e,
+	0x15, 0x42, 0xc9, 0x4c, 0xff, 0x80, 0x64, 0x23, 0xd7, 0x18, 0x51, 0x6f, 0x78, 0xf2, 0xff, 0x60,
+	0x1c, 0x2d, 0x1c, 0x1d, 0xbc, 0x5b, 0x1c, 0xa0, 0xbe, 0x0f, 0xed, 0xfd, 0xd8, 0xcc, 0x01, 0xeb,
+	0xf2, 0xd3, 0x94, 0x1a, 0x0c, 0xc0, 0x1e, 0x45, 0x93, 0x68, 0x1a, 0x51, 0xd2, 0x7f, 0x0f, 0xdd,
+	0xc3, 0xf3, 0xec, 0x08, 0x3a, 0xe7, 0x17, 0xd3, 0x98, 0x47, 0x57, 0x17, 0xe3, 0x68, 0x44, 0x0d,
+	0xd6, 0x01, 0x27, 0xfa, 0x7c, 0xf9, 0x81, 0x47, 0x23, 0x4a, 0x34, 0xa8, 0x15, 0x53, 0x83, 0xea,
+	0x33, 0x23, 0x6a, 0xbd, 0x38, 0x85, 0xf6, 0x3e, 0x5c, 0xdd, 0xe9, 0xed, 0xf9, 0x17, 0x6a, 0x60,
+	0x31, 0x99, 0x50, 0xf2, 0xee, 0xf5, 0xcd, 0x9d, 0x67, 0xdc, 0xde, 0x79, 0xc6, 0xcd, 0xce, 0x23,
+	0xb7, 0x3b, 0x8f, 0xfc, 0xde, 0x79, 0xe4, 0xe7, 0x1f, 0xcf, 0xf8, 0x7a, 0x3a, 0x97, 0x81, 0x28,
+	0x67, 0x69, 0xb0, 0x90, 0xa1, 0x7e, 0x87, 0x49, 0xbe, 0x08, 0xd5, 0x59, 0x58, 0xad, 0x78, 0x6d,
+	0xe3, 0x4d, 0x3d, 0xfb, 0x1b, 0x00, 0x00, 0xff, 0xff, 0x97, 0x4e, 0x45, 0x3c, 0xd3, 0x02, 0x00,
+	0x00,
 }
 
 func (m *KeyValue) Marshal() (dAtA []byte, err error) {
@@ -212,6 +298,30 @@ func (m *KeyValue) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.LeaseMode != 0 {
+		i = encodeVarintKv(dAtA, i, uint64(m.LeaseMode))
+		i--
+		dAtA[i] = 0x40
+	}
+	if len(m.ExtraLeases) > 0 {
+		dAtA2 := make([]byte, len(m.ExtraLeases)*10)
+		var j1 int
+		for _, num1 := range m.ExtraLeases {
+			num := uint64(num1)
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintKv(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0x3a
+	}
 	if m.Lease != 0 {
 		i = encodeVarintKv(dAtA, i, uint64(m.Lease))
 		i--
@@ -273,6 +383,16 @@ func (m *Event) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.RevokeReason != 0 {
+		i = encodeVarintKv(dAtA, i, uint64(m.RevokeReason))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Lease != 0 {
+		i = encodeVarintKv(dAtA, i, uint64(m.Lease))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.PrevKv != nil {
 		{
 			size, err := m.PrevKv.MarshalToSizedBuffer(dAtA[:i])
@@ -342,6 +462,16 @@ func (m *KeyValue) Size() (n int) {
 	if m.Lease != 0 {
 		n += 1 + sovKv(uint64(m.Lease))
 	}
+	if len(m.ExtraLeases) > 0 {
+		l = 0
+		for _, e := range m.ExtraLeases {
+			l += sovKv(uint64(e))
+		}
+		n += 1 + sovKv(uint64(l)) + l
+	}
+	if m.LeaseMode != 0 {
+		n += 1 + sovKv(uint64(m.LeaseMode))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -365,6 +495,12 @@ func (m *Event) Size() (n int) {
 		l = m.PrevKv.Size()
 		n += 1 + l + sovKv(uint64(l))
 	}
+	if m.Lease != 0 {
+		n += 1 + sovKv(uint64(m.Lease))
+	}
+	if m.RevokeReason != 0 {
+		n += 1 + sovKv(uint64(m.RevokeReason))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -550,6 +686,101 @@ func (m *KeyValue) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 7:
+			if wireType == 0 {
+				var v int64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowKv
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= int64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ExtraLeases = append(m.ExtraLeases, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowKv
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthKv
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthKv
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ExtraLeases) == 0 {
+					m.ExtraLeases = make([]int64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v int64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowKv
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ExtraLeases = append(m.ExtraLeases, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtraLeases", wireType)
+			}
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LeaseMode", wireType)
+			}
+			m.LeaseMode = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LeaseMode |= LeaseMode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
@@ -692,6 +923,44 @@ func (m *Event) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Lease", wireType)
+			}
+			m.Lease = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Lease |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RevokeReason", wireType)
+			}
+			m.RevokeReason = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowKv
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RevokeReason |= Event_RevokeReason(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipKv(dAtA[iNdEx:])
@@ -25,6 +25,7 @@ var (
 	ErrGRPCKeyNotFound             = status.Error(codes.InvalidArgument, "etcdserver: key not found")
 	ErrGRPCValueProvided           = status.Error(codes.InvalidArgument, "etcdserver: value is provided")
 	ErrGRPCLeaseProvided           = status.Error(codes.InvalidArgument, "etcdserver: lease is provided")
+	ErrGRPCLeaseNotProvided        = status.Error(codes.InvalidArgument, "etcdserver: extra leases are provided without a lease")
 	ErrGRPCTooManyOps              = status.Error(codes.InvalidArgument, "etcdserver: too many operations in txn request")
 	ErrGRPCDuplicateKey            = status.Error(codes.InvalidArgument, "etcdserver: duplicate key given in txn request")
 	ErrGRPCInvalidClientAPIVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid client api version")
@@ -33,10 +34,17 @@ var (
 	ErrGRPCFutureRev               = status.Error(codes.OutOfRange, "etcdserver: mvcc: required revision is a future revision")
 	ErrGRPCNoSpace                 = status.Error(codes.ResourceExhausted, "etcdserver: mvcc: database space exceeded")
 
+	ErrGRPCInvalidCompactionPolicy  = status.Error(codes.InvalidArgument, "etcdserver: mvcc: retention policy requires a non-empty prefix and a positive number of revisions or keep duration")
+	ErrGRPCCompactionPolicyNotFound = status.Error(codes.NotFound, "etcdserver: mvcc: retention policy not found")
+
 	ErrGRPCLeaseNotFound    = status.Error(codes.NotFound, "etcdserver: requested lease not found")
 	ErrGRPCLeaseExist       = status.Error(codes.FailedPrecondition, "etcdserver: lease already exists")
 	ErrGRPCLeaseTTLTooLarge = status.Error(codes.OutOfRange, "etcdserver: too large lease TTL")
 
+	ErrGRPCLeaseGroupNotFound = status.Error(codes.NotFound, "etcdserver: requested lease group not found")
+	ErrGRPCLeaseGroupExist    = status.Error(codes.FailedPrecondition, "etcdserver: lease group already exists")
+	ErrGRPCLeaseGroupEmpty    = status.Error(codes.FailedPrecondition, "etcdserver: lease group has no leases to renew")
+
 	ErrGRPCWatchCanceled = status.Error(codes.Canceled, "etcdserver: watch canceled")
 
 	ErrGRPCMemberExist            = status.Error(codes.FailedPrecondition, "etcdserver: member ID already exist")
@@ -46,6 +54,9 @@ var (
 	ErrGRPCMemberNotFound         = status.Error(codes.NotFound, "etcdserver: member not found")
 	ErrGRPCMemberNotLearner       = status.Error(codes.FailedPrecondition, "etcdserver: can only promote a learner member")
 	ErrGRPCLearnerNotReady        = status.Error(codes.FailedPrecondition, "etcdserver: can only promote a learner member which is in sync with leader")
+	ErrGRPCMemberIsLearner        = status.Error(codes.FailedPrecondition, "etcdserver: can only replace a voting member")
+	ErrGRPCAutoPromoteNotLearner  = status.Error(codes.InvalidArgument, "etcdserver: auto promote can only be set for a learner member")
+	ErrGRPCWitnessIsLearner       = status.Error(codes.InvalidArgument, "etcdserver: a witness member cannot be a learner")
 	ErrGRPCTooManyLearners        = status.Error(codes.FailedPrecondition, "etcdserver: too many learner members in cluster")
 	ErrGRPCClusterIDMismatch      = status.Error(codes.FailedPrecondition, "etcdserver: cluster ID mismatch")
 	//revive:disable:var-naming
@@ -87,6 +98,9 @@ var (
 	ErrGRPCCorrupt                    = status.Error(codes.DataLoss, "etcdserver: corrupt cluster")
 	ErrGRPCNotSupportedForLearner     = status.Error(codes.FailedPrecondition, "etcdserver: rpc not supported for learner")
 	ErrGRPCBadLeaderTransferee        = status.Error(codes.FailedPrecondition, "etcdserver: bad leader transferee")
+	ErrGRPCDrainUnsafe                = status.Error(codes.FailedPrecondition, "etcdserver: draining the member would leave no healthy quorum")
+	ErrGRPCMemberDraining             = status.Error(codes.Unavailable, "etcdserver: member is draining")
+	ErrGRPCNotSupportedForWitness     = status.Error(codes.Unavailable, "etcdserver: rpc not supported for witness")
 
 	ErrGRPCWrongDowngradeVersionFormat   = status.Error(codes.InvalidArgument, "etcdserver: wrong downgrade target version format")
 	ErrGRPCInvalidDowngradeTargetVersion = status.Error(codes.InvalidArgument, "etcdserver: invalid downgrade target version")
@@ -98,10 +112,11 @@ var (
 	ErrGRPCDeadlineExceeded = status.Error(codes.DeadlineExceeded, "etcdserver: context deadline exceeded")
 
 	errStringToError = map[string]error{
-		ErrorDesc(ErrGRPCEmptyKey):      ErrGRPCEmptyKey,
-		ErrorDesc(ErrGRPCKeyNotFound):   ErrGRPCKeyNotFound,
-		ErrorDesc(ErrGRPCValueProvided): ErrGRPCValueProvided,
-		ErrorDesc(ErrGRPCLeaseProvided): ErrGRPCLeaseProvided,
+		ErrorDesc(ErrGRPCEmptyKey):         ErrGRPCEmptyKey,
+		ErrorDesc(ErrGRPCKeyNotFound):      ErrGRPCKeyNotFound,
+		ErrorDesc(ErrGRPCValueProvided):    ErrGRPCValueProvided,
+		ErrorDesc(ErrGRPCLeaseProvided):    ErrGRPCLeaseProvided,
+		ErrorDesc(ErrGRPCLeaseNotProvided): ErrGRPCLeaseNotProvided,
 
 		ErrorDesc(ErrGRPCTooManyOps):        ErrGRPCTooManyOps,
 		ErrorDesc(ErrGRPCDuplicateKey):      ErrGRPCDuplicateKey,
@@ -110,10 +125,17 @@ var (
 		ErrorDesc(ErrGRPCFutureRev):         ErrGRPCFutureRev,
 		ErrorDesc(ErrGRPCNoSpace):           ErrGRPCNoSpace,
 
+		ErrorDesc(ErrGRPCInvalidCompactionPolicy):  ErrGRPCInvalidCompactionPolicy,
+		ErrorDesc(ErrGRPCCompactionPolicyNotFound): ErrGRPCCompactionPolicyNotFound,
+
 		ErrorDesc(ErrGRPCLeaseNotFound):    ErrGRPCLeaseNotFound,
 		ErrorDesc(ErrGRPCLeaseExist):       ErrGRPCLeaseExist,
 		ErrorDesc(ErrGRPCLeaseTTLTooLarge): ErrGRPCLeaseTTLTooLarge,
 
+		ErrorDesc(ErrGRPCLeaseGroupNotFound): ErrGRPCLeaseGroupNotFound,
+		ErrorDesc(ErrGRPCLeaseGroupExist):    ErrGRPCLeaseGroupExist,
+		ErrorDesc(ErrGRPCLeaseGroupEmpty):    ErrGRPCLeaseGroupEmpty,
+
 		ErrorDesc(ErrGRPCMemberExist):            ErrGRPCMemberExist,
 		ErrorDesc(ErrGRPCPeerURLExist):           ErrGRPCPeerURLExist,
 		ErrorDesc(ErrGRPCMemberNotEnoughStarted): ErrGRPCMemberNotEnoughStarted,
@@ -121,6 +143,9 @@ var (
 		ErrorDesc(ErrGRPCMemberNotFound):         ErrGRPCMemberNotFound,
 		ErrorDesc(ErrGRPCMemberNotLearner):       ErrGRPCMemberNotLearner,
 		ErrorDesc(ErrGRPCLearnerNotReady):        ErrGRPCLearnerNotReady,
+		ErrorDesc(ErrGRPCMemberIsLearner):        ErrGRPCMemberIsLearner,
+		ErrorDesc(ErrGRPCAutoPromoteNotLearner):  ErrGRPCAutoPromoteNotLearner,
+		ErrorDesc(ErrGRPCWitnessIsLearner):       ErrGRPCWitnessIsLearner,
 		ErrorDesc(ErrGRPCTooManyLearners):        ErrGRPCTooManyLearners,
 		ErrorDesc(ErrGRPCClusterIDMismatch):      ErrGRPCClusterIDMismatch,
 
@@ -156,6 +181,9 @@ var (
 		ErrorDesc(ErrGRPCCorrupt):                    ErrGRPCCorrupt,
 		ErrorDesc(ErrGRPCNotSupportedForLearner):     ErrGRPCNotSupportedForLearner,
 		ErrorDesc(ErrGRPCBadLeaderTransferee):        ErrGRPCBadLeaderTransferee,
+		ErrorDesc(ErrGRPCDrainUnsafe):                ErrGRPCDrainUnsafe,
+		ErrorDesc(ErrGRPCMemberDraining):             ErrGRPCMemberDraining,
+		ErrorDesc(ErrGRPCNotSupportedForWitness):     ErrGRPCNotSupportedForWitness,
 
 		ErrorDesc(ErrGRPCClusterVersionUnavailable):     ErrGRPCClusterVersionUnavailable,
 		ErrorDesc(ErrGRPCWrongDowngradeVersionFormat):   ErrGRPCWrongDowngradeVersionFormat,
@@ -171,6 +199,7 @@ var (
 	ErrKeyNotFound       = Error(ErrGRPCKeyNotFound)
 	ErrValueProvided     = Error(ErrGRPCValueProvided)
 	ErrLeaseProvided     = Error(ErrGRPCLeaseProvided)
+	ErrLeaseNotProvided  = Error(ErrGRPCLeaseNotProvided)
 	ErrTooManyOps        = Error(ErrGRPCTooManyOps)
 	ErrDuplicateKey      = Error(ErrGRPCDuplicateKey)
 	ErrInvalidSortOption = Error(ErrGRPCInvalidSortOption)
@@ -178,10 +207,17 @@ var (
 	ErrFutureRev         = Error(ErrGRPCFutureRev)
 	ErrNoSpace           = Error(ErrGRPCNoSpace)
 
+	ErrInvalidCompactionPolicy  = Error(ErrGRPCInvalidCompactionPolicy)
+	ErrCompactionPolicyNotFound = Error(ErrGRPCCompactionPolicyNotFound)
+
 	ErrLeaseNotFound    = Error(ErrGRPCLeaseNotFound)
 	ErrLeaseExist       = Error(ErrGRPCLeaseExist)
 	ErrLeaseTTLTooLarge = Error(ErrGRPCLeaseTTLTooLarge)
 
+	ErrLeaseGroupNotFound = Error(ErrGRPCLeaseGroupNotFound)
+	ErrLeaseGroupExist    = Error(ErrGRPCLeaseGroupExist)
+	ErrLeaseGroupEmpty    = Error(ErrGRPCLeaseGroupEmpty)
+
 	ErrMemberExist            = Error(ErrGRPCMemberExist)
 	ErrPeerURLExist           = Error(ErrGRPCPeerURLExist)
 	ErrMemberNotEnoughStarted = Error(ErrGRPCMemberNotEnoughStarted)
@@ -189,6 +225,9 @@ var (
 	ErrMemberNotFound         = Error(ErrGRPCMemberNotFound)
 	ErrMemberNotLearner       = Error(ErrGRPCMemberNotLearner)
 	ErrMemberLearnerNotReady  = Error(ErrGRPCLearnerNotReady)
+	ErrMemberIsLearner        = Error(ErrGRPCMemberIsLearner)
+	ErrAutoPromoteNotLearner  = Error(ErrGRPCAutoPromoteNotLearner)
+	ErrWitnessIsLearner       = Error(ErrGRPCWitnessIsLearner)
 	ErrTooManyLearners        = Error(ErrGRPCTooManyLearners)
 
 	ErrRequestTooLarge = Error(ErrGRPCRequestTooLarge)
@@ -228,6 +267,9 @@ var (
 	ErrUnhealthy                  = Error(ErrGRPCUnhealthy)
 	ErrCorrupt                    = Error(ErrGRPCCorrupt)
 	ErrBadLeaderTransferee        = Error(ErrGRPCBadLeaderTransferee)
+	ErrDrainUnsafe                = Error(ErrGRPCDrainUnsafe)
+	ErrMemberDraining             = Error(ErrGRPCMemberDraining)
+	ErrNotSupportedForWitness     = Error(ErrGRPCNotSupportedForWitness)
 
 	ErrClusterVersionUnavailable     = Error(ErrGRPCClusterVersionUnavailable)
 	ErrWrongDowngradeVersionFormat   = Error(ErrGRPCWrongDowngradeVersionFormat)
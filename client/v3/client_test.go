@@ -545,6 +545,26 @@ func (mm mockMaintenance) Downgrade(ctx context.Context, action DowngradeAction,
 	return nil, nil
 }
 
+func (mm mockMaintenance) CompactionPolicyList(ctx context.Context) (*CompactionPolicyResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) CompactionPolicyPut(ctx context.Context, prefix string, keepRevisions int64, keepDuration time.Duration) (*CompactionPolicyResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) CompactionPolicyDelete(ctx context.Context, prefix string) (*CompactionPolicyResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) Drain(ctx context.Context, endpoint string) (*DrainResponse, error) {
+	return nil, nil
+}
+
+func (mm mockMaintenance) Undrain(ctx context.Context, endpoint string) (*UndrainResponse, error) {
+	return nil, nil
+}
+
 type mockFailingAuthServer struct {
 	*etcdserverpb.UnimplementedAuthServer
 }
@@ -577,6 +597,14 @@ func (mc *mockCluster) MemberAddAsLearner(ctx context.Context, peerAddrs []strin
 	return nil, nil
 }
 
+func (mc *mockCluster) MemberAddAsAutoPromoteLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return nil, nil
+}
+
+func (mc *mockCluster) MemberAddAsWitness(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return nil, nil
+}
+
 func (mc *mockCluster) MemberRemove(ctx context.Context, id uint64) (*MemberRemoveResponse, error) {
 	return nil, nil
 }
@@ -588,3 +616,7 @@ func (mc *mockCluster) MemberUpdate(ctx context.Context, id uint64, peerAddrs []
 func (mc *mockCluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error) {
 	return nil, nil
 }
+
+func (mc *mockCluster) MemberReplace(ctx context.Context, id uint64, peerAddrs []string) (*MemberReplaceResponse, error) {
+	return nil, nil
+}
@@ -30,6 +30,7 @@ type (
 	MemberRemoveResponse  pb.MemberRemoveResponse
 	MemberUpdateResponse  pb.MemberUpdateResponse
 	MemberPromoteResponse pb.MemberPromoteResponse
+	MemberReplaceResponse pb.MemberReplaceResponse
 )
 
 type Cluster interface {
@@ -42,6 +43,15 @@ type Cluster interface {
 	// MemberAddAsLearner adds a new learner member into the cluster.
 	MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
 
+	// MemberAddAsAutoPromoteLearner adds a new learner member into the cluster
+	// which the leader promotes to a voting member once it has caught up.
+	MemberAddAsAutoPromoteLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
+
+	// MemberAddAsWitness adds a new witness member into the cluster. A witness
+	// votes, but keeps no key-value data and does not serve clients.
+	// Supported since etcd 3.7.
+	MemberAddAsWitness(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error)
+
 	// MemberRemove removes an existing member from the cluster.
 	MemberRemove(ctx context.Context, id uint64) (*MemberRemoveResponse, error)
 
@@ -50,6 +60,11 @@ type Cluster interface {
 
 	// MemberPromote promotes a member from raft learner (non-voting) to raft voting member.
 	MemberPromote(ctx context.Context, id uint64) (*MemberPromoteResponse, error)
+
+	// MemberReplace replaces a voting member with a new one at the given peer
+	// addresses in a single joint consensus change. The new member joins as a
+	// learner and is promoted once it has caught up with the leader.
+	MemberReplace(ctx context.Context, id uint64, peerAddrs []string) (*MemberReplaceResponse, error)
 }
 
 type cluster struct {
@@ -74,23 +89,27 @@ func NewClusterFromClusterClient(remote pb.ClusterClient, c *Client) Cluster {
 }
 
 func (c *cluster) MemberAdd(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
-	return c.memberAdd(ctx, peerAddrs, false)
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs})
 }
 
 func (c *cluster) MemberAddAsLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
-	return c.memberAdd(ctx, peerAddrs, true)
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs, IsLearner: true})
+}
+
+func (c *cluster) MemberAddAsAutoPromoteLearner(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs, IsLearner: true, AutoPromote: true})
 }
 
-func (c *cluster) memberAdd(ctx context.Context, peerAddrs []string, isLearner bool) (*MemberAddResponse, error) {
+func (c *cluster) MemberAddAsWitness(ctx context.Context, peerAddrs []string) (*MemberAddResponse, error) {
+	return c.memberAdd(ctx, &pb.MemberAddRequest{PeerURLs: peerAddrs, IsWitness: true})
+}
+
+func (c *cluster) memberAdd(ctx context.Context, r *pb.MemberAddRequest) (*MemberAddResponse, error) {
 	// fail-fast before panic in rafthttp
-	if _, err := types.NewURLs(peerAddrs); err != nil {
+	if _, err := types.NewURLs(r.PeerURLs); err != nil {
 		return nil, err
 	}
 
-	r := &pb.MemberAddRequest{
-		PeerURLs:  peerAddrs,
-		IsLearner: isLearner,
-	}
 	resp, err := c.remote.MemberAdd(ctx, r, c.callOpts...)
 	if err != nil {
 		return nil, ContextError(ctx, err)
@@ -139,3 +158,17 @@ func (c *cluster) MemberPromote(ctx context.Context, id uint64) (*MemberPromoteR
 	}
 	return (*MemberPromoteResponse)(resp), nil
 }
+
+func (c *cluster) MemberReplace(ctx context.Context, id uint64, peerAddrs []string) (*MemberReplaceResponse, error) {
+	// fail-fast before panic in rafthttp
+	if _, err := types.NewURLs(peerAddrs); err != nil {
+		return nil, err
+	}
+
+	r := &pb.MemberReplaceRequest{ID: id, PeerURLs: peerAddrs}
+	resp, err := c.remote.MemberReplace(ctx, r, c.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*MemberReplaceResponse)(resp), nil
+}
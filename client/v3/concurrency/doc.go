@@ -13,5 +13,6 @@
 // limitations under the License.
 
 // Package concurrency implements concurrency operations on top of
-// etcd such as distributed locks, barriers, and elections.
+// etcd such as distributed locks, reader/writer locks, semaphores,
+// barriers, and elections.
 package concurrency
@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/mvccpb"
@@ -25,14 +26,17 @@ import (
 )
 
 var (
-	ErrElectionNotLeader = errors.New("election: not leader")
-	ErrElectionNoLeader  = errors.New("election: no leader")
+	ErrElectionNotLeader   = errors.New("election: not leader")
+	ErrElectionNoLeader    = errors.New("election: no leader")
+	ErrElectionNoCandidate = errors.New("election: candidate not found")
 )
 
 type Election struct {
 	session *Session
 
 	keyPrefix string
+	// transferKey names the campaigner leadership is handed over to.
+	transferKey string
 
 	leaderKey     string
 	leaderRev     int64
@@ -42,13 +46,14 @@ type Election struct {
 
 // NewElection returns a new election on a given key prefix.
 func NewElection(s *Session, pfx string) *Election {
-	return &Election{session: s, keyPrefix: pfx + "/"}
+	return &Election{session: s, keyPrefix: pfx + "/", transferKey: pfx + ".transfer"}
 }
 
 // ResumeElection initializes an election with a known leader.
 func ResumeElection(s *Session, pfx string, leaderKey string, leaderRev int64) *Election {
 	return &Election{
 		keyPrefix:     pfx,
+		transferKey:   pfx + ".transfer",
 		session:       s,
 		leaderKey:     leaderKey,
 		leaderRev:     leaderRev,
@@ -66,7 +71,50 @@ func ResumeElection(s *Session, pfx string, leaderKey string, leaderRev int64) *
 // returns a non-recoverable error (e.g. ErrCompacted).
 // Otherwise, until the context is not cancelled or timed-out, Campaign will
 // continue to be blocked until it becomes the leader.
+//
+// If leadership is being transferred to another campaigner, Campaign steps
+// aside and campaigns again behind it.
 func (e *Election) Campaign(ctx context.Context, val string) error {
+	resp, err := e.enqueue(ctx, val)
+	if err != nil {
+		return err
+	}
+	client := e.session.Client()
+
+	for {
+		err = waitDeletes(ctx, client, e.keyPrefix, e.leaderRev-1)
+		stepAside := false
+		if err == nil {
+			stepAside, err = e.checkTransfer(ctx)
+		}
+		if err == nil && stepAside {
+			// campaign again behind the candidate
+			if _, err = client.Delete(ctx, e.leaderKey); err == nil {
+				if resp, err = e.enqueue(ctx, val); err != nil {
+					return err
+				}
+				continue
+			}
+		}
+		if err != nil {
+			// clean up in case of context cancel
+			select {
+			case <-ctx.Done():
+				e.Resign(client.Ctx())
+			default:
+				e.leaderSession = nil
+			}
+			return err
+		}
+		break
+	}
+	e.hdr = resp.Header
+
+	return nil
+}
+
+// enqueue puts the campaign key of the session, or reuses it if it exists.
+func (e *Election) enqueue(ctx context.Context, val string) (*v3.TxnResponse, error) {
 	s := e.session
 	client := e.session.Client()
 
@@ -76,7 +124,7 @@ func (e *Election) Campaign(ctx context.Context, val string) error {
 	txn = txn.Else(v3.OpGet(k))
 	resp, err := txn.Commit()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	e.leaderKey, e.leaderRev, e.leaderSession = k, resp.Header.Revision, s
 	if !resp.Succeeded {
@@ -85,25 +133,32 @@ func (e *Election) Campaign(ctx context.Context, val string) error {
 		if string(kv.Value) != val {
 			if err = e.Proclaim(ctx, val); err != nil {
 				e.Resign(ctx)
-				return err
+				return nil, err
 			}
 		}
 	}
+	return resp, nil
+}
 
-	err = waitDeletes(ctx, client, e.keyPrefix, e.leaderRev-1)
-	if err != nil {
-		// clean up in case of context cancel
-		select {
-		case <-ctx.Done():
-			e.Resign(client.Ctx())
-		default:
-			e.leaderSession = nil
+// checkTransfer tells whether the campaigner should step aside because
+// leadership is being transferred to another campaigner. A transfer to this
+// campaigner, or to a campaigner that is gone, is cleared.
+func (e *Election) checkTransfer(ctx context.Context) (bool, error) {
+	client := e.session.Client()
+	resp, err := client.Get(ctx, e.transferKey)
+	if err != nil || len(resp.Kvs) == 0 {
+		return false, err
+	}
+	kv := resp.Kvs[0]
+	if candidate := string(kv.Value); candidate != e.leaderKey {
+		cresp, err := client.Get(ctx, candidate, v3.WithKeysOnly())
+		if err != nil || len(cresp.Kvs) != 0 {
+			return err == nil, err
 		}
-		return err
 	}
-	e.hdr = resp.Header
-
-	return nil
+	cmp := v3.Compare(v3.ModRevision(e.transferKey), "=", kv.ModRevision)
+	_, err = client.Txn(ctx).If(cmp).Then(v3.OpDelete(e.transferKey)).Commit()
+	return false, err
 }
 
 // Proclaim lets the leader announce a new value without another election.
@@ -144,6 +199,41 @@ func (e *Election) Resign(ctx context.Context) (err error) {
 	return err
 }
 
+// Transfer lets a leader hand leadership over to the campaigner with the given
+// key. The leader resigns and the campaigners ahead of the candidate step aside,
+// so that the candidate becomes the next leader.
+func (e *Election) Transfer(ctx context.Context, candidateKey string) error {
+	if e.leaderSession == nil {
+		return ErrElectionNotLeader
+	}
+	if candidateKey == e.leaderKey || !strings.HasPrefix(candidateKey, e.keyPrefix) {
+		return ErrElectionNoCandidate
+	}
+	client := e.session.Client()
+	cmp := v3.Compare(v3.CreateRevision(e.leaderKey), "=", e.leaderRev)
+	candidate := v3.Compare(v3.CreateRevision(candidateKey), ">", 0)
+	resp, err := client.Txn(ctx).If(cmp, candidate).
+		Then(v3.OpPut(e.transferKey, candidateKey), v3.OpDelete(e.leaderKey)).
+		Else(v3.OpGet(e.leaderKey)).
+		Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		kvs := resp.Responses[0].GetResponseRange().Kvs
+		if len(kvs) == 0 || kvs[0].CreateRevision != e.leaderRev {
+			e.leaderKey = ""
+			e.leaderSession = nil
+			return ErrElectionNotLeader
+		}
+		return ErrElectionNoCandidate
+	}
+	e.hdr = resp.Header
+	e.leaderKey = ""
+	e.leaderSession = nil
+	return nil
+}
+
 // Leader returns the leader value for the current election.
 func (e *Election) Leader(ctx context.Context) (*v3.GetResponse, error) {
 	client := e.session.Client()
@@ -157,6 +247,13 @@ func (e *Election) Leader(ctx context.Context) (*v3.GetResponse, error) {
 	return resp, nil
 }
 
+// Candidates returns the campaign key-values of the election in queue order;
+// the first one is the leader's.
+func (e *Election) Candidates(ctx context.Context) (*v3.GetResponse, error) {
+	client := e.session.Client()
+	return client.Get(ctx, e.keyPrefix, v3.WithPrefix(), v3.WithSort(v3.SortByCreateRevision, v3.SortAscend))
+}
+
 // Observe returns a channel that reliably observes ordered leader proposals
 // as GetResponse values on every current elected leader key. It will not
 // necessarily fetch all historical leader updates, but will always post the
@@ -0,0 +1,276 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+const defaultElectorCheckInterval = time.Second
+
+// ElectorConfig configures an Elector.
+type ElectorConfig struct {
+	// Value is the value proclaimed by the elector while it leads.
+	Value string
+	// Priority is the priority of the elector. A leader hands leadership over
+	// to the campaigner with the highest priority above its own, if any.
+	Priority int64
+	// HealthCheck tells whether the process is healthy. The elector does not
+	// campaign while it fails, and resigns leadership when it fails while
+	// leading. It is optional.
+	HealthCheck func(ctx context.Context) error
+	// CheckInterval is how often the leader checks its health and looks for
+	// campaigners with a higher priority. Defaults to one second.
+	CheckInterval time.Duration
+
+	// OnStartedLeading is called in its own goroutine when the elector
+	// becomes the leader. Its context is canceled when leadership is lost.
+	OnStartedLeading func(ctx context.Context)
+	// OnStoppedLeading is called when the elector loses leadership.
+	OnStoppedLeading func()
+	// OnNewLeader is called with the key and value of each new leader of the
+	// election, including the elector itself.
+	OnNewLeader func(key, value string)
+}
+
+// Elector runs an election on behalf of a process: it campaigns, reports
+// leadership changes through callbacks, resigns when the process turns
+// unhealthy, and hands leadership over to campaigners with a higher priority.
+type Elector struct {
+	s   *Session
+	e   *Election
+	cfg ElectorConfig
+
+	pfx         string
+	priorityPfx string
+
+	transferc chan electorTransfer
+
+	mu sync.Mutex
+	// leadc is closed when the elector stops leading, nil while it does not lead.
+	leadc chan struct{}
+}
+
+type electorTransfer struct {
+	candidate string
+	errc      chan error
+}
+
+// NewElector creates an Elector for the election on the given prefix.
+func NewElector(s *Session, pfx string, cfg ElectorConfig) *Elector {
+	if cfg.CheckInterval <= 0 {
+		cfg.CheckInterval = defaultElectorCheckInterval
+	}
+	return &Elector{
+		s:           s,
+		e:           NewElection(s, pfx),
+		cfg:         cfg,
+		pfx:         pfx + "/",
+		priorityPfx: pfx + ".priority/",
+		transferc:   make(chan electorTransfer),
+	}
+}
+
+// Key returns the campaign key of the elector, which other leaders may
+// transfer leadership to.
+func (el *Elector) Key() string { return fmt.Sprintf("%s%x", el.pfx, el.s.Lease()) }
+
+// Run campaigns for leadership until the context is canceled or the session
+// expires, campaigning again whenever leadership is lost. It resigns
+// leadership before returning.
+func (el *Elector) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	if el.cfg.OnNewLeader != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			el.observe(ctx)
+		}()
+	}
+	if el.cfg.Priority != 0 {
+		client := el.s.Client()
+		pkey := fmt.Sprintf("%s%x", el.priorityPfx, el.s.Lease())
+		if _, err := client.Put(ctx, pkey, strconv.FormatInt(el.cfg.Priority, 10), v3.WithLease(el.s.Lease())); err != nil {
+			return err
+		}
+	}
+
+	for {
+		if err := el.waitHealthy(ctx); err != nil {
+			return err
+		}
+		if err := el.e.Campaign(ctx, el.cfg.Value); err != nil {
+			return err
+		}
+		el.lead(ctx)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-el.s.Done():
+			return ErrSessionExpired
+		default:
+		}
+	}
+}
+
+// Transfer hands leadership over to the campaigner with the given key. It
+// returns ErrElectionNotLeader if the elector is not leading.
+func (el *Elector) Transfer(ctx context.Context, candidateKey string) error {
+	el.mu.Lock()
+	leadc := el.leadc
+	el.mu.Unlock()
+	if leadc == nil {
+		return ErrElectionNotLeader
+	}
+
+	t := electorTransfer{candidate: candidateKey, errc: make(chan error, 1)}
+	select {
+	case el.transferc <- t:
+	case <-leadc:
+		return ErrElectionNotLeader
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-t.errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// lead runs the leader until leadership is lost, resigned or handed over.
+func (el *Elector) lead(ctx context.Context) {
+	client := el.s.Client()
+	lctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	leadc := make(chan struct{})
+	el.mu.Lock()
+	el.leadc = leadc
+	el.mu.Unlock()
+	defer func() {
+		el.mu.Lock()
+		el.leadc = nil
+		el.mu.Unlock()
+		close(leadc)
+	}()
+
+	if el.cfg.OnStartedLeading != nil {
+		go el.cfg.OnStartedLeading(lctx)
+	}
+	if el.cfg.OnStoppedLeading != nil {
+		defer el.cfg.OnStoppedLeading()
+	}
+
+	wch := client.Watch(lctx, el.e.Key(), v3.WithRev(el.e.Header().Revision+1), v3.WithFilterPut())
+	ticker := time.NewTicker(el.cfg.CheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lctx.Done():
+			el.e.Resign(client.Ctx())
+			return
+		case <-el.s.Done():
+			return
+		case wr, ok := <-wch:
+			if !ok || wr.Err() != nil || len(wr.Events) != 0 {
+				// the leader key is gone
+				return
+			}
+		case t := <-el.transferc:
+			err := el.e.Transfer(lctx, t.candidate)
+			t.errc <- err
+			if err == nil || errors.Is(err, ErrElectionNotLeader) {
+				return
+			}
+		case <-ticker.C:
+			if el.cfg.HealthCheck != nil && el.cfg.HealthCheck(lctx) != nil {
+				el.e.Resign(lctx)
+				return
+			}
+			if candidate := el.preemptor(lctx); candidate != "" {
+				if err := el.e.Transfer(lctx, candidate); err == nil || errors.Is(err, ErrElectionNotLeader) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// preemptor returns the key of the campaigner with the highest priority above
+// the elector's own, or an empty string if there is none.
+func (el *Elector) preemptor(ctx context.Context) string {
+	resp, err := el.s.Client().Get(ctx, el.priorityPfx, v3.WithPrefix())
+	if err != nil {
+		return ""
+	}
+	var candidate string
+	best := el.cfg.Priority
+	for _, kv := range resp.Kvs {
+		p, err := strconv.ParseInt(string(kv.Value), 10, 64)
+		if err != nil || p <= best {
+			continue
+		}
+		best, candidate = p, el.pfx+strings.TrimPrefix(string(kv.Key), el.priorityPfx)
+	}
+	return candidate
+}
+
+// waitHealthy waits for the health check to pass.
+func (el *Elector) waitHealthy(ctx context.Context) error {
+	if el.cfg.HealthCheck == nil {
+		return nil
+	}
+	ticker := time.NewTicker(el.cfg.CheckInterval)
+	defer ticker.Stop()
+	for el.cfg.HealthCheck(ctx) != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-el.s.Done():
+			return ErrSessionExpired
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+// observe reports the leaders of the election to OnNewLeader.
+func (el *Elector) observe(ctx context.Context) {
+	var leader string
+	for resp := range el.e.Observe(ctx) {
+		kv := resp.Kvs[0]
+		if string(kv.Key) == leader {
+			continue
+		}
+		leader = string(kv.Key)
+		el.cfg.OnNewLeader(leader, string(kv.Value))
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// ErrNotOwner is returned by the Commit of a guarded Txn when the lock guarding
+// it is no longer held.
+var ErrNotOwner = errors.New("concurrency: lock is not held")
+
+// FencedMutex is a Mutex that hands out a fencing token when it is locked.
+// The token is the create revision of the lock key, so a later holder of the
+// lock always gets a greater token than an earlier one. Services guarded by
+// the lock can reject requests carrying a token older than the last one seen.
+type FencedMutex struct {
+	*Mutex
+}
+
+// NewFencedMutex creates a FencedMutex with the given prefix for its keys.
+func NewFencedMutex(s *Session, pfx string) *FencedMutex {
+	return &FencedMutex{NewMutex(s, pfx)}
+}
+
+// Lock locks the mutex and returns its fencing token.
+func (fm *FencedMutex) Lock(ctx context.Context) (int64, error) {
+	if err := fm.Mutex.Lock(ctx); err != nil {
+		return 0, err
+	}
+	return fm.Token(), nil
+}
+
+// TryLock locks the mutex if not already locked by another session and returns
+// its fencing token.
+func (fm *FencedMutex) TryLock(ctx context.Context) (int64, error) {
+	if err := fm.Mutex.TryLock(ctx); err != nil {
+		return 0, err
+	}
+	return fm.Token(), nil
+}
+
+// Token returns the fencing token of the held lock, or 0 if it is not held.
+func (fm *FencedMutex) Token() int64 {
+	if fm.myRev <= 0 {
+		return 0
+	}
+	return fm.myRev
+}
+
+// Txn returns a Txn that only applies while the lock is held.
+func (fm *FencedMutex) Txn(ctx context.Context) v3.Txn {
+	return NewGuardedTxn(ctx, fm.s.Client(), fm.IsOwner())
+}
+
+// NewGuardedTxn returns a Txn that only applies if all the guards hold, such
+// as the IsOwner comparisons of locks. The comparisons given to If decide
+// between the Then and Else operations as usual; if any guard fails, no
+// operation is applied and Commit returns ErrNotOwner.
+func NewGuardedTxn(ctx context.Context, client *v3.Client, guards ...v3.Cmp) v3.Txn {
+	return &guardedTxn{txn: client.Txn(ctx), guards: guards}
+}
+
+type guardedTxn struct {
+	txn    v3.Txn
+	guards []v3.Cmp
+
+	mu      sync.Mutex
+	cmps    []v3.Cmp
+	thenOps []v3.Op
+	elseOps []v3.Op
+}
+
+func (gt *guardedTxn) If(cs ...v3.Cmp) v3.Txn {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.cmps = append(gt.cmps, cs...)
+	return gt
+}
+
+func (gt *guardedTxn) Then(ops ...v3.Op) v3.Txn {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.thenOps = append(gt.thenOps, ops...)
+	return gt
+}
+
+func (gt *guardedTxn) Else(ops ...v3.Op) v3.Txn {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+	gt.elseOps = append(gt.elseOps, ops...)
+	return gt
+}
+
+func (gt *guardedTxn) Commit() (*v3.TxnResponse, error) {
+	gt.mu.Lock()
+	defer gt.mu.Unlock()
+
+	resp, err := gt.txn.If(gt.guards...).Then(v3.OpTxn(gt.cmps, gt.thenOps, gt.elseOps)).Commit()
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Succeeded {
+		return nil, ErrNotOwner
+	}
+	tresp := (*v3.TxnResponse)(resp.Responses[0].GetResponseTxn())
+	// the nested response has no header of its own
+	tresp.Header = resp.Header
+	return tresp, nil
+}
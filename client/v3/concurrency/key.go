@@ -62,3 +62,25 @@ func waitDeletes(ctx context.Context, client *v3.Client, pfx string, maxCreateRe
 		}
 	}
 }
+
+// waitPrefixDelete waits for any key with the given prefix to be deleted at
+// or after the given revision.
+func waitPrefixDelete(ctx context.Context, client *v3.Client, pfx string, rev int64) error {
+	cctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wr v3.WatchResponse
+	wch := client.Watch(cctx, pfx, v3.WithPrefix(), v3.WithRev(rev), v3.WithFilterPut())
+	for wr = range wch {
+		if len(wr.Events) != 0 {
+			return nil
+		}
+	}
+	if err := wr.Err(); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return errors.New("lost watcher waiting for delete")
+}
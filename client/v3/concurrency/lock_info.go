@@ -0,0 +1,99 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// LockOwner describes a session holding or waiting for a Mutex.
+type LockOwner struct {
+	// Key is the lock key of the session.
+	Key string
+	// Lease is the lease of the session.
+	Lease v3.LeaseID
+	// TTL is the remaining TTL of the lease in seconds, or -1 if it expired.
+	TTL int64
+	// CreateRevision is the revision the session asked for the lock at.
+	CreateRevision int64
+}
+
+// LockInfo describes the sessions holding and waiting for a Mutex.
+type LockInfo struct {
+	Header *pb.ResponseHeader
+	// Holder is the session holding the lock, or nil if it is not held.
+	Holder *LockOwner
+	// Waiters are the sessions waiting for the lock, in queue order.
+	Waiters []LockOwner
+}
+
+// GetLockInfo returns the sessions holding and waiting for the Mutex with the
+// given prefix.
+func GetLockInfo(ctx context.Context, client *v3.Client, pfx string) (*LockInfo, error) {
+	resp, err := client.Get(ctx, pfx+"/", v3.WithPrefix(), v3.WithKeysOnly(), v3.WithSort(v3.SortByCreateRevision, v3.SortAscend))
+	if err != nil {
+		return nil, err
+	}
+	info := &LockInfo{Header: resp.Header}
+	for i, kv := range resp.Kvs {
+		owner := LockOwner{
+			Key:            string(kv.Key),
+			Lease:          v3.LeaseID(kv.Lease),
+			TTL:            -1,
+			CreateRevision: kv.CreateRevision,
+		}
+		if kv.Lease != 0 {
+			lresp, lerr := client.TimeToLive(ctx, owner.Lease)
+			if lerr != nil {
+				return nil, lerr
+			}
+			owner.TTL = lresp.TTL
+		}
+		if i == 0 {
+			info.Holder = &owner
+		} else {
+			info.Waiters = append(info.Waiters, owner)
+		}
+	}
+	return info, nil
+}
+
+// ForceUnlock releases the Mutex with the given prefix on behalf of the session
+// holding it, so that the next waiting session gets hold of it. It returns the
+// released lock key, or an empty key if the lock was not held.
+func ForceUnlock(ctx context.Context, client *v3.Client, pfx string) (string, *pb.ResponseHeader, error) {
+	for {
+		resp, err := client.Get(ctx, pfx+"/", v3.WithFirstCreate()...)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(resp.Kvs) == 0 {
+			return "", resp.Header, nil
+		}
+		kv := resp.Kvs[0]
+		cmp := v3.Compare(v3.CreateRevision(string(kv.Key)), "=", kv.CreateRevision)
+		tresp, err := client.Txn(ctx).If(cmp).Then(v3.OpDelete(string(kv.Key))).Commit()
+		if err != nil {
+			return "", nil, err
+		}
+		if tresp.Succeeded {
+			return string(kv.Key), tresp.Header, nil
+		}
+		// the holder released the lock meanwhile
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"fmt"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// RWMutex is a fair reader/writer lock with etcd. The lock is granted in the
+// order it is requested: a reader waits for all the writers that asked for the
+// lock before it, and a writer waits for all the readers and writers that
+// asked for the lock before it. The lock is released when the session expires.
+type RWMutex struct {
+	s *Session
+
+	pfx   string
+	myKey string
+	myRev int64
+	hdr   *pb.ResponseHeader
+}
+
+// NewRWMutex creates a RWMutex with the given prefix for its keys.
+func NewRWMutex(s *Session, pfx string) *RWMutex {
+	return &RWMutex{s: s, pfx: pfx + "/", myRev: -1}
+}
+
+// RLock locks rwm for reading, waiting for the writers that asked for the lock
+// before it to release it.
+func (rwm *RWMutex) RLock(ctx context.Context) error {
+	return rwm.lock(ctx, "read", rwm.pfx+"write/")
+}
+
+// Lock locks rwm for writing, waiting for the readers and writers that asked
+// for the lock before it to release it.
+func (rwm *RWMutex) Lock(ctx context.Context) error {
+	return rwm.lock(ctx, "write", rwm.pfx)
+}
+
+func (rwm *RWMutex) lock(ctx context.Context, kind, waitPfx string) error {
+	client := rwm.s.Client()
+
+	rwm.myKey = fmt.Sprintf("%s%s/%x", rwm.pfx, kind, rwm.s.Lease())
+	cmp := v3.Compare(v3.CreateRevision(rwm.myKey), "=", 0)
+	put := v3.OpPut(rwm.myKey, "", v3.WithLease(rwm.s.Lease()))
+	// reuse key in case this session already holds the lock
+	get := v3.OpGet(rwm.myKey)
+	resp, err := client.Txn(ctx).If(cmp).Then(put).Else(get).Commit()
+	if err != nil {
+		return err
+	}
+	rwm.myRev = resp.Header.Revision
+	if !resp.Succeeded {
+		rwm.myRev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+
+	// wait for deletion revisions prior to myKey
+	if werr := waitDeletes(ctx, client, waitPfx, rwm.myRev-1); werr != nil {
+		rwm.Unlock(client.Ctx())
+		return werr
+	}
+
+	// make sure the session is not expired, and the owner key still exists.
+	gresp, werr := client.Get(ctx, rwm.myKey)
+	if werr != nil {
+		rwm.Unlock(client.Ctx())
+		return werr
+	}
+	if len(gresp.Kvs) == 0 {
+		return ErrSessionExpired
+	}
+	rwm.hdr = gresp.Header
+	return nil
+}
+
+// RUnlock releases the read lock.
+func (rwm *RWMutex) RUnlock(ctx context.Context) error { return rwm.Unlock(ctx) }
+
+// Unlock releases the lock, whether it is held for reading or writing.
+func (rwm *RWMutex) Unlock(ctx context.Context) error {
+	if rwm.myKey == "" || rwm.myRev <= 0 || rwm.myKey == "\x00" {
+		return ErrLockReleased
+	}
+	if _, err := rwm.s.Client().Delete(ctx, rwm.myKey); err != nil {
+		return err
+	}
+	rwm.myKey = "\x00"
+	rwm.myRev = -1
+	return nil
+}
+
+// IsOwner returns a comparison that holds while rwm holds the lock.
+func (rwm *RWMutex) IsOwner() v3.Cmp {
+	return v3.Compare(v3.CreateRevision(rwm.myKey), "=", rwm.myRev)
+}
+
+// Key returns the key of the lock held by rwm.
+func (rwm *RWMutex) Key() string { return rwm.myKey }
+
+// Header is the response header received from etcd on acquiring the lock.
+func (rwm *RWMutex) Header() *pb.ResponseHeader { return rwm.hdr }
@@ -0,0 +1,162 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package concurrency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+var (
+	// ErrNoPermit is returned by TryAcquire when all the permits of the Semaphore are held.
+	ErrNoPermit = errors.New("semaphore: no permit available")
+	// ErrInvalidPermits is returned by NewSemaphore when the number of permits is not positive.
+	ErrInvalidPermits = errors.New("semaphore: number of permits must be positive")
+)
+
+// Semaphore is a counting semaphore with etcd. Up to a fixed number of sessions
+// hold a permit at the same time; the others wait for a permit in the order they
+// asked for it. A permit is released when the session expires. All the sessions
+// using the same prefix must use the same number of permits.
+type Semaphore struct {
+	s *Session
+
+	pfx     string
+	permits int
+	myKey   string
+	myRev   int64
+	hdr     *pb.ResponseHeader
+}
+
+// NewSemaphore creates a Semaphore with the given prefix for its keys and the
+// given number of permits, which must be positive.
+func NewSemaphore(s *Session, pfx string, permits int) (*Semaphore, error) {
+	if permits <= 0 {
+		return nil, ErrInvalidPermits
+	}
+	return &Semaphore{s: s, pfx: pfx + "/", permits: permits, myRev: -1}, nil
+}
+
+// Acquire acquires a permit, waiting for one to be released if they are all held.
+func (sm *Semaphore) Acquire(ctx context.Context) error {
+	if err := sm.enqueue(ctx); err != nil {
+		return err
+	}
+	client := sm.s.Client()
+	for {
+		ok, rev, err := sm.hasPermit(ctx)
+		if err != nil {
+			sm.Release(client.Ctx())
+			return err
+		}
+		if ok {
+			break
+		}
+		// wait for a holder or an earlier waiter to go away
+		if err = waitPrefixDelete(ctx, client, sm.pfx, rev+1); err != nil {
+			sm.Release(client.Ctx())
+			return err
+		}
+	}
+
+	// make sure the session is not expired, and the permit key still exists.
+	gresp, err := client.Get(ctx, sm.myKey)
+	if err != nil {
+		sm.Release(client.Ctx())
+		return err
+	}
+	if len(gresp.Kvs) == 0 {
+		return ErrSessionExpired
+	}
+	sm.hdr = gresp.Header
+	return nil
+}
+
+// TryAcquire acquires a permit if one is available. If all the permits are
+// held, it returns ErrNoPermit immediately after attempting necessary cleanup.
+func (sm *Semaphore) TryAcquire(ctx context.Context) error {
+	if err := sm.enqueue(ctx); err != nil {
+		return err
+	}
+	ok, _, err := sm.hasPermit(ctx)
+	if err != nil {
+		sm.Release(sm.s.Client().Ctx())
+		return err
+	}
+	if !ok {
+		if err = sm.Release(ctx); err != nil {
+			return err
+		}
+		return ErrNoPermit
+	}
+	return nil
+}
+
+func (sm *Semaphore) enqueue(ctx context.Context) error {
+	client := sm.s.Client()
+	sm.myKey = fmt.Sprintf("%s%x", sm.pfx, sm.s.Lease())
+	cmp := v3.Compare(v3.CreateRevision(sm.myKey), "=", 0)
+	put := v3.OpPut(sm.myKey, "", v3.WithLease(sm.s.Lease()))
+	// reuse key in case this session already holds a permit
+	get := v3.OpGet(sm.myKey)
+	resp, err := client.Txn(ctx).If(cmp).Then(put).Else(get).Commit()
+	if err != nil {
+		return err
+	}
+	sm.myRev = resp.Header.Revision
+	sm.hdr = resp.Header
+	if !resp.Succeeded {
+		sm.myRev = resp.Responses[0].GetResponseRange().Kvs[0].CreateRevision
+	}
+	return nil
+}
+
+// hasPermit tells whether fewer sessions than the number of permits asked for
+// a permit before sm, along with the revision it was checked at.
+func (sm *Semaphore) hasPermit(ctx context.Context) (bool, int64, error) {
+	resp, err := sm.s.Client().Get(ctx, sm.pfx, v3.WithPrefix(), v3.WithKeysOnly(), v3.WithMaxCreateRev(sm.myRev-1))
+	if err != nil {
+		return false, 0, err
+	}
+	return len(resp.Kvs) < sm.permits, resp.Header.Revision, nil
+}
+
+// Release releases the permit.
+func (sm *Semaphore) Release(ctx context.Context) error {
+	if sm.myKey == "" || sm.myRev <= 0 || sm.myKey == "\x00" {
+		return ErrLockReleased
+	}
+	if _, err := sm.s.Client().Delete(ctx, sm.myKey); err != nil {
+		return err
+	}
+	sm.myKey = "\x00"
+	sm.myRev = -1
+	return nil
+}
+
+// IsOwner returns a comparison that holds while sm holds a permit.
+func (sm *Semaphore) IsOwner() v3.Cmp {
+	return v3.Compare(v3.CreateRevision(sm.myKey), "=", sm.myRev)
+}
+
+// Key returns the key of the permit held by sm.
+func (sm *Semaphore) Key() string { return sm.myKey }
+
+// Header is the response header received from etcd on acquiring the permit.
+func (sm *Semaphore) Header() *pb.ResponseHeader { return sm.hdr }
@@ -55,6 +55,18 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	}
 
 	ctx, cancel := context.WithCancel(ops.ctx)
+	if ops.groupID != 0 {
+		if _, err := client.GroupAttach(ctx, ops.groupID, id); err != nil {
+			cancel()
+			return nil, err
+		}
+		donec := make(chan struct{})
+		s := &Session{client: client, opts: ops, id: id, ctx: ctx, cancel: cancel, donec: donec}
+		// the lease is kept alive by the keepalive of its group
+		go s.waitGroupLease(donec)
+		return s, nil
+	}
+
 	keepAlive, err := client.KeepAlive(ctx, id)
 	if err != nil || keepAlive == nil {
 		cancel()
@@ -78,6 +90,45 @@ func NewSession(client *v3.Client, opts ...SessionOption) (*Session, error) {
 	return s, nil
 }
 
+// waitGroupLease closes donec and cancels the session once its lease, kept
+// alive by the keepalive of its group, expires. The lease is only checked
+// once its remaining TTL falls to a third of the session TTL, which does not
+// happen while the group is kept alive, and again when it would expire.
+func (s *Session) waitGroupLease(donec chan<- struct{}) {
+	defer func() {
+		close(donec)
+		s.cancel()
+	}()
+
+	ttl := time.Duration(s.opts.ttl) * time.Second
+	deadline := time.Now().Add(ttl)
+	for {
+		wait := time.Until(deadline) - ttl/3
+		if wait <= 0 {
+			wait = time.Until(deadline)
+		}
+		select {
+		case <-time.After(wait):
+		case <-s.ctx.Done():
+			return
+		}
+
+		resp, err := s.client.TimeToLive(s.ctx, s.id)
+		switch {
+		case s.ctx.Err() != nil:
+			return
+		case err == nil && resp.TTL <= 0:
+			// the lease expired, or is about to without being renewed
+			return
+		case err == nil:
+			deadline = time.Now().Add(time.Duration(resp.TTL) * time.Second)
+		case !time.Now().Before(deadline):
+			// the lease is assumed to be expired if it cannot be checked
+			return
+		}
+	}
+}
+
 // Client is the etcd client that is attached to the session.
 func (s *Session) Client() *v3.Client {
 	return s.client
@@ -117,6 +168,7 @@ func (s *Session) Close() error {
 type sessionOptions struct {
 	ttl     int
 	leaseID v3.LeaseID
+	groupID v3.LeaseGroupID
 	ctx     context.Context
 }
 
@@ -144,6 +196,18 @@ func WithLease(leaseID v3.LeaseID) SessionOption {
 	}
 }
 
+// WithLeaseGroup attaches the session lease to the given lease group instead
+// of keeping the lease alive on its own. The lease is then renewed by the
+// keepalive of the group, which is left to the caller, so that many sessions
+// are renewed by a single request. The session is done once it is orphaned,
+// its context is canceled, or its lease expires because the group is no
+// longer kept alive.
+func WithLeaseGroup(groupID v3.LeaseGroupID) SessionOption {
+	return func(so *sessionOptions, _ *zap.Logger) {
+		so.groupID = groupID
+	}
+}
+
 // WithContext assigns a context to the session instead of defaulting to
 // using the client context. This is useful for canceling NewSession and
 // Close operations immediately without having to close the client. If the
@@ -15,9 +15,16 @@
 package concurrency
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"math"
+	"math/rand"
+	"sort"
+	"time"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	v3 "go.etcd.io/etcd/client/v3"
 )
 
@@ -26,6 +33,17 @@ type STM interface {
 	// Get returns the value for a key and inserts the key in the txn's read set.
 	// If Get fails, it aborts the transaction with an error, never returning.
 	Get(key ...string) string
+	// Range returns the key-values in the range [key, end), including the
+	// txn's own writes, and inserts the range in the txn's read set, so that
+	// any change to the range fails the txn. An empty end reads the single
+	// key, and an end of "\x00" reads all the keys from key on. The revisions
+	// of the key-values written by the txn are zero. Since every key read
+	// must still exist at commit, each key read takes a compare in the
+	// committing txn, and Range aborts the transaction with ErrRangeTooLarge
+	// once the read set needs more compares than the txn ops limit (see
+	// WithMaxTxnOps). If Range fails, it aborts the transaction with an
+	// error, never returning.
+	Range(key, end string) []*mvccpb.KeyValue
 	// Put adds a value for a key to the write set.
 	Put(key, val string, opts ...v3.OpOption)
 	// Rev returns the revision of a key in the read set.
@@ -35,6 +53,8 @@ type STM interface {
 
 	// commit attempts to apply the txn's changes to the server.
 	commit() *v3.TxnResponse
+	// conflicted returns the keys that made the last commit fail.
+	conflicted() []string
 	reset()
 }
 
@@ -56,13 +76,73 @@ const (
 	ReadCommitted
 )
 
+// defaultMaxTxnOps is the default limit of operations in a txn of the server.
+const defaultMaxTxnOps = 128
+
+// ErrRangeTooLarge is returned when the ranges read by an STM transaction need
+// more compares than a txn may hold.
+var ErrRangeTooLarge = errors.New("stm: read set too large for a single txn")
+
+// ConflictError is returned when an STM transaction keeps conflicting with
+// other writers until its retry policy gives up.
+type ConflictError struct {
+	// Keys are the keys that conflicted on the last attempt.
+	Keys []string
+	// Attempts is the number of times the transaction was tried.
+	Attempts int
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("stm: conflict on keys %q after %d attempts", e.Keys, e.Attempts)
+}
+
+// RetryPolicy describes how an STM transaction is retried on conflicts.
+type RetryPolicy struct {
+	// MaxAttempts is the number of times the transaction is tried before
+	// giving up with a ConflictError. Zero means no limit.
+	MaxAttempts int
+	// Backoff is the wait before the first retry. It doubles with each retry,
+	// up to MaxBackoff, and is jittered so that conflicting transactions do
+	// not retry in lockstep. Zero means retrying right away.
+	Backoff time.Duration
+	// MaxBackoff bounds the wait between retries. Zero means no bound.
+	MaxBackoff time.Duration
+}
+
+// wait waits before retrying the transaction for the given attempt.
+func (rp RetryPolicy) wait(ctx context.Context, attempt int) error {
+	if rp.Backoff <= 0 {
+		return nil
+	}
+	d := rp.Backoff
+	for i := 1; i < attempt && (rp.MaxBackoff <= 0 || d < rp.MaxBackoff); i++ {
+		d *= 2
+	}
+	if rp.MaxBackoff > 0 && d > rp.MaxBackoff {
+		d = rp.MaxBackoff
+	}
+	// wait anywhere between half and all of the backoff
+	d = d/2 + time.Duration(rand.Int63n(int64(d/2)+1))
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 // stmError safely passes STM errors through panic to the STM error channel.
 type stmError struct{ err error }
 
 type stmOptions struct {
-	iso      Isolation
-	ctx      context.Context
-	prefetch []string
+	iso        Isolation
+	ctx        context.Context
+	prefetch   []string
+	retry      RetryPolicy
+	onConflict func(attempt int, keys []string)
+	maxTxnOps  int
 }
 
 type stmOption func(*stmOptions)
@@ -85,9 +165,29 @@ func WithPrefetch(keys ...string) stmOption {
 	return func(so *stmOptions) { so.prefetch = append(so.prefetch, keys...) }
 }
 
+// WithRetryPolicy specifies how the transaction is retried on conflicts. By
+// default, it is retried right away until it succeeds.
+func WithRetryPolicy(rp RetryPolicy) stmOption {
+	return func(so *stmOptions) { so.retry = rp }
+}
+
+// WithConflictCallback specifies a function called with the attempt number and
+// the conflicting keys each time the transaction fails to commit because of a
+// conflict, for instance to count conflicts.
+func WithConflictCallback(f func(attempt int, keys []string)) stmOption {
+	return func(so *stmOptions) { so.onConflict = f }
+}
+
+// WithMaxTxnOps specifies the maximum number of operations in a txn, which the
+// read set of the transaction may not exceed. It must match the --max-txn-ops
+// of the server and defaults to 128.
+func WithMaxTxnOps(n uint) stmOption {
+	return func(so *stmOptions) { so.maxTxnOps = int(n) }
+}
+
 // NewSTM initiates a new STM instance, using serializable snapshot isolation by default.
 func NewSTM(c *v3.Client, apply func(STM) error, so ...stmOption) (*v3.TxnResponse, error) {
-	opts := &stmOptions{ctx: c.Ctx()}
+	opts := &stmOptions{ctx: c.Ctx(), maxTxnOps: defaultMaxTxnOps}
 	for _, f := range so {
 		f(opts)
 	}
@@ -98,30 +198,32 @@ func NewSTM(c *v3.Client, apply func(STM) error, so ...stmOption) (*v3.TxnRespon
 			return f(s)
 		}
 	}
-	return runSTM(mkSTM(c, opts), apply)
+	return runSTM(mkSTM(c, opts), apply, opts)
 }
 
 func mkSTM(c *v3.Client, opts *stmOptions) STM {
 	switch opts.iso {
 	case SerializableSnapshot:
 		s := &stmSerializable{
-			stm:      stm{client: c, ctx: opts.ctx},
+			stm:      stm{client: c, ctx: opts.ctx, maxTxnOps: opts.maxTxnOps},
 			prefetch: make(map[string]*v3.GetResponse),
 		}
+		s.checkWrites = true
 		s.conflicts = func() []v3.Cmp {
-			return append(s.rset.cmps(), s.wset.cmps(s.rset.first()+1)...)
+			cmps := append(s.rset.cmps(), s.rngs.cmps()...)
+			return append(cmps, s.wset.cmps(s.first()+1)...)
 		}
 		return s
 	case Serializable:
 		s := &stmSerializable{
-			stm:      stm{client: c, ctx: opts.ctx},
+			stm:      stm{client: c, ctx: opts.ctx, maxTxnOps: opts.maxTxnOps},
 			prefetch: make(map[string]*v3.GetResponse),
 		}
-		s.conflicts = func() []v3.Cmp { return s.rset.cmps() }
+		s.conflicts = func() []v3.Cmp { return append(s.rset.cmps(), s.rngs.cmps()...) }
 		return s
 	case RepeatableReads:
-		s := &stm{client: c, ctx: opts.ctx, getOpts: []v3.OpOption{v3.WithSerializable()}}
-		s.conflicts = func() []v3.Cmp { return s.rset.cmps() }
+		s := &stm{client: c, ctx: opts.ctx, getOpts: []v3.OpOption{v3.WithSerializable()}, maxTxnOps: opts.maxTxnOps}
+		s.conflicts = func() []v3.Cmp { return append(s.rset.cmps(), s.rngs.cmps()...) }
 		return s
 	case ReadCommitted:
 		s := &stm{client: c, ctx: opts.ctx, getOpts: []v3.OpOption{v3.WithSerializable()}}
@@ -137,7 +239,7 @@ type stmResponse struct {
 	err  error
 }
 
-func runSTM(s STM, apply func(STM) error) (*v3.TxnResponse, error) {
+func runSTM(s STM, apply func(STM) error, opts *stmOptions) (*v3.TxnResponse, error) {
 	outc := make(chan stmResponse, 1)
 	go func() {
 		defer func() {
@@ -151,7 +253,7 @@ func runSTM(s STM, apply func(STM) error) (*v3.TxnResponse, error) {
 			}
 		}()
 		var out stmResponse
-		for {
+		for attempt := 1; ; attempt++ {
 			s.reset()
 			if out.err = apply(s); out.err != nil {
 				break
@@ -159,6 +261,17 @@ func runSTM(s STM, apply func(STM) error) (*v3.TxnResponse, error) {
 			if out.resp = s.commit(); out.resp != nil {
 				break
 			}
+			keys := s.conflicted()
+			if opts.onConflict != nil {
+				opts.onConflict(attempt, keys)
+			}
+			if opts.retry.MaxAttempts > 0 && attempt >= opts.retry.MaxAttempts {
+				out.err = &ConflictError{Keys: keys, Attempts: attempt}
+				break
+			}
+			if out.err = opts.retry.wait(opts.ctx, attempt); out.err != nil {
+				break
+			}
 		}
 		outc <- out
 	}()
@@ -172,12 +285,20 @@ type stm struct {
 	ctx    context.Context
 	// rset holds read key values and revisions
 	rset readSet
+	// rngs holds read ranges and their revisions
+	rngs rangeSet
 	// wset holds overwritten keys and their values
 	wset writeSet
 	// getOpts are the opts used for gets
 	getOpts []v3.OpOption
 	// conflicts computes the current conflicts on the txn
 	conflicts func() []v3.Cmp
+	// checkWrites tells whether the txn conflicts with writes to its write set
+	checkWrites bool
+	// conflictKeys holds the keys that made the last commit fail
+	conflictKeys []string
+	// maxTxnOps bounds the compares guarding the read set; zero means no bound
+	maxTxnOps int
 }
 
 type stmPut struct {
@@ -188,8 +309,8 @@ type stmPut struct {
 type readSet map[string]*v3.GetResponse
 
 func (rs readSet) add(keys []string, txnresp *v3.TxnResponse) {
-	for i, resp := range txnresp.Responses {
-		rs[keys[i]] = (*v3.GetResponse)(resp.GetResponseRange())
+	for i, key := range keys {
+		rs[key] = (*v3.GetResponse)(txnresp.Responses[i].GetResponseRange())
 	}
 }
 
@@ -213,6 +334,61 @@ func (rs readSet) cmps() []v3.Cmp {
 	return cmps
 }
 
+type stmRange struct{ key, end string }
+
+// rangeRead holds a range read and the revision it was read at.
+type rangeRead struct {
+	resp *v3.GetResponse
+	rev  int64
+}
+
+type rangeSet map[stmRange]rangeRead
+
+// first returns the earliest revision ranges were read at
+func (rs rangeSet) first() int64 {
+	ret := int64(math.MaxInt64 - 1)
+	for _, r := range rs {
+		if r.rev < ret {
+			ret = r.rev
+		}
+	}
+	return ret
+}
+
+// ncmps returns the number of compares guarding the read ranges.
+func (rs rangeSet) ncmps() int {
+	n := 0
+	for _, rr := range rs {
+		n += 1 + len(rr.resp.Kvs)
+	}
+	return n
+}
+
+// cmps guards the txn from updates to the read ranges: no key of a range may
+// be written past the read revision, and the keys read must still exist. A
+// compare on a range does not fail on deleted keys, so each key read takes
+// its own compare.
+func (rs rangeSet) cmps() []v3.Cmp {
+	var cmps []v3.Cmp
+	for r, rr := range rs {
+		cmps = append(cmps, v3.Compare(v3.ModRevision(r.key), "<", rr.rev+1).WithRange(r.end))
+		for _, kv := range rr.resp.Kvs {
+			cmps = append(cmps, v3.Compare(v3.ModRevision(string(kv.Key)), "=", kv.ModRevision))
+		}
+	}
+	return cmps
+}
+
+func (r stmRange) contains(key string) bool {
+	switch r.end {
+	case "":
+		return key == r.key
+	case "\x00":
+		return key >= r.key
+	}
+	return key >= r.key && key < r.end
+}
+
 type writeSet map[string]stmPut
 
 func (ws writeSet) get(keys ...string) *stmPut {
@@ -249,6 +425,28 @@ func (s *stm) Get(keys ...string) string {
 	return respToValue(s.fetch(keys...))
 }
 
+func (s *stm) Range(key, end string) []*mvccpb.KeyValue {
+	return s.withWrites(stmRange{key, end}, s.fetchRange(key, end, 0))
+}
+
+// withWrites returns the key-values of a range read updated with the writes
+// of the txn in the range.
+func (s *stm) withWrites(r stmRange, resp *v3.GetResponse) []*mvccpb.KeyValue {
+	kvs := make([]*mvccpb.KeyValue, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		if _, ok := s.wset[string(kv.Key)]; !ok {
+			kvs = append(kvs, kv)
+		}
+	}
+	for key, wv := range s.wset {
+		if r.contains(key) && !wv.op.IsDelete() {
+			kvs = append(kvs, &mvccpb.KeyValue{Key: []byte(key), Value: []byte(wv.val)})
+		}
+	}
+	sort.Slice(kvs, func(i, j int) bool { return bytes.Compare(kvs[i].Key, kvs[j].Key) < 0 })
+	return kvs
+}
+
 func (s *stm) Put(key, val string, opts ...v3.OpOption) {
 	s.wset[key] = stmPut{val, v3.OpPut(key, val, opts...)}
 }
@@ -263,16 +461,84 @@ func (s *stm) Rev(key string) int64 {
 }
 
 func (s *stm) commit() *v3.TxnResponse {
-	txnresp, err := s.client.Txn(s.ctx).If(s.conflicts()...).Then(s.wset.puts()...).Commit()
+	keys, getops := s.gets()
+	txn := s.client.Txn(s.ctx).If(s.conflicts()...).Then(s.wset.puts()...)
+	// use Else to fetch the read set in case of conflict to tell the conflicting keys
+	txnresp, err := txn.Else(getops...).Commit()
 	if err != nil {
 		panic(stmError{err})
 	}
 	if txnresp.Succeeded {
 		return txnresp
 	}
+	s.conflictKeys = s.findConflicts(keys, txnresp)
 	return nil
 }
 
+// gets returns the keys of the read set, along with the written keys if writes
+// conflict, and the ops fetching them followed by the ops fetching the ranges.
+func (s *stm) gets() ([]string, []v3.Op) {
+	keys := make([]string, 0, len(s.rset))
+	for k := range s.rset {
+		keys = append(keys, k)
+	}
+	if s.checkWrites {
+		for k := range s.wset {
+			if _, ok := s.rset[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+	}
+	ops := make([]v3.Op, 0, len(keys)+len(s.rngs))
+	for _, k := range keys {
+		ops = append(ops, v3.OpGet(k))
+	}
+	for r := range s.rngs {
+		ops = append(ops, v3.OpGet(r.key, v3.WithRange(r.end), v3.WithKeysOnly()))
+	}
+	return keys, ops
+}
+
+// findConflicts returns the keys changed since they were read, given the
+// responses to the ops from gets.
+func (s *stm) findConflicts(keys []string, txnresp *v3.TxnResponse) []string {
+	var conflicts []string
+	first := s.first()
+	for i, k := range keys {
+		cur := modRevision((*v3.GetResponse)(txnresp.Responses[i].GetResponseRange()))
+		rk, ok := s.rset[k]
+		if (ok && cur != modRevision(rk)) || (s.checkWrites && s.wset.get(k) != nil && cur > first) {
+			conflicts = append(conflicts, k)
+		}
+	}
+	i := len(keys)
+	for r := range s.rngs {
+		cur := txnresp.Responses[i].GetResponseRange()
+		i++
+		read := make(map[string]int64, len(s.rngs[r].resp.Kvs))
+		for _, kv := range s.rngs[r].resp.Kvs {
+			read[string(kv.Key)] = kv.ModRevision
+		}
+		for _, kv := range cur.Kvs {
+			if rev, ok := read[string(kv.Key)]; !ok || rev != kv.ModRevision {
+				conflicts = append(conflicts, string(kv.Key))
+			}
+			delete(read, string(kv.Key))
+		}
+		// keys deleted since the range was read
+		for k := range read {
+			conflicts = append(conflicts, k)
+		}
+	}
+	sort.Strings(conflicts)
+	return dedupe(conflicts)
+}
+
+func (s *stm) conflicted() []string { return s.conflictKeys }
+
+// first returns the store revision from the first fetch
+func (s *stm) first() int64 { return min(s.rset.first(), s.rngs.first()) }
+
 func (s *stm) fetch(keys ...string) *v3.GetResponse {
 	if len(keys) == 0 {
 		return nil
@@ -292,14 +558,39 @@ func (s *stm) fetch(keys ...string) *v3.GetResponse {
 	return (*v3.GetResponse)(txnresp.Responses[0].GetResponseRange())
 }
 
+// fetchRange reads a range at the given revision, or at the latest one if zero.
+func (s *stm) fetchRange(key, end string, rev int64) *v3.GetResponse {
+	r := stmRange{key, end}
+	if rr, ok := s.rngs[r]; ok {
+		return rr.resp
+	}
+	opts := append([]v3.OpOption{v3.WithRange(end)}, s.getOpts...)
+	resp, err := s.client.Get(s.ctx, key, opts...)
+	if err != nil {
+		panic(stmError{err})
+	}
+	if s.maxTxnOps > 0 && len(s.rset)+s.rngs.ncmps()+1+len(resp.Kvs) > s.maxTxnOps {
+		panic(stmError{ErrRangeTooLarge})
+	}
+	if rev == 0 {
+		rev = resp.Header.Revision
+	}
+	s.rngs[r] = rangeRead{resp: resp, rev: rev}
+	return resp
+}
+
 func (s *stm) reset() {
 	s.rset = make(map[string]*v3.GetResponse)
+	s.rngs = make(map[stmRange]rangeRead)
 	s.wset = make(map[string]stmPut)
+	s.conflictKeys = nil
 }
 
 type stmSerializable struct {
 	stm
 	prefetch map[string]*v3.GetResponse
+	// rev is the base revision of the txn, set by its first read
+	rev int64
 }
 
 func (s *stmSerializable) Get(keys ...string) string {
@@ -310,7 +601,7 @@ func (s *stmSerializable) Get(keys ...string) string {
 	if wv := s.wset.get(keys...); wv != nil {
 		return wv.val
 	}
-	firstRead := len(s.rset) == 0
+	firstRead := len(s.rset) == 0 && len(s.rngs) == 0
 	for _, key := range keys {
 		if resp, ok := s.prefetch[key]; ok {
 			delete(s.prefetch, key)
@@ -319,28 +610,32 @@ func (s *stmSerializable) Get(keys ...string) string {
 	}
 	resp := s.stm.fetch(keys...)
 	if firstRead {
-		// txn's base revision is defined by the first read
-		s.getOpts = []v3.OpOption{
-			v3.WithRev(resp.Header.Revision),
-			v3.WithSerializable(),
-		}
+		s.setBase(resp.Header.Revision)
 	}
 	return respToValue(resp)
 }
 
-func (s *stmSerializable) Rev(key string) int64 {
-	s.Get(key)
-	return s.stm.Rev(key)
+func (s *stmSerializable) Range(key, end string) []*mvccpb.KeyValue {
+	firstRead := len(s.rset) == 0 && len(s.rngs) == 0
+	resp := s.stm.fetchRange(key, end, s.rev)
+	if firstRead {
+		s.setBase(resp.Header.Revision)
+	}
+	return s.withWrites(stmRange{key, end}, resp)
 }
 
-func (s *stmSerializable) gets() ([]string, []v3.Op) {
-	keys := make([]string, 0, len(s.rset))
-	ops := make([]v3.Op, 0, len(s.rset))
-	for k := range s.rset {
-		keys = append(keys, k)
-		ops = append(ops, v3.OpGet(k))
+// setBase sets the txn's base revision, which is defined by the first read.
+func (s *stmSerializable) setBase(rev int64) {
+	s.rev = rev
+	s.getOpts = []v3.OpOption{
+		v3.WithRev(rev),
+		v3.WithSerializable(),
 	}
-	return keys, ops
+}
+
+func (s *stmSerializable) Rev(key string) int64 {
+	s.Get(key)
+	return s.stm.Rev(key)
 }
 
 func (s *stmSerializable) commit() *v3.TxnResponse {
@@ -354,10 +649,12 @@ func (s *stmSerializable) commit() *v3.TxnResponse {
 	if txnresp.Succeeded {
 		return txnresp
 	}
+	s.conflictKeys = s.findConflicts(keys, txnresp)
 	// load prefetch with Else data
 	s.rset.add(keys, txnresp)
 	s.prefetch = s.rset
 	s.getOpts = nil
+	s.rev = 0
 	return nil
 }
 
@@ -368,6 +665,27 @@ func isKeyCurrent(k string, r *v3.GetResponse) v3.Cmp {
 	return v3.Compare(v3.ModRevision(k), "=", 0)
 }
 
+func modRevision(resp *v3.GetResponse) int64 {
+	if len(resp.Kvs) == 0 {
+		return 0
+	}
+	return resp.Kvs[0].ModRevision
+}
+
+// dedupe removes adjacent duplicates from sorted keys.
+func dedupe(keys []string) []string {
+	if len(keys) == 0 {
+		return keys
+	}
+	out := keys[:1]
+	for _, k := range keys[1:] {
+		if k != out[len(out)-1] {
+			out = append(out, k)
+		}
+	}
+	return out
+}
+
 func respToValue(resp *v3.GetResponse) string {
 	if resp == nil || len(resp.Kvs) == 0 {
 		return ""
@@ -15,7 +15,9 @@
 package concurrency
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -50,3 +52,45 @@ func TestGet(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryPolicyWait(t *testing.T) {
+	rp := RetryPolicy{Backoff: 10 * time.Millisecond, MaxBackoff: 40 * time.Millisecond}
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{1, 5 * time.Millisecond, 10 * time.Millisecond},
+		{2, 10 * time.Millisecond, 20 * time.Millisecond},
+		{5, 20 * time.Millisecond, 40 * time.Millisecond},
+	}
+	for _, test := range tests {
+		start := time.Now()
+		assert.NoError(t, rp.wait(t.Context(), test.attempt))
+		took := time.Since(start)
+		assert.GreaterOrEqual(t, took, test.min)
+		// leave room for the scheduler
+		assert.Less(t, took, test.max+50*time.Millisecond)
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	assert.ErrorIs(t, rp.wait(ctx, 1), context.Canceled)
+}
+
+func TestRangeContains(t *testing.T) {
+	tests := []struct {
+		r    stmRange
+		key  string
+		want bool
+	}{
+		{stmRange{"a", ""}, "a", true},
+		{stmRange{"a", ""}, "ab", false},
+		{stmRange{"a", "c"}, "b", true},
+		{stmRange{"a", "c"}, "c", false},
+		{stmRange{"b", "\x00"}, "a", false},
+		{stmRange{"b", "\x00"}, "z", true},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, test.r.contains(test.key), "%+v contains %q", test.r, test.key)
+	}
+}
@@ -0,0 +1,404 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipe
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	v3 "go.etcd.io/etcd/client/v3"
+)
+
+// ErrClaimExpired is returned when acknowledging a work item whose claim
+// expired, so that it may have been delivered to another consumer.
+var ErrClaimExpired = errors.New("recipe: work item claim expired")
+
+const (
+	defaultVisibilityTimeout = 30 * time.Second
+	// workQueuePageSize is the number of item keys Dequeue reads at a time,
+	// whose claims are checked by a single transaction.
+	workQueuePageSize = 64
+)
+
+// WorkQueueConfig configures a WorkQueue.
+type WorkQueueConfig struct {
+	// VisibilityTimeout is how long a dequeued item stays claimed by its
+	// consumer before it is delivered again, unless the claim is extended.
+	// It is rounded up to the second. Defaults to 30 seconds.
+	VisibilityTimeout time.Duration
+	// MaxAttempts is the number of times an item is delivered before it is
+	// moved to the dead-letter prefix of the queue. Zero means no limit.
+	MaxAttempts int
+}
+
+// WorkQueue is a multi-reader, multi-writer distributed work queue with
+// at-least-once delivery. A dequeued item is claimed under a lease of its
+// consumer, and is delivered again once the claim expires unless the
+// consumer acknowledges it. Items are delivered in the order of their IDs,
+// which follow the revisions they were enqueued at.
+//
+// Items are kept under <prefix>/items, their claims under <prefix>/claims,
+// and dead-lettered items under <prefix>/dead.
+type WorkQueue struct {
+	client *v3.Client
+	cfg    WorkQueueConfig
+
+	itemPfx  string
+	claimPfx string
+	deadPfx  string
+}
+
+// WorkItem is an item of a WorkQueue.
+type WorkItem struct {
+	// ID identifies the item in the queue.
+	ID string
+	// Value is the enqueued value.
+	Value string
+	// Enqueued is when the item was enqueued.
+	Enqueued time.Time
+	// Attempts is the number of times the item was delivered, including this one.
+	Attempts int
+
+	q        *WorkQueue
+	lease    v3.LeaseID
+	claimRev int64
+}
+
+// workItem is the stored form of a work item.
+type workItem struct {
+	Value    string `json:"value"`
+	Enqueued int64  `json:"enqueued"`
+	Attempts int    `json:"attempts"`
+}
+
+// WorkQueueStats describes the content of a WorkQueue.
+type WorkQueueStats struct {
+	// Ready is the number of items waiting to be delivered.
+	Ready int
+	// InFlight is the number of items claimed by consumers.
+	InFlight int
+	// DeadLettered is the number of items that exceeded the maximum attempts.
+	DeadLettered int
+	// Oldest is when the oldest item in the queue, ready or in flight, was
+	// enqueued. It is zero if the queue is empty.
+	Oldest time.Time
+}
+
+// NewWorkQueue creates a WorkQueue with the given prefix for its keys.
+func NewWorkQueue(client *v3.Client, prefix string, cfg WorkQueueConfig) *WorkQueue {
+	if cfg.VisibilityTimeout <= 0 {
+		cfg.VisibilityTimeout = defaultVisibilityTimeout
+	}
+	return &WorkQueue{
+		client:   client,
+		cfg:      cfg,
+		itemPfx:  prefix + "/items/",
+		claimPfx: prefix + "/claims/",
+		deadPfx:  prefix + "/dead/",
+	}
+}
+
+// Enqueue adds a value to the queue and returns the ID of its item. The ID is
+// built from the revision the item is put at, and the item is only put if no
+// other producer took the ID first.
+func (q *WorkQueue) Enqueue(ctx context.Context, val string) (string, error) {
+	enqueued := time.Now()
+	data, err := json.Marshal(workItem{Value: val, Enqueued: enqueued.UnixNano()})
+	if err != nil {
+		return "", err
+	}
+	gresp, err := q.client.Get(ctx, q.itemPfx, v3.WithCountOnly())
+	if err != nil {
+		return "", err
+	}
+	rev := gresp.Header.Revision
+	for {
+		id := fmt.Sprintf("%016d", rev+1)
+		key := q.itemPfx + id
+		resp, err := q.client.Txn(ctx).If(v3.Compare(v3.Version(key), "=", 0)).Then(v3.OpPut(key, string(data))).Commit()
+		if err != nil {
+			return "", err
+		}
+		if resp.Succeeded {
+			return id, nil
+		}
+		rev = resp.Header.Revision
+	}
+}
+
+// Dequeue claims the first ready item of the queue. If no item is ready, it
+// blocks until one is. Items delivered too many times are moved to the
+// dead-letter prefix instead of being returned.
+//
+// The keys of the items are read a page at a time, and the value of an item
+// only once it is not claimed, so that a large backlog is not read at once.
+func (q *WorkQueue) Dequeue(ctx context.Context) (*WorkItem, error) {
+	var lease v3.LeaseID
+	for {
+		from, end := q.itemPfx, v3.GetPrefixRangeEnd(q.itemPfx)
+		rev := int64(0)
+		for {
+			resp, err := q.client.Get(ctx, from, v3.WithRange(end), v3.WithKeysOnly(), v3.WithLimit(workQueuePageSize))
+			if err != nil {
+				q.revoke(lease)
+				return nil, err
+			}
+			if rev == 0 {
+				rev = resp.Header.Revision
+			}
+			ids := make([]string, len(resp.Kvs))
+			for i, kv := range resp.Kvs {
+				ids[i] = strings.TrimPrefix(string(kv.Key), q.itemPfx)
+			}
+			claimed, err := q.claimed(ctx, ids)
+			if err != nil {
+				q.revoke(lease)
+				return nil, err
+			}
+			for i, id := range ids {
+				if claimed[i] {
+					continue
+				}
+				item, err := q.tryClaim(ctx, id, &lease)
+				if err != nil {
+					q.revoke(lease)
+					return nil, err
+				}
+				if item != nil {
+					return item, nil
+				}
+			}
+			if !resp.More || len(resp.Kvs) == 0 {
+				break
+			}
+			from = string(resp.Kvs[len(resp.Kvs)-1].Key) + "\x00"
+		}
+
+		// nothing ready; wait for items to be enqueued or claims to expire,
+		// without holding a lease that could expire meanwhile
+		q.revoke(lease)
+		lease = v3.NoLease
+		if err := q.wait(ctx, rev); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// claimed returns whether each of the items is claimed.
+func (q *WorkQueue) claimed(ctx context.Context, ids []string) ([]bool, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	ops := make([]v3.Op, len(ids))
+	for i, id := range ids {
+		ops[i] = v3.OpGet(q.claimPfx+id, v3.WithCountOnly())
+	}
+	resp, err := q.client.Txn(ctx).Then(ops...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	claimed := make([]bool, len(ids))
+	for i, r := range resp.Responses {
+		claimed[i] = r.GetResponseRange().Count != 0
+	}
+	return claimed, nil
+}
+
+// tryClaim reads the item and claims it, granting the lease of the claim if
+// there is none yet. It returns nil if the item is gone, dead-lettered, or
+// claimed by another consumer first.
+func (q *WorkQueue) tryClaim(ctx context.Context, id string, lease *v3.LeaseID) (*WorkItem, error) {
+	resp, err := q.client.Get(ctx, q.itemPfx+id)
+	if err != nil || len(resp.Kvs) == 0 {
+		return nil, err
+	}
+	kv := resp.Kvs[0]
+	var wi workItem
+	if err = json.Unmarshal(kv.Value, &wi); err != nil {
+		return nil, err
+	}
+	if q.cfg.MaxAttempts > 0 && wi.Attempts >= q.cfg.MaxAttempts {
+		return nil, q.deadLetter(ctx, id, kv)
+	}
+	if *lease == v3.NoLease {
+		ttl := int64((q.cfg.VisibilityTimeout + time.Second - 1) / time.Second)
+		lresp, err := q.client.Grant(ctx, ttl)
+		if err != nil {
+			return nil, err
+		}
+		*lease = lresp.ID
+	}
+	return q.claim(ctx, id, kv, wi, *lease)
+}
+
+// claim claims the item for the lease, returning nil if another consumer
+// claimed or changed it first.
+func (q *WorkQueue) claim(ctx context.Context, id string, kv *mvccpb.KeyValue, wi workItem, lease v3.LeaseID) (*WorkItem, error) {
+	wi.Attempts++
+	data, err := json.Marshal(wi)
+	if err != nil {
+		return nil, err
+	}
+	claimKey := q.claimPfx + id
+	resp, err := q.client.Txn(ctx).If(
+		v3.Compare(v3.Version(claimKey), "=", 0),
+		v3.Compare(v3.ModRevision(string(kv.Key)), "=", kv.ModRevision),
+	).Then(
+		v3.OpPut(claimKey, "", v3.WithLease(lease)),
+		v3.OpPut(string(kv.Key), string(data)),
+	).Commit()
+	if err != nil || !resp.Succeeded {
+		return nil, err
+	}
+	return &WorkItem{
+		ID:       id,
+		Value:    wi.Value,
+		Enqueued: time.Unix(0, wi.Enqueued),
+		Attempts: wi.Attempts,
+		q:        q,
+		lease:    lease,
+		claimRev: resp.Header.Revision,
+	}, nil
+}
+
+// deadLetter moves an unclaimed item to the dead-letter prefix.
+func (q *WorkQueue) deadLetter(ctx context.Context, id string, kv *mvccpb.KeyValue) error {
+	_, err := q.client.Txn(ctx).If(
+		v3.Compare(v3.Version(q.claimPfx+id), "=", 0),
+		v3.Compare(v3.ModRevision(string(kv.Key)), "=", kv.ModRevision),
+	).Then(
+		v3.OpPut(q.deadPfx+id, string(kv.Value)),
+		v3.OpDelete(string(kv.Key)),
+	).Commit()
+	return err
+}
+
+// wait waits for an item to be enqueued or a claim to go away after the
+// given revision.
+func (q *WorkQueue) wait(ctx context.Context, rev int64) error {
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	pfx := strings.TrimSuffix(q.itemPfx, "items/")
+	for wresp := range q.client.Watch(wctx, pfx, v3.WithPrefix(), v3.WithRev(rev+1)) {
+		if err := wresp.Err(); err != nil {
+			return err
+		}
+		for _, ev := range wresp.Events {
+			key := string(ev.Kv.Key)
+			if (ev.Type == mvccpb.PUT && ev.IsCreate() && strings.HasPrefix(key, q.itemPfx)) ||
+				(ev.Type == mvccpb.DELETE && strings.HasPrefix(key, q.claimPfx)) {
+				return nil
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return ErrNoWatcher
+}
+
+func (q *WorkQueue) revoke(lease v3.LeaseID) {
+	if lease != v3.NoLease {
+		q.client.Revoke(q.client.Ctx(), lease)
+	}
+}
+
+// DeadLetters returns the items moved to the dead-letter prefix of the queue.
+func (q *WorkQueue) DeadLetters(ctx context.Context) ([]*WorkItem, error) {
+	resp, err := q.client.Get(ctx, q.deadPfx, v3.WithPrefix(), v3.WithSort(v3.SortByCreateRevision, v3.SortAscend))
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*WorkItem, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var wi workItem
+		if err = json.Unmarshal(kv.Value, &wi); err != nil {
+			return nil, err
+		}
+		items = append(items, &WorkItem{
+			ID:       strings.TrimPrefix(string(kv.Key), q.deadPfx),
+			Value:    wi.Value,
+			Enqueued: time.Unix(0, wi.Enqueued),
+			Attempts: wi.Attempts,
+		})
+	}
+	return items, nil
+}
+
+// Stats returns the depth and age of the queue.
+func (q *WorkQueue) Stats(ctx context.Context) (*WorkQueueStats, error) {
+	resp, err := q.client.Txn(ctx).Then(
+		v3.OpGet(q.itemPfx, v3.WithPrefix(), v3.WithCountOnly()),
+		v3.OpGet(q.itemPfx, v3.WithFirstCreate()...),
+		v3.OpGet(q.claimPfx, v3.WithPrefix(), v3.WithCountOnly()),
+		v3.OpGet(q.deadPfx, v3.WithPrefix(), v3.WithCountOnly()),
+	).Commit()
+	if err != nil {
+		return nil, err
+	}
+	items := int(resp.Responses[0].GetResponseRange().Count)
+	inFlight := int(resp.Responses[2].GetResponseRange().Count)
+	st := &WorkQueueStats{
+		Ready:        items - inFlight,
+		InFlight:     inFlight,
+		DeadLettered: int(resp.Responses[3].GetResponseRange().Count),
+	}
+	if kvs := resp.Responses[1].GetResponseRange().Kvs; len(kvs) != 0 {
+		var wi workItem
+		if err = json.Unmarshal(kvs[0].Value, &wi); err != nil {
+			return nil, err
+		}
+		st.Oldest = time.Unix(0, wi.Enqueued)
+	}
+	return st, nil
+}
+
+// Ack acknowledges the item, removing it from the queue. It returns
+// ErrClaimExpired if the claim of the item expired before.
+func (wi *WorkItem) Ack(ctx context.Context) error {
+	q := wi.q
+	return wi.finish(ctx, v3.OpDelete(q.claimPfx+wi.ID), v3.OpDelete(q.itemPfx+wi.ID))
+}
+
+// Nack gives the item up, so that it is delivered again right away. It
+// returns ErrClaimExpired if the claim of the item expired before.
+func (wi *WorkItem) Nack(ctx context.Context) error {
+	return wi.finish(ctx, v3.OpDelete(wi.q.claimPfx+wi.ID))
+}
+
+// Extend renews the claim of the item for another visibility timeout.
+func (wi *WorkItem) Extend(ctx context.Context) error {
+	_, err := wi.q.client.KeepAliveOnce(ctx, wi.lease)
+	return err
+}
+
+func (wi *WorkItem) finish(ctx context.Context, ops ...v3.Op) error {
+	q := wi.q
+	cmp := v3.Compare(v3.CreateRevision(q.claimPfx+wi.ID), "=", wi.claimRev)
+	resp, err := q.client.Txn(ctx).If(cmp).Then(ops...).Commit()
+	if err != nil {
+		return err
+	}
+	q.revoke(wi.lease)
+	if !resp.Succeeded {
+		return ErrClaimExpired
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ package clientv3
 
 import (
 	"context"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -24,11 +25,12 @@ import (
 )
 
 type (
-	CompactResponse pb.CompactionResponse
-	PutResponse     pb.PutResponse
-	GetResponse     pb.RangeResponse
-	DeleteResponse  pb.DeleteRangeResponse
-	TxnResponse     pb.TxnResponse
+	CompactResponse    pb.CompactionResponse
+	PutResponse        pb.PutResponse
+	GetResponse        pb.RangeResponse
+	DeleteResponse     pb.DeleteRangeResponse
+	TxnResponse        pb.TxnResponse
+	RevisionAtResponse pb.RevisionAtResponse
 )
 
 type KV interface {
@@ -54,6 +56,16 @@ type KV interface {
 	// Compact compacts etcd KV history before the given rev.
 	Compact(ctx context.Context, rev int64, opts ...CompactOption) (*CompactResponse, error)
 
+	// RevisionAt returns the latest revision known to have been applied at or
+	// before the given time. Passing the returned revision to WithRev reads
+	// the keys as they were at that time. When passed WithSerializable(), the
+	// lookup is served by the local member without a linearizable read.
+	// If the revisions at the given time are compacted or no longer sampled,
+	// the request will fail with ErrCompacted.
+	//
+	// Supported since etcd 3.7.
+	RevisionAt(ctx context.Context, t time.Time, opts ...OpOption) (*RevisionAtResponse, error)
+
 	// Do applies a single Op on KV without a transaction.
 	// Do is useful when creating arbitrary operations to be issued at a
 	// later time; the user can range over the operations, calling Do to
@@ -137,6 +149,16 @@ func (kv *kv) Compact(ctx context.Context, rev int64, opts ...CompactOption) (*C
 	return (*CompactResponse)(resp), nil
 }
 
+func (kv *kv) RevisionAt(ctx context.Context, t time.Time, opts ...OpOption) (*RevisionAtResponse, error) {
+	op := &Op{}
+	op.applyOpts(opts)
+	resp, err := kv.remote.RevisionAt(ctx, &pb.RevisionAtRequest{Timestamp: t.UnixNano(), Serializable: op.serializable}, kv.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*RevisionAtResponse)(resp), nil
+}
+
 func (kv *kv) Txn(ctx context.Context) Txn {
 	return &txn{
 		kv:       kv,
@@ -161,6 +183,7 @@ func (kv *kv) Do(ctx context.Context, op Op) (OpResponse, error) {
 	case tPut:
 		var resp *pb.PutResponse
 		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r.ExtraLeases, r.LeaseMode = op.extraLeasesPb(), op.leaseMode
 		resp, err = kv.remote.Put(ctx, r, kv.callOpts...)
 		if err == nil {
 			return OpResponse{put: (*PutResponse)(resp)}, nil
@@ -29,8 +29,12 @@ import (
 )
 
 type (
-	LeaseRevokeResponse pb.LeaseRevokeResponse
-	LeaseID             int64
+	LeaseRevokeResponse      pb.LeaseRevokeResponse
+	LeaseID                  int64
+	LeaseGroupRevokeResponse pb.LeaseGroupRevokeResponse
+	LeaseGroupAttachResponse pb.LeaseGroupAttachResponse
+	LeaseGroupDetachResponse pb.LeaseGroupDetachResponse
+	LeaseGroupID             int64
 )
 
 // LeaseGrantResponse wraps the protobuf message LeaseGrantResponse.
@@ -66,13 +70,36 @@ type LeaseTimeToLiveResponse struct {
 // LeaseStatus represents a lease status.
 type LeaseStatus struct {
 	ID LeaseID `json:"id"`
-	// TODO: TTL int64
+	// TTL is the remaining TTL in seconds for the lease.
+	TTL int64 `json:"ttl"`
+	// Labels are the labels the lease was granted with.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Owner is the user who granted the lease.
+	Owner string `json:"owner,omitempty"`
 }
 
 // LeaseLeasesResponse wraps the protobuf message LeaseLeasesResponse.
 type LeaseLeasesResponse struct {
 	*pb.ResponseHeader
 	Leases []LeaseStatus `json:"leases"`
+	// More indicates if there are more leases to list after the last one returned.
+	More bool `json:"more"`
+}
+
+// LeaseGroupGrantResponse wraps the protobuf message LeaseGroupGrantResponse.
+type LeaseGroupGrantResponse struct {
+	*pb.ResponseHeader
+	ID LeaseGroupID
+}
+
+// LeaseGroupKeepAliveResponse wraps the protobuf message LeaseGroupKeepAliveResponse.
+type LeaseGroupKeepAliveResponse struct {
+	*pb.ResponseHeader
+	ID LeaseGroupID
+	// TTL is the smallest TTL of the renewed leases, or 0 if the group has no leases to renew.
+	TTL int64
+	// Renewed is the number of renewed leases.
+	Renewed int64
 }
 
 const (
@@ -107,8 +134,9 @@ func (e ErrKeepAliveHalted) Error() string {
 }
 
 type Lease interface {
-	// Grant creates a new lease.
-	Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error)
+	// Grant creates a new lease. When passed WithMaxLifetime or WithRenewOnWrite,
+	// the lease is granted with a maximum lifetime or renewed on writes to its keys.
+	Grant(ctx context.Context, ttl int64, opts ...LeaseOption) (*LeaseGrantResponse, error)
 
 	// Revoke revokes the given lease.
 	Revoke(ctx context.Context, id LeaseID) (*LeaseRevokeResponse, error)
@@ -116,8 +144,10 @@ type Lease interface {
 	// TimeToLive retrieves the lease information of the given lease ID.
 	TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption) (*LeaseTimeToLiveResponse, error)
 
-	// Leases retrieves all leases.
-	Leases(ctx context.Context) (*LeaseLeasesResponse, error)
+	// Leases retrieves all leases. When passed WithLabels, WithOwner or
+	// WithRemainingTTL, only the matching leases are retrieved; WithLeasesLimit
+	// and WithLeasesAfter page through them.
+	Leases(ctx context.Context, opts ...LeaseOption) (*LeaseLeasesResponse, error)
 
 	// KeepAlive attempts to keep the given lease alive forever. If the keepalive responses posted
 	// to the channel are not consumed promptly the channel may become full. When full, the lease
@@ -143,6 +173,31 @@ type Lease interface {
 	// In most of the cases, Keepalive should be used instead of KeepAliveOnce.
 	KeepAliveOnce(ctx context.Context, id LeaseID) (*LeaseKeepAliveResponse, error)
 
+	// GroupGrant creates a new lease group. All the leases attached to a group are
+	// kept alive by a single keep alive of the group.
+	GroupGrant(ctx context.Context) (*LeaseGroupGrantResponse, error)
+
+	// GroupRevoke removes the given lease group. Its leases are detached from
+	// the group but not revoked.
+	GroupRevoke(ctx context.Context, id LeaseGroupID) (*LeaseGroupRevokeResponse, error)
+
+	// GroupAttach attaches leases to the given lease group. A lease belongs to at
+	// most one group, so attaching it detaches it from its previous group.
+	GroupAttach(ctx context.Context, id LeaseGroupID, leases ...LeaseID) (*LeaseGroupAttachResponse, error)
+
+	// GroupDetach detaches leases from the given lease group.
+	GroupDetach(ctx context.Context, id LeaseGroupID, leases ...LeaseID) (*LeaseGroupDetachResponse, error)
+
+	// GroupKeepAlive attempts to keep all the leases of the given lease group alive
+	// until the context is canceled. The returned channel receives a response for every
+	// renewal of the group; responses are dropped if the channel is not consumed promptly.
+	// The channel closes if the context is canceled, the group is revoked or has no
+	// leases to renew, or the group could not be renewed before its leases expired.
+	GroupKeepAlive(ctx context.Context, id LeaseGroupID) (<-chan *LeaseGroupKeepAliveResponse, error)
+
+	// GroupKeepAliveOnce renews all the leases of the given lease group once.
+	GroupKeepAliveOnce(ctx context.Context, id LeaseGroupID) (*LeaseGroupKeepAliveResponse, error)
+
 	// Close releases all resources Lease keeps for efficient communication
 	// with the etcd server.
 	Close() error
@@ -212,8 +267,8 @@ func NewLeaseFromLeaseClient(remote pb.LeaseClient, c *Client, keepAliveTimeout
 	return l
 }
 
-func (l *lessor) Grant(ctx context.Context, ttl int64) (*LeaseGrantResponse, error) {
-	r := &pb.LeaseGrantRequest{TTL: ttl}
+func (l *lessor) Grant(ctx context.Context, ttl int64, opts ...LeaseOption) (*LeaseGrantResponse, error) {
+	r := toLeaseGrantRequest(ttl, opts...)
 	resp, err := l.remote.LeaseGrant(ctx, r, l.callOpts...)
 	if err == nil {
 		gresp := &LeaseGrantResponse{
@@ -252,14 +307,14 @@ func (l *lessor) TimeToLive(ctx context.Context, id LeaseID, opts ...LeaseOption
 	return gresp, nil
 }
 
-func (l *lessor) Leases(ctx context.Context) (*LeaseLeasesResponse, error) {
-	resp, err := l.remote.LeaseLeases(ctx, &pb.LeaseLeasesRequest{}, l.callOpts...)
+func (l *lessor) Leases(ctx context.Context, opts ...LeaseOption) (*LeaseLeasesResponse, error) {
+	resp, err := l.remote.LeaseLeases(ctx, toLeaseLeasesRequest(opts...), l.callOpts...)
 	if err == nil {
 		leases := make([]LeaseStatus, len(resp.Leases))
-		for i := range resp.Leases {
-			leases[i] = LeaseStatus{ID: LeaseID(resp.Leases[i].ID)}
+		for i, ls := range resp.Leases {
+			leases[i] = LeaseStatus{ID: LeaseID(ls.ID), TTL: ls.TTL, Labels: ls.Labels, Owner: ls.Owner}
 		}
-		return &LeaseLeasesResponse{ResponseHeader: resp.GetHeader(), Leases: leases}, nil
+		return &LeaseLeasesResponse{ResponseHeader: resp.GetHeader(), Leases: leases, More: resp.More}, nil
 	}
 	return nil, ContextError(ctx, err)
 }
@@ -331,6 +386,113 @@ func (l *lessor) KeepAliveOnce(ctx context.Context, id LeaseID) (*LeaseKeepAlive
 	}
 }
 
+func (l *lessor) GroupGrant(ctx context.Context) (*LeaseGroupGrantResponse, error) {
+	resp, err := l.remote.LeaseGroupGrant(ctx, &pb.LeaseGroupGrantRequest{}, l.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return &LeaseGroupGrantResponse{ResponseHeader: resp.GetHeader(), ID: LeaseGroupID(resp.ID)}, nil
+}
+
+func (l *lessor) GroupRevoke(ctx context.Context, id LeaseGroupID) (*LeaseGroupRevokeResponse, error) {
+	resp, err := l.remote.LeaseGroupRevoke(ctx, &pb.LeaseGroupRevokeRequest{ID: int64(id)}, l.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*LeaseGroupRevokeResponse)(resp), nil
+}
+
+func (l *lessor) GroupAttach(ctx context.Context, id LeaseGroupID, leases ...LeaseID) (*LeaseGroupAttachResponse, error) {
+	r := &pb.LeaseGroupAttachRequest{ID: int64(id), Leases: toLeaseIDs(leases)}
+	resp, err := l.remote.LeaseGroupAttach(ctx, r, l.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*LeaseGroupAttachResponse)(resp), nil
+}
+
+func (l *lessor) GroupDetach(ctx context.Context, id LeaseGroupID, leases ...LeaseID) (*LeaseGroupDetachResponse, error) {
+	r := &pb.LeaseGroupDetachRequest{ID: int64(id), Leases: toLeaseIDs(leases)}
+	resp, err := l.remote.LeaseGroupDetach(ctx, r, l.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*LeaseGroupDetachResponse)(resp), nil
+}
+
+func toLeaseIDs(leases []LeaseID) []int64 {
+	ids := make([]int64, len(leases))
+	for i, id := range leases {
+		ids[i] = int64(id)
+	}
+	return ids
+}
+
+func (l *lessor) GroupKeepAlive(ctx context.Context, id LeaseGroupID) (<-chan *LeaseGroupKeepAliveResponse, error) {
+	ch := make(chan *LeaseGroupKeepAliveResponse, LeaseResponseChSize)
+	select {
+	case <-l.stopCtx.Done():
+		close(ch)
+		return ch, ErrKeepAliveHalted{Reason: l.stopCtx.Err()}
+	default:
+	}
+	go l.groupKeepAliveLoop(ctx, id, ch)
+	return ch, nil
+}
+
+// groupKeepAliveLoop renews the lease group every third of the smallest TTL
+// of its leases until ctx is canceled, the lessor is closed, or the group
+// fails to be renewed before the deadline of its leases.
+func (l *lessor) groupKeepAliveLoop(ctx context.Context, id LeaseGroupID, ch chan<- *LeaseGroupKeepAliveResponse) {
+	defer close(ch)
+	ctx, cancel := context.WithCancel(WithRequireLeader(ctx))
+	defer cancel()
+	stop := context.AfterFunc(l.stopCtx, cancel)
+	defer stop()
+
+	deadline := time.Now().Add(l.firstKeepAliveTimeout)
+	for {
+		wait := retryConnWait
+		resp, err := l.GroupKeepAliveOnce(ctx, id)
+		switch {
+		case err == nil:
+			select {
+			case ch <- resp:
+			default:
+			}
+			ttl := time.Duration(resp.TTL) * time.Second
+			deadline = time.Now().Add(ttl)
+			wait = ttl / 3
+		case isHaltErr(ctx, err):
+			if l.lg != nil && ctx.Err() == nil {
+				l.lg.Warn("lease group keepalive halted", zap.Int64("lease-group-id", int64(id)), zap.Error(err))
+			}
+			return
+		case time.Now().After(deadline):
+			return
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (l *lessor) GroupKeepAliveOnce(ctx context.Context, id LeaseGroupID) (*LeaseGroupKeepAliveResponse, error) {
+	resp, err := l.remote.LeaseGroupKeepAlive(ctx, &pb.LeaseGroupKeepAliveRequest{ID: int64(id)}, l.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return &LeaseGroupKeepAliveResponse{
+		ResponseHeader: resp.GetHeader(),
+		ID:             LeaseGroupID(resp.ID),
+		TTL:            resp.TTL,
+		Renewed:        resp.Renewed,
+	}, nil
+}
+
 func (l *lessor) Close() error {
 	l.stopCancel()
 	// close for synchronous teardown if stream goroutines never launched
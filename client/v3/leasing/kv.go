@@ -118,6 +118,10 @@ func (lkv *leasingKV) Compact(ctx context.Context, rev int64, opts ...v3.Compact
 	return lkv.kv.Compact(ctx, rev, opts...)
 }
 
+func (lkv *leasingKV) RevisionAt(ctx context.Context, t time.Time, opts ...v3.OpOption) (*v3.RevisionAtResponse, error) {
+	return lkv.kv.RevisionAt(ctx, t, opts...)
+}
+
 func (lkv *leasingKV) Txn(ctx context.Context) v3.Txn {
 	return &txnLeasing{Txn: lkv.kv.Txn(ctx), lkv: lkv, ctx: ctx}
 }
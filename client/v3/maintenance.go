@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
@@ -35,6 +36,11 @@ type (
 	MoveLeaderResponse pb.MoveLeaderResponse
 	DowngradeResponse  pb.DowngradeResponse
 
+	CompactionPolicyResponse pb.CompactionPolicyResponse
+
+	DrainResponse   pb.DrainResponse
+	UndrainResponse pb.UndrainResponse
+
 	DowngradeAction pb.DowngradeRequest_DowngradeAction
 )
 
@@ -87,6 +93,35 @@ type Maintenance interface {
 	// on the cluster version.
 	// Supported since etcd 3.5.
 	Downgrade(ctx context.Context, action DowngradeAction, version string) (*DowngradeResponse, error)
+
+	// CompactionPolicyList lists the per-prefix retention policies applied
+	// when the key-value store is compacted.
+	// Supported since etcd 3.7.
+	CompactionPolicyList(ctx context.Context) (*CompactionPolicyResponse, error)
+
+	// CompactionPolicyPut makes compaction keep the keepRevisions most recent
+	// revisions of every key under prefix, and the revisions applied within
+	// keepDuration, replacing any existing policy for the prefix. Either may
+	// be zero. keepDuration is truncated to whole seconds.
+	// Supported since etcd 3.7.
+	CompactionPolicyPut(ctx context.Context, prefix string, keepRevisions int64, keepDuration time.Duration) (*CompactionPolicyResponse, error)
+
+	// CompactionPolicyDelete removes the retention policy for prefix.
+	// Supported since etcd 3.7.
+	CompactionPolicyDelete(ctx context.Context, prefix string) (*CompactionPolicyResponse, error)
+
+	// Drain marks the etcd member at the given endpoint as draining. A draining
+	// member transfers its leadership away, cancels its watch and lease keep alive
+	// streams, stops serving clients until it restarts and reports not ready. The
+	// request is refused if the remaining members would not form a healthy quorum.
+	// Supported since etcd 3.7.
+	Drain(ctx context.Context, endpoint string) (*DrainResponse, error)
+
+	// Undrain clears the draining mark of the etcd member at the given endpoint.
+	// Since a drained etcd member stops serving clients, its mark is otherwise
+	// cleared by restarting it.
+	// Supported since etcd 3.7.
+	Undrain(ctx context.Context, endpoint string) (*UndrainResponse, error)
 }
 
 // SnapshotResponse is aggregated response from the snapshot stream.
@@ -348,3 +383,59 @@ func (m *maintenance) Downgrade(ctx context.Context, action DowngradeAction, ver
 	resp, err := m.remote.Downgrade(ctx, &pb.DowngradeRequest{Action: actionType, Version: version}, m.callOpts...)
 	return (*DowngradeResponse)(resp), ContextError(ctx, err)
 }
+
+func (m *maintenance) CompactionPolicyList(ctx context.Context) (*CompactionPolicyResponse, error) {
+	return m.compactionPolicy(ctx, &pb.CompactionPolicyRequest{Action: pb.CompactionPolicyRequest_GET})
+}
+
+func (m *maintenance) CompactionPolicyPut(ctx context.Context, prefix string, keepRevisions int64, keepDuration time.Duration) (*CompactionPolicyResponse, error) {
+	return m.compactionPolicy(ctx, &pb.CompactionPolicyRequest{
+		Action: pb.CompactionPolicyRequest_PUT,
+		Policy: &pb.CompactionPolicy{
+			Prefix:        []byte(prefix),
+			KeepRevisions: keepRevisions,
+			KeepSeconds:   int64(keepDuration / time.Second),
+		},
+	})
+}
+
+func (m *maintenance) CompactionPolicyDelete(ctx context.Context, prefix string) (*CompactionPolicyResponse, error) {
+	return m.compactionPolicy(ctx, &pb.CompactionPolicyRequest{
+		Action: pb.CompactionPolicyRequest_DELETE,
+		Policy: &pb.CompactionPolicy{Prefix: []byte(prefix)},
+	})
+}
+
+func (m *maintenance) compactionPolicy(ctx context.Context, req *pb.CompactionPolicyRequest) (*CompactionPolicyResponse, error) {
+	resp, err := m.remote.CompactionPolicy(ctx, req, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*CompactionPolicyResponse)(resp), nil
+}
+
+func (m *maintenance) Drain(ctx context.Context, endpoint string) (*DrainResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.Drain(ctx, &pb.DrainRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*DrainResponse)(resp), nil
+}
+
+func (m *maintenance) Undrain(ctx context.Context, endpoint string) (*UndrainResponse, error) {
+	remote, cancel, err := m.dial(endpoint)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	defer cancel()
+	resp, err := remote.Undrain(ctx, &pb.UndrainRequest{}, m.callOpts...)
+	if err != nil {
+		return nil, ContextError(ctx, err)
+	}
+	return (*UndrainResponse)(resp), nil
+}
@@ -187,6 +187,10 @@ func (m *mockKVServer) Compact(context.Context, *pb.CompactionRequest) (*pb.Comp
 	return &pb.CompactionResponse{}, nil
 }
 
+func (m *mockKVServer) RevisionAt(context.Context, *pb.RevisionAtRequest) (*pb.RevisionAtResponse, error) {
+	return &pb.RevisionAtResponse{}, nil
+}
+
 func (m *mockKVServer) Lease(context.Context, *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
 	return &pb.LeaseGrantResponse{}, nil
 }
@@ -212,3 +216,23 @@ func (s *mockLeaseServer) LeaseTimeToLive(context.Context, *pb.LeaseTimeToLiveRe
 func (s *mockLeaseServer) LeaseLeases(context.Context, *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
 	return &pb.LeaseLeasesResponse{}, nil
 }
+
+func (s *mockLeaseServer) LeaseGroupGrant(context.Context, *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error) {
+	return &pb.LeaseGroupGrantResponse{}, nil
+}
+
+func (s *mockLeaseServer) LeaseGroupRevoke(context.Context, *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error) {
+	return &pb.LeaseGroupRevokeResponse{}, nil
+}
+
+func (s *mockLeaseServer) LeaseGroupAttach(context.Context, *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error) {
+	return &pb.LeaseGroupAttachResponse{}, nil
+}
+
+func (s *mockLeaseServer) LeaseGroupDetach(context.Context, *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error) {
+	return &pb.LeaseGroupDetachResponse{}, nil
+}
+
+func (s *mockLeaseServer) LeaseGroupKeepAlive(context.Context, *pb.LeaseGroupKeepAliveRequest) (*pb.LeaseGroupKeepAliveResponse, error) {
+	return &pb.LeaseGroupKeepAliveResponse{}, nil
+}
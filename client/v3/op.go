@@ -14,7 +14,10 @@
 
 package clientv3
 
-import pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+import (
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+)
 
 type opType int
 
@@ -70,8 +73,10 @@ type Op struct {
 	filterDelete bool
 
 	// for put
-	val     []byte
-	leaseID LeaseID
+	val         []byte
+	leaseID     LeaseID
+	extraLeases []LeaseID
+	leaseMode   LeaseMode
 
 	// txn
 	cmps    []Cmp
@@ -198,6 +203,7 @@ func (op Op) toRequestOp() *pb.RequestOp {
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestRange{RequestRange: op.toRangeRequest()}}
 	case tPut:
 		r := &pb.PutRequest{Key: op.key, Value: op.val, Lease: int64(op.leaseID), PrevKv: op.prevKV, IgnoreValue: op.ignoreValue, IgnoreLease: op.ignoreLease}
+		r.ExtraLeases, r.LeaseMode = op.extraLeasesPb(), op.leaseMode
 		return &pb.RequestOp{Request: &pb.RequestOp_RequestPut{RequestPut: r}}
 	case tDeleteRange:
 		r := &pb.DeleteRangeRequest{Key: op.key, RangeEnd: op.end, PrevKv: op.prevKV}
@@ -312,8 +318,6 @@ func opWatch(key string, opts ...OpOption) Op {
 	ret := Op{t: tRange, key: []byte(key)}
 	ret.applyOpts(opts)
 	switch {
-	case ret.leaseID != 0:
-		panic("unexpected lease in watch")
 	case ret.limit != 0:
 		panic("unexpected limit in watch")
 	case ret.sort != nil:
@@ -340,10 +344,49 @@ func (op *Op) applyOpts(opts []OpOption) {
 type OpOption func(*Op)
 
 // WithLease attaches a lease ID to a key in 'Put' request.
+// In 'Watch' request, it waits for the lease to be revoked instead of
+// watching the key range; the watcher receives a single DELETE event
+// carrying the lease ID and revoke reason, then its channel is closed.
+// The key range is only used for permission checks.
 func WithLease(leaseID LeaseID) OpOption {
 	return func(op *Op) { op.leaseID = leaseID }
 }
 
+// LeaseMode tells when a key attached to several leases is deleted.
+type LeaseMode = mvccpb.LeaseMode
+
+const (
+	// LeaseModeAny deletes the key as soon as any of its leases is revoked.
+	LeaseModeAny LeaseMode = mvccpb.ANY
+	// LeaseModeAll deletes the key once all of its leases are revoked.
+	LeaseModeAll LeaseMode = mvccpb.ALL
+)
+
+// WithLeases attaches several lease IDs to a key in 'Put' request. The first
+// lease is the primary lease of the key, reported as its lease. The mode
+// tells whether the key is deleted when any or all of the leases are revoked.
+func WithLeases(mode LeaseMode, leaseIDs ...LeaseID) OpOption {
+	return func(op *Op) {
+		if len(leaseIDs) == 0 {
+			return
+		}
+		op.leaseID = leaseIDs[0]
+		op.extraLeases = leaseIDs[1:]
+		op.leaseMode = mode
+	}
+}
+
+func (op Op) extraLeasesPb() []int64 {
+	if len(op.extraLeases) == 0 {
+		return nil
+	}
+	ids := make([]int64, len(op.extraLeases))
+	for i, id := range op.extraLeases {
+		ids[i] = int64(id)
+	}
+	return ids
+}
+
 // WithLimit limits the number of results to return from 'Get' request.
 // If WithLimit is given a 0 limit, it is treated as no limit.
 func WithLimit(n int64) OpOption { return func(op *Op) { op.limit = n } }
@@ -553,6 +596,20 @@ type LeaseOp struct {
 
 	// for TimeToLive
 	attachedKeys bool
+
+	// for Grant
+	maxLifetime  int64
+	renewOnWrite bool
+
+	// for Grant and Leases
+	labels map[string]string
+
+	// for Leases
+	owner  string
+	minTTL int64
+	maxTTL int64
+	limit  int64
+	after  LeaseID
 }
 
 // LeaseOption configures lease operations.
@@ -569,6 +626,72 @@ func WithAttachedKeys() LeaseOption {
 	return func(op *LeaseOp) { op.attachedKeys = true }
 }
 
+// WithLeaseID makes Grant create the lease with the given ID rather than one
+// chosen by the server. Grant fails with ErrLeaseExist if the ID is in use.
+func WithLeaseID(id LeaseID) LeaseOption {
+	return func(op *LeaseOp) { op.id = id }
+}
+
+// WithMaxLifetime makes Grant revoke the lease once it has lived for the given
+// number of seconds, however often it is kept alive.
+func WithMaxLifetime(seconds int64) LeaseOption {
+	return func(op *LeaseOp) { op.maxLifetime = seconds }
+}
+
+// WithRenewOnWrite makes Grant renew the lease on every put to a key attached to it.
+func WithRenewOnWrite() LeaseOption {
+	return func(op *LeaseOp) { op.renewOnWrite = true }
+}
+
+// WithLabels makes Grant attach the given labels to the lease. In Leases, it
+// only lists the leases carrying all the given labels.
+func WithLabels(labels map[string]string) LeaseOption {
+	return func(op *LeaseOp) { op.labels = labels }
+}
+
+// WithOwner makes Leases only list the leases granted by the given user.
+func WithOwner(owner string) LeaseOption {
+	return func(op *LeaseOp) { op.owner = owner }
+}
+
+// WithRemainingTTL makes Leases only list the leases with a remaining TTL
+// between minTTL and maxTTL seconds. A maxTTL of 0 means no upper bound.
+func WithRemainingTTL(minTTL, maxTTL int64) LeaseOption {
+	return func(op *LeaseOp) { op.minTTL, op.maxTTL = minTTL, maxTTL }
+}
+
+// WithLeasesLimit limits the number of leases returned by Leases. The
+// response tells if more leases are left; the next page is fetched by
+// passing the ID of the last returned lease to WithLeasesAfter.
+func WithLeasesLimit(limit int64) LeaseOption {
+	return func(op *LeaseOp) { op.limit = limit }
+}
+
+// WithLeasesAfter makes Leases only list the leases with an ID greater than
+// the given one.
+func WithLeasesAfter(id LeaseID) LeaseOption {
+	return func(op *LeaseOp) { op.after = id }
+}
+
+func toLeaseGrantRequest(ttl int64, opts ...LeaseOption) *pb.LeaseGrantRequest {
+	ret := &LeaseOp{}
+	ret.applyOpts(opts)
+	return &pb.LeaseGrantRequest{TTL: ttl, ID: int64(ret.id), MaxLifetime: ret.maxLifetime, RenewOnWrite: ret.renewOnWrite, Labels: ret.labels}
+}
+
+func toLeaseLeasesRequest(opts ...LeaseOption) *pb.LeaseLeasesRequest {
+	ret := &LeaseOp{}
+	ret.applyOpts(opts)
+	return &pb.LeaseLeasesRequest{
+		Labels: ret.labels,
+		Owner:  ret.owner,
+		MinTtl: ret.minTTL,
+		MaxTtl: ret.maxTTL,
+		Limit:  ret.limit,
+		After:  int64(ret.after),
+	}
+}
+
 func toLeaseTimeToLiveRequest(id LeaseID, opts ...LeaseOption) *pb.LeaseTimeToLiveRequest {
 	ret := &LeaseOp{id: id}
 	ret.applyOpts(opts)
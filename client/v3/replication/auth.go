@@ -0,0 +1,123 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+
+	"go.etcd.io/etcd/api/v3/authpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// syncAuth replicates the roles with their permissions, and the users with
+// their roles. The roles and users of the destination missing from the
+// source are left as is, so that the replication keeps its access to the
+// destination.
+func syncAuth(ctx context.Context, src, dest *clientv3.Client) error {
+	roles, err := src.RoleList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles.Roles {
+		if err = syncRole(ctx, src, dest, role); err != nil {
+			return err
+		}
+	}
+
+	users, err := src.UserList(ctx)
+	if err != nil {
+		return err
+	}
+	for _, user := range users.Users {
+		if err = syncUser(ctx, src, dest, user); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncRole(ctx context.Context, src, dest *clientv3.Client, role string) error {
+	sresp, err := src.RoleGet(ctx, role)
+	if err != nil {
+		return err
+	}
+	if _, err = dest.RoleAdd(ctx, role); err != nil && !errors.Is(err, rpctypes.ErrRoleAlreadyExist) {
+		return err
+	}
+	dresp, err := dest.RoleGet(ctx, role)
+	if err != nil {
+		return err
+	}
+
+	type permRange struct{ key, rangeEnd string }
+	perms := make(map[permRange]authpb.Permission_Type, len(dresp.Perm))
+	for _, p := range dresp.Perm {
+		perms[permRange{string(p.Key), string(p.RangeEnd)}] = p.PermType
+	}
+	for _, p := range sresp.Perm {
+		r := permRange{string(p.Key), string(p.RangeEnd)}
+		if t, ok := perms[r]; !ok || t != p.PermType {
+			if _, err = dest.RoleGrantPermission(ctx, role, r.key, r.rangeEnd, clientv3.PermissionType(p.PermType)); err != nil {
+				return err
+			}
+		}
+		delete(perms, r)
+	}
+	for r := range perms {
+		if _, err = dest.RoleRevokePermission(ctx, role, r.key, r.rangeEnd); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func syncUser(ctx context.Context, src, dest *clientv3.Client, user string) error {
+	sresp, err := src.UserGet(ctx, user)
+	if err != nil {
+		return err
+	}
+	dresp, err := dest.UserGet(ctx, user)
+	if errors.Is(err, rpctypes.ErrUserNotFound) {
+		// passwords cannot be read from the source.
+		if _, err = dest.UserAddWithOptions(ctx, user, "", &clientv3.UserAddOptions{NoPassword: true}); err != nil {
+			return err
+		}
+		dresp, err = dest.UserGet(ctx, user)
+	}
+	if err != nil {
+		return err
+	}
+
+	roles := make(map[string]struct{}, len(dresp.Roles))
+	for _, role := range dresp.Roles {
+		roles[role] = struct{}{}
+	}
+	for _, role := range sresp.Roles {
+		if _, ok := roles[role]; !ok {
+			if _, err = dest.UserGrantRole(ctx, user, role); err != nil {
+				return err
+			}
+		}
+		delete(roles, role)
+	}
+	for role := range roles {
+		if _, err = dest.UserRevokeRole(ctx, user, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
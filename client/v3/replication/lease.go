@@ -0,0 +1,260 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// leaseLabel is the label of the leases granted in the destination by a
+// replication, whose value is the name of the replication.
+const leaseLabel = "replication"
+
+// leaseSyncer replicates the leases the replicated keys are attached to. The
+// leases are granted in the destination with the IDs and TTLs they have in
+// the source, kept alive while they are alive in the source, and revoked once
+// they are not. The granted leases are recorded in the metadata of the
+// replication, so that the leases expiring in the destination, with the keys
+// attached to them, are noticed even while the replication is stopped.
+type leaseSyncer struct {
+	src  *clientv3.Client
+	dest *clientv3.Client
+	name string
+	meta metaKeys
+	lg   *zap.Logger
+	// expired is called when a lease expired in the destination while it is
+	// alive in the source, so that the keys attached to it are replicated
+	// again.
+	expired func()
+
+	// revokeMu is held for reading by the transactions attaching keys to the
+	// replicated leases, and for writing when revoking them, so that no key
+	// is attached to a revoked lease.
+	revokeMu sync.RWMutex
+
+	mu     sync.Mutex
+	leases map[clientv3.LeaseID]struct{}
+}
+
+func newLeaseSyncer(src, dest *clientv3.Client, name string, lg *zap.Logger, expired func()) *leaseSyncer {
+	return &leaseSyncer{
+		src:     src,
+		dest:    dest,
+		name:    name,
+		meta:    newMetaKeys(name),
+		lg:      lg,
+		expired: expired,
+		leases:  make(map[clientv3.LeaseID]struct{}),
+	}
+}
+
+func (s *leaseSyncer) labels() map[string]string {
+	return map[string]string{leaseLabel: s.name}
+}
+
+// load loads the leases granted by the replication in a previous run. It
+// grants again the ones that expired in the destination while they are alive
+// in the source, and returns whether there were any, as the keys attached to
+// them were deleted.
+func (s *leaseSyncer) load(ctx context.Context) (bool, error) {
+	after := clientv3.LeaseID(0)
+	for {
+		resp, err := s.dest.Leases(ctx, clientv3.WithLabels(s.labels()), clientv3.WithLeasesLimit(batchLimit), clientv3.WithLeasesAfter(after))
+		if err != nil {
+			return false, err
+		}
+		s.mu.Lock()
+		for _, l := range resp.Leases {
+			s.leases[l.ID] = struct{}{}
+		}
+		s.mu.Unlock()
+		if !resp.More || len(resp.Leases) == 0 {
+			break
+		}
+		after = resp.Leases[len(resp.Leases)-1].ID
+	}
+
+	expired := false
+	err := rangePrefix(ctx, s.dest, s.meta.leases, 0, true, func(kvs []*mvccpb.KeyValue) error {
+		for _, kv := range kvs {
+			id, err := strconv.ParseInt(string(kv.Key[len(s.meta.leases):]), 16, 64)
+			if err != nil {
+				return fmt.Errorf("replication: invalid lease key %q: %w", kv.Key, err)
+			}
+			lid := clientv3.LeaseID(id)
+			s.mu.Lock()
+			_, ok := s.leases[lid]
+			s.mu.Unlock()
+			if ok {
+				continue
+			}
+			ttl, err := s.src.TimeToLive(ctx, lid)
+			if err != nil {
+				return err
+			}
+			if ttl.TTL == -1 {
+				// the deletion of its keys is replicated from the source.
+				if _, err = s.dest.Delete(ctx, string(kv.Key)); err != nil {
+					return err
+				}
+				continue
+			}
+			s.lg.Warn("replicated lease expired in the destination", zap.Int64("lease-id", id))
+			if err = s.grant(ctx, lid, ttl.GrantedTTL); err != nil {
+				return err
+			}
+			expired = true
+		}
+		return nil
+	})
+	return expired, err
+}
+
+// ensure grants the given source leases in the destination, and returns the
+// ones alive in the source to attach the replicated key to. The keys of the
+// expired leases are deleted by the following source revisions.
+func (s *leaseSyncer) ensure(ctx context.Context, ids []int64) ([]clientv3.LeaseID, error) {
+	alive := make([]clientv3.LeaseID, 0, len(ids))
+	for _, id := range ids {
+		lid := clientv3.LeaseID(id)
+		s.mu.Lock()
+		_, ok := s.leases[lid]
+		s.mu.Unlock()
+		if !ok {
+			ttl, err := s.src.TimeToLive(ctx, lid)
+			if err != nil {
+				return nil, err
+			}
+			if ttl.TTL == -1 {
+				continue
+			}
+			if err = s.grant(ctx, lid, ttl.GrantedTTL); err != nil {
+				return nil, err
+			}
+		}
+		alive = append(alive, lid)
+	}
+	return alive, nil
+}
+
+// grant grants the lease in the destination and records it. A lease of the
+// same ID already in the destination must have been granted by the
+// replication.
+func (s *leaseSyncer) grant(ctx context.Context, id clientv3.LeaseID, ttl int64) error {
+	_, err := s.dest.Grant(ctx, ttl, clientv3.WithLeaseID(id), clientv3.WithLabels(s.labels()))
+	if errors.Is(err, rpctypes.ErrLeaseExist) {
+		err = s.checkGranted(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err = s.dest.Put(ctx, s.meta.leaseKey(id), ""); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.leases[id] = struct{}{}
+	s.mu.Unlock()
+	return nil
+}
+
+// checkGranted returns ErrLeaseConflict unless the destination lease carries
+// the label of the replication.
+func (s *leaseSyncer) checkGranted(ctx context.Context, id clientv3.LeaseID) error {
+	resp, err := s.dest.Leases(ctx, clientv3.WithLabels(s.labels()), clientv3.WithLeasesLimit(1), clientv3.WithLeasesAfter(id-1))
+	if err != nil {
+		return err
+	}
+	if len(resp.Leases) == 0 || resp.Leases[0].ID != id {
+		return fmt.Errorf("%w: %x", ErrLeaseConflict, id)
+	}
+	return nil
+}
+
+// run keeps the replicated leases in sync with the source every interval.
+func (s *leaseSyncer) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := s.sync(ctx); err != nil && ctx.Err() == nil {
+				s.lg.Warn("failed to replicate leases", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sync keeps the replicated leases alive in the destination while they are
+// alive in the source, and revokes them otherwise.
+func (s *leaseSyncer) sync(ctx context.Context) error {
+	s.mu.Lock()
+	ids := make([]clientv3.LeaseID, 0, len(s.leases))
+	for id := range s.leases {
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	for _, id := range ids {
+		ttl, err := s.src.TimeToLive(ctx, id)
+		if err != nil {
+			return err
+		}
+		if ttl.TTL == -1 {
+			if err = s.revoke(ctx, id); err != nil {
+				return err
+			}
+			continue
+		}
+		_, err = s.dest.KeepAliveOnce(ctx, id)
+		if errors.Is(err, rpctypes.ErrLeaseNotFound) {
+			s.lg.Warn("replicated lease expired in the destination", zap.Int64("lease-id", int64(id)))
+			if err = s.grant(ctx, id, ttl.GrantedTTL); err == nil {
+				s.expired()
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *leaseSyncer) revoke(ctx context.Context, id clientv3.LeaseID) error {
+	s.revokeMu.Lock()
+	defer s.revokeMu.Unlock()
+	if _, err := s.dest.Revoke(ctx, id); err != nil && !errors.Is(err, rpctypes.ErrLeaseNotFound) {
+		return err
+	}
+	if _, err := s.dest.Delete(ctx, s.meta.leaseKey(id)); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.leases, id)
+	s.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// MetaPrefix is the prefix of the keys a replication records its metadata
+// under in its destination. These keys are never replicated.
+const MetaPrefix = "__replication/"
+
+// promoteCheckInterval is how often Promote checks whether the destination
+// caught up with the source.
+var promoteCheckInterval = 100 * time.Millisecond
+
+// metaKeys are the metadata keys of a replication in its destination.
+type metaKeys struct {
+	// position holds the last source revision applied to the destination.
+	position string
+	// source holds the ID of the source cluster.
+	source string
+	// promoted holds the last source revision applied to the destination
+	// when it was promoted.
+	promoted string
+	// leases is the prefix of the keys recording the leases granted in the
+	// destination, which outlive them.
+	leases string
+	// syncing holds the source revision the destination is being synced
+	// with, until the sync completes. The destination mixes the keys of
+	// the former position and of that revision meanwhile.
+	syncing string
+}
+
+func newMetaKeys(name string) metaKeys {
+	p := MetaPrefix + name + "/"
+	return metaKeys{
+		position: p + "position",
+		source:   p + "source",
+		promoted: p + "promoted",
+		leases:   p + "leases/",
+		syncing:  p + "syncing",
+	}
+}
+
+func (m metaKeys) leaseKey(id clientv3.LeaseID) string {
+	return m.leases + strconv.FormatInt(int64(id), 16)
+}
+
+func isMetaKey(key []byte) bool {
+	return strings.HasPrefix(string(key), MetaPrefix)
+}
+
+// Position is the position of a replication recorded in its destination.
+type Position struct {
+	// SourceRevision is the last source revision applied to the destination.
+	SourceRevision int64 `json:"source-revision"`
+	// DestRevision is the destination revision SourceRevision was applied at.
+	DestRevision int64 `json:"dest-revision"`
+	// SourceClusterID is the ID of the source cluster.
+	SourceClusterID uint64 `json:"source-cluster-id"`
+	// Promoted tells whether the destination was promoted.
+	Promoted bool `json:"promoted"`
+	// Syncing tells whether the destination is being synced with the
+	// source, so that it is not consistent with any source revision.
+	Syncing bool `json:"syncing"`
+}
+
+// GetPosition returns the position of the named replication recorded in the
+// destination. It is zero if the replication never applied a revision.
+func GetPosition(ctx context.Context, dest *clientv3.Client, name string) (Position, error) {
+	if name == "" {
+		name = DefaultName
+	}
+	meta := newMetaKeys(name)
+	resp, err := dest.Txn(ctx).Then(
+		clientv3.OpGet(meta.position),
+		clientv3.OpGet(meta.source),
+		clientv3.OpGet(meta.promoted),
+		clientv3.OpGet(meta.syncing, clientv3.WithCountOnly()),
+	).Commit()
+	if err != nil {
+		return Position{}, err
+	}
+	var pos Position
+	if kvs := resp.Responses[0].GetResponseRange().Kvs; len(kvs) > 0 {
+		if pos.SourceRevision, err = strconv.ParseInt(string(kvs[0].Value), 10, 64); err != nil {
+			return Position{}, fmt.Errorf("replication: invalid position %q: %w", kvs[0].Value, err)
+		}
+		pos.DestRevision = kvs[0].ModRevision
+	}
+	if kvs := resp.Responses[1].GetResponseRange().Kvs; len(kvs) > 0 {
+		if pos.SourceClusterID, err = strconv.ParseUint(string(kvs[0].Value), 16, 64); err != nil {
+			return Position{}, fmt.Errorf("replication: invalid source cluster ID %q: %w", kvs[0].Value, err)
+		}
+	}
+	pos.Promoted = len(resp.Responses[2].GetResponseRange().Kvs) > 0
+	pos.Syncing = resp.Responses[3].GetResponseRange().Count > 0
+	return pos, nil
+}
+
+// SourceRevision returns the last source revision the named replication
+// applied to the destination as of the given destination revision, which
+// must not be compacted. It is 0 if no source revision was applied then.
+func SourceRevision(ctx context.Context, dest *clientv3.Client, name string, destRev int64) (int64, error) {
+	if name == "" {
+		name = DefaultName
+	}
+	resp, err := dest.Get(ctx, newMetaKeys(name).position, clientv3.WithRev(destRev))
+	if err != nil {
+		return 0, err
+	}
+	if len(resp.Kvs) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseInt(string(resp.Kvs[0].Value), 10, 64)
+}
+
+// Promote promotes the destination of the named replication into a primary,
+// which stops the replication. With a source, the cutover is clean: the
+// source must no longer be written to, and the destination is promoted once
+// the replication applied the current source revision. Without a source, as
+// when it is lost, the destination is promoted at its position, losing the
+// source revisions it lagged behind. Promote returns the position the
+// destination was promoted at. It fails with ErrSyncInProgress while the
+// destination is being synced with the source, as it is not consistent with
+// any source revision then.
+func Promote(ctx context.Context, src, dest *clientv3.Client, name string) (Position, error) {
+	if name == "" {
+		name = DefaultName
+	}
+	meta := newMetaKeys(name)
+	for {
+		pos, err := GetPosition(ctx, dest, name)
+		if err != nil {
+			return Position{}, err
+		}
+		if pos.Promoted {
+			return pos, ErrPromoted
+		}
+		if pos.Syncing {
+			return pos, ErrSyncInProgress
+		}
+		if src != nil {
+			resp, err := src.Get(ctx, meta.position, clientv3.WithCountOnly())
+			if err != nil {
+				return Position{}, err
+			}
+			if pos.SourceRevision < resp.Header.Revision {
+				select {
+				case <-time.After(promoteCheckInterval):
+					continue
+				case <-ctx.Done():
+					return Position{}, ctx.Err()
+				}
+			}
+		}
+
+		resp, err := dest.Txn(ctx).If(
+			clientv3.Compare(clientv3.ModRevision(meta.position), "=", pos.DestRevision),
+			clientv3.Compare(clientv3.CreateRevision(meta.promoted), "=", 0),
+			clientv3.Compare(clientv3.CreateRevision(meta.syncing), "=", 0),
+		).Then(
+			clientv3.OpPut(meta.promoted, strconv.FormatInt(pos.SourceRevision, 10)),
+		).Commit()
+		if err != nil {
+			return Position{}, err
+		}
+		if resp.Succeeded {
+			pos.Promoted = true
+			return pos, nil
+		}
+		// the replication applied a source revision, or started syncing the
+		// destination, in the meantime.
+	}
+}
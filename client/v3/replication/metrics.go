@@ -0,0 +1,109 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	sourceRevision = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replication",
+		Name:      "source_revision",
+		Help:      "The latest source revision known by the replication.",
+	},
+		[]string{"name"},
+	)
+	appliedRevision = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replication",
+		Name:      "applied_revision",
+		Help:      "The last source revision applied to the destination.",
+	},
+		[]string{"name"},
+	)
+	lagRevisions = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replication",
+		Name:      "lag_revisions",
+		Help:      "The number of source revisions not applied to the destination yet.",
+	},
+		[]string{"name"},
+	)
+	lagSeconds = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "replication",
+		Name:      "lag_seconds",
+		Help:      "How long the destination has been behind the source, which bounds the data lost on failover.",
+	},
+		[]string{"name"},
+	)
+	events = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "replication",
+		Name:      "events_total",
+		Help:      "The total number of source events applied to the destination.",
+	},
+		[]string{"name"},
+	)
+	resyncs = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "replication",
+		Name:      "resyncs_total",
+		Help:      "The total number of syncs of the destination with the source after its position was compacted or a replicated lease expired in it.",
+	},
+		[]string{"name"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(sourceRevision)
+	prometheus.MustRegister(appliedRevision)
+	prometheus.MustRegister(lagRevisions)
+	prometheus.MustRegister(lagSeconds)
+	prometheus.MustRegister(events)
+	prometheus.MustRegister(resyncs)
+}
+
+// statusGauges are the status metrics of a replication.
+type statusGauges struct {
+	source       prometheus.Gauge
+	applied      prometheus.Gauge
+	lagRevisions prometheus.Gauge
+	lagSeconds   prometheus.Gauge
+}
+
+func newStatusGauges(name string) *statusGauges {
+	return &statusGauges{
+		source:       sourceRevision.WithLabelValues(name),
+		applied:      appliedRevision.WithLabelValues(name),
+		lagRevisions: lagRevisions.WithLabelValues(name),
+		lagSeconds:   lagSeconds.WithLabelValues(name),
+	}
+}
+
+func (g *statusGauges) update(head, applied int64, behindSince time.Time) {
+	g.source.Set(float64(head))
+	g.applied.Set(float64(applied))
+	g.lagRevisions.Set(float64(max(head-applied, 0)))
+	if behindSince.IsZero() {
+		g.lagSeconds.Set(0)
+	} else {
+		g.lagSeconds.Set(time.Since(behindSince).Seconds())
+	}
+}
@@ -0,0 +1,616 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package replication implements the asynchronous replication of the keys of
+// a primary etcd cluster to a standby cluster.
+//
+// The replication streams the committed MVCC events of the primary, and
+// applies them to the standby in transactions that also record its position:
+// the last primary revision applied to the standby. As the modification
+// revision of the position key is the standby revision the primary revision
+// was applied at, the history of the position key maps the revisions of both
+// clusters. The transactions are guarded by the position key, so that a
+// single replication writes to the standby at a time, and by the promotion
+// key, so that no primary revision is applied once the standby is promoted
+// into a primary.
+package replication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// DefaultName is the name of a replication without one.
+	DefaultName = "default"
+
+	defaultMaxTxnOps        = 128
+	defaultProgressInterval = time.Second
+	defaultLeaseInterval    = time.Second
+	defaultAuthInterval     = time.Minute
+
+	batchLimit = 1000
+	// minMaxTxnOps leaves room in the last transaction of a sync for at
+	// least one key, the position, the source and the end of the sync.
+	minMaxTxnOps = 4
+)
+
+var (
+	// ErrPromoted is returned when the destination of a replication was
+	// promoted, which stops the replication.
+	ErrPromoted = errors.New("replication: destination was promoted")
+	// ErrConflict is returned when another replication of the same name
+	// wrote to the destination.
+	ErrConflict = errors.New("replication: concurrent replication to the destination")
+	// ErrSourceMismatch is returned when the destination was replicated from
+	// another source cluster.
+	ErrSourceMismatch = errors.New("replication: destination was replicated from another source cluster")
+	// ErrSyncInProgress is returned when promoting a destination that is
+	// being synced with the source.
+	ErrSyncInProgress = errors.New("replication: destination is being synced with the source")
+	// ErrLeaseConflict is returned when the destination has a lease of the ID
+	// of a replicated lease that was not granted by the replication.
+	ErrLeaseConflict = errors.New("replication: destination lease was not granted by the replication")
+
+	// errLeaseExpired interrupts the replication of the source events when a
+	// replicated lease expired in the destination, deleting the keys attached
+	// to it.
+	errLeaseExpired = errors.New("replication: replicated lease expired in the destination")
+)
+
+// Config configures a replication.
+type Config struct {
+	// Name identifies the replication in the destination, which may be the
+	// destination of several replications of different prefixes.
+	Name string
+	// Prefix is the prefix of the keys replicated from the source. An empty
+	// prefix replicates all the keys.
+	Prefix string
+	// DestPrefix, if set, replaces Prefix in the keys written to the
+	// destination.
+	DestPrefix string
+
+	// Leases replicates the leases the keys are attached to, granted in the
+	// destination with the same IDs and TTLs and kept alive while they are
+	// alive in the source.
+	Leases bool
+	// Auth replicates the roles, and the users with their roles. Passwords
+	// cannot be read from the source, so users missing from the destination
+	// are added without one. Authentication is not enabled in the
+	// destination.
+	Auth bool
+
+	// MaxTxnOps is the maximum number of operations of the destination
+	// transactions, 128 by default as the --max-txn-ops of etcd. The events of
+	// a source revision exceeding it are applied by several transactions. It
+	// is at least 4.
+	MaxTxnOps int
+	// ProgressInterval is how often the source is asked for the progress of
+	// the replication, which bounds how stale the lag is. It is one second by
+	// default.
+	ProgressInterval time.Duration
+	// LeaseInterval is how often the replicated leases are kept alive, one
+	// second by default.
+	LeaseInterval time.Duration
+	// AuthInterval is how often the roles and users are replicated, one minute
+	// by default.
+	AuthInterval time.Duration
+}
+
+// Status is the status of a running replication.
+type Status struct {
+	// SourceRevision is the latest source revision known.
+	SourceRevision int64 `json:"source-revision"`
+	// AppliedRevision is the last source revision applied to the destination.
+	AppliedRevision int64 `json:"applied-revision"`
+	// DestRevision is the destination revision AppliedRevision was applied at.
+	DestRevision int64 `json:"dest-revision"`
+	// Lag is how long the destination has been behind the source.
+	Lag time.Duration `json:"lag"`
+}
+
+// Replicator replicates the keys of a source cluster to a destination cluster.
+type Replicator struct {
+	src  *clientv3.Client
+	dest *clientv3.Client
+	cfg  Config
+	lg   *zap.Logger
+
+	meta   metaKeys
+	leases *leaseSyncer
+
+	// applied is the last source revision applied to the destination, and
+	// destRev the destination revision it was applied at. destRev guards the
+	// transactions of the replication.
+	applied int64
+	destRev int64
+
+	mu           sync.Mutex
+	head         int64
+	behindSince  time.Time
+	statusGauges *statusGauges
+	// interrupt interrupts the replication of the source events, if running,
+	// with its cause. resync is set to sync the destination with the source
+	// before replicating the source events again otherwise.
+	interrupt context.CancelCauseFunc
+	resync    bool
+}
+
+// New returns a replicator of the keys of src to dest.
+func New(src, dest *clientv3.Client, cfg Config) *Replicator {
+	if cfg.Name == "" {
+		cfg.Name = DefaultName
+	}
+	if cfg.DestPrefix == "" {
+		cfg.DestPrefix = cfg.Prefix
+	}
+	if cfg.MaxTxnOps <= 1 {
+		cfg.MaxTxnOps = defaultMaxTxnOps
+	}
+	cfg.MaxTxnOps = max(cfg.MaxTxnOps, minMaxTxnOps)
+	if cfg.ProgressInterval <= 0 {
+		cfg.ProgressInterval = defaultProgressInterval
+	}
+	if cfg.LeaseInterval <= 0 {
+		cfg.LeaseInterval = defaultLeaseInterval
+	}
+	if cfg.AuthInterval <= 0 {
+		cfg.AuthInterval = defaultAuthInterval
+	}
+	lg := src.GetLogger()
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	r := &Replicator{
+		src:          src,
+		dest:         dest,
+		cfg:          cfg,
+		lg:           lg.With(zap.String("replication", cfg.Name)),
+		meta:         newMetaKeys(cfg.Name),
+		statusGauges: newStatusGauges(cfg.Name),
+	}
+	if cfg.Leases {
+		r.leases = newLeaseSyncer(src, dest, cfg.Name, r.lg, r.leaseExpired)
+	}
+	return r
+}
+
+// Status returns the status of the replication.
+func (r *Replicator) Status() Status {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	st := Status{SourceRevision: r.head, AppliedRevision: r.applied, DestRevision: r.destRev}
+	if !r.behindSince.IsZero() {
+		st.Lag = time.Since(r.behindSince)
+	}
+	return st
+}
+
+// Run replicates the source to the destination until the context is done or
+// the replication fails. It resumes from the position recorded in the
+// destination, and syncs the destination with the source when it has none or
+// the source compacted it. It returns ErrPromoted once the destination is
+// promoted.
+func (r *Replicator) Run(ctx context.Context) error {
+	pos, err := GetPosition(ctx, r.dest, r.cfg.Name)
+	if err != nil {
+		return err
+	}
+	if pos.Promoted {
+		return ErrPromoted
+	}
+	resp, err := r.src.Get(ctx, r.meta.position, clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	srcID := resp.Header.ClusterId
+	if pos.SourceClusterID != 0 && pos.SourceClusterID != srcID {
+		return fmt.Errorf("%w: %x, not %x", ErrSourceMismatch, pos.SourceClusterID, srcID)
+	}
+	r.setApplied(pos.SourceRevision, pos.DestRevision)
+	r.observeHead(resp.Header.Revision)
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel(nil)
+		wg.Wait()
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		r.watchPromotion(ctx, cancel)
+	}()
+	// a sync interrupted in a previous run is done again.
+	resync := r.applied == 0 || pos.Syncing
+	if r.leases != nil {
+		expired, err := r.leases.load(ctx)
+		if err != nil {
+			return err
+		}
+		resync = resync || expired
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.leases.run(ctx, r.cfg.LeaseInterval)
+		}()
+	}
+	if r.cfg.Auth {
+		if err = syncAuth(ctx, r.src, r.dest); err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.runAuthSync(ctx)
+		}()
+	}
+
+	r.lg.Info("starting replication",
+		zap.String("prefix", r.cfg.Prefix),
+		zap.String("dest-prefix", r.cfg.DestPrefix),
+		zap.Int64("applied-revision", r.applied),
+		zap.Int64("dest-revision", r.destRev),
+	)
+	for ; ; resync = true {
+		if resync {
+			if err = r.sync(ctx, srcID); err != nil {
+				return r.stopErr(ctx, err)
+			}
+		}
+		err = r.replicate(ctx, srcID)
+		switch {
+		case errors.Is(err, rpctypes.ErrCompacted):
+			r.lg.Warn("replication position was compacted in the source, syncing the destination", zap.Int64("applied-revision", r.applied))
+		case errors.Is(err, errLeaseExpired):
+			r.lg.Warn("replicated lease expired in the destination, syncing the destination", zap.Int64("applied-revision", r.applied))
+		default:
+			return r.stopErr(ctx, err)
+		}
+		resyncs.WithLabelValues(r.cfg.Name).Inc()
+	}
+}
+
+// leaseExpired syncs the destination with the source again once a replicated
+// lease expired in the destination, deleting the keys attached to it.
+func (r *Replicator) leaseExpired() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.interrupt != nil {
+		r.interrupt(errLeaseExpired)
+		return
+	}
+	r.resync = true
+}
+
+// stopErr returns the error stopping the replication, which is the cause of
+// the cancellation of the context if it was canceled.
+func (r *Replicator) stopErr(ctx context.Context, err error) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+	return err
+}
+
+// watchPromotion cancels the replication once the destination is promoted.
+func (r *Replicator) watchPromotion(ctx context.Context, cancel context.CancelCauseFunc) {
+	wch := r.dest.Watch(ctx, r.meta.promoted, clientv3.WithFilterDelete())
+	for wr := range wch {
+		if len(wr.Events) > 0 {
+			cancel(ErrPromoted)
+			return
+		}
+	}
+}
+
+func (r *Replicator) runAuthSync(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.AuthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := syncAuth(ctx, r.src, r.dest); err != nil && ctx.Err() == nil {
+				r.lg.Warn("failed to replicate roles and users", zap.Error(err))
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// sync makes the destination the copy of the source at its current revision,
+// deleting the destination keys the source does not have. The destination is
+// marked as being synced until the last transaction, which records the
+// position, so that it is not promoted while it mixes the keys of its former
+// position and of the source revision.
+func (r *Replicator) sync(ctx context.Context, srcID uint64) error {
+	resp, err := r.src.Get(ctx, r.meta.position, clientv3.WithCountOnly())
+	if err != nil {
+		return err
+	}
+	rev := resp.Header.Revision
+	r.lg.Info("syncing destination with source", zap.Int64("source-revision", rev))
+	r.holdLeases()
+	defer r.releaseLeases()
+
+	if err = r.commit(ctx, []clientv3.Op{clientv3.OpPut(r.meta.syncing, strconv.FormatInt(rev, 10))}, 0, srcID); err != nil {
+		return err
+	}
+
+	keys := make(map[string]struct{})
+	var ops []clientv3.Op
+	err = rangePrefix(ctx, r.src, r.cfg.Prefix, rev, false, func(kvs []*mvccpb.KeyValue) error {
+		for _, kv := range kvs {
+			if isMetaKey(kv.Key) {
+				continue
+			}
+			op, err := r.put(ctx, kv)
+			if err != nil {
+				return err
+			}
+			keys[r.destKey(kv.Key)] = struct{}{}
+			if ops = append(ops, op); len(ops) == r.cfg.MaxTxnOps-1 {
+				if err = r.commit(ctx, ops, 0, srcID); err != nil {
+					return err
+				}
+				ops = nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	err = rangePrefix(ctx, r.dest, r.cfg.DestPrefix, 0, true, func(kvs []*mvccpb.KeyValue) error {
+		for _, kv := range kvs {
+			if _, ok := keys[string(kv.Key)]; ok || isMetaKey(kv.Key) {
+				continue
+			}
+			if ops = append(ops, clientv3.OpDelete(string(kv.Key))); len(ops) == r.cfg.MaxTxnOps-1 {
+				if err = r.commit(ctx, ops, 0, srcID); err != nil {
+					return err
+				}
+				ops = nil
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// the last transaction also records the position and the source.
+	if len(ops) > r.cfg.MaxTxnOps-3 {
+		if err = r.commit(ctx, ops, 0, srcID); err != nil {
+			return err
+		}
+		ops = nil
+	}
+	return r.commit(ctx, append(ops, clientv3.OpDelete(r.meta.syncing)), rev, srcID)
+}
+
+// replicate applies the source events following the applied revision, until
+// it fails or is interrupted.
+func (r *Replicator) replicate(ctx context.Context, srcID uint64) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	r.mu.Lock()
+	resync := r.resync
+	r.interrupt, r.resync = cancel, false
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.interrupt = nil
+		r.mu.Unlock()
+	}()
+	if resync {
+		return errLeaseExpired
+	}
+
+	for b := range Stream(ctx, r.src, r.cfg.Prefix, r.applied+1, r.cfg.ProgressInterval) {
+		err := b.Err
+		if err == nil {
+			r.observeHead(b.Head)
+			err = r.apply(ctx, b, srcID)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return context.Cause(ctx)
+			}
+			return err
+		}
+		events.WithLabelValues(r.cfg.Name).Add(float64(len(b.Events)))
+	}
+	return context.Cause(ctx)
+}
+
+// apply applies the events of a batch, grouping the revisions in
+// transactions of up to MaxTxnOps operations.
+func (r *Replicator) apply(ctx context.Context, b Batch, srcID uint64) error {
+	r.holdLeases()
+	defer r.releaseLeases()
+
+	var ops []clientv3.Op
+	// keys are the keys of ops, which a transaction cannot modify twice.
+	keys := make(map[string]struct{})
+	completed := int64(0)
+	for i, ev := range b.Events {
+		key := r.destKey(ev.Kv.Key)
+		if _, ok := keys[key]; ok {
+			// the key is modified again by a later revision.
+			if err := r.commit(ctx, ops, completed, srcID); err != nil {
+				return err
+			}
+			ops, keys = nil, make(map[string]struct{})
+		}
+		op := clientv3.OpDelete(key)
+		if ev.Type == mvccpb.PUT {
+			var err error
+			if op, err = r.put(ctx, ev.Kv); err != nil {
+				return err
+			}
+		}
+		ops = append(ops, op)
+		keys[key] = struct{}{}
+		if i == len(b.Events)-1 || b.Events[i+1].Kv.ModRevision != ev.Kv.ModRevision {
+			completed = ev.Kv.ModRevision
+		}
+		if len(ops) == r.cfg.MaxTxnOps-1 {
+			if err := r.commit(ctx, ops, completed, srcID); err != nil {
+				return err
+			}
+			ops, keys = nil, make(map[string]struct{})
+		}
+	}
+	return r.commit(ctx, ops, b.Revision, srcID)
+}
+
+// put returns the operation putting the key-value to the destination.
+func (r *Replicator) put(ctx context.Context, kv *mvccpb.KeyValue) (clientv3.Op, error) {
+	key := r.destKey(kv.Key)
+	if r.leases == nil || kv.Lease == 0 {
+		return clientv3.OpPut(key, string(kv.Value)), nil
+	}
+	ids, err := r.leases.ensure(ctx, append([]int64{kv.Lease}, kv.ExtraLeases...))
+	if err != nil {
+		return clientv3.Op{}, err
+	}
+	return clientv3.OpPut(key, string(kv.Value), clientv3.WithLeases(kv.LeaseMode, ids...)), nil
+}
+
+// holdLeases keeps the replicated leases from being revoked until the
+// transactions attaching keys to them are committed.
+func (r *Replicator) holdLeases() {
+	if r.leases != nil {
+		r.leases.revokeMu.RLock()
+	}
+}
+
+func (r *Replicator) releaseLeases() {
+	if r.leases != nil {
+		r.leases.revokeMu.RUnlock()
+	}
+}
+
+// commit applies the operations to the destination, and records the source
+// revision as the position of the replication if it is newer.
+func (r *Replicator) commit(ctx context.Context, ops []clientv3.Op, rev int64, srcID uint64) error {
+	advance := rev > r.applied
+	if !advance && len(ops) == 0 {
+		return nil
+	}
+	if advance {
+		ops = append(ops, clientv3.OpPut(r.meta.position, strconv.FormatInt(rev, 10)))
+		if r.destRev == 0 {
+			ops = append(ops, clientv3.OpPut(r.meta.source, strconv.FormatUint(srcID, 16)))
+		}
+	}
+	resp, err := r.dest.Txn(ctx).If(
+		clientv3.Compare(clientv3.ModRevision(r.meta.position), "=", r.destRev),
+		clientv3.Compare(clientv3.CreateRevision(r.meta.promoted), "=", 0),
+	).Then(ops...).Commit()
+	if err != nil {
+		return err
+	}
+	if !resp.Succeeded {
+		pos, err := GetPosition(ctx, r.dest, r.cfg.Name)
+		if err != nil {
+			return err
+		}
+		if pos.Promoted {
+			return ErrPromoted
+		}
+		return ErrConflict
+	}
+	if advance {
+		r.setApplied(rev, resp.Header.Revision)
+	}
+	return nil
+}
+
+func (r *Replicator) destKey(key []byte) string {
+	return r.cfg.DestPrefix + string(key[len(r.cfg.Prefix):])
+}
+
+func (r *Replicator) setApplied(rev, destRev int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.applied, r.destRev = rev, destRev
+	r.updateLag()
+}
+
+// observeHead records the latest source revision known.
+func (r *Replicator) observeHead(rev int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if rev > r.head {
+		r.head = rev
+	}
+	r.updateLag()
+}
+
+func (r *Replicator) updateLag() {
+	if r.applied >= r.head {
+		r.behindSince = time.Time{}
+	} else if r.behindSince.IsZero() {
+		r.behindSince = time.Now()
+	}
+	r.statusGauges.update(r.head, r.applied, r.behindSince)
+}
+
+// rangePrefix ranges the keys of the prefix at the given revision, the
+// current one if 0, in pages passed to f.
+func rangePrefix(ctx context.Context, c *clientv3.Client, prefix string, rev int64, keysOnly bool, f func([]*mvccpb.KeyValue) error) error {
+	key := prefix
+	opts := []clientv3.OpOption{
+		clientv3.WithLimit(batchLimit), clientv3.WithRev(rev),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+	}
+	if prefix == "" {
+		key = "\x00"
+		opts = append(opts, clientv3.WithFromKey())
+	} else {
+		opts = append(opts, clientv3.WithRange(clientv3.GetPrefixRangeEnd(prefix)))
+	}
+	if keysOnly {
+		opts = append(opts, clientv3.WithKeysOnly())
+	}
+	for {
+		resp, err := c.Get(ctx, key, opts...)
+		if err != nil {
+			return err
+		}
+		if err = f(resp.Kvs); err != nil {
+			return err
+		}
+		if !resp.More {
+			return nil
+		}
+		key = string(append(resp.Kvs[len(resp.Kvs)-1].Key, 0))
+		if rev == 0 {
+			// the following pages are read at the revision of the first one.
+			rev = resp.Header.Revision
+			opts = append(opts, clientv3.WithRev(rev))
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package replication
+
+import (
+	"context"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// Batch is a batch of committed source revisions.
+type Batch struct {
+	// Revision is the source revision the stream is complete up to: all the
+	// events of the revisions up to it were streamed.
+	Revision int64
+	// Head is the current revision of the source when the batch was sent.
+	Head int64
+	// Events are the events of the batch in revision order, without the
+	// events of the replication metadata keys. A batch only reporting the
+	// progress of the stream has none.
+	Events []*clientv3.Event
+	// Err is the error the stream failed with, which is rpctypes.ErrCompacted
+	// if the revisions to stream were compacted. It is set on the last batch.
+	Err error
+}
+
+// Stream streams the committed revisions of the keys of the prefix, starting
+// at the given revision, to an observer of the source that keeps its own
+// position. The events of a revision are always streamed in the same batch.
+// Every progress interval, the source is asked for the progress of the
+// stream, which is streamed as a batch without events. The channel is closed
+// when the context is done or the stream fails.
+func Stream(ctx context.Context, c *clientv3.Client, prefix string, rev int64, progressInterval time.Duration) <-chan Batch {
+	if progressInterval <= 0 {
+		progressInterval = defaultProgressInterval
+	}
+	bc := make(chan Batch)
+	go func() {
+		defer close(bc)
+		ctx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+		defer cancel()
+		w := clientv3.NewWatcher(c)
+		defer w.Close()
+
+		wch := w.Watch(ctx, prefix, clientv3.WithPrefix(), clientv3.WithRev(rev), clientv3.WithProgressNotify())
+		ticker := time.NewTicker(progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case wr, ok := <-wch:
+				if !ok {
+					return
+				}
+				if wr.Created {
+					continue
+				}
+				b := Batch{Head: wr.Header.Revision}
+				switch {
+				case wr.CompactRevision != 0:
+					b.Err = rpctypes.ErrCompacted
+				case wr.Err() != nil:
+					b.Err = wr.Err()
+				case wr.IsProgressNotify():
+					b.Revision = wr.Header.Revision
+				default:
+					b.Revision = wr.Events[len(wr.Events)-1].Kv.ModRevision
+					for _, ev := range wr.Events {
+						if !isMetaKey(ev.Kv.Key) {
+							b.Events = append(b.Events, ev)
+						}
+					}
+				}
+				select {
+				case bc <- b:
+				case <-ctx.Done():
+					return
+				}
+				if b.Err != nil {
+					return
+				}
+			case <-ticker.C:
+				// a failed request is retried on the next tick.
+				w.RequestProgress(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return bc
+}
@@ -124,6 +124,10 @@ func (rkv *retryKVClient) Compact(ctx context.Context, in *pb.CompactionRequest,
 	return rkv.kc.Compact(ctx, in, opts...)
 }
 
+func (rkv *retryKVClient) RevisionAt(ctx context.Context, in *pb.RevisionAtRequest, opts ...grpc.CallOption) (resp *pb.RevisionAtResponse, err error) {
+	return rkv.kc.RevisionAt(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryLeaseClient struct {
 	lc pb.LeaseClient
 }
@@ -155,6 +159,26 @@ func (rlc *retryLeaseClient) LeaseKeepAlive(ctx context.Context, opts ...grpc.Ca
 	return rlc.lc.LeaseKeepAlive(ctx, append(opts, withRepeatablePolicy())...)
 }
 
+func (rlc *retryLeaseClient) LeaseGroupGrant(ctx context.Context, in *pb.LeaseGroupGrantRequest, opts ...grpc.CallOption) (resp *pb.LeaseGroupGrantResponse, err error) {
+	return rlc.lc.LeaseGroupGrant(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rlc *retryLeaseClient) LeaseGroupRevoke(ctx context.Context, in *pb.LeaseGroupRevokeRequest, opts ...grpc.CallOption) (resp *pb.LeaseGroupRevokeResponse, err error) {
+	return rlc.lc.LeaseGroupRevoke(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rlc *retryLeaseClient) LeaseGroupAttach(ctx context.Context, in *pb.LeaseGroupAttachRequest, opts ...grpc.CallOption) (resp *pb.LeaseGroupAttachResponse, err error) {
+	return rlc.lc.LeaseGroupAttach(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rlc *retryLeaseClient) LeaseGroupDetach(ctx context.Context, in *pb.LeaseGroupDetachRequest, opts ...grpc.CallOption) (resp *pb.LeaseGroupDetachResponse, err error) {
+	return rlc.lc.LeaseGroupDetach(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rlc *retryLeaseClient) LeaseGroupKeepAlive(ctx context.Context, in *pb.LeaseGroupKeepAliveRequest, opts ...grpc.CallOption) (resp *pb.LeaseGroupKeepAliveResponse, err error) {
+	return rlc.lc.LeaseGroupKeepAlive(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryClusterClient struct {
 	cc pb.ClusterClient
 }
@@ -186,6 +210,10 @@ func (rcc *retryClusterClient) MemberPromote(ctx context.Context, in *pb.MemberP
 	return rcc.cc.MemberPromote(ctx, in, opts...)
 }
 
+func (rcc *retryClusterClient) MemberReplace(ctx context.Context, in *pb.MemberReplaceRequest, opts ...grpc.CallOption) (resp *pb.MemberReplaceResponse, err error) {
+	return rcc.cc.MemberReplace(ctx, in, opts...)
+}
+
 type retryMaintenanceClient struct {
 	mc pb.MaintenanceClient
 }
@@ -229,6 +257,18 @@ func (rmc *retryMaintenanceClient) Downgrade(ctx context.Context, in *pb.Downgra
 	return rmc.mc.Downgrade(ctx, in, opts...)
 }
 
+func (rmc *retryMaintenanceClient) CompactionPolicy(ctx context.Context, in *pb.CompactionPolicyRequest, opts ...grpc.CallOption) (resp *pb.CompactionPolicyResponse, err error) {
+	return rmc.mc.CompactionPolicy(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) Drain(ctx context.Context, in *pb.DrainRequest, opts ...grpc.CallOption) (resp *pb.DrainResponse, err error) {
+	return rmc.mc.Drain(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
+func (rmc *retryMaintenanceClient) Undrain(ctx context.Context, in *pb.UndrainRequest, opts ...grpc.CallOption) (resp *pb.UndrainResponse, err error) {
+	return rmc.mc.Undrain(ctx, in, append(opts, withRepeatablePolicy())...)
+}
+
 type retryAuthClient struct {
 	ac pb.AuthClient
 }
@@ -119,6 +119,18 @@ func (e *Event) IsModify() bool {
 	return e.Type == EventTypePut && e.Kv.CreateRevision != e.Kv.ModRevision
 }
 
+// IsLeaseExpired returns true if the event tells that the key was deleted
+// because its lease expired.
+func (e *Event) IsLeaseExpired() bool {
+	return e.Type == EventTypeDelete && e.RevokeReason == mvccpb.EXPIRED
+}
+
+// IsLeaseRevoked returns true if the event tells that the key was deleted
+// because its lease was explicitly revoked.
+func (e *Event) IsLeaseRevoked() bool {
+	return e.Type == EventTypeDelete && e.RevokeReason == mvccpb.REVOKED
+}
+
 // Err is the error value if this WatchResponse holds an error.
 func (wr *WatchResponse) Err() error {
 	switch {
@@ -216,6 +228,8 @@ type watchRequest struct {
 	filters []pb.WatchCreateRequest_FilterType
 	// get the previous key-value pair before the event happens
 	prevKV bool
+	// lease is the lease to wait on instead of the key range
+	lease LeaseID
 	// retc receives a chan WatchResponse once the watcher is established
 	retc chan chan WatchResponse
 }
@@ -316,6 +330,7 @@ func (w *watcher) Watch(ctx context.Context, key string, opts ...OpOption) Watch
 		fragment:       ow.fragment,
 		filters:        filters,
 		prevKV:         ow.prevKV,
+		lease:          ow.leaseID,
 		retc:           make(chan chan WatchResponse, 1),
 	}
 
@@ -818,7 +833,19 @@ func (w *watchGRPCStream) serveSubstream(ws *watcherStream, resumec chan struct{
 			ws.buf = ws.buf[1:]
 		case wr, ok := <-ws.recvc:
 			if !ok {
-				// shutdown from closeSubstream
+				// shutdown from closeSubstream; deliver the responses
+				// buffered before the watcher was canceled, such as the
+				// final event of a lease watcher
+				for _, bwr := range ws.buf {
+					select {
+					case ws.outc <- *bwr:
+					case <-w.ctx.Done():
+						return
+					case <-ws.initReq.ctx.Done():
+						return
+					}
+				}
+				ws.buf = nil
 				return
 			}
 
@@ -1023,6 +1050,7 @@ func (wr *watchRequest) toPB() *pb.WatchRequest {
 		Filters:        wr.filters,
 		PrevKv:         wr.prevKV,
 		Fragment:       wr.fragment,
+		Lease:          int64(wr.lease),
 	}
 	cr := &pb.WatchRequest_CreateRequest{CreateRequest: req}
 	return &pb.WatchRequest{RequestUnion: cr}
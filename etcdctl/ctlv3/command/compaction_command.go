@@ -34,6 +34,7 @@ func NewCompactionCommand() *cobra.Command {
 		Run:   compactionCommandFunc,
 	}
 	cmd.Flags().BoolVar(&compactPhysical, "physical", false, "'true' to wait for compaction to physically remove all old revisions")
+	cmd.AddCommand(NewCompactionPolicyCommand())
 	return cmd
 }
 
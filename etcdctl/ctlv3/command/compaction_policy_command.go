@@ -0,0 +1,116 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+// NewCompactionPolicyCommand returns the cobra command for "compaction policy".
+func NewCompactionPolicyCommand() *cobra.Command {
+	pc := &cobra.Command{
+		Use:   "policy <subcommand>",
+		Short: "Per-prefix compaction retention policy related commands",
+	}
+
+	pc.AddCommand(NewCompactionPolicyListCommand())
+	pc.AddCommand(NewCompactionPolicyPutCommand())
+	pc.AddCommand(NewCompactionPolicyDeleteCommand())
+
+	return pc
+}
+
+func NewCompactionPolicyListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists all compaction retention policies",
+		Run:   compactionPolicyListCommandFunc,
+	}
+}
+
+var policyKeepDuration time.Duration
+
+func NewCompactionPolicyPutCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "put <prefix> [keep-revisions]",
+		Short: "Keeps the given number of revisions or duration of history for keys under a prefix on compaction",
+		Run:   compactionPolicyPutCommandFunc,
+	}
+	cmd.Flags().DurationVar(&policyKeepDuration, "keep-duration", 0, "Keeps the revisions applied within the given duration, in whole seconds (e.g. 24h)")
+	return cmd
+}
+
+func NewCompactionPolicyDeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <prefix>",
+		Short: "Deletes the compaction retention policy for a prefix",
+		Run:   compactionPolicyDeleteCommandFunc,
+	}
+}
+
+// compactionPolicyListCommandFunc executes the "compaction policy list" command.
+func compactionPolicyListCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("compaction policy list command accepts no arguments"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).CompactionPolicyList(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.CompactionPolicy(*resp)
+}
+
+// compactionPolicyPutCommandFunc executes the "compaction policy put" command.
+func compactionPolicyPutCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 && (len(args) != 1 || policyKeepDuration == 0) {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("compaction policy put command needs a prefix and a number of revisions or --keep-duration"))
+	}
+	var keep int64
+	if len(args) == 2 {
+		var err error
+		if keep, err = strconv.ParseInt(args[1], 10, 64); err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad number of revisions (%w)", err))
+		}
+	}
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).CompactionPolicyPut(ctx, args[0], keep, policyKeepDuration)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.CompactionPolicy(*resp)
+}
+
+// compactionPolicyDeleteCommandFunc executes the "compaction policy delete" command.
+func compactionPolicyDeleteCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("compaction policy delete command needs 1 argument"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).CompactionPolicyDelete(ctx, args[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.CompactionPolicy(*resp)
+}
@@ -28,7 +28,11 @@ import (
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
-var electListen bool
+var (
+	electListen   bool
+	electPriority int64
+	electTransfer string
+)
 
 // NewElectCommand returns the cobra command for "elect".
 func NewElectCommand() *cobra.Command {
@@ -38,6 +42,8 @@ func NewElectCommand() *cobra.Command {
 		Run:   electCommandFunc,
 	}
 	cmd.Flags().BoolVarP(&electListen, "listen", "l", false, "observation mode")
+	cmd.Flags().Int64Var(&electPriority, "priority", 0, "campaign priority; the leader hands leadership over to candidates with a higher priority")
+	cmd.Flags().StringVar(&electTransfer, "transfer", "", "hand the leadership of the election over to the candidate with the given key")
 	return cmd
 }
 
@@ -48,7 +54,12 @@ func electCommandFunc(cmd *cobra.Command, args []string) {
 	c := mustClientFromCmd(cmd)
 
 	var err error
-	if len(args) == 1 {
+	if electTransfer != "" {
+		if len(args) != 1 || electListen {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--transfer takes one election name argument and no -l"))
+		}
+		err = transfer(cmd, c, args[0], electTransfer)
+	} else if len(args) == 1 {
 		if !electListen {
 			cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("no proposal argument but -l not set"))
 		}
@@ -103,34 +114,56 @@ func campaign(c *clientv3.Client, election string, prop string) error {
 	if err != nil {
 		return err
 	}
-	e := concurrency.NewElection(s, election)
 	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
 
-	donec := make(chan struct{})
 	sigc := make(chan os.Signal, 1)
 	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
 	go func() {
 		<-sigc
 		cancel()
-		close(donec)
 	}()
 
-	if err = e.Campaign(ctx, prop); err != nil {
-		return err
+	var el *concurrency.Elector
+	el = concurrency.NewElector(s, election, concurrency.ElectorConfig{
+		Value:    prop,
+		Priority: electPriority,
+		OnStartedLeading: func(ctx context.Context) {
+			// print key since elected
+			if resp, err := c.Get(ctx, el.Key()); err == nil {
+				display.Get(*resp)
+			}
+		},
+	})
+	err = el.Run(ctx)
+	if errors.Is(err, concurrency.ErrSessionExpired) {
+		return errors.New("elect: session expired")
+	}
+	if ctx.Err() != nil {
+		// terminated by a signal; Run resigned leadership, if any
+		return nil
 	}
+	return err
+}
 
-	// print key since elected
-	resp, err := c.Get(ctx, e.Key())
+func transfer(cmd *cobra.Command, c *clientv3.Client, election string, candidate string) error {
+	s, err := concurrency.NewSession(c)
 	if err != nil {
 		return err
 	}
-	display.Get(*resp)
+	defer s.Close()
+	ctx, cancel := commandCtx(cmd)
+	defer cancel()
 
-	select {
-	case <-donec:
-	case <-s.Done():
-		return errors.New("elect: session expired")
+	resp, err := concurrency.NewElection(s, election).Leader(ctx)
+	if err != nil {
+		return err
 	}
-
-	return e.Resign(context.TODO())
+	kv := resp.Kvs[0]
+	e := concurrency.ResumeElection(s, election, string(kv.Key), kv.CreateRevision)
+	if err = e.Transfer(ctx, candidate); err != nil {
+		return err
+	}
+	display.Get(*resp)
+	return nil
 }
@@ -32,6 +32,7 @@ var (
 	getPrefix       bool
 	getFromKey      bool
 	getRev          int64
+	getAtTime       string
 	getKeysOnly     bool
 	getCountOnly    bool
 	printValueOnly  bool
@@ -56,6 +57,7 @@ func NewGetCommand() *cobra.Command {
 	cmd.Flags().BoolVar(&getPrefix, "prefix", false, "Get keys with matching prefix")
 	cmd.Flags().BoolVar(&getFromKey, "from-key", false, "Get keys that are greater than or equal to the given key using byte compare")
 	cmd.Flags().Int64Var(&getRev, "rev", 0, "Specify the kv revision")
+	cmd.Flags().StringVar(&getAtTime, "at-time", "", "Get the keys as of the given RFC 3339 timestamp (e.g. 2006-01-02T15:04:05Z)")
 	cmd.Flags().BoolVar(&getKeysOnly, "keys-only", false, "Get only the keys")
 	cmd.Flags().BoolVar(&getCountOnly, "count-only", false, "Get only the count")
 	cmd.Flags().BoolVar(&printValueOnly, "print-value-only", false, `Only write values when using the "simple" output format`)
@@ -80,8 +82,21 @@ func NewGetCommand() *cobra.Command {
 // getCommandFunc executes the "get" command.
 func getCommandFunc(cmd *cobra.Command, args []string) {
 	key, opts := getGetOp(args)
+	c := mustClientFromCmd(cmd)
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Get(ctx, key, opts...)
+	if getAtTime != "" {
+		var rOpts []clientv3.OpOption
+		if IsSerializable(getConsistency) {
+			rOpts = append(rOpts, clientv3.WithSerializable())
+		}
+		rev, err := revisionAt(ctx, c, getAtTime, rOpts...)
+		if err != nil {
+			cancel()
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		opts = append(opts, clientv3.WithRev(rev))
+	}
+	resp, err := c.Get(ctx, key, opts...)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
@@ -116,6 +131,10 @@ func getGetOp(args []string) (string, []clientv3.OpOption) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--keys-only` and `--count-only` cannot be set at the same time, choose one"))
 	}
 
+	if getRev > 0 && getAtTime != "" {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("`--rev` and `--at-time` cannot be set at the same time, choose one"))
+	}
+
 	var opts []clientv3.OpOption
 	if IsSerializable(getConsistency) {
 		opts = append(opts, clientv3.WithSerializable())
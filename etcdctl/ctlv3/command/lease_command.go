@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -37,18 +38,28 @@ func NewLeaseCommand() *cobra.Command {
 	lc.AddCommand(NewLeaseTimeToLiveCommand())
 	lc.AddCommand(NewLeaseListCommand())
 	lc.AddCommand(NewLeaseKeepAliveCommand())
+	lc.AddCommand(NewLeaseGroupCommand())
 
 	return lc
 }
 
+var (
+	leaseGrantMaxLifetime  int64
+	leaseGrantRenewOnWrite bool
+	leaseGrantLabels       map[string]string
+)
+
 // NewLeaseGrantCommand returns the cobra command for "lease grant".
 func NewLeaseGrantCommand() *cobra.Command {
 	lc := &cobra.Command{
-		Use:   "grant <ttl>",
+		Use:   "grant <ttl> [options]",
 		Short: "Creates leases",
 
 		Run: leaseGrantCommandFunc,
 	}
+	lc.Flags().Int64Var(&leaseGrantMaxLifetime, "max-lifetime", 0, "Revokes the lease after the given number of seconds, however often it is kept alive")
+	lc.Flags().BoolVar(&leaseGrantRenewOnWrite, "renew-on-write", false, "Renews the lease on every put to a key attached to it")
+	lc.Flags().StringToStringVar(&leaseGrantLabels, "label", nil, "Attaches labels to the lease (e.g. --label owner=svc-a,purpose=election)")
 
 	return lc
 }
@@ -64,8 +75,19 @@ func leaseGrantCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad TTL (%w)", err))
 	}
 
+	var opts []v3.LeaseOption
+	if leaseGrantMaxLifetime > 0 {
+		opts = append(opts, v3.WithMaxLifetime(leaseGrantMaxLifetime))
+	}
+	if leaseGrantRenewOnWrite {
+		opts = append(opts, v3.WithRenewOnWrite())
+	}
+	if len(leaseGrantLabels) > 0 {
+		opts = append(opts, v3.WithLabels(leaseGrantLabels))
+	}
+
 	ctx, cancel := commandCtx(cmd)
-	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl)
+	resp, err := mustClientFromCmd(cmd).Grant(ctx, ttl, opts...)
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to grant lease (%w)", err))
@@ -132,25 +154,73 @@ func leaseTimeToLiveCommandFunc(cmd *cobra.Command, args []string) {
 	display.TimeToLive(*resp, timeToLiveKeys)
 }
 
+var (
+	leaseListSelector string
+	leaseListOwner    string
+	leaseListMinTTL   int64
+	leaseListMaxTTL   int64
+	leaseListLimit    int64
+	leaseListAfter    string
+)
+
 // NewLeaseListCommand returns the cobra command for "lease list".
 func NewLeaseListCommand() *cobra.Command {
 	lc := &cobra.Command{
-		Use:   "list",
+		Use:   "list [options]",
 		Short: "List all active leases",
 		Run:   leaseListCommandFunc,
 	}
+	lc.Flags().StringVar(&leaseListSelector, "selector", "", "Lists only the leases carrying all the given labels (e.g. --selector owner=svc-a,purpose=election)")
+	lc.Flags().StringVar(&leaseListOwner, "owner", "", "Lists only the leases granted by the given user")
+	lc.Flags().Int64Var(&leaseListMinTTL, "min-ttl", 0, "Lists only the leases with at least the given remaining TTL in seconds")
+	lc.Flags().Int64Var(&leaseListMaxTTL, "max-ttl", 0, "Lists only the leases with at most the given remaining TTL in seconds")
+	lc.Flags().Int64Var(&leaseListLimit, "limit", 0, "Maximum number of leases to list")
+	lc.Flags().StringVar(&leaseListAfter, "after", "", "Lists only the leases with an ID greater than the given lease ID")
 	return lc
 }
 
 // leaseListCommandFunc executes the "lease list" command.
 func leaseListCommandFunc(cmd *cobra.Command, args []string) {
-	resp, rerr := mustClientFromCmd(cmd).Leases(context.TODO())
+	var opts []v3.LeaseOption
+	if leaseListSelector != "" {
+		labels, err := parseLabelSelector(leaseListSelector)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, err)
+		}
+		opts = append(opts, v3.WithLabels(labels))
+	}
+	if leaseListOwner != "" {
+		opts = append(opts, v3.WithOwner(leaseListOwner))
+	}
+	if leaseListMinTTL != 0 || leaseListMaxTTL != 0 {
+		opts = append(opts, v3.WithRemainingTTL(leaseListMinTTL, leaseListMaxTTL))
+	}
+	if leaseListLimit > 0 {
+		opts = append(opts, v3.WithLeasesLimit(leaseListLimit))
+	}
+	if leaseListAfter != "" {
+		opts = append(opts, v3.WithLeasesAfter(leaseFromArgs(leaseListAfter)))
+	}
+	resp, rerr := mustClientFromCmd(cmd).Leases(context.TODO(), opts...)
 	if rerr != nil {
 		cobrautl.ExitWithError(cobrautl.ExitBadConnection, rerr)
 	}
 	display.Leases(*resp)
 }
 
+// parseLabelSelector parses a comma separated list of key=value pairs.
+func parseLabelSelector(selector string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, term := range strings.Split(selector, ",") {
+		k, v, ok := strings.Cut(term, "=")
+		if !ok || k == "" {
+			return nil, fmt.Errorf("bad selector term %q, expected key=value", term)
+		}
+		labels[k] = v
+	}
+	return labels, nil
+}
+
 var leaseKeepAliveOnce bool
 
 // NewLeaseKeepAliveCommand returns the cobra command for "lease keep-alive".
@@ -197,6 +267,189 @@ func leaseKeepAliveCommandFunc(cmd *cobra.Command, args []string) {
 	}
 }
 
+// NewLeaseGroupCommand returns the cobra command for "lease group".
+func NewLeaseGroupCommand() *cobra.Command {
+	gc := &cobra.Command{
+		Use:   "group <subcommand>",
+		Short: "Lease group related commands",
+	}
+
+	gc.AddCommand(NewLeaseGroupGrantCommand())
+	gc.AddCommand(NewLeaseGroupRevokeCommand())
+	gc.AddCommand(NewLeaseGroupAttachCommand())
+	gc.AddCommand(NewLeaseGroupDetachCommand())
+	gc.AddCommand(NewLeaseGroupKeepAliveCommand())
+
+	return gc
+}
+
+// NewLeaseGroupGrantCommand returns the cobra command for "lease group grant".
+func NewLeaseGroupGrantCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "grant",
+		Short: "Creates lease groups",
+
+		Run: leaseGroupGrantCommandFunc,
+	}
+}
+
+// leaseGroupGrantCommandFunc executes the "lease group grant" command.
+func leaseGroupGrantCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease group grant command takes no arguments"))
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).GroupGrant(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to grant lease group (%w)", err))
+	}
+	display.LeaseGroupGrant(*resp)
+}
+
+// NewLeaseGroupRevokeCommand returns the cobra command for "lease group revoke".
+func NewLeaseGroupRevokeCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <groupID>",
+		Short: "Revokes lease groups, detaching their leases",
+
+		Run: leaseGroupRevokeCommandFunc,
+	}
+}
+
+// leaseGroupRevokeCommandFunc executes the "lease group revoke" command.
+func leaseGroupRevokeCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease group revoke command needs 1 argument"))
+	}
+
+	id := leaseGroupFromArgs(args[0])
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).GroupRevoke(ctx, id)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to revoke lease group (%w)", err))
+	}
+	display.LeaseGroupRevoke(id, *resp)
+}
+
+// NewLeaseGroupAttachCommand returns the cobra command for "lease group attach".
+func NewLeaseGroupAttachCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach <groupID> <leaseID> [leaseID...]",
+		Short: "Attaches leases to a lease group",
+
+		Run: leaseGroupAttachCommandFunc,
+	}
+}
+
+// leaseGroupAttachCommandFunc executes the "lease group attach" command.
+func leaseGroupAttachCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease group attach command needs group ID and lease ID arguments"))
+	}
+
+	id := leaseGroupFromArgs(args[0])
+	leases := leasesFromArgs(args[1:])
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).GroupAttach(ctx, id, leases...)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to attach leases (%w)", err))
+	}
+	display.LeaseGroupAttach(id, *resp)
+}
+
+// NewLeaseGroupDetachCommand returns the cobra command for "lease group detach".
+func NewLeaseGroupDetachCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "detach <groupID> <leaseID> [leaseID...]",
+		Short: "Detaches leases from a lease group",
+
+		Run: leaseGroupDetachCommandFunc,
+	}
+}
+
+// leaseGroupDetachCommandFunc executes the "lease group detach" command.
+func leaseGroupDetachCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) < 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease group detach command needs group ID and lease ID arguments"))
+	}
+
+	id := leaseGroupFromArgs(args[0])
+	leases := leasesFromArgs(args[1:])
+	ctx, cancel := commandCtx(cmd)
+	resp, err := mustClientFromCmd(cmd).GroupDetach(ctx, id, leases...)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, fmt.Errorf("failed to detach leases (%w)", err))
+	}
+	display.LeaseGroupDetach(id, *resp)
+}
+
+var leaseGroupKeepAliveOnce bool
+
+// NewLeaseGroupKeepAliveCommand returns the cobra command for "lease group keep-alive".
+func NewLeaseGroupKeepAliveCommand() *cobra.Command {
+	lc := &cobra.Command{
+		Use:   "keep-alive [options] <groupID>",
+		Short: "Keeps all the leases of a lease group alive (renew)",
+
+		Run: leaseGroupKeepAliveCommandFunc,
+	}
+
+	lc.Flags().BoolVar(&leaseGroupKeepAliveOnce, "once", false, "Renews the leases of the group once and exits immediately")
+
+	return lc
+}
+
+// leaseGroupKeepAliveCommandFunc executes the "lease group keep-alive" command.
+func leaseGroupKeepAliveCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("lease group keep-alive command needs group ID as argument"))
+	}
+
+	id := leaseGroupFromArgs(args[0])
+
+	if leaseGroupKeepAliveOnce {
+		resp, kerr := mustClientFromCmd(cmd).GroupKeepAliveOnce(context.TODO(), id)
+		if kerr != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadConnection, kerr)
+		}
+		display.LeaseGroupKeepAlive(*resp)
+		return
+	}
+
+	respc, kerr := mustClientFromCmd(cmd).GroupKeepAlive(context.TODO(), id)
+	if kerr != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadConnection, kerr)
+	}
+	for resp := range respc {
+		display.LeaseGroupKeepAlive(*resp)
+	}
+
+	if _, ok := (display).(*simplePrinter); ok {
+		fmt.Printf("lease group %016x revoked or no longer renewed.\n", id)
+	}
+}
+
+func leaseGroupFromArgs(arg string) v3.LeaseGroupID {
+	id, err := strconv.ParseInt(arg, 16, 64)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease group ID arg (%w), expecting ID in Hex", err))
+	}
+	return v3.LeaseGroupID(id)
+}
+
+func leasesFromArgs(args []string) []v3.LeaseID {
+	leases := make([]v3.LeaseID, len(args))
+	for i, arg := range args {
+		leases[i] = leaseFromArgs(arg)
+	}
+	return leases
+}
+
 func leaseFromArgs(arg string) v3.LeaseID {
 	id, err := strconv.ParseInt(arg, 16, 64)
 	if err != nil {
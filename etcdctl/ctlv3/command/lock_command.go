@@ -22,6 +22,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -30,7 +31,11 @@ import (
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
-var lockTTL = 10
+var (
+	lockTTL        = 10
+	lockTry        bool
+	lockTryTimeout time.Duration
+)
 
 // NewLockCommand returns the cobra command for "lock".
 func NewLockCommand() *cobra.Command {
@@ -40,9 +45,45 @@ func NewLockCommand() *cobra.Command {
 		Run:   lockCommandFunc,
 	}
 	c.Flags().IntVarP(&lockTTL, "ttl", "", lockTTL, "timeout for session")
+	c.Flags().BoolVar(&lockTry, "try", false, "fail instead of waiting if the lock is held by another session")
+	c.Flags().DurationVar(&lockTryTimeout, "try-timeout", 0, "with --try, how long to wait for the lock before failing")
+	c.AddCommand(NewLockInfoCommand())
 	return c
 }
 
+// NewLockInfoCommand returns the cobra command for "lock info".
+func NewLockInfoCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "info <lockname>",
+		Short: "Prints the holder of a named lock and the sessions waiting for it",
+		Run:   lockInfoCommandFunc,
+	}
+}
+
+func lockInfoCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("lock info takes a lock name argument"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	info, err := concurrency.GetLockInfo(ctx, mustClientFromCmd(cmd), args[0])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	if info.Holder == nil {
+		fmt.Println("not held")
+		return
+	}
+	printLockOwner("holder", *info.Holder)
+	for _, w := range info.Waiters {
+		printLockOwner("waiter", w)
+	}
+}
+
+func printLockOwner(role string, o concurrency.LockOwner) {
+	fmt.Printf("%s: %s (lease: %016x, ttl: %d, revision: %d)\n", role, o.Key, o.Lease, o.TTL, o.CreateRevision)
+}
+
 func lockCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) == 0 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("lock takes a lock name argument and an optional command to execute"))
@@ -88,7 +129,10 @@ func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) erro
 		close(donec)
 	}()
 
-	if err := m.Lock(ctx); err != nil {
+	if err := lockMutex(ctx, m); err != nil {
+		if lockTry {
+			s.Close()
+		}
 		return err
 	}
 
@@ -122,6 +166,23 @@ func lockUntilSignal(c *clientv3.Client, lockname string, cmdArgs []string) erro
 	return errors.New("session expired")
 }
 
+// lockMutex locks the mutex, or tries to if --try is set.
+func lockMutex(ctx context.Context, m *concurrency.Mutex) error {
+	if !lockTry {
+		return m.Lock(ctx)
+	}
+	if lockTryTimeout <= 0 {
+		return m.TryLock(ctx)
+	}
+	tctx, cancel := context.WithTimeout(ctx, lockTryTimeout)
+	defer cancel()
+	err := m.Lock(tctx)
+	if err != nil && ctx.Err() == nil && errors.Is(tctx.Err(), context.DeadlineExceeded) {
+		return concurrency.ErrLocked
+	}
+	return err
+}
+
 func environLockResponse(m *concurrency.Mutex) []string {
 	return []string{
 		"ETCD_LOCK_KEY=" + m.Key(),
@@ -74,18 +74,18 @@ func NewMakeMirrorCommand() *cobra.Command {
 	return c
 }
 
-func authDestCfg() *clientv3.AuthConfig {
-	if mmuser == "" {
+func authDestCfg(user, password string) *clientv3.AuthConfig {
+	if user == "" {
 		return nil
 	}
 
 	var cfg clientv3.AuthConfig
 
-	if mmpassword == "" {
-		splitted := strings.SplitN(mmuser, ":", 2)
+	if password == "" {
+		splitted := strings.SplitN(user, ":", 2)
 		if len(splitted) < 2 {
 			var err error
-			cfg.Username = mmuser
+			cfg.Username = user
 			cfg.Password, err = speakeasy.Ask("Destination Password: ")
 			if err != nil {
 				cobrautl.ExitWithError(cobrautl.ExitError, err)
@@ -95,8 +95,8 @@ func authDestCfg() *clientv3.AuthConfig {
 			cfg.Password = splitted[1]
 		}
 	} else {
-		cfg.Username = mmuser
-		cfg.Password = mmpassword
+		cfg.Username = user
+		cfg.Password = password
 	}
 
 	return &cfg
@@ -107,35 +107,33 @@ func makeMirrorCommandFunc(cmd *cobra.Command, args []string) {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("make-mirror takes one destination argument"))
 	}
 
-	dialTimeout := dialTimeoutFromCmd(cmd)
-	keepAliveTime := keepAliveTimeFromCmd(cmd)
-	keepAliveTimeout := keepAliveTimeoutFromCmd(cmd)
-	maxCallSendMsgSize := maxCallSendMsgSizeFromCmd(cmd)
-	maxCallRecvMsgSize := maxCallRecvMsgSizeFromCmd(cmd)
 	sec := &clientv3.SecureConfig{
 		Cert:              mmcert,
 		Key:               mmkey,
 		Cacert:            mmcacert,
 		InsecureTransport: mminsecureTr,
 	}
+	dc := mustDestClient(cmd, args[0], sec, authDestCfg(mmuser, mmpassword))
+	c := mustClientFromCmd(cmd)
 
-	auth := authDestCfg()
+	err := makeMirror(context.TODO(), c, dc)
+	cobrautl.ExitWithError(cobrautl.ExitError, err)
+}
 
+// mustDestClient returns the client of the destination cluster at the given
+// endpoint, with the client settings of the command.
+func mustDestClient(cmd *cobra.Command, endpoint string, sec *clientv3.SecureConfig, auth *clientv3.AuthConfig) *clientv3.Client {
 	cc := &clientv3.ConfigSpec{
-		Endpoints:          []string{args[0]},
-		DialTimeout:        dialTimeout,
-		KeepAliveTime:      keepAliveTime,
-		KeepAliveTimeout:   keepAliveTimeout,
-		MaxCallSendMsgSize: maxCallSendMsgSize,
-		MaxCallRecvMsgSize: maxCallRecvMsgSize,
+		Endpoints:          []string{endpoint},
+		DialTimeout:        dialTimeoutFromCmd(cmd),
+		KeepAliveTime:      keepAliveTimeFromCmd(cmd),
+		KeepAliveTimeout:   keepAliveTimeoutFromCmd(cmd),
+		MaxCallSendMsgSize: maxCallSendMsgSizeFromCmd(cmd),
+		MaxCallRecvMsgSize: maxCallRecvMsgSizeFromCmd(cmd),
 		Secure:             sec,
 		Auth:               auth,
 	}
-	dc := mustClient(cc)
-	c := mustClientFromCmd(cmd)
-
-	err := makeMirror(context.TODO(), c, dc)
-	cobrautl.ExitWithError(cobrautl.ExitError, err)
+	return mustClient(cc)
 }
 
 func makeMirror(ctx context.Context, c *clientv3.Client, dc *clientv3.Client) error {
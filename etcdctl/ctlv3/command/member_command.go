@@ -15,21 +15,31 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/pkg/v3/cobrautl"
 )
 
 var (
-	memberPeerURLs    string
-	isLearner         bool
-	memberConsistency string
+	memberPeerURLs       string
+	isLearner            bool
+	isAutoPromote        bool
+	isWitness            bool
+	memberConsistency    string
+	memberReplaceName    string
+	memberCatchUpTimeout time.Duration
+	isUndrain            bool
 )
 
 // NewMemberCommand returns the cobra command for "member".
@@ -44,6 +54,8 @@ func NewMemberCommand() *cobra.Command {
 	mc.AddCommand(NewMemberUpdateCommand())
 	mc.AddCommand(NewMemberListCommand())
 	mc.AddCommand(NewMemberPromoteCommand())
+	mc.AddCommand(NewMemberReplaceCommand())
+	mc.AddCommand(NewMemberDrainCommand())
 
 	return mc
 }
@@ -59,6 +71,8 @@ func NewMemberAddCommand() *cobra.Command {
 
 	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the new member.")
 	cc.Flags().BoolVar(&isLearner, "learner", false, "indicates if the new member is raft learner")
+	cc.Flags().BoolVar(&isAutoPromote, "auto-promote", false, "indicates if the new learner is promoted by the leader once it has caught up (requires --learner)")
+	cc.Flags().BoolVar(&isWitness, "witness", false, "indicates if the new member is a witness, which votes but keeps no key-value data")
 
 	return cc
 }
@@ -120,6 +134,45 @@ func NewMemberPromoteCommand() *cobra.Command {
 	return cc
 }
 
+// NewMemberReplaceCommand returns the cobra command for "member replace".
+func NewMemberReplaceCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "replace <memberID> [options]",
+		Short: "Replaces a voting member in the cluster",
+		Long: `Replaces a voting member with a new member in a single joint consensus change.
+The new member is added as a learner, unless it already is one, and the configuration
+to start it with is printed. Once it has caught up with the leader, it is promoted and
+the replaced member is removed at the same time.
+`,
+
+		Run: memberReplaceCommandFunc,
+	}
+
+	cc.Flags().StringVar(&memberPeerURLs, "peer-urls", "", "comma separated peer URLs for the new member.")
+	cc.Flags().StringVar(&memberReplaceName, "name", "", "name of the new member, required unless it is already a learner")
+	cc.Flags().DurationVar(&memberCatchUpTimeout, "catch-up-timeout", 5*time.Minute, "how long to wait for the new member to catch up with the leader")
+
+	return cc
+}
+
+// NewMemberDrainCommand returns the cobra command for "member drain".
+func NewMemberDrainCommand() *cobra.Command {
+	cc := &cobra.Command{
+		Use:   "drain <memberID> [options]",
+		Short: "Drains a member in the cluster for maintenance",
+		Long: `Marks a member as draining. A draining member transfers its leadership away, rejects
+new watch and lease keep alive streams and reports not ready on /readyz. Draining is refused
+if the remaining members would not form a healthy quorum.
+`,
+
+		Run: memberDrainCommandFunc,
+	}
+
+	cc.Flags().BoolVar(&isUndrain, "undrain", false, "clears the draining mark of the member instead.")
+
+	return cc
+}
+
 // memberAddCommandFunc executes the "member add" command.
 func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(args) < 1 {
@@ -139,6 +192,12 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 	if len(memberPeerURLs) == 0 {
 		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("member peer urls not provided"))
 	}
+	if isAutoPromote && !isLearner {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--auto-promote requires --learner"))
+	}
+	if isWitness && isLearner {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("--witness cannot be combined with --learner"))
+	}
 
 	urls := strings.Split(memberPeerURLs, ",")
 	ctx, cancel := commandCtx(cmd)
@@ -147,37 +206,46 @@ func memberAddCommandFunc(cmd *cobra.Command, args []string) {
 		resp *clientv3.MemberAddResponse
 		err  error
 	)
-	if isLearner {
+	switch {
+	case isAutoPromote:
+		resp, err = cli.MemberAddAsAutoPromoteLearner(ctx, urls)
+	case isLearner:
 		resp, err = cli.MemberAddAsLearner(ctx, urls)
-	} else {
+	case isWitness:
+		resp, err = cli.MemberAddAsWitness(ctx, urls)
+	default:
 		resp, err = cli.MemberAdd(ctx, urls)
 	}
 	cancel()
 	if err != nil {
 		cobrautl.ExitWithError(cobrautl.ExitError, err)
 	}
-	newID := resp.Member.ID
 
 	display.MemberAdd(*resp)
+	printMemberAddConfig(*resp, newMemberName)
+}
 
-	if _, ok := (display).(*simplePrinter); ok {
-		var conf []string
-		for _, memb := range resp.Members {
-			for _, u := range memb.PeerURLs {
-				n := memb.Name
-				if memb.ID == newID {
-					n = newMemberName
-				}
-				conf = append(conf, fmt.Sprintf("%s=%s", n, u))
+// printMemberAddConfig prints the configuration to start an added member with.
+func printMemberAddConfig(resp clientv3.MemberAddResponse, newMemberName string) {
+	if _, ok := (display).(*simplePrinter); !ok {
+		return
+	}
+	var conf []string
+	for _, memb := range resp.Members {
+		for _, u := range memb.PeerURLs {
+			n := memb.Name
+			if memb.ID == resp.Member.ID {
+				n = newMemberName
 			}
+			conf = append(conf, fmt.Sprintf("%s=%s", n, u))
 		}
-
-		fmt.Print("\n")
-		fmt.Printf("ETCD_NAME=%q\n", newMemberName)
-		fmt.Printf("ETCD_INITIAL_CLUSTER=%q\n", strings.Join(conf, ","))
-		fmt.Printf("ETCD_INITIAL_ADVERTISE_PEER_URLS=%q\n", memberPeerURLs)
-		fmt.Print("ETCD_INITIAL_CLUSTER_STATE=\"existing\"\n")
 	}
+
+	fmt.Print("\n")
+	fmt.Printf("ETCD_NAME=%q\n", newMemberName)
+	fmt.Printf("ETCD_INITIAL_CLUSTER=%q\n", strings.Join(conf, ","))
+	fmt.Printf("ETCD_INITIAL_ADVERTISE_PEER_URLS=%q\n", memberPeerURLs)
+	fmt.Print("ETCD_INITIAL_CLUSTER_STATE=\"existing\"\n")
 }
 
 // memberRemoveCommandFunc executes the "member remove" command.
@@ -262,3 +330,124 @@ func memberPromoteCommandFunc(cmd *cobra.Command, args []string) {
 	}
 	display.MemberPromote(id, *resp)
 }
+
+// memberReplaceCommandFunc executes the "member replace" command.
+func memberReplaceCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
+	}
+
+	id, err := strconv.ParseUint(args[0], 16, 64)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
+	}
+
+	if len(memberPeerURLs) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member peer urls not provided"))
+	}
+
+	urls := strings.Split(memberPeerURLs, ",")
+	cli := mustClientFromCmd(cmd)
+
+	ctx, cancel := commandCtx(cmd)
+	lresp, err := cli.MemberList(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	if !slices.ContainsFunc(lresp.Members, func(m *pb.Member) bool { return m.ID == id }) {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member %x not found", id))
+	}
+
+	// add the new member as a learner first, so that it can be started and
+	// catch up while the replacement waits for it.
+	sorted := slices.Sorted(slices.Values(urls))
+	if !slices.ContainsFunc(lresp.Members, func(m *pb.Member) bool {
+		return slices.Equal(sorted, slices.Sorted(slices.Values(m.PeerURLs)))
+	}) {
+		if len(memberReplaceName) == 0 {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("member name not provided"))
+		}
+		ctx, cancel = commandCtx(cmd)
+		aresp, aerr := cli.MemberAddAsLearner(ctx, urls)
+		cancel()
+		if aerr != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, aerr)
+		}
+		display.MemberAdd(*aresp)
+		printMemberAddConfig(*aresp, memberReplaceName)
+		fmt.Fprintf(os.Stderr, "waiting up to %v for member %16x to be started and catch up with the leader\n", memberCatchUpTimeout, aresp.Member.ID)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), memberCatchUpTimeout)
+	resp, err := cli.MemberReplace(ctx, id, urls)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.MemberReplace(id, *resp)
+}
+
+// memberDrainCommandFunc executes the "member drain" command.
+func memberDrainCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("member ID is not provided"))
+	}
+
+	id, err := strconv.ParseUint(args[0], 16, 64)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad member ID arg (%w), expecting ID in Hex", err))
+	}
+
+	cli := mustClientFromCmd(cmd)
+	ep, err := memberEndpoint(cmd, cli, id)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	defer cancel()
+	if isUndrain {
+		resp, err := cli.Undrain(ctx, ep)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitError, err)
+		}
+		display.MemberUndrain(id, *resp)
+		return
+	}
+	resp, err := cli.Drain(ctx, ep)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	display.MemberDrain(id, *resp)
+}
+
+// memberEndpoint returns the given endpoint served by the member, or the first
+// client URL the member advertises if none of the endpoints is served by it.
+func memberEndpoint(cmd *cobra.Command, cli *clientv3.Client, id uint64) (string, error) {
+	for _, ep := range cli.Endpoints() {
+		ctx, cancel := commandCtx(cmd)
+		resp, err := cli.Status(ctx, ep)
+		cancel()
+		if err == nil && resp.Header.GetMemberId() == id {
+			return ep, nil
+		}
+	}
+
+	ctx, cancel := commandCtx(cmd)
+	resp, err := cli.MemberList(ctx)
+	cancel()
+	if err != nil {
+		return "", err
+	}
+	for _, m := range resp.Members {
+		if m.ID != id {
+			continue
+		}
+		if len(m.ClientURLs) == 0 {
+			return "", fmt.Errorf("member %x has not published its client URLs", id)
+		}
+		return m.ClientURLs[0], nil
+	}
+	return "", fmt.Errorf("member %x not found", id)
+}
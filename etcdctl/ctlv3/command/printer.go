@@ -17,6 +17,7 @@ package command
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -40,10 +41,19 @@ type printer interface {
 	TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool)
 	Leases(r v3.LeaseLeasesResponse)
 
+	LeaseGroupGrant(r v3.LeaseGroupGrantResponse)
+	LeaseGroupRevoke(id v3.LeaseGroupID, r v3.LeaseGroupRevokeResponse)
+	LeaseGroupAttach(id v3.LeaseGroupID, r v3.LeaseGroupAttachResponse)
+	LeaseGroupDetach(id v3.LeaseGroupID, r v3.LeaseGroupDetachResponse)
+	LeaseGroupKeepAlive(r v3.LeaseGroupKeepAliveResponse)
+
 	MemberAdd(v3.MemberAddResponse)
 	MemberRemove(id uint64, r v3.MemberRemoveResponse)
 	MemberUpdate(id uint64, r v3.MemberUpdateResponse)
 	MemberPromote(id uint64, r v3.MemberPromoteResponse)
+	MemberReplace(id uint64, r v3.MemberReplaceResponse)
+	MemberDrain(id uint64, r v3.DrainResponse)
+	MemberUndrain(id uint64, r v3.UndrainResponse)
 	MemberList(v3.MemberListResponse)
 
 	EndpointHealth([]epHealth)
@@ -57,6 +67,8 @@ type printer interface {
 
 	Alarm(v3.AlarmResponse)
 
+	CompactionPolicy(v3.CompactionPolicyResponse)
+
 	RoleAdd(role string, r v3.AuthRoleAddResponse)
 	RoleGet(role string, r v3.AuthRoleGetResponse)
 	RoleDelete(role string, r v3.AuthRoleDeleteResponse)
@@ -108,6 +120,18 @@ func (p *printerRPC) KeepAlive(r v3.LeaseKeepAliveResponse)              { p.p(r
 func (p *printerRPC) TimeToLive(r v3.LeaseTimeToLiveResponse, keys bool) { p.p(&r) }
 func (p *printerRPC) Leases(r v3.LeaseLeasesResponse)                    { p.p(&r) }
 
+func (p *printerRPC) LeaseGroupGrant(r v3.LeaseGroupGrantResponse) { p.p(r) }
+func (p *printerRPC) LeaseGroupRevoke(id v3.LeaseGroupID, r v3.LeaseGroupRevokeResponse) {
+	p.p((*pb.LeaseGroupRevokeResponse)(&r))
+}
+func (p *printerRPC) LeaseGroupAttach(id v3.LeaseGroupID, r v3.LeaseGroupAttachResponse) {
+	p.p((*pb.LeaseGroupAttachResponse)(&r))
+}
+func (p *printerRPC) LeaseGroupDetach(id v3.LeaseGroupID, r v3.LeaseGroupDetachResponse) {
+	p.p((*pb.LeaseGroupDetachResponse)(&r))
+}
+func (p *printerRPC) LeaseGroupKeepAlive(r v3.LeaseGroupKeepAliveResponse) { p.p(r) }
+
 func (p *printerRPC) MemberAdd(r v3.MemberAddResponse) { p.p((*pb.MemberAddResponse)(&r)) }
 func (p *printerRPC) MemberRemove(id uint64, r v3.MemberRemoveResponse) {
 	p.p((*pb.MemberRemoveResponse)(&r))
@@ -120,8 +144,23 @@ func (p *printerRPC) MemberUpdate(id uint64, r v3.MemberUpdateResponse) {
 func (p *printerRPC) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	p.p((*pb.MemberPromoteResponse)(&r))
 }
+
+func (p *printerRPC) MemberReplace(id uint64, r v3.MemberReplaceResponse) {
+	p.p((*pb.MemberReplaceResponse)(&r))
+}
+
+func (p *printerRPC) MemberDrain(id uint64, r v3.DrainResponse) {
+	p.p((*pb.DrainResponse)(&r))
+}
+
+func (p *printerRPC) MemberUndrain(id uint64, r v3.UndrainResponse) {
+	p.p((*pb.UndrainResponse)(&r))
+}
 func (p *printerRPC) MemberList(r v3.MemberListResponse) { p.p((*pb.MemberListResponse)(&r)) }
 func (p *printerRPC) Alarm(r v3.AlarmResponse)           { p.p((*pb.AlarmResponse)(&r)) }
+func (p *printerRPC) CompactionPolicy(r v3.CompactionPolicyResponse) {
+	p.p((*pb.CompactionPolicyResponse)(&r))
+}
 func (p *printerRPC) MoveLeader(leader, target uint64, r v3.MoveLeaderResponse) {
 	p.p((*pb.MoveLeaderResponse)(&r))
 }
@@ -205,6 +244,16 @@ func makeMemberListTable(r v3.MemberListResponse) (hdr []string, rows [][]string
 	return hdr, rows
 }
 
+// formatLabels returns the labels as comma separated key=value pairs, sorted by key.
+func formatLabels(labels map[string]string) string {
+	kvs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		kvs = append(kvs, k+"="+v)
+	}
+	sort.Strings(kvs)
+	return strings.Join(kvs, ",")
+}
+
 func makeEndpointHealthTable(healthList []epHealth) (hdr []string, rows [][]string) {
 	hdr = []string{"endpoint", "health", "took", "error"}
 	for _, h := range healthList {
@@ -16,6 +16,7 @@ package command
 
 import (
 	"fmt"
+	"sort"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	spb "go.etcd.io/etcd/api/v3/mvccpb"
@@ -156,9 +157,54 @@ func (p *fieldsPrinter) Leases(r v3.LeaseLeasesResponse) {
 		} else {
 			fmt.Println(`"ID" :`, item.ID)
 		}
+		fmt.Println(`"TTL" :`, item.TTL)
+		if item.Owner != "" {
+			fmt.Printf("\"Owner\" : %q\n", item.Owner)
+		}
+		keys := make([]string, 0, len(item.Labels))
+		for k := range item.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("\"Label\" : %q=%q\n", k, item.Labels[k])
+		}
+	}
+	fmt.Println(`"More" :`, r.More)
+}
+
+func (p *fieldsPrinter) LeaseGroupGrant(r v3.LeaseGroupGrantResponse) {
+	p.hdr(r.ResponseHeader)
+	if p.isHex {
+		fmt.Printf("\"ID\" : %016x\n", r.ID)
+	} else {
+		fmt.Println(`"ID" :`, r.ID)
 	}
 }
 
+func (p *fieldsPrinter) LeaseGroupRevoke(id v3.LeaseGroupID, r v3.LeaseGroupRevokeResponse) {
+	p.hdr(r.Header)
+}
+
+func (p *fieldsPrinter) LeaseGroupAttach(id v3.LeaseGroupID, r v3.LeaseGroupAttachResponse) {
+	p.hdr(r.Header)
+}
+
+func (p *fieldsPrinter) LeaseGroupDetach(id v3.LeaseGroupID, r v3.LeaseGroupDetachResponse) {
+	p.hdr(r.Header)
+}
+
+func (p *fieldsPrinter) LeaseGroupKeepAlive(r v3.LeaseGroupKeepAliveResponse) {
+	p.hdr(r.ResponseHeader)
+	if p.isHex {
+		fmt.Printf("\"ID\" : %016x\n", r.ID)
+	} else {
+		fmt.Println(`"ID" :`, r.ID)
+	}
+	fmt.Println(`"TTL" :`, r.TTL)
+	fmt.Println(`"Renewed" :`, r.Renewed)
+}
+
 func (p *fieldsPrinter) MemberList(r v3.MemberListResponse) {
 	p.hdr(r.Header)
 	for _, m := range r.Members {
@@ -232,6 +278,17 @@ func (p *fieldsPrinter) Alarm(r v3.AlarmResponse) {
 	}
 }
 
+func (p *fieldsPrinter) CompactionPolicy(r v3.CompactionPolicyResponse) {
+	p.hdr(r.Header)
+	for _, cp := range r.Policies {
+		fmt.Printf("\"Prefix\" : %q\n", string(cp.Prefix))
+		fmt.Println(`"KeepRevisions" :`, cp.KeepRevisions)
+		fmt.Println(`"KeepSeconds" :`, cp.KeepSeconds)
+		fmt.Println(`"CompactRevision" :`, cp.CompactRevision)
+		fmt.Println()
+	}
+}
+
 func (p *fieldsPrinter) RoleAdd(role string, r v3.AuthRoleAddResponse) { p.hdr(r.Header) }
 func (p *fieldsPrinter) RoleGet(role string, r v3.AuthRoleGetResponse) {
 	p.hdr(r.Header)
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/types"
@@ -115,21 +116,60 @@ func (s *simplePrinter) TimeToLive(resp v3.LeaseTimeToLiveResponse, keys bool) {
 func (s *simplePrinter) Leases(resp v3.LeaseLeasesResponse) {
 	fmt.Printf("found %d leases\n", len(resp.Leases))
 	for _, item := range resp.Leases {
-		fmt.Printf("%016x\n", item.ID)
+		if item.Owner == "" && len(item.Labels) == 0 {
+			fmt.Printf("%016x\n", item.ID)
+			continue
+		}
+		fmt.Printf("%016x owner=%q labels=%s\n", item.ID, item.Owner, formatLabels(item.Labels))
+	}
+	if resp.More {
+		fmt.Println("more leases available")
 	}
 }
 
+func (s *simplePrinter) LeaseGroupGrant(resp v3.LeaseGroupGrantResponse) {
+	fmt.Printf("lease group %016x granted\n", resp.ID)
+}
+
+func (s *simplePrinter) LeaseGroupRevoke(id v3.LeaseGroupID, r v3.LeaseGroupRevokeResponse) {
+	fmt.Printf("lease group %016x revoked\n", id)
+}
+
+func (s *simplePrinter) LeaseGroupAttach(id v3.LeaseGroupID, r v3.LeaseGroupAttachResponse) {
+	fmt.Printf("leases attached to lease group %016x\n", id)
+}
+
+func (s *simplePrinter) LeaseGroupDetach(id v3.LeaseGroupID, r v3.LeaseGroupDetachResponse) {
+	fmt.Printf("leases detached from lease group %016x\n", id)
+}
+
+func (s *simplePrinter) LeaseGroupKeepAlive(resp v3.LeaseGroupKeepAliveResponse) {
+	fmt.Printf("lease group %016x keepalived %d leases with TTL(%d)\n", resp.ID, resp.Renewed, resp.TTL)
+}
+
 func (s *simplePrinter) Alarm(resp v3.AlarmResponse) {
 	for _, e := range resp.Alarms {
 		fmt.Printf("%+v\n", e)
 	}
 }
 
+func (s *simplePrinter) CompactionPolicy(resp v3.CompactionPolicyResponse) {
+	for _, p := range resp.Policies {
+		fmt.Printf("%s, %d, %s\n", string(p.Prefix), p.KeepRevisions, time.Duration(p.KeepSeconds)*time.Second)
+	}
+}
+
 func (s *simplePrinter) MemberAdd(r v3.MemberAddResponse) {
 	asLearner := " "
 	if r.Member.IsLearner {
 		asLearner = " as learner "
 	}
+	if r.Member.AutoPromote {
+		asLearner = " as auto promote learner "
+	}
+	if r.Member.IsWitness {
+		asLearner = " as witness "
+	}
 	fmt.Printf("Member %16x added%sto cluster %16x\n", r.Member.ID, asLearner, r.Header.ClusterId)
 }
 
@@ -145,6 +185,18 @@ func (s *simplePrinter) MemberPromote(id uint64, r v3.MemberPromoteResponse) {
 	fmt.Printf("Member %16x promoted in cluster %16x\n", id, r.Header.ClusterId)
 }
 
+func (s *simplePrinter) MemberReplace(id uint64, r v3.MemberReplaceResponse) {
+	fmt.Printf("Member %16x replaced by member %16x in cluster %16x\n", id, r.Member.ID, r.Header.ClusterId)
+}
+
+func (s *simplePrinter) MemberDrain(id uint64, r v3.DrainResponse) {
+	fmt.Printf("Member %16x is draining in cluster %16x\n", id, r.Header.ClusterId)
+}
+
+func (s *simplePrinter) MemberUndrain(id uint64, r v3.UndrainResponse) {
+	fmt.Printf("Member %16x is no longer draining in cluster %16x\n", id, r.Header.ClusterId)
+}
+
 func (s *simplePrinter) MemberList(resp v3.MemberListResponse) {
 	_, rows := makeMemberListTable(resp)
 	for _, row := range rows {
@@ -16,6 +16,7 @@ package command
 
 import (
 	"os"
+	"strconv"
 
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/tw"
@@ -27,6 +28,10 @@ type tablePrinter struct{ printer }
 
 func (tp *tablePrinter) MemberList(r v3.MemberListResponse) {
 	hdr, rows := makeMemberListTable(r)
+	hdr = append(hdr, "Is Witness", "Labels")
+	for i, m := range r.Members {
+		rows[i] = append(rows[i], strconv.FormatBool(m.IsWitness), formatLabels(m.Labels))
+	}
 	cfgBuilder := tablewriter.NewConfigBuilder().WithRowAlignment(tw.AlignRight)
 	table := tablewriter.NewTable(os.Stdout, tablewriter.WithConfig(cfgBuilder.Build()))
 	table.Header(hdr)
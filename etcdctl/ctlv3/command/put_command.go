@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -30,6 +31,7 @@ var (
 	putPrevKV      bool
 	putIgnoreVal   bool
 	putIgnoreLease bool
+	putLeaseMode   string
 )
 
 // NewPutCommand returns the cobra command for "put".
@@ -58,7 +60,8 @@ will store the content of the file to <key>.
 `,
 		Run: putCommandFunc,
 	}
-	cmd.Flags().StringVar(&leaseStr, "lease", "0", "lease ID (in hexadecimal) to attach to the key")
+	cmd.Flags().StringVar(&leaseStr, "lease", "0", "lease ID (in hexadecimal) to attach to the key, or a comma-separated list of lease IDs")
+	cmd.Flags().StringVar(&putLeaseMode, "lease-mode", "any", "when a key attached to several leases is deleted (any: when any lease is revoked, all: when all leases are revoked)")
 	cmd.Flags().BoolVar(&putPrevKV, "prev-kv", false, "return the previous key-value pair before modification")
 	cmd.Flags().BoolVar(&putIgnoreVal, "ignore-value", false, "updates the key using its current value")
 	cmd.Flags().BoolVar(&putIgnoreLease, "ignore-lease", false, "updates the key using its current lease")
@@ -97,14 +100,33 @@ func getPutOp(args []string) (string, string, []clientv3.OpOption) {
 		}
 	}
 
-	id, err := strconv.ParseInt(leaseStr, 16, 64)
-	if err != nil {
-		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID (%w), expecting ID in Hex", err))
+	var ids []clientv3.LeaseID
+	for _, s := range strings.Split(leaseStr, ",") {
+		id, err := strconv.ParseInt(s, 16, 64)
+		if err != nil {
+			cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease ID (%w), expecting ID in Hex", err))
+		}
+		if id != 0 {
+			ids = append(ids, clientv3.LeaseID(id))
+		}
+	}
+
+	var mode clientv3.LeaseMode
+	switch putLeaseMode {
+	case "any":
+		mode = clientv3.LeaseModeAny
+	case "all":
+		mode = clientv3.LeaseModeAll
+	default:
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, fmt.Errorf("bad lease mode %q, expecting any or all", putLeaseMode))
 	}
 
 	var opts []clientv3.OpOption
-	if id != 0 {
-		opts = append(opts, clientv3.WithLease(clientv3.LeaseID(id)))
+	switch {
+	case len(ids) == 1:
+		opts = append(opts, clientv3.WithLease(ids[0]))
+	case len(ids) > 1:
+		opts = append(opts, clientv3.WithLeases(mode, ids...))
 	}
 	if putPrevKV {
 		opts = append(opts, clientv3.WithPrevKV())
@@ -0,0 +1,213 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	recipe "go.etcd.io/etcd/client/v3/experimental/recipes"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	queueVisibilityTimeout time.Duration
+	queueMaxAttempts       int
+)
+
+// NewQueueCommand returns the cobra command for "queue".
+func NewQueueCommand() *cobra.Command {
+	qc := &cobra.Command{
+		Use:   "queue <subcommand>",
+		Short: "Work queue related commands",
+	}
+	qc.PersistentFlags().DurationVar(&queueVisibilityTimeout, "visibility-timeout", 30*time.Second, "how long a dequeued item stays claimed before it is delivered again")
+	qc.PersistentFlags().IntVar(&queueMaxAttempts, "max-attempts", 0, "number of deliveries of an item before it is dead-lettered (0 is unlimited)")
+
+	qc.AddCommand(NewQueueEnqueueCommand())
+	qc.AddCommand(NewQueueDequeueCommand())
+	qc.AddCommand(NewQueueStatsCommand())
+	qc.AddCommand(NewQueueDeadLettersCommand())
+
+	return qc
+}
+
+// NewQueueEnqueueCommand returns the cobra command for "queue enqueue".
+func NewQueueEnqueueCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enqueue <queue-name> <value>",
+		Short: "Adds a value to a work queue",
+		Run:   queueEnqueueCommandFunc,
+	}
+}
+
+// NewQueueDequeueCommand returns the cobra command for "queue dequeue".
+func NewQueueDequeueCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dequeue <queue-name> [exec-command arg1 arg2 ...]",
+		Short: "Takes an item from a work queue, waiting for one if it is empty",
+		Run:   queueDequeueCommandFunc,
+	}
+}
+
+// NewQueueStatsCommand returns the cobra command for "queue stats".
+func NewQueueStatsCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stats <queue-name>",
+		Short: "Prints the depth and age of a work queue",
+		Run:   queueStatsCommandFunc,
+	}
+}
+
+// NewQueueDeadLettersCommand returns the cobra command for "queue dead-letters".
+func NewQueueDeadLettersCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "dead-letters <queue-name>",
+		Short: "Lists the items of a work queue that exceeded the maximum attempts",
+		Run:   queueDeadLettersCommandFunc,
+	}
+}
+
+func newWorkQueue(c *clientv3.Client, name string) *recipe.WorkQueue {
+	return recipe.NewWorkQueue(c, name, recipe.WorkQueueConfig{
+		VisibilityTimeout: queueVisibilityTimeout,
+		MaxAttempts:       queueMaxAttempts,
+	})
+}
+
+func queueEnqueueCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("queue enqueue takes a queue name and a value"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	id, err := newWorkQueue(mustClientFromCmd(cmd), args[0]).Enqueue(ctx, args[1])
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Println(id)
+}
+
+func queueDequeueCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) == 0 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("queue dequeue takes a queue name and an optional command to execute"))
+	}
+	c := mustClientFromCmd(cmd)
+	if err := dequeueUntilSignal(c, args[0], args[1:]); err != nil {
+		cobrautl.ExitWithError(getExitCodeFromError(err), err)
+	}
+}
+
+// dequeueUntilSignal takes an item from the queue. Without a command, it prints
+// the item and acknowledges it. With a command, it runs the command with the
+// item in its environment, keeping the item claimed meanwhile, and acknowledges
+// the item if the command succeeds.
+func dequeueUntilSignal(c *clientv3.Client, name string, cmdArgs []string) error {
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigc
+		cancel()
+	}()
+
+	item, err := newWorkQueue(c, name).Dequeue(ctx)
+	if err != nil {
+		return err
+	}
+	if len(cmdArgs) == 0 {
+		fmt.Println(item.ID)
+		fmt.Println(item.Value)
+		return item.Ack(context.TODO())
+	}
+
+	donec := make(chan struct{})
+	defer close(donec)
+	// renew the claim well before it expires
+	interval := queueVisibilityTimeout / 3
+	if interval <= 0 {
+		interval = time.Second
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-donec:
+				return
+			case <-ticker.C:
+				item.Extend(ctx)
+			}
+		}
+	}()
+
+	cmd := exec.CommandContext(ctx, cmdArgs[0], cmdArgs[1:]...)
+	cmd.Env = append(os.Environ(),
+		"ETCD_QUEUE_ITEM_ID="+item.ID,
+		"ETCD_QUEUE_ITEM_VALUE="+item.Value,
+		fmt.Sprintf("ETCD_QUEUE_ITEM_ATTEMPTS=%d", item.Attempts),
+	)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	if err = cmd.Run(); err != nil {
+		if nerr := item.Nack(context.TODO()); nerr != nil {
+			return nerr
+		}
+		return err
+	}
+	return item.Ack(context.TODO())
+}
+
+func queueStatsCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("queue stats takes a queue name"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	st, err := newWorkQueue(mustClientFromCmd(cmd), args[0]).Stats(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("ready: %d, in-flight: %d, dead-lettered: %d", st.Ready, st.InFlight, st.DeadLettered)
+	if !st.Oldest.IsZero() {
+		fmt.Printf(", oldest: %v", time.Since(st.Oldest).Round(time.Millisecond))
+	}
+	fmt.Println()
+}
+
+func queueDeadLettersCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("queue dead-letters takes a queue name"))
+	}
+	ctx, cancel := commandCtx(cmd)
+	items, err := newWorkQueue(mustClientFromCmd(cmd), args[0]).DeadLetters(ctx)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	for _, item := range items {
+		fmt.Printf("%s (attempts: %d, enqueued: %s)\n", item.ID, item.Attempts, item.Enqueued.Format(time.RFC3339))
+		fmt.Println(item.Value)
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/replication"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+)
+
+var (
+	rpinsecureTr bool
+	rpcert       string
+	rpkey        string
+	rpcacert     string
+	rpuser       string
+	rppassword   string
+	rpname       string
+	rpprefix     string
+	rpdestprefix string
+	rpleases     bool
+	rpauth       bool
+	rpmaxTxnOps  uint
+	rpforce      bool
+)
+
+// NewReplicateCommand returns the cobra command for "replicate".
+func NewReplicateCommand() *cobra.Command {
+	rc := &cobra.Command{
+		Use:   "replicate <subcommand>",
+		Short: "Replicate related commands",
+	}
+
+	rc.PersistentFlags().StringVar(&rpname, "name", replication.DefaultName, "Name of the replication in the destination cluster")
+	rc.PersistentFlags().StringVar(&rpcert, "dest-cert", "", "Identify secure client using this TLS certificate file for the destination cluster")
+	rc.PersistentFlags().StringVar(&rpkey, "dest-key", "", "Identify secure client using this TLS key file")
+	rc.PersistentFlags().StringVar(&rpcacert, "dest-cacert", "", "Verify certificates of TLS enabled secure servers using this CA bundle")
+	// TODO: secure by default when etcd enables secure gRPC by default.
+	rc.PersistentFlags().BoolVar(&rpinsecureTr, "dest-insecure-transport", true, "Disable transport security for client connections")
+	rc.PersistentFlags().StringVar(&rpuser, "dest-user", "", "Destination username[:password] for authentication (prompt if password is not supplied)")
+	rc.PersistentFlags().StringVar(&rppassword, "dest-password", "", "Destination password for authentication (if this option is used, --user option shouldn't include password)")
+
+	rc.AddCommand(NewReplicateStartCommand())
+	rc.AddCommand(NewReplicateStatusCommand())
+	rc.AddCommand(NewReplicatePromoteCommand())
+
+	return rc
+}
+
+// NewReplicateStartCommand returns the cobra command for "replicate start".
+func NewReplicateStartCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "start [options] <destination>",
+		Short: "Replicates the keys to the destination etcd cluster until it is promoted",
+		Run:   replicateStartCommandFunc,
+	}
+
+	c.Flags().StringVar(&rpprefix, "prefix", "", "Key-value prefix to replicate")
+	c.Flags().StringVar(&rpdestprefix, "dest-prefix", "", "destination prefix to replicate a prefix to a different prefix in the destination cluster")
+	c.Flags().BoolVar(&rpleases, "leases", false, "Replicate the leases the keys are attached to")
+	c.Flags().BoolVar(&rpauth, "auth", false, "Replicate the roles, and the users with their roles")
+	c.Flags().UintVar(&rpmaxTxnOps, "max-txn-ops", defaultMaxTxnOps, "Maximum number of operations permitted in a transaction of the destination cluster")
+
+	return c
+}
+
+// NewReplicateStatusCommand returns the cobra command for "replicate status".
+func NewReplicateStatusCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status [options] <destination>",
+		Short: "Prints the position of the replication recorded in the destination etcd cluster",
+		Run:   replicateStatusCommandFunc,
+	}
+}
+
+// NewReplicatePromoteCommand returns the cobra command for "replicate promote".
+func NewReplicatePromoteCommand() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "promote [options] <destination>",
+		Short: "Promotes the destination etcd cluster once it caught up with the source",
+		Long: `Promotes the destination etcd cluster into a primary, which stops the replication.
+
+The source must no longer be written to: the destination is promoted once the
+replication applied the current source revision. If the source is lost, --force
+promotes the destination at its position without contacting the source, losing
+the source revisions it lagged behind.
+`,
+		Run: replicatePromoteCommandFunc,
+	}
+
+	c.Flags().BoolVar(&rpforce, "force", false, "Promote the destination at its position without waiting for the source")
+
+	return c
+}
+
+func replicateStartCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("replicate start takes one destination argument"))
+	}
+
+	dc := mustReplicateDestClient(cmd, args[0])
+	c := mustClientFromCmd(cmd)
+
+	r := replication.New(c, dc, replication.Config{
+		Name:       rpname,
+		Prefix:     rpprefix,
+		DestPrefix: rpdestprefix,
+		Leases:     rpleases,
+		Auth:       rpauth,
+		MaxTxnOps:  int(rpmaxTxnOps),
+	})
+
+	go func() {
+		for {
+			time.Sleep(30 * time.Second)
+			st := r.Status()
+			fmt.Printf("source-revision: %d, applied-revision: %d, lag: %v\n", st.SourceRevision, st.AppliedRevision, st.Lag)
+		}
+	}()
+
+	err := r.Run(context.TODO())
+	if errors.Is(err, replication.ErrPromoted) {
+		fmt.Println("destination promoted, replication stopped")
+		return
+	}
+	cobrautl.ExitWithError(cobrautl.ExitError, err)
+}
+
+func replicateStatusCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("replicate status takes one destination argument"))
+	}
+
+	dc := mustReplicateDestClient(cmd, args[0])
+	defer dc.Close()
+
+	ctx, cancel := commandCtx(cmd)
+	pos, err := replication.GetPosition(ctx, dc, rpname)
+	cancel()
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	printReplicationPosition(pos)
+}
+
+func replicatePromoteCommandFunc(cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		cobrautl.ExitWithError(cobrautl.ExitBadArgs, errors.New("replicate promote takes one destination argument"))
+	}
+
+	dc := mustReplicateDestClient(cmd, args[0])
+	defer dc.Close()
+
+	var c *clientv3.Client
+	if !rpforce {
+		c = mustClientFromCmd(cmd)
+		defer c.Close()
+	}
+
+	// waiting for the destination to catch up is not bounded by the command
+	// timeout.
+	pos, err := replication.Promote(context.TODO(), c, dc, rpname)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError, err)
+	}
+	fmt.Printf("destination promoted at source revision %d\n", pos.SourceRevision)
+}
+
+func mustReplicateDestClient(cmd *cobra.Command, endpoint string) *clientv3.Client {
+	sec := &clientv3.SecureConfig{
+		Cert:              rpcert,
+		Key:               rpkey,
+		Cacert:            rpcacert,
+		InsecureTransport: rpinsecureTr,
+	}
+	return mustDestClient(cmd, endpoint, sec, authDestCfg(rpuser, rppassword))
+}
+
+func printReplicationPosition(pos replication.Position) {
+	fmt.Printf("source-revision: %d\n", pos.SourceRevision)
+	fmt.Printf("dest-revision: %d\n", pos.DestRevision)
+	fmt.Printf("source-cluster-id: %x\n", pos.SourceClusterID)
+	fmt.Printf("promoted: %t\n", pos.Promoted)
+	fmt.Printf("syncing: %t\n", pos.Syncing)
+}
@@ -178,3 +178,17 @@ func IsSerializable(option string) bool {
 	}
 	return false
 }
+
+// revisionAt resolves a RFC 3339 timestamp to the latest revision known to
+// have been applied at or before it.
+func revisionAt(ctx context.Context, c *clientv3.Client, timestamp string, opts ...clientv3.OpOption) (int64, error) {
+	t, err := time.Parse(time.RFC3339Nano, timestamp)
+	if err != nil {
+		return 0, fmt.Errorf("bad timestamp %q, expected RFC 3339 format (e.g. 2006-01-02T15:04:05Z): %w", timestamp, err)
+	}
+	resp, err := c.RevisionAt(ctx, t, opts...)
+	if err != nil {
+		return 0, err
+	}
+	return resp.Revision, nil
+}
@@ -22,6 +22,7 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -38,6 +39,7 @@ var (
 
 var (
 	watchRev         int64
+	watchSinceTime   string
 	watchPrefix      bool
 	watchInteractive bool
 	watchPrevKey     bool
@@ -55,6 +57,7 @@ func NewWatchCommand() *cobra.Command {
 	cmd.Flags().BoolVarP(&watchInteractive, "interactive", "i", false, "Interactive mode")
 	cmd.Flags().BoolVar(&watchPrefix, "prefix", false, "Watch on a prefix if prefix is set")
 	cmd.Flags().Int64Var(&watchRev, "rev", 0, "Revision to start watching")
+	cmd.Flags().StringVar(&watchSinceTime, "since-time", "", "Watch events since the given RFC 3339 timestamp (e.g. 2006-01-02T15:04:05Z)")
 	cmd.Flags().BoolVar(&watchPrevKey, "prev-kv", false, "get the previous key-value pair before the event happens")
 	cmd.Flags().BoolVar(&progressNotify, "progress-notify", false, "get periodic watch progress notification from server")
 
@@ -142,8 +145,22 @@ func getWatchChan(c *clientv3.Client, args []string) (clientv3.WatchChan, error)
 		return nil, errBadArgsNum
 	}
 
+	rev := watchRev
+	if watchSinceTime != "" {
+		if watchRev != 0 {
+			return nil, fmt.Errorf("`--rev` and `--since-time` are mutually exclusive")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		atRev, err := revisionAt(ctx, c, watchSinceTime)
+		cancel()
+		if err != nil {
+			return nil, err
+		}
+		rev = atRev + 1
+	}
+
 	key := args[0]
-	opts := []clientv3.OpOption{clientv3.WithRev(watchRev)}
+	opts := []clientv3.OpOption{clientv3.WithRev(rev)}
 	if len(args) == 2 {
 		if watchPrefix {
 			return nil, fmt.Errorf("`range_end` and `--prefix` are mutually exclusive")
@@ -329,6 +346,10 @@ func parseWatchArgs(osArgs, commandArgs []string, envKey, envRange string, inter
 		if err != nil {
 			return nil, nil, err
 		}
+		watchSinceTime, err = flagset.GetString("since-time")
+		if err != nil {
+			return nil, nil, err
+		}
 		watchPrevKey, err = flagset.GetBool("prev-kv")
 		if err != nil {
 			return nil, nil, err
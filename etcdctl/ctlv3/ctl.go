@@ -92,8 +92,10 @@ func init() {
 		command.NewMemberCommand(),
 		command.NewSnapshotCommand(),
 		command.NewMakeMirrorCommand(),
+		command.NewReplicateCommand(),
 		command.NewLockCommand(),
 		command.NewElectCommand(),
+		command.NewQueueCommand(),
 		command.NewAuthCommand(),
 		command.NewUserCommand(),
 		command.NewRoleCommand(),
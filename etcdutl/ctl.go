@@ -40,6 +40,8 @@ func init() {
 
 	rootCmd.AddCommand(
 		etcdutl.NewDefragCommand(),
+		etcdutl.NewCompactCommand(),
+		etcdutl.NewPurgeCommand(),
 		etcdutl.NewSnapshotCommand(),
 		etcdutl.NewHashKVCommand(),
 		etcdutl.NewVersionCommand(),
@@ -0,0 +1,195 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+var (
+	compactDataDir  string
+	compactRevision int64
+	compactOutput   string
+)
+
+// NewCompactCommand returns the cobra command for "compact".
+func NewCompactCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "compact",
+		Short: "Compacts the key-value history of a data directory not in use by etcd",
+		Run:   compactCommandFunc,
+	}
+	cmd.Flags().StringVar(&compactDataDir, "data-dir", "", "Required. Compacts a data directory not in use by etcd.")
+	cmd.Flags().Int64Var(&compactRevision, "revision", 0, "Revision to compact to (default: latest revision)")
+	cmd.Flags().StringVar(&compactOutput, "output", "", "If set, writes the result to the given snapshot file and leaves the data directory unchanged.")
+	cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagDirname("data-dir")
+	return cmd
+}
+
+func compactCommandFunc(cmd *cobra.Command, args []string) {
+	rev, err := CompactData(GetLogger(), compactDataDir, compactRevision, compactOutput)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError,
+			fmt.Errorf("Failed to compact etcd data[%s] (%w)", compactDataDir, err))
+	}
+	fmt.Printf("Compacted revisions up to %d\n", rev)
+}
+
+// CompactData compacts the key-value history of the backend in dataDir up
+// to the given revision, or the latest revision if rev is 0, and
+// defragments it. If output is set, the result is written to that
+// snapshot file instead of the data directory. It returns the revision
+// compacted to.
+func CompactData(lg *zap.Logger, dataDir string, rev int64, output string) (int64, error) {
+	var compacted int64
+	err := modifyBackend(lg, dataDir, output, func(be backend.Backend) error {
+		// leases are not needed to compact the key space, but keys
+		// attached to one must be restored to a lessor.
+		st := mvcc.NewStore(lg, be, &lease.FakeLessor{}, mvcc.StoreConfig{})
+		defer st.Close()
+		if rev == 0 {
+			rev = st.Rev()
+		}
+		done, err := st.Compact(traceutil.TODO(), rev)
+		if err != nil {
+			return err
+		}
+		<-done
+		compacted = rev
+		return nil
+	})
+	return compacted, err
+}
+
+// modifyBackend opens the backend of a data directory not in use by etcd,
+// calls fn and defragments the result. If output is set, fn is applied to
+// a copy of the backend, which is then written to output as a snapshot file
+// that can be restored with "etcdutl snapshot restore".
+func modifyBackend(lg *zap.Logger, dataDir, output string, fn func(be backend.Backend) error) error {
+	dbPath := datadir.ToBackendFileName(dataDir)
+	if _, err := os.Stat(dbPath); err != nil {
+		return err
+	}
+	if output != "" {
+		partPath := output + ".part"
+		defer os.Remove(partPath)
+		if err := copyBackend(lg, dbPath, partPath); err != nil {
+			return err
+		}
+		dbPath = partPath
+	}
+
+	be := openBackend(lg, dbPath)
+	err := fn(be)
+	if err == nil {
+		be.ForceCommit()
+		err = be.Defrag()
+	}
+	if cerr := be.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil || output == "" {
+		return err
+	}
+	return writeSnapshotFile(dbPath, output)
+}
+
+// openBackend opens the backend at dbPath, waiting for a running etcd to
+// release its lock on the file.
+func openBackend(lg *zap.Logger, dbPath string) backend.Backend {
+	var be backend.Backend
+	bch := make(chan struct{})
+	go func() {
+		defer close(bch)
+		cfg := backend.DefaultBackendConfig(lg)
+		cfg.Path = dbPath
+		be = backend.New(cfg)
+	}()
+	select {
+	case <-bch:
+	case <-time.After(time.Second):
+		fmt.Fprintf(os.Stderr, "waiting for etcd to close and release its lock on %q. "+
+			"The data directory must not be in use by etcd.\n", dbPath)
+		<-bch
+	}
+	return be
+}
+
+// copyBackend writes a consistent copy of the backend at src to dst.
+func copyBackend(lg *zap.Logger, src, dst string) error {
+	be := openBackend(lg, src)
+	defer be.Close()
+	snap := be.Snapshot()
+	defer snap.Close()
+
+	f, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err = snap.WriteTo(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeSnapshotFile writes the database file at dbPath to output followed
+// by its sha256 checksum, in the same format as "etcdctl snapshot save".
+func writeSnapshotFile(dbPath, output string) error {
+	in, err := os.Open(dbPath)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp, err := os.CreateTemp(filepath.Dir(output), filepath.Base(output)+".tmp.*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	h := sha256.New()
+	if _, err = io.Copy(io.MultiWriter(tmp, h), in); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err = tmp.Write(h.Sum(nil)); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), output)
+}
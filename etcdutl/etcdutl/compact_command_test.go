@@ -0,0 +1,193 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/etcdutl/v3/snapshot"
+	"go.etcd.io/etcd/server/v3/lease"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+)
+
+// createDataDir writes puts of the given keys to the backend of a new data
+// directory. The latest revision is len(keys)+1.
+func createDataDir(t *testing.T, keys ...string) string {
+	dataDir := t.TempDir()
+	dbPath := datadir.ToBackendFileName(dataDir)
+	require.NoError(t, os.MkdirAll(filepath.Dir(dbPath), 0o700))
+
+	lg := zaptest.NewLogger(t)
+	be := backend.NewDefaultBackend(lg, dbPath)
+	st := mvcc.NewStore(lg, be, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	for _, k := range keys {
+		st.Put([]byte(k), []byte("bar"), lease.NoLease)
+	}
+	require.NoError(t, st.Close())
+	require.NoError(t, be.Close())
+	return dataDir
+}
+
+// openStore opens the store of a data directory and closes it at the end of
+// the test.
+func openStore(t *testing.T, dataDir string) mvcc.KV {
+	lg := zaptest.NewLogger(t)
+	be := backend.NewDefaultBackend(lg, datadir.ToBackendFileName(dataDir))
+	st := mvcc.NewStore(lg, be, &lease.FakeLessor{}, mvcc.StoreConfig{})
+	t.Cleanup(func() {
+		st.Close()
+		be.Close()
+	})
+	return st
+}
+
+func TestCompactData(t *testing.T) {
+	dataDir := createDataDir(t, "foo", "foo", "foo", "bar")
+	lg := zaptest.NewLogger(t)
+
+	rev, err := CompactData(lg, dataDir, 3, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rev)
+
+	st := openStore(t, dataDir)
+	assert.Equal(t, int64(5), st.Rev())
+	_, err = st.Range(t.Context(), []byte("foo"), nil, mvcc.RangeOptions{Rev: 2})
+	require.ErrorIs(t, err, mvcc.ErrCompacted)
+	r, err := st.Range(t.Context(), []byte("foo"), nil, mvcc.RangeOptions{Rev: 3})
+	require.NoError(t, err)
+	assert.Len(t, r.KVs, 1)
+}
+
+func TestCompactDataLatest(t *testing.T) {
+	dataDir := createDataDir(t, "foo", "foo")
+	output := filepath.Join(t.TempDir(), "compacted.db")
+
+	rev, err := CompactData(zaptest.NewLogger(t), dataDir, 0, output)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), rev)
+
+	ds, err := snapshot.NewV3(zaptest.NewLogger(t)).Status(output)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), ds.Revision)
+	assert.Equal(t, 1, ds.TotalKey)
+
+	// the data directory is left unchanged
+	st := openStore(t, dataDir)
+	r, err := st.Range(t.Context(), []byte("foo"), nil, mvcc.RangeOptions{Rev: 2})
+	require.NoError(t, err)
+	assert.Len(t, r.KVs, 1)
+}
+
+func TestCompactDataFutureRevision(t *testing.T) {
+	dataDir := createDataDir(t, "foo")
+	_, err := CompactData(zaptest.NewLogger(t), dataDir, 10, "")
+	require.ErrorIs(t, err, mvcc.ErrFutureRev)
+}
+
+func TestPurgeData(t *testing.T) {
+	tests := []struct {
+		name    string
+		keys    []string
+		bump    uint64
+		wantN   int
+		wantRev int64
+	}{
+		{
+			name:    "latest revision kept",
+			keys:    []string{"/foo/a", "/bar", "/foo/b", "/foo/a"},
+			wantN:   3,
+			wantRev: 5,
+		},
+		{
+			name:    "revision bumped",
+			keys:    []string{"/foo/a", "/bar"},
+			bump:    100,
+			wantN:   1,
+			wantRev: 103,
+		},
+		{
+			name:    "nothing purged",
+			keys:    []string{"/bar"},
+			wantN:   0,
+			wantRev: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dataDir := createDataDir(t, tt.keys...)
+
+			n, err := PurgeData(zaptest.NewLogger(t), dataDir, []byte("/foo/"), tt.bump, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantN, n)
+
+			st := openStore(t, dataDir)
+			assert.Equal(t, tt.wantRev, st.Rev())
+			r, err := st.Range(t.Context(), []byte("/"), []byte("0"), mvcc.RangeOptions{})
+			require.NoError(t, err)
+			require.Len(t, r.KVs, 1)
+			assert.Equal(t, []byte("/bar"), r.KVs[0].Key)
+			if tt.wantN > 0 {
+				_, err = st.Range(t.Context(), []byte("/bar"), nil, mvcc.RangeOptions{Rev: tt.wantRev - 1})
+				require.ErrorIs(t, err, mvcc.ErrCompacted)
+			}
+		})
+	}
+}
+
+func TestPurgeDataOutput(t *testing.T) {
+	dataDir := createDataDir(t, "/foo/a", "/bar", "/foo/b")
+	output := filepath.Join(t.TempDir(), "purged.db")
+
+	n, err := PurgeData(zaptest.NewLogger(t), dataDir, []byte("/foo/"), 0, output)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	lg := zaptest.NewLogger(t)
+	restored := filepath.Join(t.TempDir(), "restored")
+	require.NoError(t, snapshot.NewV3(lg).Restore(snapshot.RestoreConfig{
+		SnapshotPath:        output,
+		Name:                "default",
+		OutputDataDir:       restored,
+		PeerURLs:            []string{"http://localhost:2380"},
+		InitialCluster:      "default=http://localhost:2380",
+		InitialClusterToken: "etcd-cluster",
+	}))
+	st := openStore(t, restored)
+	assert.Equal(t, int64(4), st.Rev())
+	r, err := st.Range(t.Context(), []byte("/"), []byte("0"), mvcc.RangeOptions{})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+	assert.Equal(t, []byte("/bar"), r.KVs[0].Key)
+
+	// the data directory is left unchanged
+	st = openStore(t, dataDir)
+	r, err = st.Range(t.Context(), []byte("/foo/"), []byte("/foo0"), mvcc.RangeOptions{})
+	require.NoError(t, err)
+	assert.Len(t, r.KVs, 2)
+}
+
+func TestPurgeDataEmptyPrefix(t *testing.T) {
+	dataDir := createDataDir(t, "foo")
+	_, err := PurgeData(zaptest.NewLogger(t), dataDir, nil, 0, "")
+	require.ErrorIs(t, err, errEmptyPurgePrefix)
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdutl
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	"go.etcd.io/etcd/pkg/v3/cobrautl"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	purgeDataDir      string
+	purgePrefix       string
+	purgeOutput       string
+	purgeRevisionBump uint64
+)
+
+// NewPurgeCommand returns the cobra command for "purge".
+func NewPurgeCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "purge",
+		Short: "Removes all revisions of the keys under a prefix from a data directory not in use by etcd",
+		Run:   purgeCommandFunc,
+	}
+	cmd.Flags().StringVar(&purgeDataDir, "data-dir", "", "Required. Purges a data directory not in use by etcd.")
+	cmd.Flags().StringVar(&purgePrefix, "prefix", "", "Required. Prefix of the keys to purge.")
+	cmd.Flags().StringVar(&purgeOutput, "output", "", "If set, writes the result to the given snapshot file and leaves the data directory unchanged.")
+	cmd.Flags().Uint64Var(&purgeRevisionBump, "bump-revision", 0, "How much to increase the latest revision after the purge.")
+	cmd.MarkFlagRequired("data-dir")
+	cmd.MarkFlagRequired("prefix")
+	cmd.MarkFlagDirname("data-dir")
+	return cmd
+}
+
+func purgeCommandFunc(cmd *cobra.Command, args []string) {
+	n, err := PurgeData(GetLogger(), purgeDataDir, []byte(purgePrefix), purgeRevisionBump, purgeOutput)
+	if err != nil {
+		cobrautl.ExitWithError(cobrautl.ExitError,
+			fmt.Errorf("Failed to purge etcd data[%s] (%w)", purgeDataDir, err))
+	}
+	fmt.Printf("Purged %d revisions of keys with prefix %q\n", n, purgePrefix)
+	if n > 0 {
+		fmt.Fprintln(os.Stderr, "WARNING: the purged data no longer matches the other members of the cluster. "+
+			"Restore every member from the result with \"etcdutl snapshot restore\" "+
+			"or replace this member with a new one before starting it.")
+	}
+}
+
+var errEmptyPurgePrefix = errors.New("prefix must not be empty")
+
+// PurgeData removes every revision of the keys with the given prefix from
+// the backend in dataDir and returns the number of revisions removed.
+// The latest revision is kept, increased by revisionBump, and marked as
+// compacted so that clients cannot watch across the rewritten history.
+// If output is set, the result is written to that snapshot file instead
+// of the data directory.
+func PurgeData(lg *zap.Logger, dataDir string, prefix []byte, revisionBump uint64, output string) (int, error) {
+	if len(prefix) == 0 {
+		return 0, errEmptyPurgePrefix
+	}
+	var n int
+	err := modifyBackend(lg, dataDir, output, func(be backend.Backend) error {
+		tx := be.BatchTx()
+		tx.LockOutsideApply()
+		defer tx.Unlock()
+
+		var (
+			latest mvcc.Revision
+			purged [][]byte
+		)
+		err := tx.UnsafeForEach(schema.Key, func(k, v []byte) error {
+			if rev := mvcc.BytesToRev(k); rev.GreaterThan(latest) {
+				latest = rev
+			}
+			var kv mvccpb.KeyValue
+			if err := kv.Unmarshal(v); err != nil {
+				return fmt.Errorf("cannot unmarshal value of revision %x: %w", k, err)
+			}
+			if bytes.HasPrefix(kv.Key, prefix) {
+				purged = append(purged, bytes.Clone(k))
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, k := range purged {
+			tx.UnsafeDelete(schema.Key, k)
+		}
+		n = len(purged)
+		if n == 0 && revisionBump == 0 {
+			return nil
+		}
+
+		latest.Main += int64(revisionBump)
+		latest.Sub = 0
+		lg.Info(
+			"marking latest revision compacted",
+			zap.Int("purged-revisions", n),
+			zap.Int64("revision", latest.Main),
+		)
+		// an empty value keeps the latest revision if it was purged or bumped
+		tx.UnsafePut(schema.Key, mvcc.RevToBytes(latest, mvcc.NewRevBytes()), []byte{})
+		mvcc.UnsafeSetScheduledCompact(tx, latest.Main)
+		return nil
+	})
+	return n, err
+}
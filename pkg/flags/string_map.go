@@ -0,0 +1,73 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flags
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StringMapValue wraps a map of key/value strings.
+type StringMapValue struct {
+	Values map[string]string
+}
+
+// Set parses a command line set of key=value pairs, separated by comma.
+// Implements "flag.Value" interface.
+func (sm *StringMapValue) Set(s string) error {
+	values := make(map[string]string)
+	for _, kv := range strings.Split(s, ",") {
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || k == "" {
+			return fmt.Errorf("invalid key=value pair %q", kv)
+		}
+		values[k] = v
+	}
+	sm.Values = values
+	return nil
+}
+
+// String implements "flag.Value" interface.
+func (sm *StringMapValue) String() string {
+	ss := make([]string, 0, len(sm.Values))
+	for k, v := range sm.Values {
+		ss = append(ss, k+"="+v)
+	}
+	sort.Strings(ss)
+	return strings.Join(ss, ",")
+}
+
+// NewStringMapValue implements string map as "flag.Value" interface.
+// Given value is to be separated by comma, with each entry in key=value form.
+func NewStringMapValue(s string) (sm *StringMapValue) {
+	sm = &StringMapValue{Values: make(map[string]string)}
+	if s == "" {
+		return sm
+	}
+	if err := sm.Set(s); err != nil {
+		panic(fmt.Sprintf("new StringMapValue should never fail: %v", err))
+	}
+	return sm
+}
+
+// StringMapFromFlag returns a map of strings from the flag.
+func StringMapFromFlag(fs *flag.FlagSet, flagName string) map[string]string {
+	return (*fs.Lookup(flagName).Value.(*StringMapValue)).Values
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package flags
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStringMapValue(t *testing.T) {
+	tests := []struct {
+		s   string
+		exp map[string]string
+		rs  string
+	}{
+		{
+			s:   "",
+			exp: map[string]string{},
+			rs:  "",
+		},
+		{
+			s:   "zone=a",
+			exp: map[string]string{"zone": "a"},
+			rs:  "zone=a",
+		},
+		{
+			s:   "zone=a,rack=r1",
+			exp: map[string]string{"zone": "a", "rack": "r1"},
+			rs:  "rack=r1,zone=a",
+		},
+		{
+			s:   "zone=a,zone=b",
+			exp: map[string]string{"zone": "b"},
+			rs:  "zone=b",
+		},
+		{
+			s:   "ssd=",
+			exp: map[string]string{"ssd": ""},
+			rs:  "ssd=",
+		},
+	}
+	for i := range tests {
+		sm := NewStringMapValue(tests[i].s)
+		require.Equalf(t, tests[i].exp, sm.Values, "#%d: expected %+v, got %+v", i, tests[i].exp, sm.Values)
+		require.Equalf(t, tests[i].rs, sm.String(), "#%d: expected %q, got %q", i, tests[i].rs, sm.String())
+	}
+}
+
+func TestStringMapValueSetInvalid(t *testing.T) {
+	for _, s := range []string{"zone", "=a", "zone=a,rack"} {
+		sm := NewStringMapValue("")
+		require.Errorf(t, sm.Set(s), "expected error for %q", s)
+	}
+}
@@ -31,7 +31,9 @@ import (
 	"go.etcd.io/etcd/pkg/v3/featuregate"
 	"go.etcd.io/etcd/pkg/v3/netutil"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
+	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/datadir"
+	"go.etcd.io/etcd/server/v3/storage/wal"
 )
 
 const (
@@ -63,6 +65,12 @@ type ServerConfig struct {
 	MaxSnapFiles uint
 	MaxWALFiles  uint
 
+	// WALSegmentSizeBytes is the preallocated size of each WAL segment file.
+	// 0 uses wal.SegmentSizeBytes.
+	WALSegmentSizeBytes int64
+	// WALCompression is the codec used to compress new WAL records.
+	WALCompression wal.Compression
+
 	// BackendBatchInterval is the maximum time before commit the backend transaction.
 	BackendBatchInterval time.Duration
 	// BackendBatchLimit is the maximum operations before commit the backend transaction.
@@ -70,11 +78,17 @@ type ServerConfig struct {
 
 	// BackendFreelistType is the type of the backend boltdb freelist.
 	BackendFreelistType bolt.FreelistType
+	// BackendEngine is the storage engine of the backend.
+	BackendEngine backend.EngineType
 
 	InitialPeerURLsMap  types.URLsMap
 	InitialClusterToken string
 	NewCluster          bool
 	PeerTLSInfo         transport.TLSInfo
+	// PeerTransport is the transport of the raft traffic sent to peers.
+	PeerTransport string
+	// PeerCompression is the codec compressing the raft traffic sent to peers.
+	PeerCompression string
 
 	CORS map[string]struct{}
 
@@ -124,6 +138,10 @@ type ServerConfig struct {
 	QuotaBackendBytes       int64
 	MaxTxnOps               uint
 
+	// RevisionTimeSampleInterval is the interval at which the leader
+	// proposes samples of the time to revision index. 0 disables sampling.
+	RevisionTimeSampleInterval time.Duration
+
 	// MaxRequestBytes is the maximum request size to send over raft.
 	MaxRequestBytes uint
 
@@ -178,6 +196,24 @@ type ServerConfig struct {
 
 	DowngradeCheckTime time.Duration
 
+	// LearnerAutoPromoteWait is how long a learner added with auto promote
+	// must stay caught up with the leader before the leader promotes it.
+	LearnerAutoPromoteWait time.Duration
+
+	// MemberLabels are key/value pairs describing this member, such as its
+	// zone or rack. They are published to the cluster membership.
+	MemberLabels map[string]string
+	// PreferredLeaderLabels are the labels the leader should carry. A leader
+	// without them transfers leadership to a voting member that has them.
+	PreferredLeaderLabels map[string]string
+
+	// LeaseRead enables the leader to serve linearizable reads within a
+	// time-bounded lease on the read state it confirmed.
+	LeaseRead bool
+	// LeaseReadMaxClockDrift is the maximum clock drift between members the
+	// read lease is shortened by.
+	LeaseReadMaxClockDrift time.Duration
+
 	// MemoryMlock enables mlocking of etcd owned memory pages.
 	// The setting improves etcd tail latency in environments were:
 	//   - memory pressure might lead to swapping pages to disk
@@ -313,7 +349,7 @@ func (c *ServerConfig) WALDir() string {
 func (c *ServerConfig) SnapDir() string { return filepath.Join(c.MemberDir(), "snap") }
 
 func (c *ServerConfig) ShouldDiscover() bool {
-	return len(c.DiscoveryCfg.Endpoints) > 0
+	return c.DiscoveryCfg.Enabled()
 }
 
 // ReqTimeout returns timeout for request to finish.
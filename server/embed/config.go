@@ -52,6 +52,8 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3compactor"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3discovery"
 	"go.etcd.io/etcd/server/v3/features"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/wal"
 )
 
 const (
@@ -70,10 +72,13 @@ const (
 	DefaultGRPCKeepAliveInterval       = 2 * time.Hour
 	DefaultGRPCKeepAliveTimeout        = 20 * time.Second
 	DefaultDowngradeCheckTime          = 5 * time.Second
+	DefaultLearnerAutoPromoteWait      = 10 * time.Second
+	DefaultLeaseReadMaxClockDrift      = 100 * time.Millisecond
 	DefaultAutoCompactionMode          = "periodic"
 	DefaultAutoCompactionRetention     = "0"
 	DefaultAuthToken                   = "simple"
 	DefaultCompactHashCheckTime        = time.Minute
+	DefaultRevisionTimeSampleInterval  = time.Second
 	DefaultLoggingFormat               = "json"
 
 	DefaultDiscoveryDialTimeout       = 2 * time.Second
@@ -121,7 +126,7 @@ const (
 
 var (
 	ErrConflictBootstrapFlags = fmt.Errorf("multiple discovery or bootstrap flags are set. " +
-		"Choose one of \"initial-cluster\", \"discovery-endpoints\" or \"discovery-srv\"")
+		"Choose one of \"initial-cluster\", \"discovery-endpoints\", \"discovery-dir\" or \"discovery-srv\"")
 	ErrUnsetAdvertiseClientURLsFlag = fmt.Errorf("--advertise-client-urls is required when --listen-client-urls is set explicitly")
 	ErrLogRotationInvalidLogOutput  = fmt.Errorf("--log-outputs requires a single file path when --log-rotate-config-json is defined")
 
@@ -181,6 +186,11 @@ type Config struct {
 	MaxSnapFiles uint `json:"max-snapshots"`
 	//revive:disable-next-line:var-naming
 	MaxWalFiles uint `json:"max-wals"`
+	// WALSegmentSizeBytes is the preallocated size of each WAL segment file.
+	// 0 uses the default segment size.
+	WALSegmentSizeBytes int64 `json:"wal-segment-size-bytes"`
+	// WALCompression is the codec used to compress WAL records ("none", "snappy" or "zstd").
+	WALCompression string `json:"wal-compression"`
 
 	// TickMs is the number of milliseconds between heartbeat ticks.
 	// TODO: decouple tickMs and heartbeat tick (current heartbeat tick = 1).
@@ -223,9 +233,13 @@ type Config struct {
 	BackendBatchLimit int `json:"backend-batch-limit"`
 	// BackendFreelistType specifies the type of freelist that boltdb backend uses (array and map are supported types).
 	BackendFreelistType string `json:"backend-bbolt-freelist-type"`
-	QuotaBackendBytes   int64  `json:"quota-backend-bytes"`
-	MaxTxnOps           uint   `json:"max-txn-ops"`
-	MaxRequestBytes     uint   `json:"max-request-bytes"`
+	// BackendEngine is the storage engine of the backend ("bbolt" or "pebble").
+	// The "pebble" engine requires importing
+	// go.etcd.io/etcd/server/v3/storage/backend/pebble.
+	BackendEngine     string `json:"backend-engine"`
+	QuotaBackendBytes int64  `json:"quota-backend-bytes"`
+	MaxTxnOps         uint   `json:"max-txn-ops"`
+	MaxRequestBytes   uint   `json:"max-request-bytes"`
 
 	// MaxConcurrentStreams specifies the maximum number of concurrent
 	// streams that each client can open at a time.
@@ -269,6 +283,16 @@ type Config struct {
 	InitialClusterToken string `json:"initial-cluster-token"`
 	StrictReconfigCheck bool   `json:"strict-reconfig-check"`
 
+	// PeerTransport is the transport of the raft traffic between peers,
+	// either "http" or "grpc". With "grpc", the HTTP requests of the raft
+	// traffic are tunneled through gRPC streams, without gRPC compression,
+	// and raft traffic to the peers that do not serve gRPC falls back to HTTP.
+	PeerTransport string `json:"peer-transport"`
+	// PeerCompression is the codec compressing the raft traffic sent to
+	// peers, either "none", "snappy" or "zstd". Peers that do not support
+	// compression receive uncompressed traffic.
+	PeerCompression string `json:"peer-compression"`
+
 	// AutoCompactionMode is either 'periodic' or 'revision'.
 	AutoCompactionMode string `json:"auto-compaction-mode"`
 	// AutoCompactionRetention is either duration string with time unit
@@ -277,6 +301,11 @@ type Config struct {
 	// the unit defaults to hour. For example, '5' translates into 5-hour.
 	AutoCompactionRetention string `json:"auto-compaction-retention"`
 
+	// RevisionTimeSampleInterval is the interval at which the leader proposes
+	// samples of the index mapping wall-clock time to revision, used to serve
+	// RevisionAt. 0 disables sampling.
+	RevisionTimeSampleInterval time.Duration `json:"revision-time-sample-interval"`
+
 	// GRPCKeepAliveMinTime is the minimum interval that a client should
 	// wait before pinging server. When client pings "too fast", server
 	// sends goaway and closes the connection (errors: too_many_pings,
@@ -439,6 +468,29 @@ type Config struct {
 	// DowngradeCheckTime is the duration between two downgrade status checks (in seconds).
 	DowngradeCheckTime time.Duration `json:"downgrade-check-time"`
 
+	// LearnerAutoPromoteWait is how long a learner added with auto promote must stay
+	// caught up with the leader before it is promoted.
+	LearnerAutoPromoteWait time.Duration `json:"learner-auto-promote-wait"`
+
+	// MemberLabels are key/value pairs describing this member, such as its zone
+	// or rack. They are published to the cluster membership and listed with it.
+	MemberLabels map[string]string `json:"member-labels"`
+
+	// PreferredLeaderLabels are the member labels the leader should carry, e.g.
+	// {"zone": "us-east-1a"}. A leader without them transfers its leadership to
+	// a voting member that has them. It should be set the same on all members.
+	PreferredLeaderLabels map[string]string `json:"preferred-leader-labels"`
+
+	// LeaseRead enables the leader to serve linearizable reads without
+	// confirming its leadership with a heartbeat round, within a time-bounded
+	// lease on the read state it last confirmed. The lease lasts the election
+	// timeout minus LeaseReadMaxClockDrift. Reads fall back to ReadIndex when
+	// the lease does not hold. Followers always use ReadIndex.
+	LeaseRead bool `json:"lease-read"`
+	// LeaseReadMaxClockDrift is the maximum clock drift between members the
+	// read lease is shortened by.
+	LeaseReadMaxClockDrift time.Duration `json:"lease-read-max-clock-drift"`
+
 	// MemoryMlock enables mlocking of etcd owned memory pages.
 	// The setting improves etcd tail latency in environments were:
 	//   - memory pressure might lead to swapping pages to disk
@@ -505,11 +557,15 @@ func NewConfig() *Config {
 		MaxSnapFiles: DefaultMaxSnapshots,
 		MaxWalFiles:  DefaultMaxWALs,
 
+		WALCompression: string(wal.CompressionNone),
+
 		Name: DefaultName,
 
 		SnapshotCount:          etcdserver.DefaultSnapshotCount,
 		SnapshotCatchUpEntries: etcdserver.DefaultSnapshotCatchUpEntries,
 
+		BackendEngine: string(backend.EngineBbolt),
+
 		MaxTxnOps:            DefaultMaxTxnOps,
 		MaxRequestBytes:      DefaultMaxRequestBytes,
 		MaxConcurrentStreams: DefaultMaxConcurrentStreams,
@@ -537,6 +593,8 @@ func NewConfig() *Config {
 		InitialClusterToken: "etcd-cluster",
 
 		StrictReconfigCheck: DefaultStrictReconfigCheck,
+		PeerTransport:       rafthttp.PeerTransportHTTP,
+		PeerCompression:     rafthttp.CompressionNone,
 		Metrics:             "basic",
 
 		CORS:          map[string]struct{}{"*": {}},
@@ -560,9 +618,11 @@ func NewConfig() *Config {
 		LogRotationConfigJSON: DefaultLogRotationConfig,
 		EnableGRPCGateway:     true,
 
-		DowngradeCheckTime: DefaultDowngradeCheckTime,
-		MemoryMlock:        false,
-		MaxLearners:        membership.DefaultMaxLearners,
+		DowngradeCheckTime:     DefaultDowngradeCheckTime,
+		LearnerAutoPromoteWait: DefaultLearnerAutoPromoteWait,
+		LeaseReadMaxClockDrift: DefaultLeaseReadMaxClockDrift,
+		MemoryMlock:            false,
+		MaxLearners:            membership.DefaultMaxLearners,
 
 		DistributedTracingAddress:     DefaultDistributedTracingAddress,
 		DistributedTracingServiceName: DefaultDistributedTracingServiceName,
@@ -585,10 +645,11 @@ func NewConfig() *Config {
 			},
 		},
 
-		AutoCompactionMode:      DefaultAutoCompactionMode,
-		AutoCompactionRetention: DefaultAutoCompactionRetention,
-		ServerFeatureGate:       features.NewDefaultServerFeatureGate(DefaultName, nil),
-		FlagsExplicitlySet:      map[string]bool{},
+		AutoCompactionMode:         DefaultAutoCompactionMode,
+		AutoCompactionRetention:    DefaultAutoCompactionRetention,
+		RevisionTimeSampleInterval: DefaultRevisionTimeSampleInterval,
+		ServerFeatureGate:          features.NewDefaultServerFeatureGate(DefaultName, nil),
+		FlagsExplicitlySet:         map[string]bool{},
 	}
 	cfg.InitialCluster = cfg.InitialClusterFromName(cfg.Name)
 	return cfg
@@ -618,6 +679,8 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	)
 	fs.UintVar(&cfg.MaxSnapFiles, "max-snapshots", cfg.MaxSnapFiles, "Maximum number of snapshot files to retain (0 is unlimited). Deprecated in v3.6 and will be decommissioned in v3.7.")
 	fs.UintVar(&cfg.MaxWalFiles, "max-wals", cfg.MaxWalFiles, "Maximum number of wal files to retain (0 is unlimited).")
+	fs.Int64Var(&cfg.WALSegmentSizeBytes, "wal-segment-size-bytes", cfg.WALSegmentSizeBytes, "Preallocated size of each wal segment file (0 uses the default of 64MB).")
+	fs.StringVar(&cfg.WALCompression, "wal-compression", cfg.WALCompression, "Codec used to compress wal records ('none', 'snappy' or 'zstd').")
 	fs.StringVar(&cfg.Name, "name", cfg.Name, "Human-readable name for this member.")
 	fs.Uint64Var(&cfg.SnapshotCount, "snapshot-count", cfg.SnapshotCount, "Number of committed transactions to trigger a snapshot to disk. Deprecated in v3.6 and will be decommissioned in v3.7.")
 	fs.UintVar(&cfg.TickMs, "heartbeat-interval", cfg.TickMs, "Time (in milliseconds) of a heartbeat interval.")
@@ -625,6 +688,7 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&cfg.InitialElectionTickAdvance, "initial-election-tick-advance", cfg.InitialElectionTickAdvance, "Whether to fast-forward initial election ticks on boot for faster election.")
 	fs.Int64Var(&cfg.QuotaBackendBytes, "quota-backend-bytes", cfg.QuotaBackendBytes, "Raise alarms when backend size exceeds the given quota. 0 means use the default quota.")
 	fs.StringVar(&cfg.BackendFreelistType, "backend-bbolt-freelist-type", cfg.BackendFreelistType, "BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types)")
+	fs.StringVar(&cfg.BackendEngine, "backend-engine", cfg.BackendEngine, "Storage engine of the backend ('bbolt' or 'pebble').")
 	fs.DurationVar(&cfg.BackendBatchInterval, "backend-batch-interval", cfg.BackendBatchInterval, "BackendBatchInterval is the maximum time before commit the backend transaction.")
 	fs.IntVar(&cfg.BackendBatchLimit, "backend-batch-limit", cfg.BackendBatchLimit, "BackendBatchLimit is the maximum operations before commit the backend transaction.")
 	fs.UintVar(&cfg.MaxTxnOps, "max-txn-ops", cfg.MaxTxnOps, "Maximum number of operations permitted in a transaction.")
@@ -660,6 +724,9 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 		"V3 discovery: List of gRPC endpoints of the discovery service.",
 	)
 	fs.StringVar(&cfg.DiscoveryCfg.Token, "discovery-token", "", "V3 discovery: discovery token for the etcd cluster to be bootstrapped.")
+	fs.StringVar(&cfg.DiscoveryCfg.Dir, "discovery-dir", "", "V3 discovery: directory shared by the members, used instead of a discovery service.")
+	fs.DurationVar(&cfg.DiscoveryCfg.TokenTTL, "discovery-token-ttl", cfg.DiscoveryCfg.TokenTTL, "V3 discovery: time to live of the member registrations in the discovery service once the discovery ends. 0 keeps them forever.")
+	fs.DurationVar(&cfg.DiscoveryCfg.Timeout, "discovery-timeout", cfg.DiscoveryCfg.Timeout, "V3 discovery: maximum time to wait for all the members to register, after which the registration of this member is removed. 0 waits forever.")
 	fs.DurationVar(&cfg.DiscoveryCfg.DialTimeout, "discovery-dial-timeout", cfg.DiscoveryCfg.DialTimeout, "V3 discovery: dial timeout for client connections.")
 	fs.DurationVar(&cfg.DiscoveryCfg.RequestTimeout, "discovery-request-timeout", cfg.DiscoveryCfg.RequestTimeout, "V3 discovery: timeout for discovery requests (excluding dial timeout).")
 	fs.DurationVar(&cfg.DiscoveryCfg.KeepAliveTime, "discovery-keepalive-time", cfg.DiscoveryCfg.KeepAliveTime, "V3 discovery: keepalive time for client connections.")
@@ -677,6 +744,8 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.StringVar(&cfg.InitialCluster, "initial-cluster", cfg.InitialCluster, "Initial cluster configuration for bootstrapping.")
 	fs.StringVar(&cfg.InitialClusterToken, "initial-cluster-token", cfg.InitialClusterToken, "Initial cluster token for the etcd cluster during bootstrap.")
 	fs.BoolVar(&cfg.StrictReconfigCheck, "strict-reconfig-check", cfg.StrictReconfigCheck, "Reject reconfiguration requests that would cause quorum loss.")
+	fs.StringVar(&cfg.PeerTransport, "peer-transport", cfg.PeerTransport, "Transport of the raft traffic between peers: 'http' or 'grpc'. With 'grpc', the HTTP requests of the raft traffic are tunneled through gRPC streams, compressed only by --peer-compression, and fall back to HTTP for peers that do not serve gRPC.")
+	fs.StringVar(&cfg.PeerCompression, "peer-compression", cfg.PeerCompression, "Codec compressing the raft traffic sent to peers: 'none', 'snappy' or 'zstd'. Peers that do not support compression receive uncompressed traffic.")
 
 	fs.BoolVar(&cfg.PreVote, "pre-vote", cfg.PreVote, "Enable the raft Pre-Vote algorithm to prevent disruption when a node that has been partitioned away rejoins the cluster.")
 
@@ -723,6 +792,7 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 
 	fs.StringVar(&cfg.AutoCompactionRetention, "auto-compaction-retention", "0", "Auto compaction retention for mvcc key value store. 0 means disable auto compaction.")
 	fs.StringVar(&cfg.AutoCompactionMode, "auto-compaction-mode", "periodic", "interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.")
+	fs.DurationVar(&cfg.RevisionTimeSampleInterval, "revision-time-sample-interval", cfg.RevisionTimeSampleInterval, "Interval at which the leader proposes samples of the wall-clock time to revision index. 0 disables sampling.")
 
 	// pprof profiler via HTTP
 	fs.BoolVar(&cfg.EnablePprof, "enable-pprof", false, "Enable runtime profiling data via HTTP server. Address is at client URL + \"/debug/pprof/\"")
@@ -750,6 +820,11 @@ func (cfg *Config) AddFlags(fs *flag.FlagSet) {
 	fs.DurationVar(&cfg.CompactionSleepInterval, "compaction-sleep-interval", cfg.CompactionSleepInterval, "Sets the sleep interval between each compaction batch.")
 	fs.DurationVar(&cfg.WatchProgressNotifyInterval, "watch-progress-notify-interval", cfg.WatchProgressNotifyInterval, "Duration of periodic watch progress notifications.")
 	fs.DurationVar(&cfg.DowngradeCheckTime, "downgrade-check-time", cfg.DowngradeCheckTime, "Duration of time between two downgrade status checks.")
+	fs.DurationVar(&cfg.LearnerAutoPromoteWait, "learner-auto-promote-wait", cfg.LearnerAutoPromoteWait, "Duration a learner added with auto promote must stay caught up with the leader before it is promoted.")
+	fs.Var(flags.NewStringMapValue(""), "member-labels", "Comma-separated key=value labels describing this member, such as its zone or rack (e.g. zone=us-east-1a,rack=r1).")
+	fs.Var(flags.NewStringMapValue(""), "preferred-leader-labels", "Comma-separated key=value member labels the leader should carry (e.g. zone=us-east-1a). Should be the same on all members.")
+	fs.BoolVar(&cfg.LeaseRead, "lease-read", cfg.LeaseRead, "Serve linearizable reads on the leader within a time-bounded lease on the read state it confirmed, falling back to ReadIndex when the lease does not hold. Followers always use ReadIndex.")
+	fs.DurationVar(&cfg.LeaseReadMaxClockDrift, "lease-read-max-clock-drift", cfg.LeaseReadMaxClockDrift, "Maximum clock drift between members the read lease is shortened by. Must be less than the election timeout.")
 	fs.DurationVar(&cfg.WarningApplyDuration, "warning-apply-duration", cfg.WarningApplyDuration, "Time duration after which a warning is generated if watch progress takes more time.")
 	fs.DurationVar(&cfg.WarningUnaryRequestDuration, "warning-unary-request-duration", cfg.WarningUnaryRequestDuration, "Time duration after which a warning is generated if a unary request takes more time.")
 	fs.BoolVar(&cfg.MemoryMlock, "memory-mlock", cfg.MemoryMlock, "Enable to enforce etcd pages (in particular bbolt) to stay in RAM.")
@@ -879,7 +954,7 @@ func (cfg *configYAML) configFromFile(path string) error {
 	}
 
 	// If a discovery or discovery-endpoints flag is set, clear default initial cluster set by InitialClusterFromName
-	if (cfg.DNSCluster != "" || len(cfg.DiscoveryCfg.Endpoints) > 0) && cfg.InitialCluster == defaultInitialCluster {
+	if (cfg.DNSCluster != "" || cfg.DiscoveryCfg.Enabled()) && cfg.InitialCluster == defaultInitialCluster {
 		cfg.InitialCluster = ""
 	}
 	if cfg.ClusterState == "" {
@@ -962,7 +1037,7 @@ func (cfg *Config) Validate() error {
 	}
 	// Check if conflicting flags are passed.
 	nSet := 0
-	for _, v := range []bool{cfg.InitialCluster != "", cfg.DNSCluster != "", len(cfg.DiscoveryCfg.Endpoints) > 0} {
+	for _, v := range []bool{cfg.InitialCluster != "", cfg.DNSCluster != "", len(cfg.DiscoveryCfg.Endpoints) > 0, cfg.DiscoveryCfg.Dir != ""} {
 		if v {
 			nSet++
 		}
@@ -981,6 +1056,12 @@ func (cfg *Config) Validate() error {
 	if (cfg.DiscoveryCfg.Token != "") != (len(cfg.DiscoveryCfg.Endpoints) > 0) {
 		return errors.New("both --discovery-token and --discovery-endpoints must be set")
 	}
+	if cfg.DiscoveryCfg.Dir != "" && cfg.DiscoveryCfg.Token == "" {
+		return errors.New("--discovery-token must be set with --discovery-dir")
+	}
+	if cfg.DiscoveryCfg.TokenTTL < 0 || cfg.DiscoveryCfg.Timeout < 0 {
+		return errors.New("--discovery-token-ttl and --discovery-timeout must not be negative")
+	}
 
 	for _, ep := range cfg.DiscoveryCfg.Endpoints {
 		if strings.TrimSpace(ep) == "" {
@@ -1000,12 +1081,26 @@ func (cfg *Config) Validate() error {
 	if cfg.ElectionMs > maxElectionMs {
 		return fmt.Errorf("--election-timeout[%vms] is too long, and should be set less than %vms", cfg.ElectionMs, maxElectionMs)
 	}
+	if cfg.LeaseRead && (cfg.LeaseReadMaxClockDrift < 0 || cfg.LeaseReadMaxClockDrift >= time.Duration(cfg.ElectionMs)*time.Millisecond) {
+		return fmt.Errorf("--lease-read-max-clock-drift[%v] must be >=0 and less than --election-timeout[%vms]", cfg.LeaseReadMaxClockDrift, cfg.ElectionMs)
+	}
 
 	// check this last since proxying in etcdmain may make this OK
 	if cfg.ListenClientUrls != nil && cfg.AdvertiseClientUrls == nil {
 		return ErrUnsetAdvertiseClientURLsFlag
 	}
 
+	if _, err := backend.ParseEngineType(cfg.BackendEngine); err != nil {
+		return err
+	}
+
+	if cfg.WALSegmentSizeBytes < 0 {
+		return fmt.Errorf("wal-segment-size-bytes must not be negative, got %d", cfg.WALSegmentSizeBytes)
+	}
+	if _, err := wal.ParseCompression(cfg.WALCompression); err != nil {
+		return err
+	}
+
 	switch cfg.AutoCompactionMode {
 	case CompactorModeRevision, CompactorModePeriodic:
 	case "":
@@ -1045,6 +1140,15 @@ func (cfg *Config) Validate() error {
 			zap.String("name", cfg.Name))
 	}
 
+	switch cfg.PeerTransport {
+	case rafthttp.PeerTransportHTTP, rafthttp.PeerTransportGRPC:
+	default:
+		return fmt.Errorf("unknown peer transport %q, must be %q or %q", cfg.PeerTransport, rafthttp.PeerTransportHTTP, rafthttp.PeerTransportGRPC)
+	}
+	if !rafthttp.IsValidCompression(cfg.PeerCompression) {
+		return fmt.Errorf("unknown peer compression %q, must be %q, %q or %q", cfg.PeerCompression, rafthttp.CompressionNone, rafthttp.CompressionSnappy, rafthttp.CompressionZstd)
+	}
+
 	minVersion, err := tlsutil.GetTLSVersion(cfg.TlsMinVersion)
 	if err != nil {
 		return err
@@ -1071,7 +1175,7 @@ func (cfg *Config) Validate() error {
 func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, token string, err error) {
 	token = cfg.InitialClusterToken
 	switch {
-	case len(cfg.DiscoveryCfg.Endpoints) > 0:
+	case cfg.DiscoveryCfg.Enabled():
 		urlsmap = types.URLsMap{}
 		// If using v3 discovery, generate a temporary cluster based on
 		// self's advertised peer URLs
@@ -1116,50 +1220,15 @@ func (cfg *Config) PeerURLsMapAndToken(which string) (urlsmap types.URLsMap, tok
 // Note: Because this checks multiple sets of SRV records, discovery should only be considered to have
 // failed if the returned node list is empty.
 func (cfg *Config) GetDNSClusterNames() ([]string, error) {
-	var (
-		clusterStrs       []string
-		cerr              error
-		serviceNameSuffix string
-	)
-	if cfg.DNSClusterServiceName != "" {
-		serviceNameSuffix = "-" + cfg.DNSClusterServiceName
-	}
-
-	lg := cfg.GetLogger()
-
-	// Use both etcd-server-ssl and etcd-server for discovery.
-	// Combine the results if both are available.
-	clusterStrs, cerr = getCluster("https", "etcd-server-ssl"+serviceNameSuffix, cfg.Name, cfg.DNSCluster, cfg.AdvertisePeerUrls)
-	if cerr != nil {
-		clusterStrs = make([]string, 0)
-	}
-	lg.Info(
-		"get cluster for etcd-server-ssl SRV",
-		zap.String("service-scheme", "https"),
-		zap.String("service-name", "etcd-server-ssl"+serviceNameSuffix),
-		zap.String("server-name", cfg.Name),
-		zap.String("discovery-srv", cfg.DNSCluster),
-		zap.Strings("advertise-peer-urls", cfg.getAdvertisePeerURLs()),
-		zap.Strings("found-cluster", clusterStrs),
-		zap.Error(cerr),
-	)
-
-	defaultHTTPClusterStrs, httpCerr := getCluster("http", "etcd-server"+serviceNameSuffix, cfg.Name, cfg.DNSCluster, cfg.AdvertisePeerUrls)
-	if httpCerr == nil {
-		clusterStrs = append(clusterStrs, defaultHTTPClusterStrs...)
-	}
-	lg.Info(
-		"get cluster for etcd-server SRV",
-		zap.String("service-scheme", "http"),
-		zap.String("service-name", "etcd-server"+serviceNameSuffix),
-		zap.String("server-name", cfg.Name),
-		zap.String("discovery-srv", cfg.DNSCluster),
-		zap.Strings("advertise-peer-urls", cfg.getAdvertisePeerURLs()),
-		zap.Strings("found-cluster", clusterStrs),
-		zap.Error(httpCerr),
-	)
-
-	return clusterStrs, errors.Join(cerr, httpCerr)
+	s := &v3discovery.SRVSource{
+		Logger:            cfg.GetLogger(),
+		Name:              cfg.Name,
+		Domain:            cfg.DNSCluster,
+		ServiceName:       cfg.DNSClusterServiceName,
+		AdvertisePeerURLs: cfg.AdvertisePeerUrls,
+		GetCluster:        getCluster,
+	}
+	return s.Lookup()
 }
 
 func (cfg *Config) InitialClusterFromName(name string) (ret string) {
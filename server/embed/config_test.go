@@ -452,6 +452,28 @@ func TestAutoCompactionModeInvalid(t *testing.T) {
 	}
 }
 
+func TestBackendEngineInvalid(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Logger = "zap"
+	cfg.LogOutputs = []string{"/dev/null"}
+	cfg.BackendEngine = "leveldb"
+	err := cfg.Validate()
+	if err == nil {
+		t.Errorf("expected non-nil error, got %v", err)
+	}
+}
+
+func TestWALCompressionInvalid(t *testing.T) {
+	cfg := NewConfig()
+	cfg.Logger = "zap"
+	cfg.LogOutputs = []string{"/dev/null"}
+	cfg.WALCompression = "gzip"
+	err := cfg.Validate()
+	if err == nil {
+		t.Errorf("expected non-nil error, got %v", err)
+	}
+}
+
 func TestAutoCompactionModeParse(t *testing.T) {
 	tests := []struct {
 		mode      string
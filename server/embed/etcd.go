@@ -49,6 +49,8 @@ import (
 	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	"go.etcd.io/etcd/server/v3/features"
 	"go.etcd.io/etcd/server/v3/storage"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/wal"
 	"go.etcd.io/etcd/server/v3/verify"
 )
 
@@ -178,6 +180,14 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 	}
 
 	backendFreelistType := parseBackendFreelistType(cfg.BackendFreelistType)
+	backendEngine, err := backend.ParseEngineType(cfg.BackendEngine)
+	if err != nil {
+		return e, err
+	}
+	walCompression, err := wal.ParseCompression(cfg.WALCompression)
+	if err != nil {
+		return e, err
+	}
 
 	srvcfg := config.ServerConfig{
 		Name:                              cfg.Name,
@@ -189,6 +199,8 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		SnapshotCatchUpEntries:            cfg.SnapshotCatchUpEntries,
 		MaxSnapFiles:                      cfg.MaxSnapFiles,
 		MaxWALFiles:                       cfg.MaxWalFiles,
+		WALSegmentSizeBytes:               cfg.WALSegmentSizeBytes,
+		WALCompression:                    walCompression,
 		InitialPeerURLsMap:                urlsmap,
 		InitialClusterToken:               token,
 		DiscoveryCfg:                      cfg.DiscoveryCfg,
@@ -202,12 +214,15 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		QuotaBackendBytes:                 cfg.QuotaBackendBytes,
 		BackendBatchLimit:                 cfg.BackendBatchLimit,
 		BackendFreelistType:               backendFreelistType,
+		BackendEngine:                     backendEngine,
 		BackendBatchInterval:              cfg.BackendBatchInterval,
 		MaxTxnOps:                         cfg.MaxTxnOps,
 		MaxRequestBytes:                   cfg.MaxRequestBytes,
 		MaxConcurrentStreams:              cfg.MaxConcurrentStreams,
 		SocketOpts:                        cfg.SocketOpts,
 		StrictReconfigCheck:               cfg.StrictReconfigCheck,
+		PeerTransport:                     cfg.PeerTransport,
+		PeerCompression:                   cfg.PeerCompression,
 		ClientCertAuthEnabled:             cfg.ClientTLSInfo.ClientCertAuth,
 		AuthToken:                         cfg.AuthToken,
 		BcryptCost:                        cfg.BcryptCost,
@@ -224,8 +239,14 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 		UnsafeNoFsync:                     cfg.UnsafeNoFsync,
 		CompactionBatchLimit:              cfg.CompactionBatchLimit,
 		CompactionSleepInterval:           cfg.CompactionSleepInterval,
+		RevisionTimeSampleInterval:        cfg.RevisionTimeSampleInterval,
 		WatchProgressNotifyInterval:       cfg.WatchProgressNotifyInterval,
 		DowngradeCheckTime:                cfg.DowngradeCheckTime,
+		LearnerAutoPromoteWait:            cfg.LearnerAutoPromoteWait,
+		MemberLabels:                      cfg.MemberLabels,
+		PreferredLeaderLabels:             cfg.PreferredLeaderLabels,
+		LeaseRead:                         cfg.LeaseRead,
+		LeaseReadMaxClockDrift:            cfg.LeaseReadMaxClockDrift,
 		WarningApplyDuration:              cfg.WarningApplyDuration,
 		WarningUnaryRequestDuration:       cfg.WarningUnaryRequestDuration,
 		MemoryMlock:                       cfg.MemoryMlock,
@@ -265,8 +286,8 @@ func StartEtcd(inCfg *Config) (e *Etcd, err error) {
 	e.errc = make(chan error, len(e.Peers)+len(e.Clients)+2*len(e.sctxs))
 
 	// newly started member ("memberInitialized==false")
-	// does not need corruption check
-	if memberInitialized && srvcfg.ServerFeatureGate.Enabled(features.InitialCorruptCheck) {
+	// does not need corruption check, nor does a witness, which keeps no key-value data
+	if memberInitialized && srvcfg.ServerFeatureGate.Enabled(features.InitialCorruptCheck) && !e.Server.IsWitness() {
 		if err = e.Server.CorruptionChecker().InitialCheck(); err != nil {
 			// set "EtcdServer" to nil, so that it does not block on "EtcdServer.Close()"
 			// (nothing to close since rafthttp transports have not been started)
@@ -339,10 +360,14 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 		zap.Bool("initial-election-tick-advance", sc.InitialElectionTickAdvance),
 		zap.Uint64("snapshot-count", sc.SnapshotCount),
 		zap.Uint("max-wals", sc.MaxWALFiles),
+		zap.Int64("wal-segment-size-bytes", sc.WALSegmentSizeBytes),
+		zap.String("wal-compression", string(sc.WALCompression)),
 		zap.Uint("max-snapshots", sc.MaxSnapFiles),
 		zap.Uint64("snapshot-catchup-entries", sc.SnapshotCatchUpEntries),
 		zap.Strings("initial-advertise-peer-urls", ec.getAdvertisePeerURLs()),
 		zap.Strings("listen-peer-urls", ec.getListenPeerURLs()),
+		zap.String("peer-transport", sc.PeerTransport),
+		zap.String("peer-compression", sc.PeerCompression),
 		zap.Strings("advertise-client-urls", ec.getAdvertiseClientURLs()),
 		zap.Strings("listen-client-urls", ec.getListenClientURLs()),
 		zap.Strings("listen-metrics-urls", ec.getMetricsURLs()),
@@ -367,6 +392,9 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 
 		zap.String("discovery-token", sc.DiscoveryCfg.Token),
 		zap.String("discovery-endpoints", strings.Join(sc.DiscoveryCfg.Endpoints, ",")),
+		zap.String("discovery-dir", sc.DiscoveryCfg.Dir),
+		zap.String("discovery-token-ttl", sc.DiscoveryCfg.TokenTTL.String()),
+		zap.String("discovery-timeout", sc.DiscoveryCfg.Timeout.String()),
 		zap.String("discovery-dial-timeout", sc.DiscoveryCfg.DialTimeout.String()),
 		zap.String("discovery-request-timeout", sc.DiscoveryCfg.RequestTimeout.String()),
 		zap.String("discovery-keepalive-time", sc.DiscoveryCfg.KeepAliveTime.String()),
@@ -380,6 +408,11 @@ func print(lg *zap.Logger, ec Config, sc config.ServerConfig, memberInitialized
 
 		zap.String("downgrade-check-interval", sc.DowngradeCheckTime.String()),
 		zap.Int("max-learners", sc.MaxLearners),
+		zap.String("learner-auto-promote-wait", sc.LearnerAutoPromoteWait.String()),
+		zap.Any("member-labels", sc.MemberLabels),
+		zap.Any("preferred-leader-labels", sc.PreferredLeaderLabels),
+		zap.Bool("lease-read", sc.LeaseRead),
+		zap.String("lease-read-max-clock-drift", sc.LeaseReadMaxClockDrift.String()),
 
 		zap.String("v2-deprecation", string(ec.V2Deprecation)),
 	)
@@ -490,6 +523,10 @@ func stopServers(ctx context.Context, ss *servers) {
 	if ss.http != nil {
 		ss.http.Shutdown(ctx)
 	}
+	stopGRPCServer(ctx, ss)
+}
+
+func stopGRPCServer(ctx context.Context, ss *servers) {
 	if ss.grpc == nil {
 		return
 	}
@@ -594,9 +631,19 @@ func configurePeerListeners(cfg *Config) (peers []*peerListener, err error) {
 func (e *Etcd) servePeers() {
 	ph := etcdhttp.NewPeerHandler(e.GetLogger(), e.Server)
 
+	// raft traffic of peers using the gRPC peer transport is served by a
+	// gRPC server, matched by its HTTP/2 preface.
+	var gs *grpc.Server
+	if e.cfg.PeerTransport == rafthttp.PeerTransportGRPC {
+		gs = rafthttp.NewGRPCServer(e.GetLogger(), e.Server.RaftHandler())
+	}
+
 	for _, p := range e.Peers {
 		u := p.Listener.Addr().String()
 		m := cmux.New(p.Listener)
+		if gs != nil {
+			go gs.Serve(m.Match(cmux.HTTP2()))
+		}
 		srv := &http.Server{
 			Handler:     ph,
 			ReadTimeout: 5 * time.Minute,
@@ -619,6 +666,9 @@ func (e *Etcd) servePeers() {
 				zap.String("address", u),
 			)
 			srv.Shutdown(ctx)
+			if gs != nil {
+				gs.Stop()
+			}
 			e.cfg.logger.Info(
 				"stopped serving peer traffic",
 				zap.String("address", u),
@@ -906,6 +956,9 @@ func (e *Etcd) startHandler(handler func() error) {
 func (e *Etcd) errHandler(err error) {
 	if err != nil {
 		e.GetLogger().Error("setting up serving from embedded etcd failed.", zap.Error(err))
+	} else if e.Server != nil && e.Server.IsDraining() {
+		// the client gRPC servers of a draining member are stopped on purpose
+		return
 	}
 	select {
 	case <-e.stopc:
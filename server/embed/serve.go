@@ -216,7 +216,9 @@ func (sctx *serveCtx) serve(
 			}
 		}
 
-		sctx.serversC <- &servers{grpc: gs, http: srv}
+		ss := &servers{grpc: gs, http: srv}
+		sctx.serversC <- ss
+		sctx.stopOnDrain(s, ss)
 		sctx.lg.Info(
 			"serving client traffic insecurely; this is strongly discouraged!",
 			zap.String("traffic", traffic),
@@ -279,7 +281,9 @@ func (sctx *serveCtx) serve(
 			})
 		}
 
-		sctx.serversC <- &servers{secure: true, grpc: gs, http: srv}
+		ss := &servers{secure: true, grpc: gs, http: srv}
+		sctx.serversC <- ss
+		sctx.stopOnDrain(s, ss)
 		sctx.lg.Info(
 			"serving client traffic securely",
 			zap.String("traffic", traffic),
@@ -293,6 +297,25 @@ func (sctx *serveCtx) serve(
 	return err
 }
 
+// stopOnDrain stops the gRPC server of ss once the member starts draining, so
+// that its clients move to other endpoints. The http server keeps serving the
+// health and metrics endpoints until the member stops.
+func (sctx *serveCtx) stopOnDrain(s *etcdserver.EtcdServer, ss *servers) {
+	if ss.grpc == nil {
+		return
+	}
+	s.GoAttach(func() {
+		select {
+		case <-s.StoppingNotify():
+		case <-s.DrainNotify():
+			sctx.lg.Info("stopping grpc server of draining member", zap.String("address", sctx.l.Addr().String()))
+			ctx, cancel := context.WithTimeout(context.Background(), s.Cfg.ReqTimeout())
+			stopGRPCServer(ctx, ss)
+			cancel()
+		}
+	})
+}
+
 func configureHTTPServer(srv *http.Server, cfg config.ServerConfig) error {
 	// todo (ahrtr): should we support configuring other parameters in the future as well?
 	return http2.ConfigureServer(srv, &http2.Server{
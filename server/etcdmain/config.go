@@ -244,6 +244,9 @@ func (cfg *config) configFromCmdLine() error {
 
 	cfg.ec.LogOutputs = flags.UniqueStringsFromFlag(cfg.cf.flagSet, "log-outputs")
 
+	cfg.ec.MemberLabels = flags.StringMapFromFlag(cfg.cf.flagSet, "member-labels")
+	cfg.ec.PreferredLeaderLabels = flags.StringMapFromFlag(cfg.cf.flagSet, "preferred-leader-labels")
+
 	cfg.ec.ClusterState = cfg.cf.clusterState.String()
 
 	cfg.ec.V2Deprecation = cconfig.V2DeprecationEnum(cfg.cf.v2deprecation.String())
@@ -255,7 +258,7 @@ func (cfg *config) configFromCmdLine() error {
 	}
 
 	// disable default initial-cluster if discovery is set
-	if (cfg.ec.DNSCluster != "" || cfg.ec.DNSClusterServiceName != "" || len(cfg.ec.DiscoveryCfg.Endpoints) > 0) && !flags.IsSet(cfg.cf.flagSet, "initial-cluster") {
+	if (cfg.ec.DNSCluster != "" || cfg.ec.DNSClusterServiceName != "" || cfg.ec.DiscoveryCfg.Enabled()) && !flags.IsSet(cfg.cf.flagSet, "initial-cluster") {
 		cfg.ec.InitialCluster = ""
 	}
 
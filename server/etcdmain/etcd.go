@@ -30,6 +30,8 @@ import (
 	"go.etcd.io/etcd/pkg/v3/osutil"
 	"go.etcd.io/etcd/server/v3/embed"
 	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	// links in the pebble backend engine
+	_ "go.etcd.io/etcd/server/v3/storage/backend/pebble"
 )
 
 type dirType string
@@ -134,6 +136,7 @@ func startEtcdOrProxyV2(args []string) {
 				"failed to bootstrap; discovery token was already used",
 				zap.String("discovery-token", cfg.ec.DiscoveryCfg.Token),
 				zap.Strings("discovery-endpoints", cfg.ec.DiscoveryCfg.Endpoints),
+				zap.String("discovery-dir", cfg.ec.DiscoveryCfg.Dir),
 				zap.Error(err),
 			)
 			lg.Warn("do not reuse discovery token; generate a new one to bootstrap a cluster")
@@ -149,8 +152,8 @@ func startEtcdOrProxyV2(args []string) {
 			if types.URLs(cfg.ec.AdvertisePeerUrls).String() == embed.DefaultInitialAdvertisePeerURLs {
 				lg.Warn("forgot to set --initial-advertise-peer-urls?")
 			}
-			if cfg.ec.InitialCluster == cfg.ec.InitialClusterFromName(cfg.ec.Name) && len(cfg.ec.DiscoveryCfg.Endpoints) == 0 {
-				lg.Warn("V3 discovery settings (i.e., --discovery-token, --discovery-endpoints) are not set")
+			if cfg.ec.InitialCluster == cfg.ec.InitialClusterFromName(cfg.ec.Name) && !cfg.ec.DiscoveryCfg.Enabled() {
+				lg.Warn("V3 discovery settings (i.e., --discovery-token, --discovery-endpoints or --discovery-dir) are not set")
 			}
 			os.Exit(1)
 		}
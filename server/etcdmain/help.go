@@ -75,12 +75,18 @@ Member:
     Maximum number of snapshot files to retain (0 is unlimited). Deprecated in v3.6 and will be decommissioned in v3.7.
   --max-wals '` + strconv.Itoa(embed.DefaultMaxWALs) + `'
     Maximum number of wal files to retain (0 is unlimited).
+  --wal-segment-size-bytes '0'
+    Preallocated size of each wal segment file (0 uses the default of 64MB).
+  --wal-compression 'none'
+    Codec used to compress wal records ('none', 'snappy' or 'zstd'). Segments written with different codecs can be mixed.
   --memory-mlock
     Enable to enforce etcd pages (in particular bbolt) to stay in RAM.
   --quota-backend-bytes '0'
     Raise alarms when backend size exceeds the given quota (0 defaults to low space quota).
   --backend-bbolt-freelist-type 'map'
     BackendFreelistType specifies the type of freelist that boltdb backend uses(array and map are supported types).
+  --backend-engine 'bbolt'
+    Storage engine of the backend ('bbolt' or 'pebble'). An existing data directory is not converted; restore a snapshot to switch engines.
   --backend-batch-interval ''
     BackendBatchInterval is the maximum time before commit the backend transaction.
   --backend-batch-limit '0'
@@ -130,6 +136,12 @@ Clustering:
     V3 discovery: discovery token for the etcd cluster to be bootstrapped.
   --discovery-endpoints ''
     V3 discovery: List of gRPC endpoints of the discovery service.
+  --discovery-dir ''
+    V3 discovery: directory shared by the members, used instead of a discovery service.
+  --discovery-token-ttl '0s'
+    V3 discovery: time to live of the member registrations in the discovery service once the discovery ends. 0 keeps them forever.
+  --discovery-timeout '0s'
+    V3 discovery: maximum time to wait for all the members to register, after which the registration of this member is removed. 0 waits forever.
   --discovery-dial-timeout '2s'
     V3 discovery: dial timeout for client connections.
   --discovery-request-timeout '5s'
@@ -162,12 +174,18 @@ Clustering:
     Suffix to the dns srv name queried when bootstrapping.
   --strict-reconfig-check '` + strconv.FormatBool(embed.DefaultStrictReconfigCheck) + `'
     Reject reconfiguration requests that would cause quorum loss.
+  --peer-transport 'http'
+    Transport of the raft traffic between peers: 'http' or 'grpc'. With 'grpc', the HTTP requests of the raft traffic are tunneled through gRPC streams, compressed only by --peer-compression, and fall back to HTTP for peers that do not serve gRPC.
+  --peer-compression 'none'
+    Codec compressing the raft traffic sent to peers: 'none', 'snappy' or 'zstd'. Peers that do not support compression receive uncompressed traffic.
   --pre-vote 'true'
     Enable the raft Pre-Vote algorithm to prevent disruption when a node that has been partitioned away rejoins the cluster.
   --auto-compaction-retention '0'
     Auto compaction retention length. 0 means disable auto compaction.
   --auto-compaction-mode 'periodic'
     Interpret 'auto-compaction-retention' one of: periodic|revision. 'periodic' for duration based retention, defaulting to hours if no time unit is provided (e.g. '5m'). 'revision' for revision number based retention.
+  --revision-time-sample-interval '1s'
+    Interval at which the leader proposes samples of the wall-clock time to revision index. 0 disables sampling.
   --v2-deprecation '` + string(cconfig.V2DeprDefault) + `'
     Phase of v2store deprecation. Deprecated and scheduled for removal in v3.8. The default value is enforced, ignoring user input.
     Supported values:
@@ -293,6 +311,16 @@ Features:
     Sets the sleep interval between each compaction batch.
   --downgrade-check-time
     Duration of time between two downgrade status checks.
+  --learner-auto-promote-wait '10s'
+    Duration a learner added with auto promote must stay caught up with the leader before it is promoted.
+  --member-labels ''
+    Comma-separated key=value labels describing this member, such as its zone or rack (e.g. zone=us-east-1a,rack=r1).
+  --preferred-leader-labels ''
+    Comma-separated key=value member labels the leader should carry (e.g. zone=us-east-1a). Should be the same on all members.
+  --lease-read 'false'
+    Serve linearizable reads on the leader within a time-bounded lease on the read state it confirmed, falling back to ReadIndex when the lease does not hold. Followers always use ReadIndex.
+  --lease-read-max-clock-drift '100ms'
+    Maximum clock drift between members the read lease is shortened by. Must be less than the election timeout.
   --snapshot-catchup-entries
     Number of entries for a slow follower to catch up after compacting the raft storage entries.
 
@@ -53,6 +53,8 @@ type ServerHealth interface {
 	Config() config.ServerConfig
 	AuthStore() auth.AuthStore
 	IsLearner() bool
+	IsDraining() bool
+	IsWitness() bool
 }
 
 // HandleHealth registers metrics and health handlers. it checks health by using v3 range request
@@ -257,6 +259,10 @@ func installReadyzEndpoints(lg *zap.Logger, mux *http.ServeMux, server ServerHea
 	reg.Register("linearizable_read", readCheck(server, false))
 	// check if local is learner
 	reg.Register("non_learner", learnerCheck(server))
+	// check if local is witness, which does not serve clients
+	reg.Register("non_witness", witnessCheck(server))
+	// check if local is being drained for maintenance
+	reg.Register("non_draining", drainingCheck(server))
 	reg.InstallHTTPEndpoints(lg, mux)
 }
 
@@ -445,3 +451,21 @@ func learnerCheck(srv ServerHealth) func(ctx context.Context) error {
 		return nil
 	}
 }
+
+func witnessCheck(srv ServerHealth) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if srv.IsWitness() {
+			return fmt.Errorf("not supported for witness")
+		}
+		return nil
+	}
+}
+
+func drainingCheck(srv ServerHealth) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		if srv.IsDraining() {
+			return fmt.Errorf("member is draining")
+		}
+		return nil
+	}
+}
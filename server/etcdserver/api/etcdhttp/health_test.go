@@ -43,6 +43,8 @@ type fakeHealthServer struct {
 	missingLeader         bool
 	authStore             auth.AuthStore
 	isLearner             bool
+	isDraining            bool
+	isWitness             bool
 }
 
 func (s *fakeHealthServer) Range(_ context.Context, req *pb.RangeRequest) (*pb.RangeResponse, error) {
@@ -56,6 +58,14 @@ func (s *fakeHealthServer) IsLearner() bool {
 	return s.isLearner
 }
 
+func (s *fakeHealthServer) IsWitness() bool {
+	return s.isWitness
+}
+
+func (s *fakeHealthServer) IsDraining() bool {
+	return s.isDraining
+}
+
 func (s *fakeHealthServer) Config() config.ServerConfig {
 	return config.ServerConfig{}
 }
@@ -82,6 +92,8 @@ type healthTestCase struct {
 	apiError      error
 	missingLeader bool
 	isLearner     bool
+	isDraining    bool
+	isWitness     bool
 }
 
 func TestHealthHandler(t *testing.T) {
@@ -370,6 +382,12 @@ func TestLearnerReadyCheck(t *testing.T) {
 			expectStatusCode: http.StatusServiceUnavailable,
 			isLearner:        true,
 		},
+		{
+			name:             "not ready because member is witness",
+			healthCheckURL:   "/readyz",
+			expectStatusCode: http.StatusServiceUnavailable,
+			isWitness:        true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -381,6 +399,7 @@ func TestLearnerReadyCheck(t *testing.T) {
 				authStore:             auth.NewAuthStore(logger, schema.NewAuthBackend(logger, be), nil, 0),
 			}
 			s.isLearner = tt.isLearner
+			s.isWitness = tt.isWitness
 			HandleHealth(logger, mux, s)
 			ts := httptest.NewServer(mux)
 			defer ts.Close()
@@ -390,6 +409,47 @@ func TestLearnerReadyCheck(t *testing.T) {
 	}
 }
 
+func TestDrainingReadyCheck(t *testing.T) {
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+	tests := []healthTestCase{
+		{
+			name:             "readyz normal",
+			healthCheckURL:   "/readyz",
+			expectStatusCode: http.StatusOK,
+			isDraining:       false,
+		},
+		{
+			name:             "not ready because member is draining",
+			healthCheckURL:   "/readyz",
+			expectStatusCode: http.StatusServiceUnavailable,
+			inResult:         []string{"[-]non_draining failed: member is draining"},
+			isDraining:       true,
+		},
+		{
+			name:             "livez unaffected by draining",
+			healthCheckURL:   "/livez",
+			expectStatusCode: http.StatusOK,
+			isDraining:       true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			logger := zaptest.NewLogger(t)
+			s := &fakeHealthServer{
+				authStore: auth.NewAuthStore(logger, schema.NewAuthBackend(logger, be), nil, 0),
+			}
+			s.isDraining = tt.isDraining
+			HandleHealth(logger, mux, s)
+			ts := httptest.NewServer(mux)
+			defer ts.Close()
+			checkHTTPResponse(t, ts, tt.healthCheckURL, tt.expectStatusCode, tt.inResult, tt.notInResult)
+		})
+	}
+}
+
 func checkHTTPResponse(t *testing.T, ts *httptest.Server, url string, expectStatusCode int, inResult []string, notInResult []string) {
 	res, err := ts.Client().Do(&http.Request{Method: http.MethodGet, URL: testutil.MustNewURL(t, ts.URL+url)})
 	if err != nil {
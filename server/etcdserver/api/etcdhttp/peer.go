@@ -36,6 +36,7 @@ import (
 const (
 	peerMembersPath         = "/members"
 	peerMemberPromotePrefix = "/members/promote/"
+	peerMemberReplacePrefix = "/members/replace/"
 )
 
 // NewPeerHandler generates an http.Handler to handle etcd peer requests.
@@ -56,6 +57,7 @@ func newPeerHandler(
 	}
 	peerMembersHandler := newPeerMembersHandler(lg, s.Cluster())
 	peerMemberPromoteHandler := newPeerMemberPromoteHandler(lg, s)
+	peerMemberReplaceHandler := newPeerMemberReplaceHandler(lg, s)
 
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", http.NotFound)
@@ -63,9 +65,11 @@ func newPeerHandler(
 	mux.Handle(rafthttp.RaftPrefix+"/", raftHandler)
 	mux.Handle(peerMembersPath, peerMembersHandler)
 	mux.Handle(peerMemberPromotePrefix, peerMemberPromoteHandler)
+	mux.Handle(peerMemberReplacePrefix, peerMemberReplaceHandler)
 	if leaseHandler != nil {
 		mux.Handle(leasehttp.LeasePrefix, leaseHandler)
 		mux.Handle(leasehttp.LeaseInternalPrefix, leaseHandler)
+		mux.Handle(leasehttp.LeaseGroupPrefix, leaseHandler)
 	}
 	if downgradeEnabledHandler != nil {
 		mux.Handle(etcdserver.DowngradeEnabledPath, downgradeEnabledHandler)
@@ -103,6 +107,20 @@ type peerMemberPromoteHandler struct {
 	server  etcdserver.Server
 }
 
+func newPeerMemberReplaceHandler(lg *zap.Logger, s etcdserver.Server) http.Handler {
+	return &peerMemberReplaceHandler{
+		lg:      lg,
+		cluster: s.Cluster(),
+		server:  s,
+	}
+}
+
+type peerMemberReplaceHandler struct {
+	lg      *zap.Logger
+	cluster api.Cluster
+	server  etcdserver.Server
+}
+
 func (h *peerMembersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if !allowMethod(w, r, "GET") {
 		return
@@ -163,3 +181,56 @@ func (h *peerMemberPromoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		h.lg.Warn("failed to encode members response", zap.Error(err))
 	}
 }
+
+func (h *peerMemberReplaceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, "POST") {
+		return
+	}
+	w.Header().Set("X-Etcd-Cluster-ID", h.cluster.ID().String())
+
+	if !strings.HasPrefix(r.URL.Path, peerMemberReplacePrefix) {
+		http.Error(w, "bad path", http.StatusBadRequest)
+		return
+	}
+	idStrs := strings.Split(strings.TrimPrefix(r.URL.Path, peerMemberReplacePrefix), "/")
+	if len(idStrs) != 2 {
+		http.Error(w, "bad path", http.StatusBadRequest)
+		return
+	}
+	var ids [2]uint64
+	for i, idStr := range idStrs {
+		id, err := strconv.ParseUint(idStr, 10, 64)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("member %s not found in cluster", idStr), http.StatusNotFound)
+			return
+		}
+		ids[i] = id
+	}
+
+	resp, err := h.server.PromoteReplacement(r.Context(), ids[0], ids[1])
+	if err != nil {
+		switch {
+		case errorspkg.Is(err, membership.ErrIDNotFound):
+			http.Error(w, err.Error(), http.StatusNotFound)
+		case errorspkg.Is(err, membership.ErrMemberNotLearner),
+			errorspkg.Is(err, membership.ErrMemberIsLearner),
+			errorspkg.Is(err, errors.ErrLearnerNotReady):
+			http.Error(w, err.Error(), http.StatusPreconditionFailed)
+		default:
+			writeError(h.lg, w, r, err)
+		}
+		h.lg.Warn(
+			"failed to replace a member",
+			zap.String("member-id", types.ID(ids[0]).String()),
+			zap.String("learner-id", types.ID(ids[1]).String()),
+			zap.Error(err),
+		)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.lg.Warn("failed to encode members response", zap.Error(err))
+	}
+}
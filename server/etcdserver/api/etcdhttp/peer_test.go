@@ -76,6 +76,10 @@ func (s *fakeServer) UpdateMember(ctx context.Context, updateMemb membership.Mem
 func (s *fakeServer) PromoteMember(ctx context.Context, id uint64) ([]*membership.Member, error) {
 	return nil, fmt.Errorf("PromoteMember not implemented in fakeServer")
 }
+
+func (s *fakeServer) PromoteReplacement(ctx context.Context, id, learnerID uint64) ([]*membership.Member, error) {
+	return nil, fmt.Errorf("PromoteReplacement not implemented in fakeServer")
+}
 func (s *fakeServer) ClusterVersion() *semver.Version      { return nil }
 func (s *fakeServer) StorageVersion() *semver.Version      { return nil }
 func (s *fakeServer) Cluster() api.Cluster                 { return s.cluster }
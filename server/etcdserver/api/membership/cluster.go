@@ -263,6 +263,18 @@ func (c *RaftCluster) UnsafeLoad() {
 	c.downgradeInfo = c.be.DowngradeInfoFromBackend()
 }
 
+// SyncBackendWithStore replaces the membership and the cluster version in
+// the backend with the ones in the v2 store. A witness member receives
+// snapshots without a backend, so it recovers the membership of a snapshot
+// from the v2 store before calling Recover.
+func (c *RaftCluster) SyncBackendWithStore() {
+	c.Lock()
+	defer c.Unlock()
+
+	members, removed := membersFromStore(c.lg, c.v2store)
+	c.be.MustReplaceMembershipInBackend(members, removed, clusterVersionFromStore(c.lg, c.v2store))
+}
+
 func (c *RaftCluster) Recover(onSet func(*zap.Logger, *semver.Version)) {
 	c.Lock()
 	defer c.Unlock()
@@ -511,6 +523,7 @@ func (c *RaftCluster) PromoteMember(id types.ID, shouldApplyV3 ShouldApplyV3) {
 	if _, ok := c.members[id]; ok {
 		m := *(c.members[id])
 		m.RaftAttributes.IsLearner = false
+		m.RaftAttributes.AutoPromote = false
 		mustUpdateMemberInStore(c.lg, c.v2store, &m)
 	} else {
 		c.lg.Info("Skipped promoting non-existent member in v2store",
@@ -526,6 +539,7 @@ func (c *RaftCluster) PromoteMember(id types.ID, shouldApplyV3 ShouldApplyV3) {
 
 	if shouldApplyV3 {
 		c.members[id].RaftAttributes.IsLearner = false
+		c.members[id].RaftAttributes.AutoPromote = false
 		c.updateMembershipMetric(id, true)
 		c.be.MustSaveMemberToBackend(c.members[id])
 
@@ -777,6 +791,9 @@ func ValidateClusterAndAssignIDs(lg *zap.Logger, local *RaftCluster, existing *R
 		for j := range lms {
 			if ok, err = netutil.URLStringsEqual(ctx, lg, ems[i].PeerURLs, lms[j].PeerURLs); ok {
 				lms[j].ID = ems[i].ID
+				// a joining witness replays the log from the start, so it
+				// has to know it is a witness before applying its own addition.
+				lms[j].IsWitness = ems[i].IsWitness
 				break
 			}
 		}
@@ -807,6 +824,14 @@ func (c *RaftCluster) IsLocalMemberLearner() bool {
 	return localMember.IsLearner
 }
 
+// IsLocalMemberWitness returns if the local member is a witness
+func (c *RaftCluster) IsLocalMemberWitness() bool {
+	c.Lock()
+	defer c.Unlock()
+	localMember, ok := c.members[c.localID]
+	return ok && localMember.IsWitness
+}
+
 // DowngradeInfo returns the downgrade status of the cluster
 func (c *RaftCluster) DowngradeInfo() *serverversion.DowngradeInfo {
 	c.Lock()
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap/zaptest"
@@ -278,6 +279,22 @@ func TestClusterValidateAndAssignIDs(t *testing.T) {
 	}
 }
 
+func TestClusterValidateAndAssignIDsWitness(t *testing.T) {
+	witness := newTestMember(2, []string{"http://127.0.0.2:2379"}, "", nil)
+	witness.IsWitness = true
+	ecl := newTestCluster(t, []*Member{
+		newTestMember(1, []string{"http://127.0.0.1:2379"}, "", nil),
+		witness,
+	})
+	lcl := newTestCluster(t, []*Member{
+		newTestMember(3, []string{"http://127.0.0.1:2379"}, "", nil),
+		newTestMember(4, []string{"http://127.0.0.2:2379"}, "", nil),
+	})
+	require.NoError(t, ValidateClusterAndAssignIDs(zaptest.NewLogger(t), lcl, ecl))
+	require.False(t, lcl.Member(1).IsWitness)
+	require.True(t, lcl.Member(2).IsWitness)
+}
+
 func TestClusterValidateConfigurationChangeV3(t *testing.T) {
 	testClusterValidateConfigurationChange(t, true)
 }
@@ -1175,3 +1192,34 @@ func TestClusterStore(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterSyncBackendWithStore(t *testing.T) {
+	name := "etcd"
+	clientURLs := []string{"http://127.0.0.1:4001"}
+	witness := newTestMember(3, nil, name, clientURLs)
+	witness.IsWitness = true
+
+	src := newTestCluster(t, []*Member{
+		newTestMember(1, nil, name, clientURLs),
+		newTestMember(2, nil, name, clientURLs),
+		witness,
+	})
+	src.removed = map[types.ID]bool{types.ID(4): true}
+	src.version = semver.New("3.7.0")
+	st := v2store.New("/0", "/1")
+	src.Store(st)
+
+	c := newTestCluster(t, nil)
+	c.be.MustSaveMemberToBackend(newTestMember(1, nil, "", nil))
+	c.be.MustSaveMemberToBackend(newTestMember(5, nil, name, clientURLs))
+	c.SetStore(st)
+	c.SyncBackendWithStore()
+
+	members, removed := c.be.MustReadMembersFromBackend()
+	assert.Len(t, members, 3)
+	for _, m := range src.members {
+		assert.Equal(t, m, members[m.ID])
+	}
+	assert.Equal(t, map[types.ID]bool{types.ID(4): true}, removed)
+	assert.Equal(t, src.version, c.be.ClusterVersionFromBackend())
+}
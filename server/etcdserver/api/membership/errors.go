@@ -26,6 +26,7 @@ var (
 	ErrIDNotFound       = errors.New("membership: ID not found")
 	ErrPeerURLexists    = errors.New("membership: peerURL exists")
 	ErrMemberNotLearner = errors.New("membership: can only promote a learner member")
+	ErrMemberIsLearner  = errors.New("membership: can only replace a voting member")
 	ErrTooManyLearners  = errors.New("membership: too many learner members in cluster")
 )
 
@@ -18,6 +18,7 @@ import (
 	"crypto/sha1"
 	"encoding/binary"
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
 	"time"
@@ -32,14 +33,28 @@ type RaftAttributes struct {
 	PeerURLs []string `json:"peerURLs"`
 	// IsLearner indicates if the member is raft learner.
 	IsLearner bool `json:"isLearner,omitempty"`
+	// AutoPromote indicates if the learner is promoted by the leader
+	// once it has caught up. It is cleared when the member is promoted.
+	AutoPromote bool `json:"autoPromote,omitempty"`
+	// IsWitness indicates if the member is a witness. A witness votes in
+	// elections and log commitment, but applies only membership changes and
+	// does not serve clients.
+	IsWitness bool `json:"isWitness,omitempty"`
 }
 
 // Attributes represents all the non-raft related attributes of an etcd member.
 type Attributes struct {
 	Name       string   `json:"name,omitempty"`
 	ClientURLs []string `json:"clientURLs,omitempty"`
+	// Labels are key/value pairs describing the member, such as its zone or rack.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Draining indicates if the member is being drained for maintenance.
+	Draining bool `json:"draining,omitempty"`
 }
 
+// ZoneLabel is the member label holding the failure zone of the member.
+const ZoneLabel = "zone"
+
 type Member struct {
 	ID types.ID `json:"id"`
 	RaftAttributes
@@ -94,10 +109,13 @@ func (m *Member) Clone() *Member {
 	mm := &Member{
 		ID: m.ID,
 		RaftAttributes: RaftAttributes{
-			IsLearner: m.IsLearner,
+			IsLearner:   m.IsLearner,
+			AutoPromote: m.AutoPromote,
+			IsWitness:   m.IsWitness,
 		},
 		Attributes: Attributes{
-			Name: m.Name,
+			Name:     m.Name,
+			Draining: m.Draining,
 		},
 	}
 	if m.PeerURLs != nil {
@@ -108,9 +126,22 @@ func (m *Member) Clone() *Member {
 		mm.ClientURLs = make([]string, len(m.ClientURLs))
 		copy(mm.ClientURLs, m.ClientURLs)
 	}
+	if m.Labels != nil {
+		mm.Labels = maps.Clone(m.Labels)
+	}
 	return mm
 }
 
+// HasLabels returns true if the member carries all the given labels.
+func (m *Member) HasLabels(labels map[string]string) bool {
+	for k, v := range labels {
+		if lv, ok := m.Labels[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *Member) IsStarted() bool {
 	return len(m.Name) != 0
 }
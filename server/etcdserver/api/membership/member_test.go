@@ -61,6 +61,8 @@ func TestMemberClone(t *testing.T) {
 		newTestMember(1, []string{"http://a"}, "abc", nil),
 		newTestMember(1, nil, "abc", []string{"http://b"}),
 		newTestMember(1, []string{"http://a"}, "abc", []string{"http://b"}),
+		{ID: 1, Attributes: Attributes{Name: "abc", Labels: map[string]string{ZoneLabel: "a"}}},
+		{ID: 1, RaftAttributes: RaftAttributes{PeerURLs: []string{"http://a"}, IsWitness: true}},
 	}
 	for i, tt := range tests {
 		nm := tt.Clone()
@@ -73,6 +75,25 @@ func TestMemberClone(t *testing.T) {
 	}
 }
 
+func TestMemberHasLabels(t *testing.T) {
+	m := &Member{Attributes: Attributes{Labels: map[string]string{ZoneLabel: "a", "rack": "r1"}}}
+	tests := []struct {
+		labels map[string]string
+		want   bool
+	}{
+		{nil, true},
+		{map[string]string{ZoneLabel: "a"}, true},
+		{map[string]string{ZoneLabel: "a", "rack": "r1"}, true},
+		{map[string]string{ZoneLabel: "b"}, false},
+		{map[string]string{ZoneLabel: "a", "class": "ssd"}, false},
+	}
+	for i, tt := range tests {
+		if got := m.HasLabels(tt.labels); got != tt.want {
+			t.Errorf("#%d: HasLabels(%v) = %v, want %v", i, tt.labels, got, tt.want)
+		}
+	}
+}
+
 func newTestMember(id uint64, peerURLs []string, name string, clientURLs []string) *Member {
 	return &Member{
 		ID:             types.ID(id),
@@ -92,6 +92,14 @@ func (b *backendMock) MustDeleteMemberFromBackend(id types.ID) {
 	b.removed[id] = true
 }
 
+func (b *backendMock) MustReplaceMembershipInBackend(members map[types.ID]*Member, removed map[types.ID]bool, ver *semver.Version) {
+	b.members = members
+	b.removed = removed
+	if ver != nil {
+		b.version = ver
+	}
+}
+
 func (b *backendMock) MustSaveDowngradeToBackend(downgradeInfo *serverversion.DowngradeInfo) {
 	b.downgradeInfo = downgradeInfo
 }
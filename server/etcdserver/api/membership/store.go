@@ -29,6 +29,10 @@ type MembershipBackend interface {
 	MemberBackend
 	DowngradeInfoBackend
 	MustCreateBackendBuckets()
+	// MustReplaceMembershipInBackend replaces the members, removed members
+	// and, if not nil, the cluster version in the backend. Unlike the other
+	// setters, it is called outside of apply.
+	MustReplaceMembershipInBackend(members map[types.ID]*Member, removed map[types.ID]bool, ver *semver.Version)
 }
 
 type ClusterVersionBackend interface {
@@ -140,6 +140,21 @@ func mustSaveClusterVersionToStore(lg *zap.Logger, s v2store.Store, ver *semver.
 	}
 }
 
+func clusterVersionFromStore(lg *zap.Logger, st v2store.Store) *semver.Version {
+	e, err := st.Get(StoreClusterVersionKey(), false, false)
+	if err != nil {
+		if isKeyNotFound(err) {
+			return nil
+		}
+		lg.Panic(
+			"failed to get cluster version from store",
+			zap.String("path", StoreClusterVersionKey()),
+			zap.Error(err),
+		)
+	}
+	return semver.Must(semver.NewVersion(*e.Node.Value))
+}
+
 // nodeToMember builds member from a key value node.
 // the child nodes of the given node MUST be sorted by key.
 func nodeToMember(lg *zap.Logger, n *v2store.NodeExtern) (*Member, error) {
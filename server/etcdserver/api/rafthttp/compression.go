@@ -0,0 +1,283 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Compression of the raft traffic is negotiated per peer. The stream readers
+// advertise the codecs they decode in the X-Raft-Accept-Compression header,
+// and the stream handler of the sending member answers with the codec it
+// compresses the stream with in the X-Raft-Compression header. Snapshots sent
+// to a peer use the codec negotiated by its streams. Members that do not
+// support compression neither advertise nor answer the headers, so the
+// traffic with them is left uncompressed.
+const (
+	CompressionNone   = "none"
+	CompressionSnappy = "snappy"
+	CompressionZstd   = "zstd"
+
+	acceptCompressionHeader = "X-Raft-Accept-Compression"
+	compressionHeader       = "X-Raft-Compression"
+)
+
+// supportedCompressions are the codecs decoded by the local member.
+var supportedCompressions = []string{CompressionZstd, CompressionSnappy}
+
+// IsValidCompression reports whether the given codec can be used to
+// compress the raft traffic.
+func IsValidCompression(codec string) bool {
+	switch codec {
+	case "", CompressionNone, CompressionSnappy, CompressionZstd:
+		return true
+	default:
+		return false
+	}
+}
+
+// negotiateCompression returns the codec to compress the traffic with, which
+// is the local codec if it is accepted by the remote peer.
+func negotiateCompression(local, accept string) string {
+	if local == "" || local == CompressionNone {
+		return ""
+	}
+	for _, codec := range strings.Split(accept, ",") {
+		if strings.TrimSpace(codec) == local {
+			return local
+		}
+	}
+	return ""
+}
+
+type compressor interface {
+	io.WriteCloser
+	Flush() error
+}
+
+func newCompressor(codec string, w io.Writer) (compressor, error) {
+	switch codec {
+	case CompressionSnappy:
+		return snappy.NewBufferedWriter(w), nil
+	case CompressionZstd:
+		return zstd.NewWriter(w, zstd.WithEncoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unknown compression %q", codec)
+	}
+}
+
+// newDecompressor returns a reader decompressing r with the codec. It holds
+// no resources besides r, so that it does not need to be closed.
+func newDecompressor(codec string, r io.Reader) (io.Reader, error) {
+	switch codec {
+	case CompressionSnappy:
+		return snappy.NewReader(r), nil
+	case CompressionZstd:
+		// a single goroutine decodes synchronously.
+		return zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	default:
+		return nil, fmt.Errorf("unknown compression %q", codec)
+	}
+}
+
+// timedWriter counts the bytes written to w and the time spent writing them.
+type timedWriter struct {
+	w    io.Writer
+	n    int64
+	took time.Duration
+}
+
+func (w *timedWriter) Write(p []byte) (int, error) {
+	start := time.Now()
+	n, err := w.w.Write(p)
+	w.took += time.Since(start)
+	w.n += int64(n)
+	return n, err
+}
+
+// compressWriter compresses the traffic sent to a peer. Each flush sends
+// what was written since the previous one, so that a batch of messages is
+// compressed together, and records the compression metrics.
+type compressWriter struct {
+	c   compressor
+	out *timedWriter
+	f   http.Flusher
+
+	in   int64
+	took time.Duration
+	err  error
+
+	inBytes  prometheus.Counter
+	outBytes prometheus.Counter
+	seconds  prometheus.Counter
+	ratio    prometheus.Observer
+}
+
+// newCompressWriter returns a writer compressing to w with the codec. The
+// given flusher, if any, is flushed after the compressor.
+func newCompressWriter(codec string, w io.Writer, f http.Flusher, to string) (*compressWriter, error) {
+	out := &timedWriter{w: w}
+	c, err := newCompressor(codec, out)
+	if err != nil {
+		return nil, err
+	}
+	return &compressWriter{
+		c:        c,
+		out:      out,
+		f:        f,
+		inBytes:  peerCompressionInBytes.WithLabelValues(to, codec),
+		outBytes: peerCompressionOutBytes.WithLabelValues(to, codec),
+		seconds:  peerCompressionSeconds.WithLabelValues(to, codec),
+		ratio:    peerCompressionRatio.WithLabelValues(to, codec),
+	}, nil
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	start := time.Now()
+	n, err := w.c.Write(p)
+	w.took += time.Since(start)
+	w.in += int64(n)
+	return n, err
+}
+
+// Flush implements http.Flusher. An error of the compressor is returned by
+// the next write.
+func (w *compressWriter) Flush() {
+	if w.err != nil {
+		return
+	}
+	if w.err = w.finish(w.c.Flush); w.err == nil && w.f != nil {
+		w.f.Flush()
+	}
+}
+
+// Close writes the end of the compressed stream.
+func (w *compressWriter) Close() error {
+	return w.finish(w.c.Close)
+}
+
+func (w *compressWriter) finish(f func() error) error {
+	start := time.Now()
+	err := f()
+	w.took += time.Since(start)
+
+	if w.in > 0 {
+		w.inBytes.Add(float64(w.in))
+		w.outBytes.Add(float64(w.out.n))
+		// the time spent writing to the connection is not compression.
+		w.seconds.Add((w.took - w.out.took).Seconds())
+		if w.out.n > 0 {
+			w.ratio.Observe(float64(w.in) / float64(w.out.n))
+		}
+	}
+	w.in, w.took, w.out.n, w.out.took = 0, 0, 0, 0
+	return err
+}
+
+// timedReader counts the time spent reading from r.
+type timedReader struct {
+	r    io.Reader
+	took time.Duration
+}
+
+func (r *timedReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := r.r.Read(p)
+	r.took += time.Since(start)
+	return n, err
+}
+
+// decompressReader decompresses the traffic received from a peer, and
+// records the decompression time.
+type decompressReader struct {
+	d   io.Reader
+	in  *timedReader
+	src io.Closer
+
+	seconds prometheus.Counter
+}
+
+// newDecompressReader returns a reader decompressing rc with the codec.
+// Closing it closes rc.
+func newDecompressReader(codec string, rc io.ReadCloser, from string) (io.ReadCloser, error) {
+	in := &timedReader{r: rc}
+	d, err := newDecompressor(codec, in)
+	if err != nil {
+		return nil, err
+	}
+	return &decompressReader{
+		d:       d,
+		in:      in,
+		src:     rc,
+		seconds: peerDecompressionSeconds.WithLabelValues(from, codec),
+	}, nil
+}
+
+func (r *decompressReader) Read(p []byte) (int, error) {
+	start := time.Now()
+	n, err := r.d.Read(p)
+	// the time spent waiting for the connection is not decompression.
+	r.seconds.Add((time.Since(start) - r.in.took).Seconds())
+	r.in.took = 0
+	return n, err
+}
+
+// Close closes the source, which may be concurrent with Read to interrupt it.
+func (r *decompressReader) Close() error {
+	return r.src.Close()
+}
+
+// compressedBody is the body of a request compressed on the fly.
+type compressedBody struct {
+	*io.PipeReader
+	body  io.Closer
+	donec chan struct{}
+}
+
+// compressBody returns a reader of the body compressed with the codec.
+// Closing it closes the body.
+func compressBody(codec string, body io.ReadCloser, to string) io.ReadCloser {
+	pr, pw := io.Pipe()
+	b := &compressedBody{PipeReader: pr, body: body, donec: make(chan struct{})}
+	go func() {
+		defer close(b.donec)
+		zw, err := newCompressWriter(codec, pw, nil, to)
+		if err == nil {
+			_, err = io.Copy(zw, body)
+			if cerr := zw.Close(); err == nil {
+				err = cerr
+			}
+		}
+		pw.CloseWithError(err)
+	}()
+	return b
+}
+
+func (b *compressedBody) Close() error {
+	b.PipeReader.Close()
+	<-b.donec
+	return b.body.Close()
+}
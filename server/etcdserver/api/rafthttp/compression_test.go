@@ -0,0 +1,273 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/time/rate"
+
+	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/client/pkg/v3/testutil"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	stats "go.etcd.io/etcd/server/v3/etcdserver/api/v2stats"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestNegotiateCompression(t *testing.T) {
+	tests := []struct {
+		local  string
+		accept string
+		want   string
+	}{
+		{"", "zstd,snappy", ""},
+		{CompressionNone, "zstd,snappy", ""},
+		{CompressionZstd, "zstd,snappy", CompressionZstd},
+		{CompressionSnappy, "zstd, snappy", CompressionSnappy},
+		{CompressionSnappy, "zstd", ""},
+		// the remote peer does not support compression.
+		{CompressionZstd, "", ""},
+	}
+	for i, tt := range tests {
+		assert.Equalf(t, tt.want, negotiateCompression(tt.local, tt.accept), "#%d", i)
+	}
+}
+
+func TestCompressWriter(t *testing.T) {
+	for _, codec := range supportedCompressions {
+		t.Run(codec, func(t *testing.T) {
+			to := "compress-writer-" + codec
+			var buf bytes.Buffer
+			w, err := newCompressWriter(codec, &buf, nil, to)
+			require.NoError(t, err)
+
+			data := bytes.Repeat([]byte("raft entry "), 1000)
+			_, err = w.Write(data)
+			require.NoError(t, err)
+			w.Flush()
+			flushed := buf.Len()
+			require.Positive(t, flushed)
+
+			rc, err := newDecompressReader(codec, io.NopCloser(&buf), to)
+			require.NoError(t, err)
+			got := make([]byte, len(data))
+			_, err = io.ReadFull(rc, got)
+			require.NoError(t, err)
+			assert.Equal(t, data, got)
+
+			assert.InDelta(t, float64(len(data)), promtestutil.ToFloat64(peerCompressionInBytes.WithLabelValues(to, codec)), 0)
+			assert.InDelta(t, float64(flushed), promtestutil.ToFloat64(peerCompressionOutBytes.WithLabelValues(to, codec)), 0)
+			assert.Positive(t, promtestutil.CollectAndCount(peerCompressionRatio))
+		})
+	}
+}
+
+func TestStreamReaderDialRequestAcceptsCompression(t *testing.T) {
+	tr := &roundTripperRecorder{rec: &testutil.RecorderBuffered{}}
+	sr := &streamReader{
+		peerID: types.ID(2),
+		tr:     &Transport{streamRt: tr, ClusterID: types.ID(1), ID: types.ID(1)},
+		picker: mustNewURLPicker(t, []string{"http://localhost:2380"}),
+		ctx:    t.Context(),
+	}
+	sr.dial(streamTypeMsgAppV2)
+
+	act, err := tr.rec.Wait(1)
+	require.NoError(t, err)
+	req := act[0].Params[0].(*http.Request)
+	assert.Equal(t, "zstd,snappy", req.Header.Get(acceptCompressionHeader))
+}
+
+// TestStreamReaderDialCompressionResult tests that the stream reader decodes
+// the codec answered by the remote peer, and only the codecs it knows.
+func TestStreamReaderDialCompressionResult(t *testing.T) {
+	tests := []struct {
+		codec string
+		wok   bool
+	}{
+		{"", true},
+		{CompressionSnappy, true},
+		{CompressionZstd, true},
+		{"gzip", false},
+	}
+	for i, tt := range tests {
+		h := http.Header{}
+		h.Add("X-Server-Version", version.Version)
+		if tt.codec != "" {
+			h.Add(compressionHeader, tt.codec)
+		}
+		sr := &streamReader{
+			peerID: types.ID(2),
+			tr:     &Transport{streamRt: &respRoundTripper{code: http.StatusOK, header: h}, ClusterID: types.ID(1)},
+			picker: mustNewURLPicker(t, []string{"http://localhost:2380"}),
+			errorc: make(chan error, 1),
+			ctx:    t.Context(),
+		}
+
+		rc, err := sr.dial(streamTypeMessage)
+		if ok := err == nil; ok != tt.wok {
+			t.Errorf("#%d: ok = %v, want %v", i, ok, tt.wok)
+		}
+		if rc != nil {
+			_, isCompressed := rc.(*decompressReader)
+			assert.Equalf(t, tt.codec != "", isCompressed, "#%d", i)
+		}
+	}
+}
+
+func TestCompressedStream(t *testing.T) {
+	msgapp := raftpb.Message{
+		Type:    raftpb.MsgApp,
+		From:    2,
+		To:      1,
+		Term:    1,
+		LogTerm: 1,
+		Index:   3,
+		Entries: []raftpb.Entry{{Term: 1, Index: 4, Data: bytes.Repeat([]byte("a"), 4096)}},
+	}
+	for _, codec := range supportedCompressions {
+		for _, st := range []streamType{streamTypeMessage, streamTypeMsgAppV2} {
+			t.Run(codec+"/"+st.String(), func(t *testing.T) {
+				recvc := make(chan raftpb.Message, streamBufSize)
+				h := &compressedStreamHandler{t: st, codec: codec}
+				srv := httptest.NewServer(h)
+				defer srv.Close()
+
+				sw := startStreamWriter(zaptest.NewLogger(t), types.ID(0), types.ID(1), newPeerStatus(zaptest.NewLogger(t), types.ID(0), types.ID(1)), &stats.FollowerStats{}, &fakeRaft{})
+				defer sw.stop()
+				h.sw = sw
+
+				sr := &streamReader{
+					peerID: types.ID(2),
+					typ:    st,
+					tr:     &Transport{streamRt: &http.Transport{}, ClusterID: types.ID(1), ID: types.ID(1)},
+					picker: mustNewURLPicker(t, []string{srv.URL}),
+					status: newPeerStatus(zaptest.NewLogger(t), types.ID(0), types.ID(2)),
+					recvc:  recvc,
+					propc:  make(chan raftpb.Message, streamBufSize),
+					rl:     rate.NewLimiter(rate.Every(100*time.Millisecond), 1),
+				}
+				sr.start()
+				defer sr.stop()
+
+				var writec chan<- raftpb.Message
+				for {
+					var ok bool
+					if writec, ok = sw.writec(); ok {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+
+				for i := 0; i < 3; i++ {
+					m := msgapp
+					m.Index, m.Entries = msgapp.Index+uint64(i), []raftpb.Entry{{Term: 1, Index: msgapp.Index + uint64(i) + 1, Data: msgapp.Entries[0].Data}}
+					writec <- m
+					select {
+					case got := <-recvc:
+						assert.Equal(t, m, got)
+					case <-time.After(time.Second):
+						t.Fatalf("#%d: failed to receive message from the channel", i)
+					}
+				}
+				assert.Equal(t, codec, h.negotiated)
+			})
+		}
+	}
+}
+
+// compressedStreamHandler is a stream handler negotiating the compression
+// like streamHandler.
+type compressedStreamHandler struct {
+	t          streamType
+	sw         *streamWriter
+	codec      string
+	negotiated string
+}
+
+func (h *compressedStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("X-Server-Version", version.Version)
+	h.negotiated = negotiateCompression(h.codec, r.Header.Get(acceptCompressionHeader))
+	if h.negotiated != "" {
+		w.Header().Set(compressionHeader, h.negotiated)
+	}
+	w.(http.Flusher).Flush()
+	c := newCloseNotifier()
+	h.sw.attach(&outgoingConn{
+		t:           h.t,
+		Writer:      w,
+		Flusher:     w.(http.Flusher),
+		Closer:      c,
+		compression: h.negotiated,
+	})
+	<-c.closeNotify()
+}
+
+func TestCompressedSnapshotSend(t *testing.T) {
+	for _, codec := range supportedCompressions {
+		t.Run(codec, func(t *testing.T) {
+			d := t.TempDir()
+
+			r := &fakeRaft{}
+			tr := &Transport{pipelineRt: &http.Transport{}, ClusterID: types.ID(1), Raft: r}
+			ch := make(chan struct{}, 1)
+			h := &syncHandler{newSnapshotHandler(tr, r, snap.New(zaptest.NewLogger(t), d), types.ID(1)), ch}
+			hdrc := make(chan string, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				hdrc <- req.Header.Get(compressionHeader)
+				h.ServeHTTP(w, req)
+			}))
+			defer srv.Close()
+
+			picker := mustNewURLPicker(t, []string{srv.URL})
+			snapsend := newSnapshotSender(tr, picker, types.ID(1), newPeerStatus(zaptest.NewLogger(t), types.ID(0), types.ID(1)))
+			defer snapsend.stop()
+			snapsend.compression.Store(codec)
+
+			db := strings.Repeat("snapshot data ", 10000)
+			sm := snap.NewMessage(raftpb.Message{Type: raftpb.MsgSnap, To: 1, Snapshot: &raftpb.Snapshot{}}, strReaderCloser{strings.NewReader(db)}, int64(len(db)))
+			snapsend.send(*sm)
+
+			select {
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out sending snapshot")
+			case sent := <-sm.CloseNotify():
+				require.True(t, sent)
+			}
+			<-ch
+			assert.Equal(t, codec, <-hdrc)
+
+			files, err := os.ReadDir(d)
+			require.NoError(t, err)
+			require.Len(t, files, 1)
+			b, err := os.ReadFile(filepath.Join(d, files[0].Name()))
+			require.NoError(t, err)
+			assert.Equal(t, db, string(b))
+		})
+	}
+}
@@ -0,0 +1,635 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	grpcpeer "google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+)
+
+// The gRPC peer transport carries the requests of the streams, pipelines and
+// snapshot senders through bidirectional gRPC streams instead of HTTP/1.1
+// connections. Each request is tunneled through its own gRPC stream and
+// served by the same handlers as the HTTP transport, so the message
+// priorities, flow control, snapshot chunking and metrics are shared by both
+// transports.
+//
+// The tunnel is not a native gRPC protocol for raft messages: the frames carry
+// the HTTP requests and responses of the HTTP transport as is. In particular,
+// gRPC compression is not used; the raft traffic is compressed by the codec
+// negotiated in the tunneled headers (see Transport.Compression), as with
+// HTTP. The certificates of the peers are verified by the TLS peer listeners,
+// and the TLS connection state of the peer is set on the tunneled requests
+// (http.Request.TLS), so that the handlers check it as with HTTP.
+const (
+	// PeerTransportHTTP sends raft traffic over HTTP/1.1 connections.
+	PeerTransportHTTP = "http"
+	// PeerTransportGRPC sends raft traffic over gRPC streams, falling back
+	// to HTTP for peers that do not serve gRPC.
+	PeerTransportGRPC = "grpc"
+
+	tunnelFullMethod = "/rafthttp.Tunnel/RoundTrip"
+
+	// tunnelChunkSize is the maximum size of the body carried by a frame.
+	tunnelChunkSize = 32 * 1024
+)
+
+const (
+	frameHead byte = iota + 1
+	frameData
+	frameEnd
+	framePing
+)
+
+var (
+	// grpcFallbackInterval is how long raft traffic to a peer that does not
+	// serve gRPC is sent over HTTP before trying gRPC again.
+	grpcFallbackInterval = time.Minute
+
+	// tunnelPingInterval is how often the sender of a request pings the
+	// remote peer until the response ends. The peer listeners time out
+	// idle connections after ConnReadTimeout.
+	tunnelPingInterval = ConnReadTimeout / 3
+)
+
+// tunnelHead is the head of a tunneled request or response.
+type tunnelHead struct {
+	Method string      `json:"method,omitempty"`
+	URL    string      `json:"url,omitempty"`
+	Status int         `json:"status,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// tunnelFrame is a message of a tunnel stream. A request is sent as a head
+// frame, data frames and an end frame, followed by ping frames until the
+// response ends. A response is sent as a head frame and data frames.
+type tunnelFrame struct {
+	kind byte
+	head *tunnelHead
+	data []byte
+}
+
+// tunnelCodec encodes tunnel frames as their kind followed by the JSON
+// encoded head or the raw data.
+type tunnelCodec struct{}
+
+func (tunnelCodec) Marshal(v any) ([]byte, error) {
+	f, ok := v.(*tunnelFrame)
+	if !ok {
+		return nil, fmt.Errorf("unexpected message type %T", v)
+	}
+	if f.kind != frameHead {
+		return append([]byte{f.kind}, f.data...), nil
+	}
+	b, err := json.Marshal(f.head)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{frameHead}, b...), nil
+}
+
+func (tunnelCodec) Unmarshal(data []byte, v any) error {
+	f, ok := v.(*tunnelFrame)
+	if !ok {
+		return fmt.Errorf("unexpected message type %T", v)
+	}
+	if len(data) == 0 {
+		return errors.New("empty frame")
+	}
+	*f = tunnelFrame{kind: data[0]}
+	switch f.kind {
+	case frameHead:
+		f.head = &tunnelHead{}
+		return json.Unmarshal(data[1:], f.head)
+	case frameData:
+		// the buffer is reused once Unmarshal returns.
+		f.data = append([]byte(nil), data[1:]...)
+	case frameEnd, framePing:
+	default:
+		return fmt.Errorf("unknown frame kind %d", f.kind)
+	}
+	return nil
+}
+
+func (tunnelCodec) Name() string { return "rafthttp-tunnel" }
+
+// tunnelService is implemented by the server of the tunnel.
+type tunnelService interface {
+	roundTrip(stream grpc.ServerStream) error
+}
+
+var tunnelStreamDesc = grpc.StreamDesc{
+	StreamName: "RoundTrip",
+	Handler: func(srv any, stream grpc.ServerStream) error {
+		return srv.(tunnelService).roundTrip(stream)
+	},
+	ServerStreams: true,
+	ClientStreams: true,
+}
+
+var tunnelServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rafthttp.Tunnel",
+	HandlerType: (*tunnelService)(nil),
+	Streams:     []grpc.StreamDesc{tunnelStreamDesc},
+}
+
+// NewGRPCServer returns a gRPC server that serves the raft traffic of peers
+// using the gRPC peer transport with the given handler, which is usually
+// the one returned by Transport.Handler. The server is expected to be served
+// on the peer listeners, next to the HTTP server.
+func NewGRPCServer(lg *zap.Logger, h http.Handler) *grpc.Server {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	gs := grpc.NewServer(
+		grpc.Creds(peerListenerCredentials{}),
+		grpc.ForceServerCodec(tunnelCodec{}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             ConnReadTimeout,
+			PermitWithoutStream: true,
+		}),
+	)
+	gs.RegisterService(&tunnelServiceDesc, &tunnelServer{lg: lg, h: h})
+	return gs
+}
+
+// peerListenerCredentials exposes the TLS connection state of the
+// connections accepted by the TLS peer listeners, which already completed the
+// handshake, to the gRPC server. Other connections are served as is.
+type peerListenerCredentials struct{}
+
+func (peerListenerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	tc, ok := tlsConn(conn)
+	if !ok {
+		return conn, nil, nil
+	}
+	return conn, credentials.TLSInfo{
+		State:          tc.ConnectionState(),
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.PrivacyAndIntegrity},
+	}, nil
+}
+
+func (peerListenerCredentials) ClientHandshake(context.Context, string, net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return nil, nil, errors.New("rafthttp: peer listener credentials only serve connections")
+}
+
+func (peerListenerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: "tls"}
+}
+
+func (c peerListenerCredentials) Clone() credentials.TransportCredentials { return c }
+
+func (peerListenerCredentials) OverrideServerName(string) error { return nil }
+
+// tlsConn returns the TLS connection under the connections matched by cmux.
+func tlsConn(conn net.Conn) (*tls.Conn, bool) {
+	for {
+		switch c := conn.(type) {
+		case *tls.Conn:
+			return c, true
+		case *cmux.MuxConn:
+			conn = c.Conn
+		default:
+			return nil, false
+		}
+	}
+}
+
+type tunnelServer struct {
+	lg *zap.Logger
+	h  http.Handler
+}
+
+// roundTrip serves the request tunneled through the stream with the handler.
+func (s *tunnelServer) roundTrip(stream grpc.ServerStream) error {
+	var f tunnelFrame
+	if err := stream.RecvMsg(&f); err != nil {
+		return err
+	}
+	if f.kind != frameHead {
+		return status.Error(codes.InvalidArgument, "rafthttp: missing request head")
+	}
+
+	ctx := stream.Context()
+	pr, pw := io.Pipe()
+	req, err := http.NewRequestWithContext(ctx, f.head.Method, f.head.URL, pr)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	req.RequestURI = f.head.URL
+	if f.head.Header != nil {
+		req.Header = f.head.Header
+	}
+	if p, ok := grpcpeer.FromContext(ctx); ok {
+		req.RemoteAddr = p.Addr.String()
+		if ti, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			state := ti.State
+			req.TLS = &state
+		}
+	}
+
+	// keep receiving the pings of the client after the end of the body, so
+	// that they do not fill the stream window.
+	go func() {
+		for {
+			var f tunnelFrame
+			if err := stream.RecvMsg(&f); err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			switch f.kind {
+			case frameData:
+				if _, err := pw.Write(f.data); err != nil && !errors.Is(err, io.ErrClosedPipe) {
+					s.lg.Debug("failed to forward tunneled request body", zap.Error(err))
+				}
+			case frameEnd:
+				pw.Close()
+			}
+		}
+	}()
+	defer pr.Close()
+
+	w := &tunnelResponseWriter{stream: stream, header: make(http.Header)}
+	w.buf = bufio.NewWriterSize(tunnelDataWriter{stream}, tunnelChunkSize)
+	s.h.ServeHTTP(w, req)
+	w.Flush()
+	return w.err
+}
+
+// tunnelResponseWriter is the http.ResponseWriter of a tunneled request.
+// It buffers the body until it is flushed.
+type tunnelResponseWriter struct {
+	stream      grpc.ServerStream
+	header      http.Header
+	wroteHeader bool
+	buf         *bufio.Writer
+	err         error
+}
+
+func (w *tunnelResponseWriter) Header() http.Header { return w.header }
+
+func (w *tunnelResponseWriter) WriteHeader(code int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.err = w.stream.SendMsg(&tunnelFrame{kind: frameHead, head: &tunnelHead{Status: code, Header: w.header}})
+}
+
+func (w *tunnelResponseWriter) Write(p []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	if w.err != nil {
+		return 0, w.err
+	}
+	return w.buf.Write(p)
+}
+
+func (w *tunnelResponseWriter) Flush() {
+	w.WriteHeader(http.StatusOK)
+	if w.err == nil {
+		w.err = w.buf.Flush()
+	}
+}
+
+// tunnelDataWriter sends the written bytes as a data frame.
+type tunnelDataWriter struct {
+	stream grpc.Stream
+}
+
+func (w tunnelDataWriter) Write(p []byte) (int, error) {
+	if err := w.stream.SendMsg(&tunnelFrame{kind: frameData, data: p}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// grpcClient holds the gRPC connections to the remote peers, and the peers
+// to which requests fall back to HTTP.
+type grpcClient struct {
+	lg          *zap.Logger
+	tlsInfo     transport.TLSInfo
+	dialTimeout time.Duration
+
+	mu        sync.Mutex
+	conns     map[string]*grpc.ClientConn
+	fallbacks map[string]time.Time
+}
+
+func newGRPCClient(lg *zap.Logger, tlsInfo transport.TLSInfo, dialTimeout time.Duration) *grpcClient {
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	return &grpcClient{
+		lg:          lg,
+		tlsInfo:     tlsInfo,
+		dialTimeout: dialTimeout,
+		conns:       make(map[string]*grpc.ClientConn),
+		fallbacks:   make(map[string]time.Time),
+	}
+}
+
+// roundTripper returns a roundTripper tunneling the requests through gRPC,
+// which falls back to the given roundTripper.
+func (c *grpcClient) roundTripper(fallback http.RoundTripper) http.RoundTripper {
+	return &grpcRoundTripper{c: c, fallback: fallback}
+}
+
+func (c *grpcClient) conn(u *url.URL) (*grpc.ClientConn, error) {
+	key := u.Scheme + "://" + u.Host
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cc, ok := c.conns[key]; ok {
+		return cc, nil
+	}
+
+	creds := insecure.NewCredentials()
+	if u.Scheme == "https" || u.Scheme == "unixs" {
+		cfg, err := c.tlsInfo.ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(cfg)
+	}
+	network := "tcp"
+	if u.Scheme == "unix" || u.Scheme == "unixs" {
+		network = "unix"
+	}
+	dialer := &net.Dialer{Timeout: c.dialTimeout, KeepAlive: 30 * time.Second}
+	cc, err := grpc.NewClient("passthrough:///"+u.Host,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, addr)
+		}),
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  100 * time.Millisecond,
+				Multiplier: 1.6,
+				Jitter:     0.2,
+				MaxDelay:   time.Second,
+			},
+			MinConnectTimeout: c.dialTimeout,
+		}),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                2 * ConnReadTimeout,
+			Timeout:             ConnReadTimeout,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(tunnelCodec{})),
+	)
+	if err != nil {
+		return nil, err
+	}
+	c.conns[key] = cc
+	return cc, nil
+}
+
+func (c *grpcClient) fallingBack(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	until, ok := c.fallbacks[key]
+	if ok && time.Now().After(until) {
+		delete(c.fallbacks, key)
+		return false
+	}
+	return ok
+}
+
+func (c *grpcClient) fallBack(key string, err error) {
+	c.mu.Lock()
+	_, ok := c.fallbacks[key]
+	c.fallbacks[key] = time.Now().Add(grpcFallbackInterval)
+	c.mu.Unlock()
+	if !ok {
+		peerTransportFallbacks.WithLabelValues(key).Inc()
+		c.lg.Warn(
+			"remote peer does not serve gRPC; falling back to HTTP",
+			zap.String("remote-peer-url", key),
+			zap.Duration("retry-after", grpcFallbackInterval),
+			zap.Error(err),
+		)
+	}
+}
+
+// close closes the gRPC connections.
+func (c *grpcClient) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, cc := range c.conns {
+		cc.Close()
+		delete(c.conns, key)
+	}
+}
+
+type grpcRoundTripper struct {
+	c        *grpcClient
+	fallback http.RoundTripper
+}
+
+func (rt *grpcRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.URL.Scheme + "://" + req.URL.Host
+	if rt.c.fallingBack(key) {
+		return rt.fallback.RoundTrip(req)
+	}
+
+	resp, sent, err := rt.roundTrip(req)
+	if err == nil {
+		return resp, nil
+	}
+	if code := status.Code(err); code != codes.Unavailable && code != codes.Unimplemented {
+		return nil, err
+	}
+	// Retry the request over HTTP, unless its body was consumed. The remote
+	// peer may be an older member, or may be still starting.
+	freq := req
+	if sent && req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return nil, err
+		}
+		body, berr := req.GetBody()
+		if berr != nil {
+			return nil, err
+		}
+		freq = req.Clone(req.Context())
+		freq.Body = body
+	}
+	resp, ferr := rt.fallback.RoundTrip(freq)
+	if ferr != nil {
+		return nil, ferr
+	}
+	if servesHTTPOnly(err) {
+		rt.c.fallBack(key, err)
+	}
+	return resp, nil
+}
+
+// servesHTTPOnly reports whether the error of a tunneled request shows that
+// the remote peer does not serve gRPC, rather than being unreachable.
+func servesHTTPOnly(err error) bool {
+	s, _ := status.FromError(err)
+	switch s.Code() {
+	case codes.Unimplemented:
+		// the HTTP/2 server of the peer does not know the tunnel.
+		return true
+	case codes.Unavailable:
+		// the peer answered the HTTP/2 preface with HTTP/1.1.
+		return strings.Contains(s.Message(), "HTTP/1.1")
+	default:
+		return false
+	}
+}
+
+// roundTrip tunnels the request through a new stream. It reports whether
+// the request was sent, which consumes its body.
+func (rt *grpcRoundTripper) roundTrip(req *http.Request) (*http.Response, bool, error) {
+	cc, err := rt.c.conn(req.URL)
+	if err != nil {
+		return nil, false, err
+	}
+	ctx, cancel := context.WithCancel(req.Context())
+	stream, err := cc.NewStream(ctx, &tunnelStreamDesc, tunnelFullMethod)
+	if err != nil {
+		cancel()
+		return nil, false, err
+	}
+
+	go sendTunnelRequest(ctx, cancel, stream, req)
+
+	var f tunnelFrame
+	if err = stream.RecvMsg(&f); err != nil {
+		cancel()
+		return nil, true, err
+	}
+	if f.kind != frameHead {
+		cancel()
+		return nil, true, fmt.Errorf("rafthttp: unexpected frame kind %d before response head", f.kind)
+	}
+	header := f.head.Header
+	if header == nil {
+		header = make(http.Header)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", f.head.Status, http.StatusText(f.head.Status)),
+		StatusCode:    f.head.Status,
+		Proto:         "HTTP/2.0",
+		ProtoMajor:    2,
+		Header:        header,
+		Body:          &tunnelResponseBody{stream: stream, cancel: cancel},
+		ContentLength: -1,
+		Request:       req,
+	}, true, nil
+}
+
+// sendTunnelRequest sends the request through the stream, then pings the
+// remote peer until the stream ends.
+func sendTunnelRequest(ctx context.Context, cancel context.CancelFunc, stream grpc.ClientStream, req *http.Request) {
+	head := &tunnelHead{Method: req.Method, URL: req.URL.RequestURI(), Header: req.Header}
+	if err := stream.SendMsg(&tunnelFrame{kind: frameHead, head: head}); err != nil {
+		return
+	}
+	if req.Body != nil {
+		err := sendTunnelBody(stream, req.Body)
+		req.Body.Close()
+		if err != nil {
+			// the stream is unusable with a partially sent body.
+			cancel()
+			return
+		}
+	}
+	if err := stream.SendMsg(&tunnelFrame{kind: frameEnd}); err != nil {
+		return
+	}
+
+	ticker := time.NewTicker(tunnelPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := stream.SendMsg(&tunnelFrame{kind: framePing}); err != nil {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func sendTunnelBody(stream grpc.ClientStream, body io.Reader) error {
+	buf := make([]byte, tunnelChunkSize)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if serr := stream.SendMsg(&tunnelFrame{kind: frameData, data: buf[:n]}); serr != nil {
+				return serr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// tunnelResponseBody reads the body of a response from the data frames.
+type tunnelResponseBody struct {
+	stream grpc.ClientStream
+	cancel context.CancelFunc
+	buf    bytes.Reader
+}
+
+func (b *tunnelResponseBody) Read(p []byte) (int, error) {
+	for b.buf.Len() == 0 {
+		var f tunnelFrame
+		if err := b.stream.RecvMsg(&f); err != nil {
+			b.cancel()
+			return 0, err
+		}
+		if f.kind == frameData {
+			b.buf.Reset(f.data)
+		}
+	}
+	return b.buf.Read(p)
+}
+
+func (b *tunnelResponseBody) Close() error {
+	b.cancel()
+	return nil
+}
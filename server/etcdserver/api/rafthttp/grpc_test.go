@@ -0,0 +1,256 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rafthttp
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/soheilhy/cmux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+	"golang.org/x/time/rate"
+
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
+	stats "go.etcd.io/etcd/server/v3/etcdserver/api/v2stats"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// newGRPCTestServer serves the handler over both gRPC and HTTP on a local
+// listener, like the peer listeners of etcd, and returns its URL.
+func newGRPCTestServer(t *testing.T, h http.Handler) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	m := cmux.New(ln)
+	gs := NewGRPCServer(zaptest.NewLogger(t), h)
+	hs := &http.Server{Handler: h}
+	go gs.Serve(m.Match(cmux.HTTP2()))
+	go hs.Serve(m.Match(cmux.Any()))
+	go m.Serve()
+	t.Cleanup(func() {
+		gs.Stop()
+		hs.Close()
+		m.Close()
+	})
+	return "http://" + ln.Addr().String()
+}
+
+func newGRPCTestTransport(t *testing.T, id types.ID, r Raft) *Transport {
+	tr := &Transport{
+		ID:            id,
+		ClusterID:     types.ID(1),
+		Raft:          r,
+		ServerStats:   newServerStats(),
+		LeaderStats:   stats.NewLeaderStats(zaptest.NewLogger(t), id.String()),
+		PeerTransport: PeerTransportGRPC,
+	}
+	require.NoError(t, tr.Start())
+	return tr
+}
+
+func TestSendMessageGRPC(t *testing.T) {
+	tr := newGRPCTestTransport(t, types.ID(1), &fakeRaft{})
+	u := newGRPCTestServer(t, tr.Handler())
+
+	recvc := make(chan raftpb.Message, 1)
+	tr2 := newGRPCTestTransport(t, types.ID(2), &fakeRaft{recvc: recvc})
+	u2 := newGRPCTestServer(t, tr2.Handler())
+
+	tr.AddPeer(types.ID(2), []string{u2})
+	defer tr.Stop()
+	tr2.AddPeer(types.ID(1), []string{u})
+	defer tr2.Stop()
+	require.Truef(t, waitStreamWorking(tr.Get(types.ID(2)).(*peer)), "stream from 1 to 2 is not in work as expected")
+
+	data := []byte("some data")
+	tests := []raftpb.Message{
+		{Type: raftpb.MsgProp, From: 1, To: 2, Entries: []raftpb.Entry{{Data: data}}},
+		{Type: raftpb.MsgApp, From: 1, To: 2, Term: 1, Index: 3, LogTerm: 0, Entries: []raftpb.Entry{{Index: 4, Term: 1, Data: data}}, Commit: 3},
+		{Type: raftpb.MsgAppResp, From: 1, To: 2, Term: 1, Index: 3},
+		{Type: raftpb.MsgVote, From: 1, To: 2, Term: 1, Index: 3, LogTerm: 0},
+		{Type: raftpb.MsgSnap, From: 1, To: 2, Term: 1, Snapshot: &raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 1000, Term: 1}, Data: data}},
+		{Type: raftpb.MsgHeartbeat, From: 1, To: 2, Term: 1, Commit: 3},
+	}
+	for i, tt := range tests {
+		tr.Send([]raftpb.Message{tt})
+		assert.Equalf(t, tt, <-recvc, "#%d", i)
+	}
+	assert.False(t, tr.grpcClient.fallingBack(u2), "raft traffic fell back to HTTP")
+}
+
+func TestSendMessageGRPCFallback(t *testing.T) {
+	tr := newGRPCTestTransport(t, types.ID(1), &fakeRaft{})
+	u := newGRPCTestServer(t, tr.Handler())
+
+	// member 2 only serves HTTP.
+	recvc := make(chan raftpb.Message, 1)
+	tr2 := &Transport{
+		ID:          types.ID(2),
+		ClusterID:   types.ID(1),
+		Raft:        &fakeRaft{recvc: recvc},
+		ServerStats: newServerStats(),
+		LeaderStats: stats.NewLeaderStats(zaptest.NewLogger(t), "2"),
+	}
+	require.NoError(t, tr2.Start())
+	srv2 := httptest.NewServer(tr2.Handler())
+	defer srv2.Close()
+
+	tr.AddPeer(types.ID(2), []string{srv2.URL})
+	defer tr.Stop()
+	tr2.AddPeer(types.ID(1), []string{u})
+	defer tr2.Stop()
+	// the streams from 2 to 1 are dialed by member 1.
+	require.Truef(t, waitStreamWorking(tr2.Get(types.ID(1)).(*peer)), "stream from 2 to 1 is not in work as expected")
+
+	// MsgSnap is sent by the pipeline.
+	m := raftpb.Message{Type: raftpb.MsgSnap, From: 1, To: 2, Term: 1, Snapshot: &raftpb.Snapshot{Metadata: raftpb.SnapshotMetadata{Index: 1000, Term: 1}}}
+	tr.Send([]raftpb.Message{m})
+	assert.Equal(t, m, <-recvc)
+	assert.True(t, tr.grpcClient.fallingBack(srv2.URL), "raft traffic did not fall back to HTTP")
+}
+
+func TestSnapshotSendGRPC(t *testing.T) {
+	d := t.TempDir()
+
+	r := &fakeRaft{}
+	c := newGRPCClient(zaptest.NewLogger(t), transport.TLSInfo{}, time.Second)
+	defer c.close()
+	tr := &Transport{pipelineRt: c.roundTripper(&http.Transport{}), ClusterID: types.ID(1), Raft: r}
+	ch := make(chan struct{}, 1)
+	h := &syncHandler{newSnapshotHandler(tr, r, snap.New(zaptest.NewLogger(t), d), types.ID(1)), ch}
+	u := newGRPCTestServer(t, h)
+
+	picker := mustNewURLPicker(t, []string{u})
+	snapsend := newSnapshotSender(tr, picker, types.ID(1), newPeerStatus(zaptest.NewLogger(t), types.ID(0), types.ID(1)))
+	defer snapsend.stop()
+
+	// the snapshot spans many frames.
+	db := strings.Repeat("a", 10*tunnelChunkSize+1)
+	sm := snap.NewMessage(raftpb.Message{Type: raftpb.MsgSnap, To: 1, Snapshot: &raftpb.Snapshot{}}, strReaderCloser{strings.NewReader(db)}, int64(len(db)))
+	snapsend.send(*sm)
+
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out sending snapshot")
+	case sent := <-sm.CloseNotify():
+		require.True(t, sent)
+	}
+	<-ch
+
+	files, err := os.ReadDir(d)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	b, err := os.ReadFile(filepath.Join(d, files[0].Name()))
+	require.NoError(t, err)
+	assert.Equal(t, db, string(b))
+	assert.False(t, c.fallingBack(u), "snapshot fell back to HTTP")
+}
+
+// TestCompressedStreamGRPC tests that the compression of the streams is
+// negotiated through the headers tunneled over gRPC, as gRPC compression is
+// not used.
+func TestCompressedStreamGRPC(t *testing.T) {
+	recvc := make(chan raftpb.Message, streamBufSize)
+	h := &compressedStreamHandler{t: streamTypeMessage, codec: CompressionZstd}
+	u := newGRPCTestServer(t, h)
+
+	sw := startStreamWriter(zaptest.NewLogger(t), types.ID(0), types.ID(1), newPeerStatus(zaptest.NewLogger(t), types.ID(0), types.ID(1)), &stats.FollowerStats{}, &fakeRaft{})
+	defer sw.stop()
+	h.sw = sw
+
+	c := newGRPCClient(zaptest.NewLogger(t), transport.TLSInfo{}, time.Second)
+	defer c.close()
+	sr := &streamReader{
+		peerID: types.ID(2),
+		typ:    streamTypeMessage,
+		tr:     &Transport{streamRt: c.roundTripper(&http.Transport{}), ClusterID: types.ID(1), ID: types.ID(1)},
+		picker: mustNewURLPicker(t, []string{u}),
+		status: newPeerStatus(zaptest.NewLogger(t), types.ID(0), types.ID(2)),
+		recvc:  recvc,
+		propc:  make(chan raftpb.Message, streamBufSize),
+		rl:     rate.NewLimiter(rate.Every(100*time.Millisecond), 1),
+	}
+	sr.start()
+	defer sr.stop()
+
+	var writec chan<- raftpb.Message
+	for {
+		var ok bool
+		if writec, ok = sw.writec(); ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	m := raftpb.Message{Type: raftpb.MsgApp, From: 2, To: 1, Term: 1, LogTerm: 1, Index: 3, Entries: []raftpb.Entry{{Term: 1, Index: 4, Data: bytes.Repeat([]byte("a"), 4096)}}}
+	writec <- m
+	select {
+	case got := <-recvc:
+		assert.Equal(t, m, got)
+	case <-time.After(time.Second):
+		t.Fatal("failed to receive message from the channel")
+	}
+	assert.Equal(t, CompressionZstd, h.negotiated)
+	assert.False(t, c.fallingBack(u), "raft traffic fell back to HTTP")
+}
+
+// TestTunnelPeerTLS ensures that the TLS connection state of the peer is set
+// on the requests tunneled through the TLS peer listeners.
+func TestTunnelPeerTLS(t *testing.T) {
+	tlsInfo, err := transport.SelfCert(zaptest.NewLogger(t), t.TempDir(), []string{"127.0.0.1:0"}, 1, x509.ExtKeyUsageClientAuth)
+	require.NoError(t, err)
+	tlsInfo.TrustedCAFile = tlsInfo.CertFile
+	tlsInfo.ClientCertAuth = true
+
+	ln, err := transport.NewListener("127.0.0.1:0", "https", &tlsInfo)
+	require.NoError(t, err)
+	statec := make(chan *tls.ConnectionState, 1)
+	m := cmux.New(ln)
+	gs := NewGRPCServer(zaptest.NewLogger(t), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		statec <- r.TLS
+	}))
+	go gs.Serve(m.Match(cmux.HTTP2()))
+	go m.Serve()
+	defer func() {
+		gs.Stop()
+		m.Close()
+	}()
+
+	c := newGRPCClient(zaptest.NewLogger(t), tlsInfo, time.Second)
+	defer c.close()
+	req, err := http.NewRequest(http.MethodGet, "https://"+ln.Addr().String()+RaftPrefix, nil)
+	require.NoError(t, err)
+	resp, err := c.roundTripper(&http.Transport{}).RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	state := <-statec
+	require.NotNil(t, state)
+	require.Len(t, state.PeerCertificates, 1)
+	assert.False(t, c.fallingBack("https://"+ln.Addr().String()), "request fell back to HTTP")
+}
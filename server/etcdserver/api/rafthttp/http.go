@@ -217,7 +217,19 @@ func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	addRemoteFromRequest(h.tr, r)
 
-	dec := &messageDecoder{r: r.Body}
+	body := io.Reader(r.Body)
+	if codec := r.Header.Get(compressionHeader); codec != "" {
+		rc, err := newDecompressReader(codec, r.Body, r.Header.Get("X-Server-From"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusUnsupportedMediaType)
+			snapshotReceiveFailures.WithLabelValues(unknownSnapshotSender).Inc()
+			return
+		}
+		defer rc.Close()
+		body = rc
+	}
+
+	dec := &messageDecoder{r: body}
 	// let snapshots be very large since they can exceed 512MB for large installations
 	m, err := dec.decodeLimit(snapshotLimitByte)
 	from := types.ID(m.From).String()
@@ -266,7 +278,7 @@ func (h *snapshotHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// save incoming database snapshot.
 
-	n, err := h.snapshotter.SaveDBFrom(r.Body, m.Snapshot.Metadata.Index)
+	n, err := h.snapshotter.SaveDBFrom(body, m.Snapshot.Metadata.Index)
 	if err != nil {
 		msg := fmt.Sprintf("failed to save KV snapshot (%v)", err)
 		h.lg.Warn(
@@ -437,17 +449,23 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	codec := negotiateCompression(h.tr.Compression, r.Header.Get(acceptCompressionHeader))
+	if codec != "" {
+		w.Header().Set(compressionHeader, codec)
+	}
+
 	w.WriteHeader(http.StatusOK)
 	w.(http.Flusher).Flush()
 
 	c := newCloseNotifier()
 	conn := &outgoingConn{
-		t:       t,
-		Writer:  w,
-		Flusher: w.(http.Flusher),
-		Closer:  c,
-		localID: h.tr.ID,
-		peerID:  from,
+		t:           t,
+		Writer:      w,
+		Flusher:     w.(http.Flusher),
+		Closer:      c,
+		localID:     h.tr.ID,
+		peerID:      from,
+		compression: codec,
 	}
 	p.attachOutgoingConn(conn)
 	<-c.closeNotify()
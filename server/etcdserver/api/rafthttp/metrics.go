@@ -165,6 +165,70 @@ var (
 		[]string{"From"},
 	)
 
+	peerTransportFallbacks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "peer_grpc_fallbacks_total",
+			Help:      "The total number of times raft traffic to a peer URL fell back from gRPC to HTTP.",
+		},
+		[]string{"To"},
+	)
+
+	peerCompressionInBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "peer_compression_input_bytes_total",
+			Help:      "The total number of bytes of raft traffic to peers before compression.",
+		},
+		[]string{"To", "Codec"},
+	)
+
+	peerCompressionOutBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "peer_compression_output_bytes_total",
+			Help:      "The total number of bytes of raft traffic to peers after compression.",
+		},
+		[]string{"To", "Codec"},
+	)
+
+	peerCompressionRatio = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "peer_compression_ratio",
+			Help:      "The ratio of the uncompressed to the compressed size of the batches of raft traffic sent to peers.",
+
+			// lowest bucket start of upper bound 1 with factor 1.5
+			// highest bucket start of 1 * 1.5^9 == 38.4
+			Buckets: prometheus.ExponentialBuckets(1, 1.5, 10),
+		},
+		[]string{"To", "Codec"},
+	)
+
+	peerCompressionSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "peer_compression_seconds_total",
+			Help:      "The total time spent compressing raft traffic to peers.",
+		},
+		[]string{"To", "Codec"},
+	)
+
+	peerDecompressionSeconds = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "network",
+			Name:      "peer_decompression_seconds_total",
+			Help:      "The total time spent decompressing raft traffic from peers.",
+		},
+		[]string{"From", "Codec"},
+	)
+
 	rttSec = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "etcd",
@@ -197,5 +261,13 @@ func init() {
 	prometheus.MustRegister(snapshotReceiveFailures)
 	prometheus.MustRegister(snapshotReceiveSeconds)
 
+	prometheus.MustRegister(peerTransportFallbacks)
+
+	prometheus.MustRegister(peerCompressionInBytes)
+	prometheus.MustRegister(peerCompressionOutBytes)
+	prometheus.MustRegister(peerCompressionRatio)
+	prometheus.MustRegister(peerCompressionSeconds)
+	prometheus.MustRegister(peerDecompressionSeconds)
+
 	prometheus.MustRegister(rttSec)
 }
@@ -274,6 +274,8 @@ func (p *peer) update(urls types.URLs) {
 }
 
 func (p *peer) attachOutgoingConn(conn *outgoingConn) {
+	// snapshots are compressed as the streams the peer reads.
+	p.snapSender.compression.Store(conn.compression)
 	var ok bool
 	switch conn.t {
 	case streamTypeMsgAppV2:
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/dustin/go-humanize"
@@ -44,6 +45,8 @@ type snapshotSender struct {
 	status *peerStatus
 	r      Raft
 	errorc chan error
+	// compression is the codec negotiated by the streams of the peer.
+	compression atomic.Value
 
 	stopc chan struct{}
 }
@@ -71,10 +74,17 @@ func (s *snapshotSender) send(merged snap.Message) {
 	to := types.ID(m.To).String()
 
 	body := createSnapBody(s.tr.Logger, merged)
+	codec, _ := s.compression.Load().(string)
+	if codec != "" {
+		body = compressBody(codec, body, to)
+	}
 	defer body.Close()
 
 	u := s.picker.pick()
 	req := createPostRequest(s.tr.Logger, u, RaftSnapshotPrefix, body, "application/octet-stream", s.tr.URLs, s.from, s.cid)
+	if codec != "" {
+		req.Header.Set(compressionHeader, codec)
+	}
 
 	snapshotSizeVal := uint64(merged.TotalSize)
 	snapshotSize := humanize.Bytes(snapshotSizeVal)
@@ -85,6 +95,7 @@ func (s *snapshotSender) send(merged snap.Message) {
 			zap.String("remote-peer-id", to),
 			zap.Uint64("bytes", snapshotSizeVal),
 			zap.String("size", snapshotSize),
+			zap.String("compression", codec),
 		)
 	}
 
@@ -108,6 +108,8 @@ type outgoingConn struct {
 
 	localID types.ID
 	peerID  types.ID
+	// compression is the codec the stream is compressed with, if any.
+	compression string
 }
 
 // streamWriter writes messages to the attached outgoingConn.
@@ -235,11 +237,18 @@ func (cw *streamWriter) run() {
 			cw.mu.Lock()
 			closed := cw.closeUnlocked()
 			t = conn.t
+			w := io.Writer(conn.Writer)
+			flusher = conn.Flusher
+			if conn.compression != "" {
+				// the negotiated codec is known to be valid.
+				zw, _ := newCompressWriter(conn.compression, conn.Writer, conn.Flusher, conn.peerID.String())
+				w, flusher = zw, zw
+			}
 			switch conn.t {
 			case streamTypeMsgAppV2:
-				enc = newMsgAppV2Encoder(conn.Writer, cw.fs)
+				enc = newMsgAppV2Encoder(w, cw.fs)
 			case streamTypeMessage:
-				enc = &messageEncoder{w: conn.Writer}
+				enc = &messageEncoder{w: w}
 			default:
 				if cw.lg != nil {
 					cw.lg.Panic("unhandled stream type", zap.String("stream-type", t.String()))
@@ -251,9 +260,9 @@ func (cw *streamWriter) run() {
 					zap.String("from", conn.localID.String()),
 					zap.String("to", conn.peerID.String()),
 					zap.String("stream-type", t.String()),
+					zap.String("compression", conn.compression),
 				)
 			}
-			flusher = conn.Flusher
 			unflushed = 0
 			cw.status.activate()
 			cw.closer = conn.Closer
@@ -584,6 +593,7 @@ func (cr *streamReader) dial(t streamType) (io.ReadCloser, error) {
 	req.Header.Set("X-Min-Cluster-Version", version.MinClusterVersion)
 	req.Header.Set("X-Etcd-Cluster-ID", cr.tr.ClusterID.String())
 	req.Header.Set("X-Raft-To", cr.peerID.String())
+	req.Header.Set(acceptCompressionHeader, strings.Join(supportedCompressions, ","))
 
 	setPeerURLsHeader(req, cr.tr.URLs)
 
@@ -620,7 +630,17 @@ func (cr *streamReader) dial(t streamType) (io.ReadCloser, error) {
 		return nil, errMemberRemoved
 
 	case http.StatusOK:
-		return resp.Body, nil
+		codec := resp.Header.Get(compressionHeader)
+		if codec == "" {
+			return resp.Body, nil
+		}
+		rc, err := newDecompressReader(codec, resp.Body, cr.peerID.String())
+		if err != nil {
+			httputil.GracefulClose(resp)
+			cr.picker.unreachable(u)
+			return nil, err
+		}
+		return rc, nil
 
 	case http.StatusNotFound:
 		httputil.GracefulClose(resp)
@@ -103,6 +103,12 @@ type Transport struct {
 	DialRetryFrequency rate.Limit
 
 	TLSInfo transport.TLSInfo // TLS information used when creating connection
+	// PeerTransport is the transport of the raft traffic sent to peers,
+	// either PeerTransportHTTP (default) or PeerTransportGRPC.
+	PeerTransport string
+	// Compression is the codec the raft traffic sent to peers is compressed
+	// with, for the peers supporting it. It is not compressed by default.
+	Compression string
 
 	ID          types.ID   // local member ID
 	URLs        types.URLs // local peer URLs
@@ -121,6 +127,7 @@ type Transport struct {
 
 	streamRt   http.RoundTripper // roundTripper used by streams
 	pipelineRt http.RoundTripper // roundTripper used by pipelines
+	grpcClient *grpcClient       // gRPC connections used by the roundTrippers with the gRPC peer transport
 
 	mu      sync.RWMutex         // protect the remote and peer map
 	remotes map[types.ID]*remote // remotes map that helps newly joined member to catch up
@@ -140,6 +147,11 @@ func (t *Transport) Start() error {
 	if err != nil {
 		return err
 	}
+	if t.PeerTransport == PeerTransportGRPC {
+		t.grpcClient = newGRPCClient(t.Logger, t.TLSInfo, t.DialTimeout)
+		t.streamRt = t.grpcClient.roundTripper(t.streamRt)
+		t.pipelineRt = t.grpcClient.roundTripper(t.pipelineRt)
+	}
 	t.remotes = make(map[types.ID]*remote)
 	t.peers = make(map[types.ID]Peer)
 	t.pipelineProber = probing.NewProber(t.pipelineRt)
@@ -219,11 +231,16 @@ func (t *Transport) Stop() {
 	}
 	t.pipelineProber.RemoveAll()
 	t.streamProber.RemoveAll()
-	if tr, ok := t.streamRt.(*http.Transport); ok {
-		tr.CloseIdleConnections()
+	for _, rt := range []http.RoundTripper{t.streamRt, t.pipelineRt} {
+		if grt, ok := rt.(*grpcRoundTripper); ok {
+			rt = grt.fallback
+		}
+		if tr, ok := rt.(*http.Transport); ok {
+			tr.CloseIdleConnections()
+		}
 	}
-	if tr, ok := t.pipelineRt.(*http.Transport); ok {
-		tr.CloseIdleConnections()
+	if t.grpcClient != nil {
+		t.grpcClient.close()
 	}
 	t.peers = nil
 	t.remotes = nil
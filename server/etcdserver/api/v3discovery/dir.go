@@ -0,0 +1,291 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+// dirPollInterval is how often the directory source lists the registered
+// members while waiting for the other members.
+var dirPollInterval = time.Second
+
+// dirSource is the Source backed by a directory shared by the members, for
+// provisioning without a discovery service. It mirrors the keys of the
+// discovery service:
+//
+//	<dir>/<ClusterToken>/_config/size
+//	<dir>/<ClusterToken>/members/<seq>.<memberID>
+//
+// The size file is written by the provisioner, and each member writes its
+// own file containing "memberName=peerURLs". The members are ordered by the
+// sequence number in the name of their files, which a member allocates when
+// it registers, as the discovery service orders them by create revision.
+// Registrations are made and removed under the lock of the file
+// "members/.lock".
+type dirSource struct {
+	lg           *zap.Logger
+	dir          string
+	clusterToken string
+}
+
+var _ Source = (*dirSource)(nil)
+
+func newDirSource(lg *zap.Logger, cfg *DiscoveryConfig) *dirSource {
+	return &dirSource{
+		lg:           lg.With(zap.String("discovery-token", cfg.Token), zap.String("discovery-dir", cfg.Dir)),
+		dir:          cfg.Dir,
+		clusterToken: cfg.Token,
+	}
+}
+
+func (s *dirSource) sizeFile() string {
+	return filepath.Join(s.dir, s.clusterToken, "_config", "size")
+}
+
+func (s *dirSource) membersDir() string {
+	return filepath.Join(s.dir, s.clusterToken, "members")
+}
+
+// Join registers the member in the directory and polls it until all the
+// members are registered.
+func (s *dirSource) Join(ctx context.Context, id types.ID, config string) (string, error) {
+	selfKey := getMemberKey(s.clusterToken, id.String())
+	if _, _, err := s.checkCluster(selfKey); err != nil {
+		return "", err
+	}
+
+	if err := s.register(id, config); err != nil {
+		return "", err
+	}
+
+	for {
+		cls, clusterSize, err := s.checkCluster(selfKey)
+		if err != nil {
+			return "", err
+		}
+		if cls.Len() >= clusterSize {
+			return cls.getInitClusterStr(clusterSize)
+		}
+
+		s.lg.Info(
+			"waiting for peers from discovery directory",
+			zap.Int("clusterSize", clusterSize),
+			zap.Int("found-peers", cls.Len()),
+		)
+		select {
+		case <-time.After(dirPollInterval):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+}
+
+// Unregister removes the file of the member, unless the cluster is full and
+// the member is among the first clusterSize ones, since the other members may
+// have bootstrapped with it.
+func (s *dirSource) Unregister(_ context.Context, id types.ID) error {
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	fn, err := s.memberFile(id)
+	if err != nil || fn == "" {
+		return err
+	}
+	clusterSize, err := s.getClusterSize()
+	if err != nil {
+		return err
+	}
+	cls, err := s.getClusterMembers()
+	if err != nil {
+		return err
+	}
+	if cls.Len() >= clusterSize && cls.inCluster(clusterSize, getMemberKey(s.clusterToken, id.String())) {
+		s.lg.Warn("kept member registration of a full cluster", zap.String("path", fn))
+		return nil
+	}
+	if err = os.Remove(fn); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	s.lg.Info("removed member registration", zap.String("path", fn))
+	return nil
+}
+
+func (s *dirSource) Close() error { return nil }
+
+func (s *dirSource) checkCluster(selfKey string) (*clusterInfo, int, error) {
+	clusterSize, err := s.getClusterSize()
+	if err != nil {
+		return nil, 0, err
+	}
+	cls, err := s.getClusterMembers()
+	if err != nil {
+		return nil, 0, err
+	}
+	return cls, clusterSize, cls.checkFull(clusterSize, selfKey)
+}
+
+func (s *dirSource) getClusterSize() (int, error) {
+	b, err := os.ReadFile(s.sizeFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, ErrSizeNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	clusterSize, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 0)
+	if err != nil || clusterSize <= 0 {
+		return 0, ErrBadSizeKey
+	}
+	return int(clusterSize), nil
+}
+
+func (s *dirSource) getClusterMembers() (*clusterInfo, error) {
+	cls := &clusterInfo{clusterToken: s.clusterToken}
+	entries, err := os.ReadDir(s.membersDir())
+	if errors.Is(err, os.ErrNotExist) {
+		return cls, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, e := range entries {
+		seq, id, ok := parseMemberFileName(e)
+		if !ok {
+			continue
+		}
+		b, err := os.ReadFile(filepath.Join(s.membersDir(), e.Name()))
+		if err != nil {
+			// the member unregistered meanwhile.
+			continue
+		}
+		mKey := getMemberKey(s.clusterToken, id)
+		mValue := strings.TrimSpace(string(b))
+		if err := cls.add(mKey, mValue, seq); err != nil {
+			s.lg.Warn(
+				err.Error(),
+				zap.String("memberKey", mKey),
+				zap.String("memberInfo", mValue),
+			)
+		}
+	}
+	return cls, nil
+}
+
+// parseMemberFileName returns the sequence number and the member id of a
+// registration file. The lock and the temporary files of registrations in
+// progress are hidden and skipped.
+func parseMemberFileName(e os.DirEntry) (int64, string, bool) {
+	if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+		return 0, "", false
+	}
+	seqStr, id, ok := strings.Cut(e.Name(), ".")
+	if !ok {
+		return 0, "", false
+	}
+	seq, err := strconv.ParseInt(seqStr, 10, 64)
+	if err != nil {
+		return 0, "", false
+	}
+	return seq, id, true
+}
+
+// lock locks the registrations of the directory.
+func (s *dirSource) lock() (*fileutil.LockedFile, error) {
+	if err := fileutil.TouchDirAll(s.lg, s.membersDir()); err != nil {
+		return nil, err
+	}
+	return fileutil.LockFile(filepath.Join(s.membersDir(), ".lock"), os.O_WRONLY|os.O_CREATE, fileutil.PrivateFileMode)
+}
+
+// memberFile returns the path of the file of the member with the given id, or
+// an empty path if the member is not registered.
+func (s *dirSource) memberFile(id types.ID) (string, error) {
+	entries, err := os.ReadDir(s.membersDir())
+	if err != nil {
+		return "", err
+	}
+	for _, e := range entries {
+		if _, mid, ok := parseMemberFileName(e); ok && mid == id.String() {
+			return filepath.Join(s.membersDir(), e.Name()), nil
+		}
+	}
+	return "", nil
+}
+
+// register atomically writes the file of the member, with the sequence number
+// following the ones of the registered members. A member registering again
+// keeps its file, and so its position.
+func (s *dirSource) register(id types.ID, config string) error {
+	l, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer l.Close()
+
+	fn, err := s.memberFile(id)
+	if err != nil {
+		return err
+	}
+	if fn != "" {
+		if b, err := os.ReadFile(fn); err == nil && strings.TrimSpace(string(b)) == config {
+			return nil
+		}
+	} else {
+		entries, err := os.ReadDir(s.membersDir())
+		if err != nil {
+			return err
+		}
+		var last int64
+		for _, e := range entries {
+			if seq, _, ok := parseMemberFileName(e); ok && seq > last {
+				last = seq
+			}
+		}
+		fn = filepath.Join(s.membersDir(), fmt.Sprintf("%d.%s", last+1, id))
+	}
+
+	tmp := filepath.Join(s.membersDir(), "."+id.String()+".tmp")
+	if err := os.WriteFile(tmp, []byte(config), fileutil.PrivateFileMode); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, fn); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	s.lg.Info(
+		"register member itself successfully",
+		zap.String("path", fn),
+		zap.String("memberInfo", config),
+	)
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3discovery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+func newTestDirConfig(t *testing.T, size string) *DiscoveryConfig {
+	t.Helper()
+	cfg := &DiscoveryConfig{
+		ConfigSpec: clientv3.ConfigSpec{RequestTimeout: time.Second},
+		Token:      "fakeToken",
+		Dir:        t.TempDir(),
+	}
+	if size != "" {
+		sizeFile := filepath.Join(cfg.Dir, cfg.Token, "_config", "size")
+		require.NoError(t, os.MkdirAll(filepath.Dir(sizeFile), 0o700))
+		require.NoError(t, os.WriteFile(sizeFile, []byte(size), 0o600))
+	}
+	return cfg
+}
+
+func TestDirSourceJoin(t *testing.T) {
+	defer func(interval time.Duration) { dirPollInterval = interval }(dirPollInterval)
+	dirPollInterval = 10 * time.Millisecond
+
+	cfg := newTestDirConfig(t, "3")
+
+	var wg sync.WaitGroup
+	clusters := make([]string, 3)
+	errs := make([]error, 3)
+	for i := range clusters {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			config := fmt.Sprintf("infra%d=http://127.0.0.1:%d2380", i, i)
+			clusters[i], errs[i] = JoinCluster(zaptest.NewLogger(t), cfg, types.ID(i+1), config)
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range clusters {
+		require.NoError(t, errs[i])
+		assert.Equal(t, clusters[0], clusters[i])
+	}
+	urlsmap, err := types.NewURLsMap(clusters[0])
+	require.NoError(t, err)
+	assert.Len(t, urlsmap, 3)
+}
+
+func TestDirSourceErrors(t *testing.T) {
+	cases := []struct {
+		name        string
+		size        string
+		expectedErr error
+	}{
+		{
+			name:        "cluster size not defined",
+			expectedErr: ErrSizeNotFound,
+		},
+		{
+			name:        "invalid cluster size",
+			size:        "invalidSize",
+			expectedErr: ErrBadSizeKey,
+		},
+		{
+			name:        "cluster is full",
+			size:        "1",
+			expectedErr: ErrFullCluster,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestDirConfig(t, tc.size)
+			s := newDirSource(zaptest.NewLogger(t), cfg)
+			require.NoError(t, s.register(types.ID(1), "infra1=http://127.0.0.1:2380"))
+
+			_, err := s.Join(t.Context(), types.ID(2), "infra2=http://127.0.0.1:22380")
+			require.ErrorIs(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestDirSourceReRegister(t *testing.T) {
+	cfg := newTestDirConfig(t, "2")
+	s := newDirSource(zaptest.NewLogger(t), cfg)
+
+	require.NoError(t, s.register(types.ID(1), "infra1=http://127.0.0.1:2380"))
+	require.NoError(t, s.register(types.ID(2), "infra2=http://127.0.0.1:22380"))
+	// the members are ordered by registration, whatever the times of their files.
+	second, err := s.memberFile(types.ID(2))
+	require.NoError(t, err)
+	past := time.Now().Add(-time.Minute)
+	require.NoError(t, os.Chtimes(second, past, past))
+
+	// registering again with the same config keeps the position of the member.
+	require.NoError(t, s.register(types.ID(1), "infra1=http://127.0.0.1:2380"))
+	cls, err := s.getClusterMembers()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"infra1=http://127.0.0.1:2380", "infra2=http://127.0.0.1:22380"}, cls.getPeerURLs())
+
+	// registering with another config replaces the registration in place.
+	require.NoError(t, s.register(types.ID(1), "infra1=http://127.0.0.1:12380"))
+	cls, err = s.getClusterMembers()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"infra1=http://127.0.0.1:12380", "infra2=http://127.0.0.1:22380"}, cls.getPeerURLs())
+}
+
+func TestDirSourceUnregister(t *testing.T) {
+	cases := []struct {
+		name string
+		size string
+		kept bool
+	}{
+		{
+			name: "cluster is not full",
+			size: "3",
+		},
+		{
+			name: "cluster is full",
+			size: "2",
+			kept: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := newTestDirConfig(t, tc.size)
+			s := newDirSource(zaptest.NewLogger(t), cfg)
+			require.NoError(t, s.register(types.ID(1), "infra1=http://127.0.0.1:2380"))
+			require.NoError(t, s.register(types.ID(2), "infra2=http://127.0.0.1:22380"))
+
+			require.NoError(t, s.Unregister(t.Context(), types.ID(1)))
+			fn, err := s.memberFile(types.ID(1))
+			require.NoError(t, err)
+			assert.Equal(t, tc.kept, fn != "")
+		})
+	}
+}
+
+func TestJoinClusterTimeoutUnregisters(t *testing.T) {
+	defer func(interval time.Duration) { dirPollInterval = interval }(dirPollInterval)
+	dirPollInterval = 10 * time.Millisecond
+
+	cfg := newTestDirConfig(t, "2")
+	cfg.Timeout = 100 * time.Millisecond
+
+	_, err := JoinCluster(zaptest.NewLogger(t), cfg, types.ID(1), "infra1=http://127.0.0.1:2380")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	entries, err := os.ReadDir(filepath.Join(cfg.Dir, cfg.Token, "members"))
+	require.NoError(t, err)
+	for _, e := range entries {
+		assert.False(t, strings.HasSuffix(e.Name(), types.ID(1).String()), "registration %s was not removed", e.Name())
+	}
+}
@@ -54,6 +54,21 @@ var (
 type DiscoveryConfig struct {
 	clientv3.ConfigSpec `json:"client"`
 	Token               string `json:"token"`
+	// Dir is a directory shared by the members, used as the discovery
+	// source instead of a discovery service.
+	Dir string `json:"dir"`
+	// TokenTTL is the time to live of the member registrations in the
+	// discovery service. Registrations are kept alive until the discovery
+	// ends. Zero keeps them forever.
+	TokenTTL time.Duration `json:"token-ttl"`
+	// Timeout bounds the time to wait for all the members to register.
+	// Zero waits forever.
+	Timeout time.Duration `json:"timeout"`
+}
+
+// Enabled returns true if a discovery source is configured.
+func (c *DiscoveryConfig) Enabled() bool {
+	return len(c.Endpoints) > 0 || c.Dir != ""
 }
 
 type memberInfo struct {
@@ -64,7 +79,8 @@ type memberInfo struct {
 	// peerURLsMap format: "peerName=peerURLs", i.e., "member1=http://127.0.0.1:2380".
 	peerURLsMap string
 	// createRev is the member's CreateRevision in the etcd cluster backing
-	// the discovery service.
+	// the discovery service, or the sequence number of its registration in
+	// a discovery directory.
 	createRev int64
 }
 
@@ -117,7 +133,7 @@ func GetCluster(lg *zap.Logger, cfg *DiscoveryConfig) (cs string, rerr error) {
 		}
 	}()
 
-	return d.getCluster()
+	return d.getCluster(context.Background())
 }
 
 // JoinCluster will connect to the discovery service at the endpoints, and
@@ -127,37 +143,68 @@ func GetCluster(lg *zap.Logger, cfg *DiscoveryConfig) (cs string, rerr error) {
 //
 // The final returned string has the same format as "--initial-cluster", such as
 // "infra1=http://127.0.0.1:12380,infra2=http://127.0.0.1:22380,infra3=http://127.0.0.1:32380".
+//
+// The discovery source is the discovery service at cfg.Endpoints, or the
+// directory cfg.Dir. If joining fails or times out before the cluster is full,
+// the registration of the server is removed, so that it does not count against
+// the cluster size. Once the cluster is full, the first registrations are kept
+// even if they were attached to a lease.
 func JoinCluster(lg *zap.Logger, cfg *DiscoveryConfig, id types.ID, config string) (cs string, rerr error) {
-	d, err := newDiscovery(lg, cfg, id)
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	s, err := newSource(lg, cfg, id)
 	if err != nil {
 		return "", err
 	}
+	defer s.Close()
+
+	ctx, cancel := context.Background(), context.CancelFunc(func() {})
+	if cfg.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, cfg.Timeout)
+	}
+	defer cancel()
 
-	defer d.close()
 	defer func() {
 		if rerr != nil {
-			d.lg.Error(
+			lg.Error(
 				"discovery failed to join cluster",
 				zap.String("cluster", cs),
 				zap.Error(rerr),
 			)
+			uctx, ucancel := context.WithTimeout(context.Background(), cfg.RequestTimeout)
+			defer ucancel()
+			if err := s.Unregister(uctx, id); err != nil {
+				lg.Warn("failed to remove member registration", zap.Error(err))
+			}
 		} else {
-			d.lg.Info(
+			lg.Info(
 				"discovery joined cluster successfully",
 				zap.String("cluster", cs),
 			)
 		}
 	}()
 
-	return d.joinCluster(config)
+	return s.Join(ctx, id, config)
 }
 
+func newSource(lg *zap.Logger, cfg *DiscoveryConfig, id types.ID) (Source, error) {
+	if cfg.Dir != "" {
+		return newDirSource(lg, cfg), nil
+	}
+	return newDiscovery(lg, cfg, id)
+}
+
+// discovery is the Source backed by an etcd discovery service.
 type discovery struct {
 	lg           *zap.Logger
 	clusterToken string
 	memberID     types.ID
 	c            *clientv3.Client
 	retries      uint
+	// leaseID is the lease of the registration of the member, if
+	// cfg.TokenTTL is set.
+	leaseID clientv3.LeaseID
 
 	cfg *DiscoveryConfig
 
@@ -189,8 +236,10 @@ func newDiscovery(lg *zap.Logger, dcfg *DiscoveryConfig, id types.ID) (*discover
 	}, nil
 }
 
-func (d *discovery) getCluster() (string, error) {
-	cls, clusterSize, rev, err := d.checkCluster()
+var _ Source = (*discovery)(nil)
+
+func (d *discovery) getCluster(ctx context.Context) (string, error) {
+	cls, clusterSize, rev, err := d.checkCluster(ctx)
 	if err != nil {
 		if errors.Is(err, ErrFullCluster) {
 			return cls.getInitClusterStr(clusterSize)
@@ -199,37 +248,125 @@ func (d *discovery) getCluster() (string, error) {
 	}
 
 	for cls.Len() < clusterSize {
-		d.waitPeers(cls, clusterSize, rev)
+		if err := d.waitPeers(ctx, cls, clusterSize, rev); err != nil {
+			return "", err
+		}
 	}
 
 	return cls.getInitClusterStr(clusterSize)
 }
 
-func (d *discovery) joinCluster(config string) (string, error) {
-	_, _, _, err := d.checkCluster()
-	if err != nil {
+// Join registers the member in the discovery service and waits for the
+// other members.
+func (d *discovery) Join(ctx context.Context, id types.ID, config string) (string, error) {
+	d.memberID = id
+	if _, _, _, err := d.checkCluster(ctx); err != nil {
 		return "", err
 	}
 
-	if err = d.registerSelf(config); err != nil {
-		return "", err
+	for {
+		if err := d.registerSelf(ctx, config); err != nil {
+			return "", err
+		}
+
+		cls, clusterSize, rev, err := d.checkCluster(ctx)
+		if err != nil {
+			return "", err
+		}
+
+		for cls.Len() < clusterSize {
+			if err := d.waitPeers(ctx, cls, clusterSize, rev); err != nil {
+				return "", err
+			}
+		}
+
+		sealed, err := d.seal(ctx, cls, clusterSize)
+		if err != nil {
+			return "", err
+		}
+		if sealed {
+			return cls.getInitClusterStr(clusterSize)
+		}
+		d.lg.Warn("registration of a peer expired before the cluster was sealed, checking the cluster again")
+	}
+}
+
+// seal detaches the first clusterSize registrations from their leases, so
+// that they outlive the discovery and stay the members of the cluster. It
+// returns false if one of them expired meanwhile.
+func (d *discovery) seal(ctx context.Context, cls *clusterInfo, clusterSize int) (bool, error) {
+	var (
+		cmps []clientv3.Cmp
+		puts []clientv3.Op
+	)
+	for _, m := range cls.members[:clusterSize] {
+		cmps = append(cmps, clientv3.Compare(clientv3.CreateRevision(m.peerRegKey), "=", m.createRev))
+		puts = append(puts, clientv3.OpPut(m.peerRegKey, m.peerURLsMap))
 	}
 
-	cls, clusterSize, rev, err := d.checkCluster()
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
+	defer cancel()
+	resp, err := d.c.Txn(ctx).If(cmps...).Then(puts...).Commit()
 	if err != nil {
-		return "", err
+		return false, err
 	}
+	return resp.Succeeded, nil
+}
 
-	for cls.Len() < clusterSize {
-		d.waitPeers(cls, clusterSize, rev)
+// Unregister deletes the registration of the member from the discovery
+// service, and revokes its lease if any. Once the cluster is full, the other
+// members may have bootstrapped with the member, so the registration is kept
+// if it is among the first clusterSize ones.
+func (d *discovery) Unregister(ctx context.Context, id types.ID) error {
+	memberKey := getMemberKey(d.clusterToken, id.String())
+	membersKeyPrefix := getMemberKeyPrefix(d.clusterToken)
+	for {
+		clusterSize, err := d.getClusterSize(ctx)
+		if err != nil {
+			return err
+		}
+		cls, rev, err := d.getClusterMembers(ctx)
+		if err != nil {
+			return err
+		}
+		if cls.Len() >= clusterSize && cls.inCluster(clusterSize, memberKey) {
+			d.lg.Warn("kept member registration of a full cluster", zap.String("memberKey", memberKey))
+			return nil
+		}
+
+		// delete the registration unless a member registered meanwhile.
+		tctx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
+		resp, err := d.c.Txn(tctx).
+			If(clientv3.Compare(clientv3.ModRevision(membersKeyPrefix), "<", rev+1).WithPrefix()).
+			Then(clientv3.OpDelete(memberKey)).
+			Commit()
+		cancel()
+		if err != nil {
+			return err
+		}
+		if resp.Succeeded {
+			break
+		}
 	}
+	d.lg.Info("removed member registration", zap.String("memberKey", memberKey))
+	if d.leaseID != clientv3.NoLease {
+		if _, err := d.c.Revoke(ctx, d.leaseID); err != nil {
+			return err
+		}
+		d.leaseID = clientv3.NoLease
+	}
+	return nil
+}
 
-	return cls.getInitClusterStr(clusterSize)
+// Close closes the client of the discovery service, which stops keeping the
+// registration lease alive.
+func (d *discovery) Close() error {
+	return d.close()
 }
 
-func (d *discovery) getClusterSize() (int, error) {
+func (d *discovery) getClusterSize(ctx context.Context) (int, error) {
 	configKey := getClusterSizeKey(d.clusterToken)
-	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.RequestTimeout)
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
 	defer cancel()
 
 	resp, err := d.c.Get(ctx, configKey)
@@ -254,9 +391,9 @@ func (d *discovery) getClusterSize() (int, error) {
 	return int(clusterSize), nil
 }
 
-func (d *discovery) getClusterMembers() (*clusterInfo, int64, error) {
+func (d *discovery) getClusterMembers(ctx context.Context) (*clusterInfo, int64, error) {
 	membersKeyPrefix := getMemberKeyPrefix(d.clusterToken)
-	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.RequestTimeout)
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
 	defer cancel()
 
 	resp, err := d.c.Get(ctx, membersKeyPrefix, clientv3.WithPrefix())
@@ -292,66 +429,55 @@ func (d *discovery) getClusterMembers() (*clusterInfo, int64, error) {
 	return cls, resp.Header.Revision, nil
 }
 
-func (d *discovery) checkClusterRetry() (*clusterInfo, int, int64, error) {
+func (d *discovery) checkClusterRetry(ctx context.Context) (*clusterInfo, int, int64, error) {
 	if d.retries < nRetries {
-		d.logAndBackoffForRetry("cluster status check")
-		return d.checkCluster()
+		if err := d.logAndBackoffForRetry(ctx, "cluster status check"); err != nil {
+			return nil, 0, 0, err
+		}
+		return d.checkCluster(ctx)
 	}
 	return nil, 0, 0, ErrTooManyRetries
 }
 
-func (d *discovery) checkCluster() (*clusterInfo, int, int64, error) {
-	clusterSize, err := d.getClusterSize()
+func (d *discovery) checkCluster(ctx context.Context) (*clusterInfo, int, int64, error) {
+	clusterSize, err := d.getClusterSize(ctx)
 	if err != nil {
 		if errors.Is(err, ErrSizeNotFound) || errors.Is(err, ErrBadSizeKey) {
 			return nil, 0, 0, err
 		}
 
-		return d.checkClusterRetry()
+		return d.checkClusterRetry(ctx)
 	}
 
-	cls, rev, err := d.getClusterMembers()
+	cls, rev, err := d.getClusterMembers(ctx)
 	if err != nil {
-		return d.checkClusterRetry()
+		return d.checkClusterRetry(ctx)
 	}
 	d.retries = 0
 
-	// find self position
-	memberSelfID := getMemberKey(d.clusterToken, d.memberID.String())
-	idx := 0
-	for _, m := range cls.members {
-		if m.peerRegKey == memberSelfID {
-			break
-		}
-		if idx >= clusterSize-1 {
-			return cls, clusterSize, rev, ErrFullCluster
-		}
-		idx++
-	}
-	return cls, clusterSize, rev, nil
+	return cls, clusterSize, rev, cls.checkFull(clusterSize, getMemberKey(d.clusterToken, d.memberID.String()))
 }
 
-func (d *discovery) registerSelfRetry(contents string) error {
+func (d *discovery) registerSelfRetry(ctx context.Context, contents string) error {
 	if d.retries < nRetries {
-		d.logAndBackoffForRetry("register member itself")
-		return d.registerSelf(contents)
+		if err := d.logAndBackoffForRetry(ctx, "register member itself"); err != nil {
+			return err
+		}
+		return d.registerSelf(ctx, contents)
 	}
 	return ErrTooManyRetries
 }
 
-func (d *discovery) registerSelf(contents string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), d.cfg.RequestTimeout)
+func (d *discovery) registerSelf(ctx context.Context, contents string) error {
 	memberKey := getMemberKey(d.clusterToken, d.memberID.String())
-	_, err := d.c.Put(ctx, memberKey, contents)
-	cancel()
-
+	err := d.putSelf(ctx, memberKey, contents)
 	if err != nil {
 		d.lg.Warn(
 			"failed to register members itself to the discovery service",
 			zap.String("memberKey", memberKey),
 			zap.Error(err),
 		)
-		return d.registerSelfRetry(contents)
+		return d.registerSelfRetry(ctx, contents)
 	}
 	d.retries = 0
 
@@ -364,10 +490,46 @@ func (d *discovery) registerSelf(contents string) error {
 	return nil
 }
 
-func (d *discovery) waitPeers(cls *clusterInfo, clusterSize int, rev int64) {
+// putSelf puts the registration of the member, attached to a lease kept alive
+// until the discovery ends if cfg.TokenTTL is set. A member registering again
+// overwrites its previous registration.
+func (d *discovery) putSelf(ctx context.Context, memberKey, contents string) error {
+	ctx, cancel := context.WithTimeout(ctx, d.cfg.RequestTimeout)
+	defer cancel()
+
+	if d.cfg.TokenTTL <= 0 {
+		_, err := d.c.Put(ctx, memberKey, contents)
+		return err
+	}
+
+	if d.leaseID == clientv3.NoLease {
+		ttl := int64(math.Ceil(d.cfg.TokenTTL.Seconds()))
+		resp, err := d.c.Grant(ctx, ttl)
+		if err != nil {
+			return err
+		}
+		// the keep alive stops when the client of the discovery service is
+		// closed, after which the registration expires.
+		ch, err := d.c.KeepAlive(context.Background(), resp.ID)
+		if err != nil {
+			return err
+		}
+		go func() {
+			for range ch {
+			}
+		}()
+		d.leaseID = resp.ID
+	}
+	_, err := d.c.Put(ctx, memberKey, contents, clientv3.WithLease(d.leaseID))
+	return err
+}
+
+func (d *discovery) waitPeers(ctx context.Context, cls *clusterInfo, clusterSize int, rev int64) error {
 	// watch from the next revision
 	membersKeyPrefix := getMemberKeyPrefix(d.clusterToken)
-	w := d.c.Watch(context.Background(), membersKeyPrefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	w := d.c.Watch(ctx, membersKeyPrefix, clientv3.WithPrefix(), clientv3.WithRev(rev+1))
 
 	d.lg.Info(
 		"waiting for peers from discovery service",
@@ -381,6 +543,18 @@ func (d *discovery) waitPeers(cls *clusterInfo, clusterSize int, rev int64) {
 			mKey := strings.TrimSpace(string(ev.Kv.Key))
 			mValue := strings.TrimSpace(string(ev.Kv.Value))
 
+			if ev.Type == clientv3.EventTypeDelete {
+				// the peer failed to bootstrap and removed its
+				// registration, or the registration expired.
+				if cls.remove(mKey) {
+					d.lg.Info(
+						"peer left discovery service",
+						zap.String("memberKey", mKey),
+					)
+				}
+				continue
+			}
+
 			if err := cls.add(mKey, mValue, ev.Kv.CreateRevision); err != nil {
 				d.lg.Warn(
 					err.Error(),
@@ -401,14 +575,19 @@ func (d *discovery) waitPeers(cls *clusterInfo, clusterSize int, rev int64) {
 		}
 	}
 
+	if cls.Len() < clusterSize {
+		return ctx.Err()
+	}
+
 	d.lg.Info(
 		"found all needed peers from discovery service",
 		zap.Int("clusterSize", clusterSize),
 		zap.Int("found-peers", cls.Len()),
 	)
+	return nil
 }
 
-func (d *discovery) logAndBackoffForRetry(step string) {
+func (d *discovery) logAndBackoffForRetry(ctx context.Context, step string) error {
 	d.retries++
 	// logAndBackoffForRetry stops exponential backoff when the retries are
 	// more than maxExpoentialRetries and is set to a constant backoff afterward.
@@ -422,7 +601,12 @@ func (d *discovery) logAndBackoffForRetry(step string) {
 		zap.String("reason", step),
 		zap.Duration("backoff", retryTimeInSecond),
 	)
-	d.clock.Sleep(retryTimeInSecond)
+	select {
+	case <-d.clock.After(retryTimeInSecond):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (d *discovery) close() error {
@@ -474,6 +658,48 @@ func (cls *clusterInfo) add(memberKey, memberValue string, rev int64) error {
 	return nil
 }
 
+// remove removes the member registered with the given key, and returns true
+// if it was found.
+func (cls *clusterInfo) remove(mKey string) bool {
+	for i, m := range cls.members {
+		if mKey == m.peerRegKey {
+			cls.members = append(cls.members[:i], cls.members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// checkFull returns ErrFullCluster if the member registered with the given
+// key is not among the first clusterSize registered members.
+func (cls *clusterInfo) checkFull(clusterSize int, selfKey string) error {
+	idx := 0
+	for _, m := range cls.members {
+		if m.peerRegKey == selfKey {
+			break
+		}
+		if idx >= clusterSize-1 {
+			return ErrFullCluster
+		}
+		idx++
+	}
+	return nil
+}
+
+// inCluster returns true if the member registered with the given key is among
+// the first clusterSize registered members.
+func (cls *clusterInfo) inCluster(clusterSize int, mKey string) bool {
+	for i, m := range cls.members {
+		if i >= clusterSize {
+			break
+		}
+		if m.peerRegKey == mKey {
+			return true
+		}
+	}
+	return false
+}
+
 func (cls *clusterInfo) exist(mKey string) bool {
 	// Usually there are just a couple of members, so performance shouldn't be a problem.
 	for _, m := range cls.members {
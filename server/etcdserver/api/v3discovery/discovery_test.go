@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/jonboulle/clockwork"
 	"go.uber.org/zap/zaptest"
@@ -91,7 +93,7 @@ func TestGetClusterSize(t *testing.T) {
 				clusterToken: "fakeToken",
 			}
 
-			if cs, err := d.getClusterSize(); !errors.Is(err, tc.expectedErr) {
+			if cs, err := d.getClusterSize(t.Context()); !errors.Is(err, tc.expectedErr) {
 				t.Errorf("Unexpected error, expected: %v got: %v", tc.expectedErr, err)
 			} else {
 				if err == nil && cs != tc.expectedSize {
@@ -203,7 +205,7 @@ func TestGetClusterMembers(t *testing.T) {
 		clusterToken: "fakeToken",
 	}
 
-	clsInfo, _, err := d.getClusterMembers()
+	clsInfo, _, err := d.getClusterMembers(t.Context())
 	if err != nil {
 		t.Errorf("Failed to get cluster members, error: %v", err)
 	}
@@ -386,7 +388,7 @@ func TestCheckCluster(t *testing.T) {
 				clock:        clockwork.NewRealClock(),
 			}
 
-			clsInfo, _, _, err := d.checkCluster()
+			clsInfo, _, _, err := d.checkCluster(t.Context())
 			if !errors.Is(err, tc.expectedError) {
 				t.Errorf("Unexpected error, expected: %v, got: %v", tc.expectedError, err)
 			}
@@ -495,7 +497,7 @@ func TestRegisterSelf(t *testing.T) {
 				clock: clockwork.NewRealClock(),
 			}
 
-			if err := d.registerSelf(tc.expectedRegValue); err != nil {
+			if err := d.registerSelf(t.Context(), tc.expectedRegValue); err != nil {
 				t.Errorf("Error occuring on register member self: %v", err)
 			}
 
@@ -618,7 +620,7 @@ func TestWaitPeers(t *testing.T) {
 		clusterToken: "fakeToken",
 	}
 
-	d.waitPeers(&cls, 3, 0)
+	d.waitPeers(t.Context(), &cls, 3, 0)
 
 	if cls.Len() != len(expectedMemberInfo) {
 		t.Errorf("unexpected member number returned by watch, expected: %d, got: %d", len(expectedMemberInfo), cls.Len())
@@ -631,6 +633,227 @@ func TestWaitPeers(t *testing.T) {
 	}
 }
 
+// fakeWatcherForPeerLeft is used to test waitPeers with peers removing their
+// registration.
+type fakeWatcherForPeerLeft struct {
+	*fakeBaseWatcher
+	events []*clientv3.Event
+}
+
+func (fw *fakeWatcherForPeerLeft) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	ch := make(chan clientv3.WatchResponse, len(fw.events))
+	for _, ev := range fw.events {
+		ch <- clientv3.WatchResponse{Events: []*clientv3.Event{ev}}
+	}
+	close(ch)
+	return ch
+}
+
+func TestWaitPeersPeerLeft(t *testing.T) {
+	put := func(id uint64, rev int64) *clientv3.Event {
+		return &clientv3.Event{
+			Type: clientv3.EventTypePut,
+			Kv: &mvccpb.KeyValue{
+				Key:            []byte("/_etcd/registry/fakeToken/members/" + types.ID(id).String()),
+				Value:          []byte(fmt.Sprintf("infra%d=http://192.168.0.%d:2380", id, id)),
+				CreateRevision: rev,
+			},
+		}
+	}
+	del := func(id uint64) *clientv3.Event {
+		return &clientv3.Event{
+			Type: clientv3.EventTypeDelete,
+			Kv:   &mvccpb.KeyValue{Key: []byte("/_etcd/registry/fakeToken/members/" + types.ID(id).String())},
+		}
+	}
+
+	d := &discovery{
+		lg: zaptest.NewLogger(t),
+		c: &clientv3.Client{
+			KV: &fakeBaseKV{},
+			Watcher: &fakeWatcherForPeerLeft{
+				fakeBaseWatcher: &fakeBaseWatcher{},
+				events:          []*clientv3.Event{put(101, 2), put(102, 3), del(101), put(103, 5), put(104, 6)},
+			},
+		},
+		cfg:          &DiscoveryConfig{},
+		clusterToken: "fakeToken",
+	}
+
+	cls := clusterInfo{clusterToken: "fakeToken"}
+	if err := d.waitPeers(t.Context(), &cls, 3, 0); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"infra102=http://192.168.0.102:2380",
+		"infra103=http://192.168.0.103:2380",
+		"infra104=http://192.168.0.104:2380",
+	}
+	if got := cls.getPeerURLs(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("unexpected peers, expected: %v, got: %v", expected, got)
+	}
+}
+
+// fakeKVForUnregister is used to test Unregister and seal.
+type fakeKVForUnregister struct {
+	*fakeBaseKV
+	clusterSize string
+	members     []memberInfo
+	txns        []*fakeTxn
+}
+
+func (fkv *fakeKVForUnregister) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	if key == getClusterSizeKey("fakeToken") {
+		return &clientv3.GetResponse{Kvs: []*mvccpb.KeyValue{{Value: []byte(fkv.clusterSize)}}}, nil
+	}
+	resp := &clientv3.GetResponse{Header: &etcdserverpb.ResponseHeader{Revision: 10}}
+	for _, m := range fkv.members {
+		resp.Kvs = append(resp.Kvs, &mvccpb.KeyValue{
+			Key:            []byte(m.peerRegKey),
+			Value:          []byte(m.peerURLsMap),
+			CreateRevision: m.createRev,
+		})
+	}
+	return resp, nil
+}
+
+func (fkv *fakeKVForUnregister) Txn(ctx context.Context) clientv3.Txn {
+	txn := &fakeTxn{}
+	fkv.txns = append(fkv.txns, txn)
+	return txn
+}
+
+// fakeTxn records the compares and operations of a txn, which succeeds.
+type fakeTxn struct {
+	cmps []clientv3.Cmp
+	ops  []clientv3.Op
+}
+
+func (txn *fakeTxn) If(cs ...clientv3.Cmp) clientv3.Txn {
+	txn.cmps = append(txn.cmps, cs...)
+	return txn
+}
+
+func (txn *fakeTxn) Then(ops ...clientv3.Op) clientv3.Txn {
+	txn.ops = append(txn.ops, ops...)
+	return txn
+}
+
+func (txn *fakeTxn) Else(ops ...clientv3.Op) clientv3.Txn { return txn }
+
+func (txn *fakeTxn) Commit() (*clientv3.TxnResponse, error) {
+	return &clientv3.TxnResponse{Succeeded: true}, nil
+}
+
+func TestUnregister(t *testing.T) {
+	members := []memberInfo{
+		{
+			peerRegKey:  "/_etcd/registry/fakeToken/members/" + types.ID(101).String(),
+			peerURLsMap: "infra1=http://192.168.0.100:2380",
+			createRev:   5,
+		},
+		{
+			peerRegKey:  "/_etcd/registry/fakeToken/members/" + types.ID(102).String(),
+			peerURLsMap: "infra2=http://192.168.0.102:2380",
+			createRev:   6,
+		},
+	}
+
+	cases := []struct {
+		name        string
+		clusterSize string
+		memberID    types.ID
+		deleted     bool
+	}{
+		{
+			name:        "cluster is not full",
+			clusterSize: "3",
+			memberID:    101,
+			deleted:     true,
+		},
+		{
+			name:        "cluster is full",
+			clusterSize: "2",
+			memberID:    101,
+		},
+		{
+			name:        "cluster is full without the member",
+			clusterSize: "1",
+			memberID:    102,
+			deleted:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fkv := &fakeKVForUnregister{fakeBaseKV: &fakeBaseKV{}, clusterSize: tc.clusterSize, members: members}
+			d := &discovery{
+				lg:           zaptest.NewLogger(t),
+				c:            &clientv3.Client{KV: fkv},
+				cfg:          &DiscoveryConfig{},
+				clusterToken: "fakeToken",
+			}
+
+			if err := d.Unregister(t.Context(), tc.memberID); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.deleted {
+				if len(fkv.txns) != 0 {
+					t.Fatalf("unexpected txns: %v", fkv.txns)
+				}
+				return
+			}
+			if len(fkv.txns) != 1 || len(fkv.txns[0].ops) != 1 {
+				t.Fatalf("expected a single txn deleting the registration, got %v", fkv.txns)
+			}
+			op := fkv.txns[0].ops[0]
+			if key := getMemberKey("fakeToken", tc.memberID.String()); !op.IsDelete() || string(op.KeyBytes()) != key {
+				t.Errorf("expected deletion of %q, got %v", key, op)
+			}
+			// the deletion is guarded against new registrations.
+			if cmps := fkv.txns[0].cmps; len(cmps) != 1 || cmps[0].Target != etcdserverpb.Compare_MOD || string(cmps[0].RangeEnd) == "" {
+				t.Errorf("unexpected compares: %v", cmps)
+			}
+		})
+	}
+}
+
+func TestSeal(t *testing.T) {
+	cls := &clusterInfo{clusterToken: "fakeToken"}
+	for i, id := range []uint64{101, 102, 103} {
+		key := "/_etcd/registry/fakeToken/members/" + types.ID(id).String()
+		if err := cls.add(key, fmt.Sprintf("infra%d=http://192.168.0.%d:2380", i, id), int64(i+5)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fkv := &fakeKVForUnregister{fakeBaseKV: &fakeBaseKV{}}
+	d := &discovery{
+		lg:           zaptest.NewLogger(t),
+		c:            &clientv3.Client{KV: fkv},
+		cfg:          &DiscoveryConfig{},
+		clusterToken: "fakeToken",
+	}
+	sealed, err := d.seal(t.Context(), cls, 2)
+	if err != nil || !sealed {
+		t.Fatalf("seal = %v, %v", sealed, err)
+	}
+
+	txn := fkv.txns[0]
+	if len(txn.cmps) != 2 || len(txn.ops) != 2 {
+		t.Fatalf("expected the first 2 registrations to be sealed, got %v", txn)
+	}
+	for i, m := range cls.members[:2] {
+		if cmp := txn.cmps[i]; string(cmp.KeyBytes()) != m.peerRegKey || cmp.Target != etcdserverpb.Compare_CREATE {
+			t.Errorf("unexpected compare %v for %q", cmp, m.peerRegKey)
+		}
+		if op := txn.ops[i]; !op.IsPut() || string(op.KeyBytes()) != m.peerRegKey || string(op.ValueBytes()) != m.peerURLsMap {
+			t.Errorf("unexpected op %v for %q", op, m.peerRegKey)
+		}
+	}
+}
+
 func TestGetInitClusterStr(t *testing.T) {
 	cases := []struct {
 		name           string
@@ -756,6 +979,10 @@ func (fkv *fakeBaseKV) Compact(ctx context.Context, rev int64, opts ...clientv3.
 	return nil, nil
 }
 
+func (fkv *fakeBaseKV) RevisionAt(ctx context.Context, t time.Time, opts ...clientv3.OpOption) (*clientv3.RevisionAtResponse, error) {
+	return nil, nil
+}
+
 func (fkv *fakeBaseKV) Do(ctx context.Context, op clientv3.Op) (clientv3.OpResponse, error) {
 	return clientv3.OpResponse{}, nil
 }
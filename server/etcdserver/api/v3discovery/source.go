@@ -0,0 +1,35 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3discovery
+
+import (
+	"context"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+// Source is a discovery source, through which the members of a cluster being
+// bootstrapped find each other.
+type Source interface {
+	// Join registers the member represented by the given id and config, in
+	// the format "memberName=peerURLs", and waits for the other members. It
+	// returns the initial cluster in the same format as "--initial-cluster".
+	Join(ctx context.Context, id types.ID, config string) (string, error)
+	// Unregister removes the registration of the member with the given id.
+	// Removing a registration that does not exist is not an error.
+	Unregister(ctx context.Context, id types.ID) error
+	// Close releases the resources held by the source.
+	Close() error
+}
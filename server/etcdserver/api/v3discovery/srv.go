@@ -0,0 +1,112 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v3discovery
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/srv"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+)
+
+// SRVSource is the Source backed by the DNS SRV records of a domain. The
+// records are provisioned beforehand, so members do not register themselves
+// and Unregister does nothing.
+type SRVSource struct {
+	Logger *zap.Logger
+	// Name is the name of the local member.
+	Name string
+	// Domain is the domain to query the SRV records of.
+	Domain string
+	// ServiceName is an optional suffix of the queried services.
+	ServiceName string
+	// AdvertisePeerURLs are the advertised peer URLs of the local member.
+	AdvertisePeerURLs types.URLs
+	// GetCluster looks up the cluster from the SRV records of a service.
+	// It defaults to srv.GetCluster.
+	GetCluster func(serviceScheme, service, name, dns string, apurls types.URLs) ([]string, error)
+}
+
+var _ Source = (*SRVSource)(nil)
+
+// Lookup returns the members found in the SRV records of both the
+// etcd-server-ssl and the etcd-server services, along with any errors
+// encountered. Because it checks both services, the lookup should only be
+// considered to have failed if the returned list is empty.
+func (s *SRVSource) Lookup() ([]string, error) {
+	var serviceNameSuffix string
+	if s.ServiceName != "" {
+		serviceNameSuffix = "-" + s.ServiceName
+	}
+	lg := s.Logger
+	if lg == nil {
+		lg = zap.NewNop()
+	}
+	getCluster := s.GetCluster
+	if getCluster == nil {
+		getCluster = srv.GetCluster
+	}
+
+	// Use both etcd-server-ssl and etcd-server for discovery.
+	// Combine the results if both are available.
+	clusterStrs, cerr := getCluster("https", "etcd-server-ssl"+serviceNameSuffix, s.Name, s.Domain, s.AdvertisePeerURLs)
+	if cerr != nil {
+		clusterStrs = make([]string, 0)
+	}
+	lg.Info(
+		"get cluster for etcd-server-ssl SRV",
+		zap.String("service-scheme", "https"),
+		zap.String("service-name", "etcd-server-ssl"+serviceNameSuffix),
+		zap.String("server-name", s.Name),
+		zap.String("discovery-srv", s.Domain),
+		zap.Strings("advertise-peer-urls", s.AdvertisePeerURLs.StringSlice()),
+		zap.Strings("found-cluster", clusterStrs),
+		zap.Error(cerr),
+	)
+
+	defaultHTTPClusterStrs, httpCerr := getCluster("http", "etcd-server"+serviceNameSuffix, s.Name, s.Domain, s.AdvertisePeerURLs)
+	if httpCerr == nil {
+		clusterStrs = append(clusterStrs, defaultHTTPClusterStrs...)
+	}
+	lg.Info(
+		"get cluster for etcd-server SRV",
+		zap.String("service-scheme", "http"),
+		zap.String("service-name", "etcd-server"+serviceNameSuffix),
+		zap.String("server-name", s.Name),
+		zap.String("discovery-srv", s.Domain),
+		zap.Strings("advertise-peer-urls", s.AdvertisePeerURLs.StringSlice()),
+		zap.Strings("found-cluster", clusterStrs),
+		zap.Error(httpCerr),
+	)
+
+	return clusterStrs, errors.Join(cerr, httpCerr)
+}
+
+// Join looks up the SRV records; the given id and config are not used.
+func (s *SRVSource) Join(_ context.Context, _ types.ID, _ string) (string, error) {
+	clusterStrs, err := s.Lookup()
+	if len(clusterStrs) == 0 {
+		return "", err
+	}
+	return strings.Join(clusterStrs, ","), nil
+}
+
+func (s *SRVSource) Unregister(context.Context, types.ID) error { return nil }
+
+func (s *SRVSource) Close() error { return nil }
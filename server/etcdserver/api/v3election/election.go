@@ -120,6 +120,33 @@ func (es *electionServer) Resign(ctx context.Context, req *epb.ResignRequest) (*
 	return &epb.ResignResponse{Header: e.Header()}, nil
 }
 
+func (es *electionServer) Transfer(ctx context.Context, req *epb.TransferRequest) (*epb.TransferResponse, error) {
+	if req.Leader == nil {
+		return nil, ErrMissingLeaderKey
+	}
+	s, err := es.session(ctx, req.Leader.Lease)
+	if err != nil {
+		return nil, err
+	}
+	e := concurrency.ResumeElection(s, string(req.Leader.Name), string(req.Leader.Key), req.Leader.Rev)
+	if err := e.Transfer(ctx, string(req.Candidate)); err != nil {
+		return nil, err
+	}
+	return &epb.TransferResponse{Header: e.Header()}, nil
+}
+
+func (es *electionServer) Candidates(ctx context.Context, req *epb.CandidatesRequest) (*epb.CandidatesResponse, error) {
+	s, err := es.session(ctx, -1)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := concurrency.NewElection(s, string(req.Name)).Candidates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &epb.CandidatesResponse{Header: resp.Header, Kvs: resp.Kvs}, nil
+}
+
 func (es *electionServer) session(ctx context.Context, lease int64) (*concurrency.Session, error) {
 	s, err := concurrency.NewSession(
 		es.c,
@@ -433,6 +433,110 @@ func (m *ResignResponse) GetHeader() *etcdserverpb.ResponseHeader {
 	return nil
 }
 
+type TransferRequest struct {
+	// leader is the leadership to hand over.
+	Leader *LeaderKey `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
+	// candidate is the key of the campaigner to hand leadership over to.
+	Candidate            []byte   `protobuf:"bytes,2,opt,name=candidate,proto3" json:"candidate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TransferRequest) Reset()         { *m = TransferRequest{} }
+func (m *TransferRequest) String() string { return proto.CompactTextString(m) }
+func (*TransferRequest) ProtoMessage()    {}
+func (*TransferRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c9b1f26cc432a035, []int{7}
+}
+func (m *TransferRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferRequest.Merge(m, src)
+}
+func (m *TransferRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferRequest proto.InternalMessageInfo
+
+func (m *TransferRequest) GetLeader() *LeaderKey {
+	if m != nil {
+		return m.Leader
+	}
+	return nil
+}
+
+func (m *TransferRequest) GetCandidate() []byte {
+	if m != nil {
+		return m.Candidate
+	}
+	return nil
+}
+
+type TransferResponse struct {
+	Header               *etcdserverpb.ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *TransferResponse) Reset()         { *m = TransferResponse{} }
+func (m *TransferResponse) String() string { return proto.CompactTextString(m) }
+func (*TransferResponse) ProtoMessage()    {}
+func (*TransferResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c9b1f26cc432a035, []int{8}
+}
+func (m *TransferResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TransferResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TransferResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TransferResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TransferResponse.Merge(m, src)
+}
+func (m *TransferResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *TransferResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_TransferResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TransferResponse proto.InternalMessageInfo
+
+func (m *TransferResponse) GetHeader() *etcdserverpb.ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
 type ProclaimRequest struct {
 	// leader is the leadership hold on the election.
 	Leader *LeaderKey `protobuf:"bytes,1,opt,name=leader,proto3" json:"leader,omitempty"`
@@ -447,7 +551,7 @@ func (m *ProclaimRequest) Reset()         { *m = ProclaimRequest{} }
 func (m *ProclaimRequest) String() string { return proto.CompactTextString(m) }
 func (*ProclaimRequest) ProtoMessage()    {}
 func (*ProclaimRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c9b1f26cc432a035, []int{7}
+	return fileDescriptor_c9b1f26cc432a035, []int{9}
 }
 func (m *ProclaimRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -501,7 +605,7 @@ func (m *ProclaimResponse) Reset()         { *m = ProclaimResponse{} }
 func (m *ProclaimResponse) String() string { return proto.CompactTextString(m) }
 func (*ProclaimResponse) ProtoMessage()    {}
 func (*ProclaimResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_c9b1f26cc432a035, []int{8}
+	return fileDescriptor_c9b1f26cc432a035, []int{10}
 }
 func (m *ProclaimResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -537,6 +641,110 @@ func (m *ProclaimResponse) GetHeader() *etcdserverpb.ResponseHeader {
 	return nil
 }
 
+type CandidatesRequest struct {
+	// name is the election identifier for the candidates.
+	Name                 []byte   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CandidatesRequest) Reset()         { *m = CandidatesRequest{} }
+func (m *CandidatesRequest) String() string { return proto.CompactTextString(m) }
+func (*CandidatesRequest) ProtoMessage()    {}
+func (*CandidatesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c9b1f26cc432a035, []int{11}
+}
+func (m *CandidatesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CandidatesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CandidatesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CandidatesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandidatesRequest.Merge(m, src)
+}
+func (m *CandidatesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *CandidatesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandidatesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandidatesRequest proto.InternalMessageInfo
+
+func (m *CandidatesRequest) GetName() []byte {
+	if m != nil {
+		return m.Name
+	}
+	return nil
+}
+
+type CandidatesResponse struct {
+	Header *etcdserverpb.ResponseHeader `protobuf:"bytes,1,opt,name=header,proto3" json:"header,omitempty"`
+	// kvs are the campaign key-values of the candidates, in queue order.
+	Kvs                  []*mvccpb.KeyValue `protobuf:"bytes,2,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CandidatesResponse) Reset()         { *m = CandidatesResponse{} }
+func (m *CandidatesResponse) String() string { return proto.CompactTextString(m) }
+func (*CandidatesResponse) ProtoMessage()    {}
+func (*CandidatesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_c9b1f26cc432a035, []int{12}
+}
+func (m *CandidatesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CandidatesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CandidatesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CandidatesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CandidatesResponse.Merge(m, src)
+}
+func (m *CandidatesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *CandidatesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CandidatesResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CandidatesResponse proto.InternalMessageInfo
+
+func (m *CandidatesResponse) GetHeader() *etcdserverpb.ResponseHeader {
+	if m != nil {
+		return m.Header
+	}
+	return nil
+}
+
+func (m *CandidatesResponse) GetKvs() []*mvccpb.KeyValue {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*CampaignRequest)(nil), "v3electionpb.CampaignRequest")
 	proto.RegisterType((*CampaignResponse)(nil), "v3electionpb.CampaignResponse")
@@ -545,49 +753,60 @@ func init() {
 	proto.RegisterType((*LeaderResponse)(nil), "v3electionpb.LeaderResponse")
 	proto.RegisterType((*ResignRequest)(nil), "v3electionpb.ResignRequest")
 	proto.RegisterType((*ResignResponse)(nil), "v3electionpb.ResignResponse")
+	proto.RegisterType((*TransferRequest)(nil), "v3electionpb.TransferRequest")
+	proto.RegisterType((*TransferResponse)(nil), "v3electionpb.TransferResponse")
 	proto.RegisterType((*ProclaimRequest)(nil), "v3electionpb.ProclaimRequest")
 	proto.RegisterType((*ProclaimResponse)(nil), "v3electionpb.ProclaimResponse")
+	proto.RegisterType((*CandidatesRequest)(nil), "v3electionpb.CandidatesRequest")
+	proto.RegisterType((*CandidatesResponse)(nil), "v3electionpb.CandidatesResponse")
 }
 
 func init() { proto.RegisterFile("v3election.proto", fileDescriptor_c9b1f26cc432a035) }
 
 var fileDescriptor_c9b1f26cc432a035 = []byte{
-	// 556 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x94, 0x41, 0x6f, 0xd3, 0x30,
-	0x14, 0xc7, 0x71, 0x5a, 0xca, 0x78, 0x74, 0x5b, 0x15, 0x8a, 0x28, 0xa5, 0x64, 0x95, 0xb9, 0x4c,
-	0x3d, 0xc4, 0x68, 0xe5, 0xd4, 0xd3, 0x04, 0x02, 0x4d, 0x1a, 0x12, 0xe0, 0x03, 0x02, 0x6e, 0x6e,
-	0xf6, 0x94, 0x55, 0x4d, 0xe3, 0x90, 0x74, 0x91, 0x7a, 0xe5, 0x2b, 0x70, 0x80, 0x8f, 0xc4, 0x11,
-	0x89, 0x2f, 0x80, 0x0a, 0x1f, 0x04, 0xd9, 0x4e, 0x9a, 0x34, 0x6a, 0x11, 0x5a, 0x6f, 0x8e, 0xdf,
-	0xdf, 0xef, 0xf7, 0xfe, 0xcf, 0x2f, 0x86, 0x56, 0x3a, 0xc4, 0x00, 0xbd, 0xf9, 0x44, 0x86, 0x6e,
-	0x14, 0xcb, 0xb9, 0xb4, 0x9b, 0xc5, 0x4e, 0x34, 0xee, 0xb6, 0x7d, 0xe9, 0x4b, 0x1d, 0x60, 0x6a,
-	0x65, 0x34, 0xdd, 0x23, 0x9c, 0x7b, 0x17, 0x4c, 0x44, 0x13, 0xa6, 0x16, 0x09, 0xc6, 0x29, 0xc6,
-	0xd1, 0x98, 0xc5, 0x91, 0x97, 0x09, 0x3a, 0x2b, 0xc1, 0x2c, 0xf5, 0xbc, 0x68, 0xcc, 0xa6, 0x69,
-	0x16, 0xe9, 0xf9, 0x52, 0xfa, 0x01, 0xea, 0x98, 0x08, 0x43, 0x39, 0x17, 0x8a, 0x94, 0x98, 0x28,
-	0x7d, 0x0b, 0x87, 0xcf, 0xc5, 0x2c, 0x12, 0x13, 0x3f, 0xe4, 0xf8, 0xe9, 0x0a, 0x93, 0xb9, 0x6d,
-	0x43, 0x3d, 0x14, 0x33, 0xec, 0x90, 0x3e, 0x39, 0x6e, 0x72, 0xbd, 0xb6, 0xdb, 0x70, 0x33, 0x40,
-	0x91, 0x60, 0xc7, 0xea, 0x93, 0xe3, 0x1a, 0x37, 0x1f, 0x6a, 0x37, 0x15, 0xc1, 0x15, 0x76, 0x6a,
-	0x5a, 0x6a, 0x3e, 0xe8, 0x02, 0x5a, 0x45, 0xca, 0x24, 0x92, 0x61, 0x82, 0xf6, 0x53, 0x68, 0x5c,
-	0xa2, 0xb8, 0xc0, 0x58, 0x67, 0xbd, 0x73, 0xd2, 0x73, 0xcb, 0x3e, 0xdc, 0x5c, 0x77, 0xa6, 0x35,
-	0x3c, 0xd3, 0xda, 0x0c, 0x1a, 0x81, 0x39, 0x65, 0xe9, 0x53, 0xf7, 0xdd, 0x72, 0xab, 0xdc, 0x57,
-	0x3a, 0x76, 0x8e, 0x0b, 0x9e, 0xc9, 0xe8, 0x07, 0xb8, 0xbd, 0xda, 0xdc, 0xe8, 0xa3, 0x05, 0xb5,
-	0x29, 0x2e, 0x74, 0xba, 0x26, 0x57, 0x4b, 0xb5, 0x13, 0x63, 0xaa, 0x1d, 0xd4, 0xb8, 0x5a, 0x16,
-	0x5e, 0xeb, 0x25, 0xaf, 0xf4, 0x31, 0xec, 0x9b, 0xd4, 0xff, 0x68, 0x13, 0xbd, 0x84, 0x83, 0x5c,
-	0xb4, 0x93, 0xf1, 0x3e, 0x58, 0xd3, 0x34, 0x33, 0xdd, 0x72, 0xcd, 0x8d, 0xba, 0xe7, 0xb8, 0x78,
-	0xa7, 0x1a, 0xcc, 0xad, 0x69, 0x4a, 0x4f, 0x61, 0x9f, 0x63, 0x52, 0xba, 0xb5, 0xa2, 0x57, 0xe4,
-	0xff, 0x7a, 0xf5, 0x12, 0x0e, 0xf2, 0x0c, 0xbb, 0xd4, 0x4a, 0xdf, 0xc3, 0xe1, 0x9b, 0x58, 0x7a,
-	0x81, 0x98, 0xcc, 0xae, 0x5b, 0x4b, 0x31, 0x48, 0x56, 0x79, 0x90, 0xce, 0xa0, 0x55, 0x64, 0xde,
-	0xa5, 0xc6, 0x93, 0xaf, 0x75, 0xd8, 0x7b, 0x91, 0x15, 0x60, 0x4f, 0x61, 0x2f, 0x9f, 0x4f, 0xfb,
-	0xd1, 0x7a, 0x65, 0x95, 0x5f, 0xa1, 0xeb, 0x6c, 0x0b, 0x1b, 0x0a, 0xed, 0x7f, 0xfe, 0xf9, 0xe7,
-	0x8b, 0xd5, 0xa5, 0xf7, 0x58, 0x3a, 0x64, 0xb9, 0x90, 0x79, 0x99, 0x6c, 0x44, 0x06, 0x0a, 0x96,
-	0x7b, 0xa8, 0xc2, 0x2a, 0x5d, 0xab, 0xc2, 0xaa, 0xd6, 0xb7, 0xc0, 0xa2, 0x4c, 0xa6, 0x60, 0x1e,
-	0x34, 0x4c, 0x6f, 0xed, 0x87, 0x9b, 0x3a, 0x9e, 0x83, 0x7a, 0x9b, 0x83, 0x19, 0xc6, 0xd1, 0x98,
-	0x0e, 0xbd, 0xbb, 0x86, 0x31, 0x17, 0xa5, 0x20, 0x3e, 0xdc, 0x7a, 0x3d, 0xd6, 0x0d, 0xdf, 0x85,
-	0x72, 0xa4, 0x29, 0x0f, 0x68, 0x7b, 0x8d, 0x22, 0x4d, 0xe2, 0x11, 0x19, 0x3c, 0x21, 0xca, 0x8d,
-	0x19, 0xd0, 0x2a, 0x67, 0x6d, 0xf0, 0xab, 0x9c, 0xf5, 0x99, 0xde, 0xe2, 0x26, 0xd6, 0xa2, 0x11,
-	0x19, 0x3c, 0xe3, 0xdf, 0x97, 0x0e, 0xf9, 0xb1, 0x74, 0xc8, 0xaf, 0xa5, 0x43, 0xbe, 0xfd, 0x76,
-	0x6e, 0x7c, 0x3c, 0xf5, 0xa5, 0x9e, 0x29, 0x77, 0x22, 0xf5, 0x63, 0xcb, 0xcc, 0x70, 0xe9, 0xf3,
-	0xab, 0x51, 0xd3, 0xaf, 0x69, 0xc1, 0x65, 0xe5, 0x12, 0xc6, 0x0d, 0xfd, 0xb4, 0x0e, 0xff, 0x06,
-	0x00, 0x00, 0xff, 0xff, 0xcd, 0x58, 0x82, 0xe2, 0xeb, 0x05, 0x00, 0x00,
+	// 667 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xa4, 0x55, 0xcd, 0x6e, 0xd3, 0x40,
+	0x10, 0xc6, 0x4e, 0x1b, 0xda, 0xa1, 0x3f, 0x61, 0x29, 0x6a, 0x08, 0x21, 0xb5, 0x96, 0x03, 0x55,
+	0x0f, 0x36, 0x6a, 0x39, 0xf5, 0x54, 0x51, 0x81, 0x2a, 0x15, 0x09, 0xb0, 0x10, 0x02, 0x4e, 0x6c,
+	0x9c, 0xc5, 0xb5, 0xec, 0x78, 0x8d, 0xed, 0x5a, 0xca, 0x95, 0x57, 0xe0, 0xc2, 0x23, 0x71, 0x44,
+	0xe2, 0x05, 0x50, 0xe0, 0x25, 0xb8, 0xa1, 0xfd, 0x71, 0xec, 0xac, 0x92, 0x0a, 0xf0, 0x6d, 0x3d,
+	0xf3, 0x79, 0xbe, 0x99, 0xf9, 0x66, 0x76, 0xa1, 0x53, 0x1c, 0xd1, 0x88, 0x7a, 0x79, 0xc0, 0x62,
+	0x3b, 0x49, 0x59, 0xce, 0xd0, 0x46, 0x65, 0x49, 0x86, 0xbd, 0x1d, 0x9f, 0xf9, 0x4c, 0x38, 0x1c,
+	0x7e, 0x92, 0x98, 0xde, 0x1e, 0xcd, 0xbd, 0x91, 0x43, 0x92, 0xc0, 0xe1, 0x87, 0x8c, 0xa6, 0x05,
+	0x4d, 0x93, 0xa1, 0x93, 0x26, 0x9e, 0x02, 0x74, 0x67, 0x80, 0x71, 0xe1, 0x79, 0xc9, 0xd0, 0x09,
+	0x0b, 0xe5, 0xe9, 0xfb, 0x8c, 0xf9, 0x11, 0x15, 0x3e, 0x12, 0xc7, 0x2c, 0x27, 0x9c, 0x29, 0x93,
+	0x5e, 0xfc, 0x12, 0xb6, 0x4f, 0xc9, 0x38, 0x21, 0x81, 0x1f, 0xbb, 0xf4, 0xe3, 0x25, 0xcd, 0x72,
+	0x84, 0x60, 0x25, 0x26, 0x63, 0xda, 0x35, 0x2c, 0x63, 0x7f, 0xc3, 0x15, 0x67, 0xb4, 0x03, 0xab,
+	0x11, 0x25, 0x19, 0xed, 0x9a, 0x96, 0xb1, 0xdf, 0x72, 0xe5, 0x07, 0xb7, 0x16, 0x24, 0xba, 0xa4,
+	0xdd, 0x96, 0x80, 0xca, 0x0f, 0x3c, 0x81, 0x4e, 0x15, 0x32, 0x4b, 0x58, 0x9c, 0x51, 0xf4, 0x08,
+	0xda, 0x17, 0x94, 0x8c, 0x68, 0x2a, 0xa2, 0xde, 0x38, 0xec, 0xdb, 0xf5, 0x3a, 0xec, 0x12, 0x77,
+	0x26, 0x30, 0xae, 0xc2, 0x22, 0x07, 0xda, 0x91, 0xfc, 0xcb, 0x14, 0x7f, 0xed, 0xda, 0xf5, 0x56,
+	0xd9, 0xcf, 0x84, 0xef, 0x9c, 0x4e, 0x5c, 0x05, 0xc3, 0x6f, 0x61, 0x7d, 0x66, 0x5c, 0x58, 0x47,
+	0x07, 0x5a, 0x21, 0x9d, 0x88, 0x70, 0x1b, 0x2e, 0x3f, 0x72, 0x4b, 0x4a, 0x0b, 0x51, 0x41, 0xcb,
+	0xe5, 0xc7, 0xaa, 0xd6, 0x95, 0x5a, 0xad, 0xf8, 0x3e, 0x6c, 0xca, 0xd0, 0x57, 0xb4, 0x09, 0x5f,
+	0xc0, 0x56, 0x09, 0x6a, 0x54, 0xb8, 0x05, 0x66, 0x58, 0xa8, 0xa2, 0x3b, 0xb6, 0x54, 0xd4, 0x3e,
+	0xa7, 0x93, 0xd7, 0xbc, 0xc1, 0xae, 0x19, 0x16, 0xf8, 0x04, 0x36, 0x5d, 0x9a, 0xd5, 0x54, 0xab,
+	0x7a, 0x65, 0xfc, 0x5d, 0xaf, 0x9e, 0xc2, 0x56, 0x19, 0xa1, 0x49, 0xae, 0xf8, 0x3d, 0x6c, 0xbf,
+	0x4a, 0x49, 0x9c, 0x7d, 0xa8, 0x5a, 0xf3, 0xaf, 0xb9, 0xa0, 0x3e, 0xac, 0x7b, 0x24, 0x1e, 0x05,
+	0x23, 0x92, 0x53, 0x25, 0x4e, 0x65, 0xc0, 0x67, 0xd0, 0xa9, 0x18, 0x1a, 0xe5, 0xfa, 0x06, 0xb6,
+	0x5f, 0xa4, 0xcc, 0x8b, 0x48, 0x30, 0xfe, 0xef, 0x5c, 0x67, 0x43, 0x6f, 0xd6, 0x87, 0xfe, 0x0c,
+	0x3a, 0x55, 0xe4, 0x46, 0x39, 0x3e, 0x80, 0x9b, 0xa7, 0x65, 0xe9, 0xd9, 0x55, 0xc3, 0x16, 0x03,
+	0xaa, 0x03, 0x1b, 0x0d, 0x1c, 0x86, 0x56, 0x58, 0x64, 0x5d, 0xd3, 0x6a, 0x2d, 0x9c, 0x38, 0xee,
+	0x3c, 0xfc, 0xbd, 0x0a, 0x6b, 0x4f, 0x54, 0x67, 0x50, 0x08, 0x6b, 0xe5, 0x92, 0xa3, 0x7b, 0xf3,
+	0x2d, 0xd3, 0xee, 0x93, 0xde, 0x60, 0x99, 0x5b, 0x66, 0x82, 0xad, 0x4f, 0xdf, 0x7f, 0x7d, 0x36,
+	0x7b, 0xf8, 0xb6, 0x53, 0x1c, 0x39, 0x25, 0xd0, 0xf1, 0x14, 0xec, 0xd8, 0x38, 0xe0, 0x64, 0x65,
+	0x73, 0x75, 0x32, 0x4d, 0x4e, 0x9d, 0x4c, 0xd7, 0x64, 0x09, 0x59, 0xa2, 0x60, 0x9c, 0xcc, 0x83,
+	0xb6, 0x14, 0x1d, 0xdd, 0x5d, 0x34, 0x0a, 0x25, 0x51, 0x7f, 0xb1, 0x53, 0xd1, 0x0c, 0x04, 0x4d,
+	0x17, 0xdf, 0x9a, 0xa3, 0x91, 0x13, 0xc4, 0x49, 0x7c, 0xb8, 0xfe, 0x7c, 0x28, 0x44, 0x69, 0xc2,
+	0xb2, 0x27, 0x58, 0xee, 0xe0, 0x9d, 0x39, 0x16, 0x26, 0x03, 0x1f, 0x1b, 0x07, 0x0f, 0x0d, 0x5e,
+	0x8d, 0xdc, 0x72, 0x9d, 0x67, 0xee, 0xf6, 0xd0, 0x79, 0xe6, 0x2f, 0x86, 0x25, 0xd5, 0xa4, 0x02,
+	0xa4, 0xf4, 0x29, 0x17, 0x54, 0xd7, 0x47, 0xbb, 0x1a, 0x74, 0x7d, 0xf4, 0xbd, 0x5e, 0xa2, 0x4f,
+	0xae, 0x60, 0x9c, 0x2c, 0x03, 0xa8, 0xc6, 0x1e, 0xed, 0xe9, 0xc3, 0xa5, 0x6d, 0x4e, 0xcf, 0x5a,
+	0x0e, 0x50, 0x94, 0x58, 0x50, 0xf6, 0xf1, 0xae, 0x36, 0x7f, 0x25, 0xf0, 0xd8, 0x38, 0x78, 0xec,
+	0x7e, 0x9d, 0x0e, 0x8c, 0x6f, 0xd3, 0x81, 0xf1, 0x63, 0x3a, 0x30, 0xbe, 0xfc, 0x1c, 0x5c, 0x7b,
+	0x77, 0xe2, 0x33, 0xb1, 0x59, 0x76, 0xc0, 0xc4, 0x9b, 0xec, 0xc8, 0x15, 0x13, 0x31, 0x66, 0x0b,
+	0x27, 0x1e, 0xdd, 0x8a, 0xdf, 0xa9, 0xa7, 0x32, 0x6c, 0x8b, 0x17, 0xf8, 0xe8, 0x4f, 0x00, 0x00,
+	0x00, 0xff, 0xff, 0xb0, 0xee, 0xc4, 0xbd, 0x12, 0x08, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -617,6 +836,12 @@ type ElectionClient interface {
 	// Resign releases election leadership so other campaigners may acquire
 	// leadership on the election.
 	Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*ResignResponse, error)
+	// Transfer hands election leadership over to a given campaigner. The
+	// campaigners ahead of it step aside so that it acquires leadership next.
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	// Candidates returns the campaigners of an election in queue order; the
+	// first one is the leader.
+	Candidates(ctx context.Context, in *CandidatesRequest, opts ...grpc.CallOption) (*CandidatesResponse, error)
 }
 
 type electionClient struct {
@@ -695,6 +920,24 @@ func (c *electionClient) Resign(ctx context.Context, in *ResignRequest, opts ...
 	return out, nil
 }
 
+func (c *electionClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/v3electionpb.Election/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *electionClient) Candidates(ctx context.Context, in *CandidatesRequest, opts ...grpc.CallOption) (*CandidatesResponse, error) {
+	out := new(CandidatesResponse)
+	err := c.cc.Invoke(ctx, "/v3electionpb.Election/Candidates", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ElectionServer is the server API for Election service.
 type ElectionServer interface {
 	// Campaign waits to acquire leadership in an election, returning a LeaderKey
@@ -712,6 +955,12 @@ type ElectionServer interface {
 	// Resign releases election leadership so other campaigners may acquire
 	// leadership on the election.
 	Resign(context.Context, *ResignRequest) (*ResignResponse, error)
+	// Transfer hands election leadership over to a given campaigner. The
+	// campaigners ahead of it step aside so that it acquires leadership next.
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	// Candidates returns the campaigners of an election in queue order; the
+	// first one is the leader.
+	Candidates(context.Context, *CandidatesRequest) (*CandidatesResponse, error)
 }
 
 // UnimplementedElectionServer can be embedded to have forward compatible implementations.
@@ -733,6 +982,12 @@ func (*UnimplementedElectionServer) Observe(req *LeaderRequest, srv Election_Obs
 func (*UnimplementedElectionServer) Resign(ctx context.Context, req *ResignRequest) (*ResignResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Resign not implemented")
 }
+func (*UnimplementedElectionServer) Transfer(ctx context.Context, req *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (*UnimplementedElectionServer) Candidates(ctx context.Context, req *CandidatesRequest) (*CandidatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Candidates not implemented")
+}
 
 func RegisterElectionServer(s *grpc.Server, srv ElectionServer) {
 	s.RegisterService(&_Election_serviceDesc, srv)
@@ -831,6 +1086,42 @@ func _Election_Resign_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Election_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElectionServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v3electionpb.Election/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElectionServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Election_Candidates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CandidatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ElectionServer).Candidates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/v3electionpb.Election/Candidates",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ElectionServer).Candidates(ctx, req.(*CandidatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Election_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "v3electionpb.Election",
 	HandlerType: (*ElectionServer)(nil),
@@ -851,6 +1142,14 @@ var _Election_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Resign",
 			Handler:    _Election_Resign_Handler,
 		},
+		{
+			MethodName: "Transfer",
+			Handler:    _Election_Transfer_Handler,
+		},
+		{
+			MethodName: "Candidates",
+			Handler:    _Election_Candidates_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1173,6 +1472,91 @@ func (m *ResignResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *TransferRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Candidate) > 0 {
+		i -= len(m.Candidate)
+		copy(dAtA[i:], m.Candidate)
+		i = encodeVarintV3Election(dAtA, i, uint64(len(m.Candidate)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Leader != nil {
+		{
+			size, err := m.Leader.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintV3Election(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TransferResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TransferResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TransferResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintV3Election(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *ProclaimRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -1258,8 +1642,95 @@ func (m *ProclaimResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintV3Election(dAtA []byte, offset int, v uint64) int {
-	offset -= sovV3Election(v)
+func (m *CandidatesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CandidatesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CandidatesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintV3Election(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CandidatesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CandidatesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CandidatesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Kvs) > 0 {
+		for iNdEx := len(m.Kvs) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Kvs[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintV3Election(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if m.Header != nil {
+		{
+			size, err := m.Header.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintV3Election(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintV3Election(dAtA []byte, offset int, v uint64) int {
+	offset -= sovV3Election(v)
 	base := offset
 	for v >= 1<<7 {
 		dAtA[offset] = uint8(v&0x7f | 0x80)
@@ -1406,6 +1877,42 @@ func (m *ResignResponse) Size() (n int) {
 	return n
 }
 
+func (m *TransferRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Leader != nil {
+		l = m.Leader.Size()
+		n += 1 + l + sovV3Election(uint64(l))
+	}
+	l = len(m.Candidate)
+	if l > 0 {
+		n += 1 + l + sovV3Election(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *TransferResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovV3Election(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func (m *ProclaimRequest) Size() (n int) {
 	if m == nil {
 		return 0
@@ -1442,6 +1949,44 @@ func (m *ProclaimResponse) Size() (n int) {
 	return n
 }
 
+func (m *CandidatesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovV3Election(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *CandidatesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Header != nil {
+		l = m.Header.Size()
+		n += 1 + l + sovV3Election(uint64(l))
+	}
+	if len(m.Kvs) > 0 {
+		for _, e := range m.Kvs {
+			l = e.Size()
+			n += 1 + l + sovV3Election(uint64(l))
+		}
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
 func sovV3Election(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -2248,7 +2793,7 @@ func (m *ResignResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProclaimRequest) Unmarshal(dAtA []byte) error {
+func (m *TransferRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2271,10 +2816,10 @@ func (m *ProclaimRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProclaimRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProclaimRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2315,7 +2860,7 @@ func (m *ProclaimRequest) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Candidate", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -2342,9 +2887,9 @@ func (m *ProclaimRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
-			if m.Value == nil {
-				m.Value = []byte{}
+			m.Candidate = append(m.Candidate[:0], dAtA[iNdEx:postIndex]...)
+			if m.Candidate == nil {
+				m.Candidate = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -2369,7 +2914,7 @@ func (m *ProclaimRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *ProclaimResponse) Unmarshal(dAtA []byte) error {
+func (m *TransferResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -2392,10 +2937,10 @@ func (m *ProclaimResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: ProclaimResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: TransferResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: ProclaimResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TransferResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -2456,6 +3001,420 @@ func (m *ProclaimResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *ProclaimRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowV3Election
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProclaimRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProclaimRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Leader", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Leader == nil {
+				m.Leader = &LeaderKey{}
+			}
+			if err := m.Leader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = append(m.Value[:0], dAtA[iNdEx:postIndex]...)
+			if m.Value == nil {
+				m.Value = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipV3Election(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *ProclaimResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowV3Election
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: ProclaimResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: ProclaimResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &etcdserverpb.ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipV3Election(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CandidatesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowV3Election
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CandidatesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CandidatesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Name", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Name = append(m.Name[:0], dAtA[iNdEx:postIndex]...)
+			if m.Name == nil {
+				m.Name = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipV3Election(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CandidatesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowV3Election
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CandidatesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CandidatesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &etcdserverpb.ResponseHeader{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kvs", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowV3Election
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kvs = append(m.Kvs, &mvccpb.KeyValue{})
+			if err := m.Kvs[len(m.Kvs)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipV3Election(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthV3Election
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipV3Election(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
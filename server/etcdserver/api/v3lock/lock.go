@@ -16,12 +16,20 @@ package v3lock
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/client/v3/concurrency"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/v3lock/v3lockpb"
 )
 
+// ErrLocked is returned by TryLock when the lock is held by another owner.
+var ErrLocked = status.Error(codes.FailedPrecondition, "lock is held by another owner")
+
 type lockServer struct {
 	c *clientv3.Client
 }
@@ -31,20 +39,44 @@ func NewLockServer(c *clientv3.Client) v3lockpb.LockServer {
 }
 
 func (ls *lockServer) Lock(ctx context.Context, req *v3lockpb.LockRequest) (*v3lockpb.LockResponse, error) {
-	s, err := concurrency.NewSession(
-		ls.c,
-		concurrency.WithLease(clientv3.LeaseID(req.Lease)),
-		concurrency.WithContext(ctx),
-	)
+	s, err := ls.session(ctx, req.Lease, req.Ttl)
 	if err != nil {
 		return nil, err
 	}
-	s.Orphan()
 	m := concurrency.NewMutex(s, string(req.Name))
 	if err = m.Lock(ctx); err != nil {
 		return nil, err
 	}
-	return &v3lockpb.LockResponse{Header: m.Header(), Key: []byte(m.Key())}, nil
+	return &v3lockpb.LockResponse{Header: m.Header(), Key: []byte(m.Key()), Lease: int64(s.Lease())}, nil
+}
+
+func (ls *lockServer) TryLock(ctx context.Context, req *v3lockpb.TryLockRequest) (*v3lockpb.LockResponse, error) {
+	s, err := ls.session(ctx, req.Lease, req.Ttl)
+	if err != nil {
+		return nil, err
+	}
+	m := concurrency.NewMutex(s, string(req.Name))
+	if req.Timeout <= 0 {
+		err = m.TryLock(ctx)
+	} else {
+		lctx, cancel := context.WithTimeout(ctx, time.Duration(req.Timeout)*time.Millisecond)
+		err = m.Lock(lctx)
+		cancel()
+		if err != nil && ctx.Err() == nil && errors.Is(lctx.Err(), context.DeadlineExceeded) {
+			err = concurrency.ErrLocked
+		}
+	}
+	if err != nil {
+		if req.Lease == 0 {
+			// the lease was granted for the lock
+			s.Close()
+		}
+		if errors.Is(err, concurrency.ErrLocked) {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+	return &v3lockpb.LockResponse{Header: m.Header(), Key: []byte(m.Key()), Lease: int64(s.Lease())}, nil
 }
 
 func (ls *lockServer) Unlock(ctx context.Context, req *v3lockpb.UnlockRequest) (*v3lockpb.UnlockResponse, error) {
@@ -54,3 +86,53 @@ func (ls *lockServer) Unlock(ctx context.Context, req *v3lockpb.UnlockRequest) (
 	}
 	return &v3lockpb.UnlockResponse{Header: resp.Header}, nil
 }
+
+func (ls *lockServer) LockInfo(ctx context.Context, req *v3lockpb.LockInfoRequest) (*v3lockpb.LockInfoResponse, error) {
+	info, err := concurrency.GetLockInfo(ctx, ls.c, string(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	resp := &v3lockpb.LockInfoResponse{Header: info.Header}
+	if info.Holder != nil {
+		resp.Holder = lockOwnerToPb(*info.Holder)
+	}
+	for _, w := range info.Waiters {
+		resp.Waiters = append(resp.Waiters, lockOwnerToPb(w))
+	}
+	return resp, nil
+}
+
+func (ls *lockServer) ForceUnlock(ctx context.Context, req *v3lockpb.ForceUnlockRequest) (*v3lockpb.ForceUnlockResponse, error) {
+	key, hdr, err := concurrency.ForceUnlock(ctx, ls.c, string(req.Name))
+	if err != nil {
+		return nil, err
+	}
+	return &v3lockpb.ForceUnlockResponse{Header: hdr, Key: []byte(key)}, nil
+}
+
+// session returns an orphaned session on the given lease, or on a new lease
+// with the given TTL if there is none.
+func (ls *lockServer) session(ctx context.Context, lease, ttl int64) (*concurrency.Session, error) {
+	opts := []concurrency.SessionOption{
+		concurrency.WithLease(clientv3.LeaseID(lease)),
+		concurrency.WithContext(ctx),
+	}
+	if lease == 0 && ttl > 0 {
+		opts = append(opts, concurrency.WithTTL(int(ttl)))
+	}
+	s, err := concurrency.NewSession(ls.c, opts...)
+	if err != nil {
+		return nil, err
+	}
+	s.Orphan()
+	return s, nil
+}
+
+func lockOwnerToPb(o concurrency.LockOwner) *v3lockpb.LockOwner {
+	return &v3lockpb.LockOwner{
+		Key:            []byte(o.Key),
+		Lease:          int64(o.Lease),
+		Ttl:            o.TTL,
+		CreateRevision: o.CreateRevision,
+	}
+}
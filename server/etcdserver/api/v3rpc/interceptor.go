@@ -36,11 +36,17 @@ import (
 const (
 	maxNoLeaderCnt = 3
 	snapshotMethod = "/etcdserverpb.Maintenance/Snapshot"
+
+	watchMethod          = "/etcdserverpb.Watch/Watch"
+	leaseKeepAliveMethod = "/etcdserverpb.Lease/LeaseKeepAlive"
 )
 
 type streamsMap struct {
 	mu      sync.Mutex
 	streams map[grpc.ServerStream]struct{}
+	// drainable holds the watch and lease keep alive streams, which are
+	// canceled once the member is draining.
+	drainable map[grpc.ServerStream]struct{}
 }
 
 func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
@@ -53,6 +59,10 @@ func newUnaryInterceptor(s *etcdserver.EtcdServer) grpc.UnaryServerInterceptor {
 			return nil, rpctypes.ErrGRPCNotSupportedForLearner
 		}
 
+		if s.IsWitness() && !isRPCSupportedForWitness(req) {
+			return nil, rpctypes.ErrGRPCNotSupportedForWitness
+		}
+
 		md, ok := metadata.FromIncomingContext(ctx)
 		if ok {
 			ver, vs := "unknown", md.Get(rpctypes.MetadataClientAPIVersionKey)
@@ -224,6 +234,12 @@ func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor
 			return rpctypes.ErrGRPCNotSupportedForLearner
 		}
 
+		if s.IsWitness() { // witness does not support any stream RPC
+			return rpctypes.ErrGRPCNotSupportedForWitness
+		}
+
+		drainable := info.FullMethod == watchMethod || info.FullMethod == leaseKeepAliveMethod
+
 		md, ok := metadata.FromIncomingContext(ss.Context())
 		if ok {
 			ver, vs := "unknown", md.Get(rpctypes.MetadataClientAPIVersionKey)
@@ -257,6 +273,33 @@ func newStreamInterceptor(s *etcdserver.EtcdServer) grpc.StreamServerInterceptor
 			}
 		}
 
+		if drainable {
+			ssWithCtx, ok := ss.(serverStreamWithCtx)
+			if !ok {
+				ssWithCtx = serverStreamWithCtx{ctx: newCancellableContext(ss.Context()), ServerStream: ss}
+				ss = ssWithCtx
+				defer ssWithCtx.ctx.Cancel(nil)
+			}
+
+			smap.mu.Lock()
+			smap.drainable[ss] = struct{}{}
+			smap.mu.Unlock()
+
+			defer func() {
+				smap.mu.Lock()
+				delete(smap.drainable, ss)
+				smap.mu.Unlock()
+			}()
+
+			// a draining member turns watch and lease keep alive streams away as
+			// unavailable so that clients reconnect them to another endpoint. The
+			// stream is checked once tracked, so that it is either rejected here
+			// or canceled when the drain starts.
+			if s.IsDraining() {
+				return rpctypes.ErrGRPCMemberDraining
+			}
+		}
+
 		return handler(srv, ss)
 	}
 }
@@ -313,7 +356,8 @@ func (ssc serverStreamWithCtx) Context() context.Context { return ssc.ctx }
 
 func monitorLeader(s *etcdserver.EtcdServer) *streamsMap {
 	smap := &streamsMap{
-		streams: make(map[grpc.ServerStream]struct{}),
+		streams:   make(map[grpc.ServerStream]struct{}),
+		drainable: make(map[grpc.ServerStream]struct{}),
 	}
 
 	s.GoAttach(func() {
@@ -324,6 +368,19 @@ func monitorLeader(s *etcdserver.EtcdServer) *streamsMap {
 			select {
 			case <-s.StoppingNotify():
 				return
+			case <-s.DrainNotify():
+				// Existing watch and lease keep alive streams are canceled with a
+				// retryable error as soon as the member starts draining, so that
+				// clients move them to another endpoint before the member goes away.
+				smap.mu.Lock()
+				for ss := range smap.drainable {
+					if ssWithCtx, ok := ss.(serverStreamWithCtx); ok {
+						ssWithCtx.ctx.Cancel(rpctypes.ErrGRPCMemberDraining)
+						<-ss.Context().Done()
+					}
+				}
+				smap.drainable = make(map[grpc.ServerStream]struct{})
+				smap.mu.Unlock()
 			case <-time.After(election):
 				if s.Leader() == types.ID(raft.None) {
 					noLeaderCnt++
@@ -111,6 +111,16 @@ func (s *kvServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.Co
 	return resp, nil
 }
 
+func (s *kvServer) RevisionAt(ctx context.Context, r *pb.RevisionAtRequest) (*pb.RevisionAtResponse, error) {
+	resp, err := s.kv.RevisionAt(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+
+	s.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 func checkRangeRequest(r *pb.RangeRequest) error {
 	if len(r.Key) == 0 {
 		return rpctypes.ErrGRPCEmptyKey
@@ -134,9 +144,12 @@ func checkPutRequest(r *pb.PutRequest) error {
 	if r.IgnoreValue && len(r.Value) != 0 {
 		return rpctypes.ErrGRPCValueProvided
 	}
-	if r.IgnoreLease && r.Lease != 0 {
+	if r.IgnoreLease && (r.Lease != 0 || len(r.ExtraLeases) != 0) {
 		return rpctypes.ErrGRPCLeaseProvided
 	}
+	if r.Lease == 0 && len(r.ExtraLeases) != 0 {
+		return rpctypes.ErrGRPCLeaseNotProvided
+	}
 	return nil
 }
 
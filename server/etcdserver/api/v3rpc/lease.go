@@ -90,6 +90,56 @@ func (ls *LeaseServer) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesReques
 	return resp, nil
 }
 
+func (ls *LeaseServer) LeaseGroupGrant(ctx context.Context, gr *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error) {
+	resp, err := ls.le.LeaseGroupGrant(ctx, gr)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ls.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ls *LeaseServer) LeaseGroupRevoke(ctx context.Context, gr *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error) {
+	resp, err := ls.le.LeaseGroupRevoke(ctx, gr)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ls.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ls *LeaseServer) LeaseGroupAttach(ctx context.Context, gr *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error) {
+	resp, err := ls.le.LeaseGroupAttach(ctx, gr)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ls.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ls *LeaseServer) LeaseGroupDetach(ctx context.Context, gr *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error) {
+	resp, err := ls.le.LeaseGroupDetach(ctx, gr)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	ls.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ls *LeaseServer) LeaseGroupKeepAlive(ctx context.Context, gr *pb.LeaseGroupKeepAliveRequest) (*pb.LeaseGroupKeepAliveResponse, error) {
+	// Create the header before renewing, as leaseKeepAlive does, so that the
+	// revision is never newer than the renewal.
+	hdr := &pb.ResponseHeader{}
+	ls.hdr.fill(hdr)
+
+	resp, err := ls.le.LeaseGroupRenew(ctx, lease.LeaseGroupID(gr.ID))
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	resp.Header = hdr
+	return resp, nil
+}
+
 func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err error) {
 	errc := make(chan error, 1)
 	go func() {
@@ -98,7 +148,8 @@ func (ls *LeaseServer) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) (err
 	select {
 	case err = <-errc:
 	case <-stream.Context().Done():
-		// the only server-side cancellation is noleader for now.
+		// the server-side cancellations are noleader and draining, which
+		// carry their own error.
 		err = stream.Context().Err()
 		if errors.Is(err, context.Canceled) {
 			err = rpctypes.ErrGRPCNoLeader
@@ -57,6 +57,15 @@ type Downgrader interface {
 	Downgrade(ctx context.Context, dr *pb.DowngradeRequest) (*pb.DowngradeResponse, error)
 }
 
+type CompactionPolicyManager interface {
+	CompactionPolicy(ctx context.Context, r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error)
+}
+
+type Drainer interface {
+	Drain(ctx context.Context) error
+	Undrain(ctx context.Context) error
+}
+
 type LeaderTransferrer interface {
 	MoveLeader(ctx context.Context, lead, target uint64) error
 }
@@ -79,6 +88,8 @@ type maintenanceServer struct {
 	hdr    header
 	cs     ClusterStatusGetter
 	d      Downgrader
+	cp     CompactionPolicyManager
+	dr     Drainer
 	vs     serverversion.Server
 	cg     ConfigGetter
 
@@ -96,6 +107,8 @@ func NewMaintenanceServer(s *etcdserver.EtcdServer, healthNotifier notifier) pb.
 		hdr:            newHeader(s),
 		cs:             s,
 		d:              s,
+		cp:             s,
+		dr:             s,
 		vs:             etcdserver.NewServerVersionAdapter(s),
 		healthNotifier: healthNotifier,
 		cg:             s,
@@ -303,6 +316,36 @@ func (ms *maintenanceServer) Downgrade(ctx context.Context, r *pb.DowngradeReque
 	return resp, nil
 }
 
+func (ms *maintenanceServer) CompactionPolicy(ctx context.Context, r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error) {
+	resp, err := ms.cp.CompactionPolicy(ctx, r)
+	if err != nil {
+		return nil, togRPCError(err)
+	}
+	if resp.Header == nil {
+		resp.Header = &pb.ResponseHeader{}
+	}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) Drain(ctx context.Context, r *pb.DrainRequest) (*pb.DrainResponse, error) {
+	if err := ms.dr.Drain(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.DrainResponse{Header: &pb.ResponseHeader{}}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
+func (ms *maintenanceServer) Undrain(ctx context.Context, r *pb.UndrainRequest) (*pb.UndrainResponse, error) {
+	if err := ms.dr.Undrain(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+	resp := &pb.UndrainResponse{Header: &pb.ResponseHeader{}}
+	ms.hdr.fill(resp.Header)
+	return resp, nil
+}
+
 type authMaintenanceServer struct {
 	*maintenanceServer
 	*AuthAdmin
@@ -362,3 +405,27 @@ func (ams *authMaintenanceServer) Downgrade(ctx context.Context, r *pb.Downgrade
 
 	return ams.maintenanceServer.Downgrade(ctx, r)
 }
+
+func (ams *authMaintenanceServer) CompactionPolicy(ctx context.Context, r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.CompactionPolicy(ctx, r)
+}
+
+func (ams *authMaintenanceServer) Drain(ctx context.Context, r *pb.DrainRequest) (*pb.DrainResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.Drain(ctx, r)
+}
+
+func (ams *authMaintenanceServer) Undrain(ctx context.Context, r *pb.UndrainRequest) (*pb.UndrainResponse, error) {
+	if err := ams.isPermitted(ctx); err != nil {
+		return nil, togRPCError(err)
+	}
+
+	return ams.maintenanceServer.Undrain(ctx, r)
+}
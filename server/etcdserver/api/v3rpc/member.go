@@ -43,13 +43,21 @@ func (cs *ClusterServer) MemberAdd(ctx context.Context, r *pb.MemberAddRequest)
 	if err != nil {
 		return nil, rpctypes.ErrGRPCMemberBadURLs
 	}
+	if r.AutoPromote && !r.IsLearner {
+		return nil, rpctypes.ErrGRPCAutoPromoteNotLearner
+	}
+	if r.IsWitness && r.IsLearner {
+		return nil, rpctypes.ErrGRPCWitnessIsLearner
+	}
 
 	now := time.Now()
 	var m *membership.Member
 	if r.IsLearner {
 		m = membership.NewMemberAsLearner("", urls, "", &now)
+		m.AutoPromote = r.AutoPromote
 	} else {
 		m = membership.NewMember("", urls, "", &now)
+		m.IsWitness = r.IsWitness
 	}
 	membs, merr := cs.server.AddMember(ctx, *m)
 	if merr != nil {
@@ -59,9 +67,11 @@ func (cs *ClusterServer) MemberAdd(ctx context.Context, r *pb.MemberAddRequest)
 	return &pb.MemberAddResponse{
 		Header: cs.header(),
 		Member: &pb.Member{
-			ID:        uint64(m.ID),
-			PeerURLs:  m.PeerURLs,
-			IsLearner: m.IsLearner,
+			ID:          uint64(m.ID),
+			PeerURLs:    m.PeerURLs,
+			IsLearner:   m.IsLearner,
+			AutoPromote: m.AutoPromote,
+			IsWitness:   m.IsWitness,
 		},
 		Members: membersToProtoMembers(membs),
 	}, nil
@@ -105,6 +115,28 @@ func (cs *ClusterServer) MemberPromote(ctx context.Context, r *pb.MemberPromoteR
 	return &pb.MemberPromoteResponse{Header: cs.header(), Members: membersToProtoMembers(membs)}, nil
 }
 
+func (cs *ClusterServer) MemberReplace(ctx context.Context, r *pb.MemberReplaceRequest) (*pb.MemberReplaceResponse, error) {
+	urls, err := types.NewURLs(r.PeerURLs)
+	if err != nil {
+		return nil, rpctypes.ErrGRPCMemberBadURLs
+	}
+
+	now := time.Now()
+	m := membership.NewMemberAsLearner("", urls, "", &now)
+	id, membs, merr := cs.server.ReplaceMember(ctx, r.ID, *m)
+	if merr != nil {
+		return nil, togRPCError(merr)
+	}
+
+	resp := &pb.MemberReplaceResponse{Header: cs.header(), Members: membersToProtoMembers(membs)}
+	for _, memb := range resp.Members {
+		if memb.ID == uint64(id) {
+			resp.Member = memb
+		}
+	}
+	return resp, nil
+}
+
 func (cs *ClusterServer) header() *pb.ResponseHeader {
 	return &pb.ResponseHeader{ClusterId: uint64(cs.cluster.ID()), MemberId: uint64(cs.server.MemberID()), RaftTerm: cs.server.Term()}
 }
@@ -113,11 +145,15 @@ func membersToProtoMembers(membs []*membership.Member) []*pb.Member {
 	protoMembs := make([]*pb.Member, len(membs))
 	for i := range membs {
 		protoMembs[i] = &pb.Member{
-			Name:       membs[i].Name,
-			ID:         uint64(membs[i].ID),
-			PeerURLs:   membs[i].PeerURLs,
-			ClientURLs: membs[i].ClientURLs,
-			IsLearner:  membs[i].IsLearner,
+			Name:        membs[i].Name,
+			ID:          uint64(membs[i].ID),
+			PeerURLs:    membs[i].PeerURLs,
+			ClientURLs:  membs[i].ClientURLs,
+			IsLearner:   membs[i].IsLearner,
+			AutoPromote: membs[i].AutoPromote,
+			Labels:      membs[i].Labels,
+			Draining:    membs[i].Draining,
+			IsWitness:   membs[i].IsWitness,
 		}
 	}
 	return protoMembs
@@ -38,15 +38,18 @@ var toGRPCErrorMap = map[error]error{
 	membership.ErrIDExists:            rpctypes.ErrGRPCMemberExist,
 	membership.ErrPeerURLexists:       rpctypes.ErrGRPCPeerURLExist,
 	membership.ErrMemberNotLearner:    rpctypes.ErrGRPCMemberNotLearner,
+	membership.ErrMemberIsLearner:     rpctypes.ErrGRPCMemberIsLearner,
 	membership.ErrTooManyLearners:     rpctypes.ErrGRPCTooManyLearners,
 	errors.ErrNotEnoughStartedMembers: rpctypes.ErrMemberNotEnoughStarted,
 	errors.ErrLearnerNotReady:         rpctypes.ErrGRPCLearnerNotReady,
 
-	mvcc.ErrCompacted:         rpctypes.ErrGRPCCompacted,
-	mvcc.ErrFutureRev:         rpctypes.ErrGRPCFutureRev,
-	errors.ErrRequestTooLarge: rpctypes.ErrGRPCRequestTooLarge,
-	errors.ErrNoSpace:         rpctypes.ErrGRPCNoSpace,
-	errors.ErrTooManyRequests: rpctypes.ErrTooManyRequests,
+	mvcc.ErrCompacted:               rpctypes.ErrGRPCCompacted,
+	mvcc.ErrFutureRev:               rpctypes.ErrGRPCFutureRev,
+	mvcc.ErrInvalidRetentionPolicy:  rpctypes.ErrGRPCInvalidCompactionPolicy,
+	mvcc.ErrRetentionPolicyNotFound: rpctypes.ErrGRPCCompactionPolicyNotFound,
+	errors.ErrRequestTooLarge:       rpctypes.ErrGRPCRequestTooLarge,
+	errors.ErrNoSpace:               rpctypes.ErrGRPCNoSpace,
+	errors.ErrTooManyRequests:       rpctypes.ErrTooManyRequests,
 
 	errors.ErrNoLeader:                   rpctypes.ErrGRPCNoLeader,
 	errors.ErrNotLeader:                  rpctypes.ErrGRPCNotLeader,
@@ -60,6 +63,9 @@ var toGRPCErrorMap = map[error]error{
 	errors.ErrKeyNotFound:                rpctypes.ErrGRPCKeyNotFound,
 	errors.ErrCorrupt:                    rpctypes.ErrGRPCCorrupt,
 	errors.ErrBadLeaderTransferee:        rpctypes.ErrGRPCBadLeaderTransferee,
+	errors.ErrNotCapable:                 rpctypes.ErrGRPCNotCapable,
+	errors.ErrDrainUnsafe:                rpctypes.ErrGRPCDrainUnsafe,
+	errors.ErrNotSupportedForWitness:     rpctypes.ErrGRPCNotSupportedForWitness,
 
 	errors.ErrClusterVersionUnavailable:      rpctypes.ErrGRPCClusterVersionUnavailable,
 	errors.ErrWrongDowngradeVersionFormat:    rpctypes.ErrGRPCWrongDowngradeVersionFormat,
@@ -71,6 +77,10 @@ var toGRPCErrorMap = map[error]error{
 	lease.ErrLeaseExists:      rpctypes.ErrGRPCLeaseExist,
 	lease.ErrLeaseTTLTooLarge: rpctypes.ErrGRPCLeaseTTLTooLarge,
 
+	lease.ErrLeaseGroupNotFound: rpctypes.ErrGRPCLeaseGroupNotFound,
+	lease.ErrLeaseGroupExists:   rpctypes.ErrGRPCLeaseGroupExist,
+	lease.ErrLeaseGroupEmpty:    rpctypes.ErrGRPCLeaseGroupEmpty,
+
 	auth.ErrRootUserNotExist:     rpctypes.ErrGRPCRootUserNotExist,
 	auth.ErrRootRoleNotExist:     rpctypes.ErrGRPCRootRoleNotExist,
 	auth.ErrUserAlreadyExist:     rpctypes.ErrGRPCUserAlreadyExist,
@@ -138,6 +148,18 @@ func isClientCtxErr(ctxErr error, err error) bool {
 	return false
 }
 
+// isRPCSupportedForWitness returns true for the requests a witness serves. A
+// witness keeps no key-value data, so it only reports its status and the
+// membership, and may be asked to transfer the leadership.
+func isRPCSupportedForWitness(req any) bool {
+	switch req.(type) {
+	case *pb.StatusRequest, *pb.MemberListRequest, *pb.MoveLeaderRequest:
+		return true
+	default:
+		return false
+	}
+}
+
 // in v3.4, learner is allowed to serve serializable read and endpoint status
 func isRPCSupportedForLearner(req any) bool {
 	switch r := req.(type) {
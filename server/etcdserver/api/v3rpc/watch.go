@@ -32,6 +32,7 @@ import (
 	"go.etcd.io/etcd/server/v3/auth"
 	"go.etcd.io/etcd/server/v3/etcdserver"
 	"go.etcd.io/etcd/server/v3/etcdserver/apply"
+	"go.etcd.io/etcd/server/v3/lease"
 	"go.etcd.io/etcd/server/v3/storage/mvcc"
 )
 
@@ -47,6 +48,7 @@ type watchServer struct {
 
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
+	lessor    lease.Lessor
 	ag        AuthGetter
 }
 
@@ -62,6 +64,7 @@ func NewWatchServer(s *etcdserver.EtcdServer) pb.WatchServer {
 
 		sg:        s,
 		watchable: s.Watchable(),
+		lessor:    s.Lessor(),
 		ag:        s,
 	}
 	if srv.lg == nil {
@@ -130,11 +133,14 @@ type serverWatchStream struct {
 
 	sg        apply.RaftStatusGetter
 	watchable mvcc.WatchableKV
+	lessor    lease.Lessor
 	ag        AuthGetter
 
 	gRPCStream  pb.Watch_WatchServer
 	watchStream mvcc.WatchStream
 	ctrlStream  chan *pb.WatchResponse
+	// leaseStream carries the final event of watchers waiting on a lease.
+	leaseStream chan *pb.WatchResponse
 
 	// mu protects progress, prevKV, fragment
 	mu sync.RWMutex
@@ -145,6 +151,8 @@ type serverWatchStream struct {
 	prevKV map[mvcc.WatchID]bool
 	// records fragmented watch IDs
 	fragment map[mvcc.WatchID]bool
+	// records watch IDs waiting on a lease, closed when the watcher is canceled
+	leaseWatch map[mvcc.WatchID]chan struct{}
 
 	// closec indicates the stream is closed.
 	closec chan struct{}
@@ -164,16 +172,19 @@ func (ws *watchServer) Watch(stream pb.Watch_WatchServer) (err error) {
 
 		sg:        ws.sg,
 		watchable: ws.watchable,
+		lessor:    ws.lessor,
 		ag:        ws.ag,
 
 		gRPCStream:  stream,
 		watchStream: ws.watchable.NewWatchStream(),
 		// chan for sending control response like watcher created and canceled.
-		ctrlStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
+		ctrlStream:  make(chan *pb.WatchResponse, ctrlStreamBufLen),
+		leaseStream: make(chan *pb.WatchResponse, ctrlStreamBufLen),
 
-		progress: make(map[mvcc.WatchID]bool),
-		prevKV:   make(map[mvcc.WatchID]bool),
-		fragment: make(map[mvcc.WatchID]bool),
+		progress:   make(map[mvcc.WatchID]bool),
+		prevKV:     make(map[mvcc.WatchID]bool),
+		fragment:   make(map[mvcc.WatchID]bool),
+		leaseWatch: make(map[mvcc.WatchID]chan struct{}),
 
 		closec: make(chan struct{}),
 	}
@@ -303,6 +314,13 @@ func (sws *serverWatchStream) recvLoop() error {
 				}
 			}
 
+			if creq.Lease != 0 {
+				if !sws.watchLease(creq) {
+					return nil
+				}
+				continue
+			}
+
 			filters := FiltersFromRequest(creq)
 
 			id, err := sws.watchStream.Watch(mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, creq.StartRevision, filters...)
@@ -357,6 +375,10 @@ func (sws *serverWatchStream) recvLoop() error {
 					delete(sws.progress, mvcc.WatchID(id))
 					delete(sws.prevKV, mvcc.WatchID(id))
 					delete(sws.fragment, mvcc.WatchID(id))
+					if stopc, ok := sws.leaseWatch[mvcc.WatchID(id)]; ok {
+						close(stopc)
+						delete(sws.leaseWatch, mvcc.WatchID(id))
+					}
 					sws.mu.Unlock()
 				}
 			}
@@ -376,11 +398,117 @@ func (sws *serverWatchStream) recvLoop() error {
 	}
 }
 
+// watchLease creates a watcher that fires once the requested lease is
+// revoked. The underlying mvcc watcher filters out every event; it only
+// provides the watch ID and cancellation. It returns false if the stream
+// was closed.
+func (sws *serverWatchStream) watchLease(creq *pb.WatchCreateRequest) bool {
+	var l *lease.Lease
+	if sws.lessor != nil {
+		l = sws.lessor.Lookup(lease.LeaseID(creq.Lease))
+	}
+	if l == nil {
+		wr := &pb.WatchResponse{
+			Header:       sws.newResponseHeader(sws.watchStream.Rev()),
+			WatchId:      clientv3.InvalidWatchID,
+			Canceled:     true,
+			Created:      true,
+			CancelReason: rpctypes.ErrGRPCLeaseNotFound.Error(),
+		}
+		select {
+		case sws.ctrlStream <- wr:
+			return true
+		case <-sws.closec:
+			return false
+		}
+	}
+
+	id, err := sws.watchStream.Watch(mvcc.WatchID(creq.WatchId), creq.Key, creq.RangeEnd, 0, filterNoPut, filterNoDelete)
+	stopc := make(chan struct{})
+	if err == nil {
+		sws.mu.Lock()
+		sws.leaseWatch[id] = stopc
+		sws.mu.Unlock()
+	} else {
+		id = clientv3.InvalidWatchID
+	}
+
+	wr := &pb.WatchResponse{
+		Header:   sws.newResponseHeader(sws.watchStream.Rev()),
+		WatchId:  int64(id),
+		Created:  true,
+		Canceled: err != nil,
+	}
+	if err != nil {
+		wr.CancelReason = err.Error()
+	}
+	select {
+	case sws.ctrlStream <- wr:
+	case <-sws.closec:
+		return false
+	}
+	if err != nil {
+		return true
+	}
+
+	go func() {
+		select {
+		case <-l.Done():
+		case <-stopc:
+			return
+		case <-sws.closec:
+			return
+		}
+		rev := sws.watchStream.Rev()
+		wr := &pb.WatchResponse{
+			Header:  sws.newResponseHeader(rev),
+			WatchId: int64(id),
+			Events: []*mvccpb.Event{{
+				Type:         mvccpb.DELETE,
+				Kv:           &mvccpb.KeyValue{ModRevision: rev},
+				Lease:        int64(l.ID),
+				RevokeReason: l.RevokeReason(),
+			}},
+		}
+		select {
+		case sws.leaseStream <- wr:
+		case <-stopc:
+		case <-sws.closec:
+		}
+	}()
+	return true
+}
+
 func (sws *serverWatchStream) sendLoop() {
 	// watch ids that are currently active
 	ids := make(map[mvcc.WatchID]struct{})
 	// watch responses pending on a watch id creation message
 	pending := make(map[mvcc.WatchID][]*pb.WatchResponse)
+	// lease revoke responses pending on a watch id creation message
+	pendingLease := make(map[mvcc.WatchID]*pb.WatchResponse)
+
+	// sendLeaseRevoke sends the final event of a lease watcher followed by
+	// its cancellation.
+	sendLeaseRevoke := func(wr *pb.WatchResponse) bool {
+		cr := &pb.WatchResponse{
+			Header:   wr.Header,
+			WatchId:  wr.WatchId,
+			Canceled: true,
+		}
+		for _, r := range []*pb.WatchResponse{wr, cr} {
+			if err := sws.gRPCStream.Send(r); err != nil {
+				if isClientCtxErr(sws.gRPCStream.Context().Err(), err) {
+					sws.lg.Debug("failed to send lease watch response to gRPC stream", zap.Error(err))
+				} else {
+					sws.lg.Warn("failed to send lease watch response to gRPC stream", zap.Error(err))
+					streamFailures.WithLabelValues("send", "watch").Inc()
+				}
+				return false
+			}
+		}
+		delete(ids, mvcc.WatchID(wr.WatchId))
+		return true
+	}
 
 	interval := GetProgressReportInterval()
 	progressTicker := time.NewTicker(interval)
@@ -515,6 +643,29 @@ func (sws *serverWatchStream) sendLoop() {
 					}
 				}
 				delete(pending, wid)
+				if lr, ok := pendingLease[wid]; ok {
+					delete(pendingLease, wid)
+					if !sendLeaseRevoke(lr) {
+						return
+					}
+				}
+			}
+
+		case lr := <-sws.leaseStream:
+			wid := mvcc.WatchID(lr.WatchId)
+			if err := sws.watchStream.Cancel(wid); err != nil {
+				// already canceled by the client
+				continue
+			}
+			sws.mu.Lock()
+			delete(sws.leaseWatch, wid)
+			sws.mu.Unlock()
+			if _, ok := ids[wid]; !ok {
+				pendingLease[wid] = lr
+				continue
+			}
+			if !sendLeaseRevoke(lr) {
+				return
 			}
 
 		case <-progressTicker.C:
@@ -76,12 +76,21 @@ type applierV3 interface {
 	DeleteRange(dr *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, *traceutil.Trace, error)
 	Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Trace, error)
 	Compaction(compaction *pb.CompactionRequest) (*pb.CompactionResponse, <-chan struct{}, *traceutil.Trace, error)
+	CompactionPolicy(r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error)
 
 	LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error)
 	LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
+	LeaseExpire(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error)
+
+	LeaseGroupGrant(lc *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error)
+	LeaseGroupRevoke(lc *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error)
+	LeaseGroupAttach(lc *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error)
+	LeaseGroupDetach(lc *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error)
 
 	LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error)
 
+	RevisionTime(r *pb.RevisionTimeRequest)
+
 	Alarm(*pb.AlarmRequest) (*pb.AlarmResponse, error)
 
 	Authenticate(r *pb.InternalAuthenticateRequest) (*pb.AuthenticateResponse, error)
@@ -176,8 +185,59 @@ func (a *applierV3backend) Compaction(compaction *pb.CompactionRequest) (*pb.Com
 	return resp, ch, trace, err
 }
 
+func (a *applierV3backend) CompactionPolicy(r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error) {
+	switch r.Action {
+	case pb.CompactionPolicyRequest_GET:
+	case pb.CompactionPolicyRequest_PUT:
+		if r.Policy == nil {
+			return nil, mvcc.ErrInvalidRetentionPolicy
+		}
+		p := mvcc.RetentionPolicy{
+			Prefix:        r.Policy.Prefix,
+			KeepRevisions: r.Policy.KeepRevisions,
+			KeepDuration:  time.Duration(r.Policy.KeepSeconds) * time.Second,
+		}
+		if err := a.options.KV.PutRetentionPolicy(p); err != nil {
+			return nil, err
+		}
+	case pb.CompactionPolicyRequest_DELETE:
+		if r.Policy == nil {
+			return nil, mvcc.ErrRetentionPolicyNotFound
+		}
+		if err := a.options.KV.DeleteRetentionPolicy(r.Policy.Prefix); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, mvcc.ErrInvalidRetentionPolicy
+	}
+
+	resp := &pb.CompactionPolicyResponse{Header: a.newHeader()}
+	for _, p := range a.options.KV.RetentionPolicies() {
+		resp.Policies = append(resp.Policies, &pb.CompactionPolicy{
+			Prefix:          p.Prefix,
+			KeepRevisions:   p.KeepRevisions,
+			CompactRevision: p.CompactRevision,
+			KeepSeconds:     int64(p.KeepDuration / time.Second),
+		})
+	}
+	return resp, nil
+}
+
 func (a *applierV3backend) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
-	l, err := a.options.Lessor.Grant(lease.LeaseID(lc.ID), lc.TTL)
+	var opts []lease.GrantOption
+	if lc.MaxLifetime > 0 {
+		opts = append(opts, lease.WithMaxLifetime(lc.MaxLifetime), lease.WithGrantTime(lc.GrantTime))
+	}
+	if lc.RenewOnWrite {
+		opts = append(opts, lease.WithRenewOnWrite())
+	}
+	if len(lc.Labels) > 0 {
+		opts = append(opts, lease.WithLabels(lc.Labels))
+	}
+	if lc.Owner != "" {
+		opts = append(opts, lease.WithOwner(lc.Owner))
+	}
+	l, err := a.options.Lessor.Grant(lease.LeaseID(lc.ID), lc.TTL, opts...)
 	resp := &pb.LeaseGrantResponse{}
 	if err == nil {
 		resp.ID = int64(l.ID)
@@ -192,8 +252,46 @@ func (a *applierV3backend) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevo
 	return &pb.LeaseRevokeResponse{Header: a.newHeader()}, err
 }
 
+func (a *applierV3backend) LeaseExpire(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	err := a.options.Lessor.Expire(lease.LeaseID(lc.ID))
+	return &pb.LeaseRevokeResponse{Header: a.newHeader()}, err
+}
+
+func (a *applierV3backend) LeaseGroupGrant(lc *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error) {
+	err := a.options.Lessor.GroupGrant(lease.LeaseGroupID(lc.ID))
+	return &pb.LeaseGroupGrantResponse{Header: a.newHeader(), ID: lc.ID}, err
+}
+
+func (a *applierV3backend) LeaseGroupRevoke(lc *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error) {
+	err := a.options.Lessor.GroupRevoke(lease.LeaseGroupID(lc.ID))
+	return &pb.LeaseGroupRevokeResponse{Header: a.newHeader()}, err
+}
+
+func (a *applierV3backend) LeaseGroupAttach(lc *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error) {
+	err := a.options.Lessor.GroupAttach(lease.LeaseGroupID(lc.ID), toLeaseIDs(lc.Leases))
+	return &pb.LeaseGroupAttachResponse{Header: a.newHeader()}, err
+}
+
+func (a *applierV3backend) LeaseGroupDetach(lc *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error) {
+	err := a.options.Lessor.GroupDetach(lease.LeaseGroupID(lc.ID), toLeaseIDs(lc.Leases))
+	return &pb.LeaseGroupDetachResponse{Header: a.newHeader()}, err
+}
+
+func toLeaseIDs(ids []int64) []lease.LeaseID {
+	lids := make([]lease.LeaseID, len(ids))
+	for i, id := range ids {
+		lids[i] = lease.LeaseID(id)
+	}
+	return lids
+}
+
 func (a *applierV3backend) LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.LeaseCheckpointResponse, error) {
 	for _, c := range lc.Checkpoints {
+		if c.RemainingLifetime > 0 {
+			if err := a.options.Lessor.CheckpointLifetime(lease.LeaseID(c.ID), c.RemainingLifetime); err != nil {
+				return &pb.LeaseCheckpointResponse{Header: a.newHeader()}, err
+			}
+		}
 		err := a.options.Lessor.Checkpoint(lease.LeaseID(c.ID), c.Remaining_TTL)
 		if err != nil {
 			return &pb.LeaseCheckpointResponse{Header: a.newHeader()}, err
@@ -202,6 +300,10 @@ func (a *applierV3backend) LeaseCheckpoint(lc *pb.LeaseCheckpointRequest) (*pb.L
 	return &pb.LeaseCheckpointResponse{Header: a.newHeader()}, nil
 }
 
+func (a *applierV3backend) RevisionTime(r *pb.RevisionTimeRequest) {
+	a.options.KV.RecordRevisionTime(time.Unix(0, r.Timestamp))
+}
+
 func (a *applierV3backend) Alarm(ar *pb.AlarmRequest) (*pb.AlarmResponse, error) {
 	resp := &pb.AlarmResponse{}
 
@@ -410,6 +512,8 @@ func (a *applierV3backend) ClusterMemberAttrSet(r *membershippb.ClusterMemberAtt
 		membership.Attributes{
 			Name:       r.MemberAttributes.Name,
 			ClientURLs: r.MemberAttributes.ClientUrls,
+			Labels:     r.MemberAttributes.Labels,
+			Draining:   r.MemberAttributes.Draining,
 		},
 		shouldApplyV3,
 	)
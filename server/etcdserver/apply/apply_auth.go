@@ -75,6 +75,11 @@ func (aa *authApplierV3) Put(r *pb.PutRequest) (*pb.PutResponse, *traceutil.Trac
 		// be forbidden.
 		return nil, nil, err
 	}
+	for _, id := range r.ExtraLeases {
+		if err := aa.checkLeasePuts(lease.LeaseID(id)); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	if r.PrevKv {
 		err := aa.as.IsRangePermitted(&aa.authInfo, r.Key, nil)
@@ -113,6 +118,14 @@ func (aa *authApplierV3) Txn(rt *pb.TxnRequest) (*pb.TxnResponse, *traceutil.Tra
 	return aa.applierV3.Txn(rt)
 }
 
+func (aa *authApplierV3) LeaseGrant(lc *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
+	// the owner of a lease is whoever proposed the grant, never what the
+	// client claims
+	req := *lc
+	req.Owner = aa.authInfo.Username
+	return aa.applierV3.LeaseGrant(&req)
+}
+
 func (aa *authApplierV3) LeaseRevoke(lc *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
 	if err := aa.checkLeasePuts(lease.LeaseID(lc.ID)); err != nil {
 		return nil, err
@@ -46,6 +46,10 @@ func (a *applierV3Corrupt) Compaction(_ *pb.CompactionRequest) (*pb.CompactionRe
 	return nil, nil, nil, errors.ErrCorrupt
 }
 
+func (a *applierV3Corrupt) CompactionPolicy(_ *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error) {
+	return nil, errors.ErrCorrupt
+}
+
 func (a *applierV3Corrupt) LeaseGrant(_ *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
 	return nil, errors.ErrCorrupt
 }
@@ -53,3 +57,23 @@ func (a *applierV3Corrupt) LeaseGrant(_ *pb.LeaseGrantRequest) (*pb.LeaseGrantRe
 func (a *applierV3Corrupt) LeaseRevoke(_ *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
 	return nil, errors.ErrCorrupt
 }
+
+func (a *applierV3Corrupt) LeaseExpire(_ *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	return nil, errors.ErrCorrupt
+}
+
+func (a *applierV3Corrupt) LeaseGroupGrant(_ *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error) {
+	return nil, errors.ErrCorrupt
+}
+
+func (a *applierV3Corrupt) LeaseGroupRevoke(_ *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error) {
+	return nil, errors.ErrCorrupt
+}
+
+func (a *applierV3Corrupt) LeaseGroupAttach(_ *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error) {
+	return nil, errors.ErrCorrupt
+}
+
+func (a *applierV3Corrupt) LeaseGroupDetach(_ *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error) {
+	return nil, errors.ErrCorrupt
+}
@@ -143,15 +143,36 @@ func (a *uberApplier) dispatch(r *pb.InternalRaftRequest, shouldApplyV3 membersh
 	case r.Compaction != nil:
 		op = "Compaction"
 		ar.Resp, ar.Physc, ar.Trace, ar.Err = a.applyV3.Compaction(r.Compaction)
+	case r.CompactionPolicy != nil:
+		op = "CompactionPolicy"
+		ar.Resp, ar.Err = a.applyV3.CompactionPolicy(r.CompactionPolicy)
 	case r.LeaseGrant != nil:
 		op = "LeaseGrant"
 		ar.Resp, ar.Err = a.applyV3.LeaseGrant(r.LeaseGrant)
 	case r.LeaseRevoke != nil:
 		op = "LeaseRevoke"
 		ar.Resp, ar.Err = a.applyV3.LeaseRevoke(r.LeaseRevoke)
+	case r.LeaseExpire != nil:
+		op = "LeaseExpire"
+		ar.Resp, ar.Err = a.applyV3.LeaseExpire(r.LeaseExpire)
+	case r.LeaseGroupGrant != nil:
+		op = "LeaseGroupGrant"
+		ar.Resp, ar.Err = a.applyV3.LeaseGroupGrant(r.LeaseGroupGrant)
+	case r.LeaseGroupRevoke != nil:
+		op = "LeaseGroupRevoke"
+		ar.Resp, ar.Err = a.applyV3.LeaseGroupRevoke(r.LeaseGroupRevoke)
+	case r.LeaseGroupAttach != nil:
+		op = "LeaseGroupAttach"
+		ar.Resp, ar.Err = a.applyV3.LeaseGroupAttach(r.LeaseGroupAttach)
+	case r.LeaseGroupDetach != nil:
+		op = "LeaseGroupDetach"
+		ar.Resp, ar.Err = a.applyV3.LeaseGroupDetach(r.LeaseGroupDetach)
 	case r.LeaseCheckpoint != nil:
 		op = "LeaseCheckpoint"
 		ar.Resp, ar.Err = a.applyV3.LeaseCheckpoint(r.LeaseCheckpoint)
+	case r.RevisionTime != nil:
+		op = "RevisionTime"
+		a.applyV3.RevisionTime(r.RevisionTime)
 	case r.Alarm != nil:
 		op = "Alarm"
 		ar.Resp, ar.Err = a.Alarm(r.Alarm)
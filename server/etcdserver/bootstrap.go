@@ -198,8 +198,8 @@ func bootstrapSnapshot(cfg config.ServerConfig) *snap.Snapshotter {
 	return snap.New(cfg.Logger, cfg.SnapDir())
 }
 
-func bootstrapBackend(cfg config.ServerConfig, haveWAL bool, st v2store.Store, ss *snap.Snapshotter) (backend *bootstrappedBackend, err error) {
-	beExist := fileutil.Exist(cfg.BackendPath())
+func bootstrapBackend(cfg config.ServerConfig, haveWAL bool, st v2store.Store, ss *snap.Snapshotter) (bb *bootstrappedBackend, err error) {
+	beExist := backend.Exist(cfg.BackendPath())
 	ci := cindex.NewConsistentIndex(nil)
 	beHooks := serverstorage.NewBackendHooks(cfg.Logger, ci)
 	be := serverstorage.OpenBackend(cfg, beHooks)
@@ -604,7 +604,7 @@ func openWALFromSnapshot(cfg config.ServerConfig, snapshot *raftpb.Snapshot) (*w
 	}
 	repaired := false
 	for {
-		w, err := wal.Open(cfg.Logger, cfg.WALDir(), walsnap)
+		w, err := wal.Open(cfg.Logger, cfg.WALDir(), walsnap, walOptions(cfg)...)
 		if err != nil {
 			cfg.Logger.Fatal("failed to open WAL", zap.Error(err))
 		}
@@ -635,6 +635,13 @@ func openWALFromSnapshot(cfg config.ServerConfig, snapshot *raftpb.Snapshot) (*w
 	}
 }
 
+func walOptions(cfg config.ServerConfig) []wal.Option {
+	return []wal.Option{
+		wal.WithSegmentSizeBytes(cfg.WALSegmentSizeBytes),
+		wal.WithCompression(cfg.WALCompression),
+	}
+}
+
 type snapshotMetadata struct {
 	nodeID, clusterID types.ID
 }
@@ -646,7 +653,7 @@ func bootstrapNewWAL(cfg config.ServerConfig, cl *bootstrappedCluster) *bootstra
 			ClusterID: uint64(cl.cl.ID()),
 		},
 	)
-	w, err := wal.Create(cfg.Logger, cfg.WALDir(), metadata)
+	w, err := wal.Create(cfg.Logger, cfg.WALDir(), metadata, walOptions(cfg)...)
 	if err != nil {
 		cfg.Logger.Panic("failed to create WAL", zap.Error(err))
 	}
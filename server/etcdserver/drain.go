@@ -0,0 +1,174 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/membershippb"
+	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+	"go.etcd.io/etcd/server/v3/etcdserver/errors"
+	"go.etcd.io/raft/v3"
+)
+
+// IsDraining returns true if the local member is being drained for maintenance.
+func (s *EtcdServer) IsDraining() bool {
+	m := s.cluster.Member(s.MemberID())
+	return m != nil && m.Draining
+}
+
+// Drain marks the local member as draining and transfers the leadership away
+// from it. Once marked, the member notifies DrainNotify so that its watch and
+// lease keep alive streams are canceled and its client servers stop, and it
+// reports not ready. Drain is refused if the other voting members that are
+// connected and not draining themselves would not form a quorum. The mark is
+// cleared by Undrain or when the member restarts and publishes its attributes.
+func (s *EtcdServer) Drain(ctx context.Context) error {
+	if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+		return errors.ErrNotCapable
+	}
+	if !s.IsDraining() {
+		if err := s.mayDrain(); err != nil {
+			return err
+		}
+		if err := s.setDraining(ctx, true); err != nil {
+			return err
+		}
+		s.Logger().Info("member is draining", zap.String("local-member-id", s.MemberID().String()))
+		s.drainStarted.Notify()
+	}
+	if s.isLeader() {
+		return s.transferLeadershipAway(ctx)
+	}
+	return nil
+}
+
+// Undrain clears the draining mark of the local member. The client servers
+// started by embed stop once the member drains, so it is only reachable
+// through servers that do not watch DrainNotify.
+func (s *EtcdServer) Undrain(ctx context.Context) error {
+	if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+		return errors.ErrNotCapable
+	}
+	if !s.IsDraining() {
+		return nil
+	}
+	if err := s.setDraining(ctx, false); err != nil {
+		return err
+	}
+	s.Logger().Info("member is no longer draining", zap.String("local-member-id", s.MemberID().String()))
+	return nil
+}
+
+// mayDrain checks that the voting members other than the local one, which
+// are connected to it and not draining, form a quorum.
+func (s *EtcdServer) mayDrain() error {
+	if s.IsLearner() {
+		return nil
+	}
+	voters := s.cluster.VotingMembers()
+	healthy := 0
+	for _, m := range voters {
+		if m.ID == s.MemberID() || m.Draining {
+			continue
+		}
+		if !s.r.transport.ActiveSince(m.ID).IsZero() {
+			healthy++
+		}
+	}
+	if quorum := len(voters)/2 + 1; healthy < quorum {
+		s.Logger().Warn(
+			"rejecting drain; the remaining members would not form a healthy quorum",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.Int("voting-members", len(voters)),
+			zap.Int("healthy-remaining-members", healthy),
+			zap.Int("quorum", quorum),
+		)
+		return errors.ErrDrainUnsafe
+	}
+	return nil
+}
+
+// setDraining replicates the draining mark of the local member through raft
+// by updating its attributes.
+func (s *EtcdServer) setDraining(ctx context.Context, draining bool) error {
+	m := s.cluster.Member(s.MemberID())
+	if m == nil {
+		return membership.ErrIDNotFound
+	}
+	req := &membershippb.ClusterMemberAttrSetRequest{
+		Member_ID: uint64(m.ID),
+		MemberAttributes: &membershippb.Attributes{
+			Name:       m.Name,
+			ClientUrls: m.ClientURLs,
+			Labels:     m.Labels,
+			Draining:   draining,
+		},
+	}
+	_, err := s.raftRequest(ctx, pb.InternalRaftRequest{ClusterMemberAttrSet: req})
+	return err
+}
+
+// transferLeadershipAway moves the leadership to the longest connected voting
+// member which is neither draining nor a witness. A witness cannot send
+// snapshots to the members keeping data, so it only considers the ones which
+// replicated the most of its log.
+func (s *EtcdServer) transferLeadershipAway(ctx context.Context) error {
+	if !s.hasMultipleVotingMembers() {
+		return nil
+	}
+	var candidates []types.ID
+	for _, m := range s.cluster.VotingMembers() {
+		if m.ID != s.MemberID() && !m.Draining && !m.IsWitness {
+			candidates = append(candidates, m.ID)
+		}
+	}
+	if s.IsWitness() {
+		candidates = mostReplicated(s.r.Status(), candidates)
+	}
+	transferee, ok := longestConnected(s.r.transport, candidates)
+	if !ok {
+		return errors.ErrUnhealthy
+	}
+	ctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	return s.MoveLeader(ctx, s.Lead(), uint64(transferee))
+}
+
+// mostReplicated returns the members of membs whose log matches the most of
+// the leader log.
+func mostReplicated(st raft.Status, membs []types.ID) []types.ID {
+	var most []types.ID
+	var match uint64
+	for _, id := range membs {
+		pr, ok := st.Progress[uint64(id)]
+		if !ok {
+			continue
+		}
+		switch {
+		case pr.Match > match:
+			match = pr.Match
+			most = []types.ID{id}
+		case pr.Match == match:
+			most = append(most, id)
+		}
+	}
+	return most
+}
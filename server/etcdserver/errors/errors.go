@@ -42,6 +42,9 @@ var (
 	ErrClusterVersionUnavailable   = errors.New("etcdserver: cluster version not found during downgrade")
 	ErrWrongDowngradeVersionFormat = errors.New("etcdserver: wrong downgrade target version format")
 	ErrKeyNotFound                 = errors.New("etcdserver: key not found")
+	ErrNotCapable                  = errors.New("etcdserver: not capable")
+	ErrDrainUnsafe                 = errors.New("etcdserver: draining the member would leave no healthy quorum")
+	ErrNotSupportedForWitness      = errors.New("etcdserver: rpc not supported for witness")
 )
 
 type DiscoveryError struct {
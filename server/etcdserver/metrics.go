@@ -59,6 +59,33 @@ var (
 		Name:      "learner_promote_successes",
 		Help:      "The total number of successful learner promotions while this member is leader.",
 	})
+	learnerAutoPromoteFailed = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "etcd",
+			Subsystem: "server",
+			Name:      "learner_auto_promote_failures",
+			Help:      "The total number of failed automatic learner promotions while this member is leader, by reason (not_ready, too_many_learners, timeout or other).",
+		},
+		[]string{"Reason"},
+	)
+	learnerAutoPromoteSucceed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "learner_auto_promote_successes",
+		Help:      "The total number of successful automatic learner promotions while this member is leader.",
+	})
+	learnerAutoPromotePending = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "learner_auto_promote_pending",
+		Help:      "The number of learners waiting to be promoted automatically while this member is leader.",
+	})
+	votersZoneConcentrated = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "voters_zone_concentrated",
+		Help:      "Whether a quorum of the voting members is in a single zone (1) or not (0), as seen by the leader.",
+	})
 	heartbeatSendFailures = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd",
 		Subsystem: "server",
@@ -107,6 +134,18 @@ var (
 		Name:      "read_indexes_failed_total",
 		Help:      "The total number of failed read indexes seen.",
 	})
+	leaseReads = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "lease_reads_total",
+		Help:      "The total number of linearizable read batches confirmed by the read lease without a read index.",
+	})
+	leaseReadFallbacks = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd",
+		Subsystem: "server",
+		Name:      "lease_read_fallbacks_total",
+		Help:      "The total number of linearizable read batches falling back to a read index as the read lease did not hold.",
+	})
 	leaseExpired = prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "etcd_debugging",
 		Subsystem: "server",
@@ -173,6 +212,8 @@ func init() {
 	prometheus.MustRegister(proposalsFailed)
 	prometheus.MustRegister(slowReadIndex)
 	prometheus.MustRegister(readIndexFailed)
+	prometheus.MustRegister(leaseReads)
+	prometheus.MustRegister(leaseReadFallbacks)
 	prometheus.MustRegister(leaseExpired)
 	prometheus.MustRegister(currentVersion)
 	prometheus.MustRegister(currentGoVersion)
@@ -180,6 +221,10 @@ func init() {
 	prometheus.MustRegister(serverFeatureEnabled)
 	prometheus.MustRegister(learnerPromoteSucceed)
 	prometheus.MustRegister(learnerPromoteFailed)
+	prometheus.MustRegister(learnerAutoPromoteSucceed)
+	prometheus.MustRegister(learnerAutoPromoteFailed)
+	prometheus.MustRegister(learnerAutoPromotePending)
+	prometheus.MustRegister(votersZoneConcentrated)
 	prometheus.MustRegister(fdUsed)
 	prometheus.MustRegister(fdLimit)
 
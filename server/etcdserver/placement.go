@@ -0,0 +1,153 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+)
+
+// leaderPlacementCheckInterval is how often the leader checks the zones of
+// the voting members and whether it carries the preferred leader labels.
+var leaderPlacementCheckInterval = 5 * time.Second
+
+// monitorLeaderPlacement warns when a quorum of the voting members is in a
+// single zone, and transfers the leadership away from a draining or witness
+// leader, or to a voting member carrying the PreferredLeaderLabels if the
+// leader does not carry them itself.
+func (s *EtcdServer) monitorLeaderPlacement() {
+	lg := s.Logger()
+	var warnedZone string
+	for {
+		select {
+		case <-time.After(leaderPlacementCheckInterval):
+		case <-s.stopping:
+			return
+		}
+
+		if !s.isLeader() {
+			warnedZone = ""
+			votersZoneConcentrated.Set(0)
+			continue
+		}
+
+		zone, concentrated := concentratedZone(s.cluster.VotingMembers())
+		if concentrated {
+			votersZoneConcentrated.Set(1)
+			if zone != warnedZone {
+				lg.Warn(
+					"a quorum of voting members is in a single zone; losing the zone loses the quorum",
+					zap.String("zone", zone),
+				)
+			}
+		} else {
+			votersZoneConcentrated.Set(0)
+		}
+		warnedZone = zone
+
+		if s.IsDraining() || s.IsWitness() {
+			// the draining member may have been elected again, and a witness
+			// may have failed to hand over the leadership when elected.
+			ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+			if err := s.transferLeadershipAway(ctx); err != nil {
+				lg.Warn("failed to transfer leadership away", zap.Error(err))
+			}
+			cancel()
+			continue
+		}
+
+		if len(s.Cfg.PreferredLeaderLabels) == 0 {
+			continue
+		}
+		if local := s.cluster.Member(s.MemberID()); local == nil || local.HasLabels(s.Cfg.PreferredLeaderLabels) {
+			continue
+		}
+		transferee, ok := s.preferredLeaderTransferee()
+		if !ok {
+			continue
+		}
+
+		lg.Info(
+			"leader does not carry the preferred leader labels; transferring leadership",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.String("transferee-member-id", transferee.String()),
+			zap.Any("preferred-leader-labels", s.Cfg.PreferredLeaderLabels),
+		)
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		err := s.MoveLeader(ctx, s.Lead(), uint64(transferee))
+		cancel()
+		if err != nil {
+			lg.Warn(
+				"failed to transfer leadership to preferred member",
+				zap.String("transferee-member-id", transferee.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// preferredLeaderTransferee returns the started, connected, not draining and
+// not witness voting member carrying the preferred leader labels that is the
+// most caught up with the leader.
+func (s *EtcdServer) preferredLeaderTransferee() (types.ID, bool) {
+	rs := s.raftStatus()
+	var (
+		transferee types.ID
+		match      uint64
+		found      bool
+	)
+	for _, m := range s.cluster.VotingMembers() {
+		if m.ID == s.MemberID() || m.Draining || m.IsWitness || !m.IsStarted() || !m.HasLabels(s.Cfg.PreferredLeaderLabels) {
+			continue
+		}
+		if s.r.transport.ActiveSince(m.ID).IsZero() {
+			continue
+		}
+		pr, ok := rs.Progress[uint64(m.ID)]
+		if !ok {
+			continue
+		}
+		if !found || pr.Match > match {
+			transferee, match, found = m.ID, pr.Match, true
+		}
+	}
+	return transferee, found
+}
+
+// concentratedZone returns the zone holding a quorum of the given voting
+// members, if any. Members without a zone label are not counted in any zone.
+func concentratedZone(voters []*membership.Member) (string, bool) {
+	if len(voters) < 2 {
+		return "", false
+	}
+	counts := make(map[string]int)
+	for _, m := range voters {
+		if zone, ok := m.Labels[membership.ZoneLabel]; ok {
+			counts[zone]++
+		}
+	}
+	quorum := len(voters)/2 + 1
+	for zone, n := range counts {
+		if n >= quorum {
+			return zone, true
+		}
+	}
+	return "", false
+}
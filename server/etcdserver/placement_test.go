@@ -0,0 +1,81 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/server/v3/etcdserver/api/membership"
+)
+
+func TestConcentratedZone(t *testing.T) {
+	voter := func(zone string) *membership.Member {
+		m := &membership.Member{}
+		if zone != "" {
+			m.Labels = map[string]string{membership.ZoneLabel: zone}
+		}
+		return m
+	}
+	tests := []struct {
+		name         string
+		voters       []*membership.Member
+		wantZone     string
+		concentrated bool
+	}{
+		{
+			name:   "single voter",
+			voters: []*membership.Member{voter("a")},
+		},
+		{
+			name:   "no zone labels",
+			voters: []*membership.Member{voter(""), voter(""), voter("")},
+		},
+		{
+			name:   "spread across zones",
+			voters: []*membership.Member{voter("a"), voter("b"), voter("c")},
+		},
+		{
+			name:   "minority in one zone",
+			voters: []*membership.Member{voter("a"), voter("a"), voter("b"), voter("b"), voter("c")},
+		},
+		{
+			name:         "quorum in one zone",
+			voters:       []*membership.Member{voter("a"), voter("a"), voter("b")},
+			wantZone:     "a",
+			concentrated: true,
+		},
+		{
+			name:         "all in one zone",
+			voters:       []*membership.Member{voter("a"), voter("a"), voter("a")},
+			wantZone:     "a",
+			concentrated: true,
+		},
+		{
+			name:         "quorum in one zone with unlabeled voters",
+			voters:       []*membership.Member{voter("a"), voter("a"), voter("a"), voter(""), voter("")},
+			wantZone:     "a",
+			concentrated: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zone, concentrated := concentratedZone(tt.voters)
+			assert.Equal(t, tt.wantZone, zone)
+			assert.Equal(t, tt.concentrated, concentrated)
+		})
+	}
+}
@@ -284,11 +284,13 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 
 				r.raftStorage.Append(rd.Entries)
 
-				confChanged := false
+				confChanged, jointChanged := false, false
 				for _, ent := range rd.CommittedEntries {
-					if ent.Type == raftpb.EntryConfChange {
+					switch ent.Type {
+					case raftpb.EntryConfChange:
 						confChanged = true
-						break
+					case raftpb.EntryConfChangeV2:
+						confChanged, jointChanged = true, true
 					}
 				}
 
@@ -323,6 +325,19 @@ func (r *raftNode) start(rh *raftReadyHandler) {
 				} else {
 					// leader already processed 'MsgSnap' and signaled
 					notifyc <- struct{}{}
+
+					// The leader leaves a joint configuration on its own once
+					// raft considers the entry entering it applied. Wait for
+					// ConfChangeV2 entries to be applied before advancing, so
+					// that raft does not act on a configuration the toApply
+					// layer has not switched to yet.
+					if jointChanged {
+						select {
+						case notifyc <- struct{}{}:
+						case <-r.stopped:
+							return
+						}
+					}
 				}
 
 				// gofail: var raftBeforeAdvance struct{}
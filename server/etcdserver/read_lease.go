@@ -0,0 +1,160 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"sync"
+	"time"
+)
+
+// campaignTransfer is the context of the votes raft requests for the
+// transferee of a leadership transfer.
+const campaignTransfer = "CampaignTransfer"
+
+// readLease is a time-bounded lease of the leader on the read state it last
+// confirmed. While the lease holds, the leader serves linearizable reads at
+// its committed index, which includes every write it acknowledged, without
+// confirming its leadership with a heartbeat round. Followers do not serve
+// reads within a lease, as their committed index may lag behind the writes
+// the leader acknowledged.
+//
+// The leader holds its leadership for an election timeout after hearing from
+// a quorum, as raft with check quorum keeps the other members from electing
+// a new leader in the meantime. The lease is shorter than the election
+// timeout by the maximum clock drift between members, and starts when the
+// ReadIndex request was sent, before raft confirmed it with a heartbeat
+// round. It is bound to the term it was confirmed in, so that it is lost on
+// leader changes.
+//
+// A leadership transfer elects the transferee without waiting for the leader
+// lease to expire, so the lease is invalidated by the leader before it
+// transfers the leadership, and by the members campaigning or voting for the
+// transferee.
+type readLease struct {
+	duration time.Duration
+	// now returns the current time. Tests skew it to inject clock faults.
+	now func() time.Time
+
+	mu    sync.Mutex
+	lead  uint64
+	term  uint64
+	index uint64
+	start time.Time
+	// notBefore is when the lease was last invalidated. ReadIndex requests
+	// sent before do not renew the lease.
+	notBefore time.Time
+	// suspended counts the leadership transfers in progress, during which
+	// the lease is not renewed.
+	suspended int
+}
+
+func newReadLease(electionTimeout, maxClockDrift time.Duration) *readLease {
+	return &readLease{duration: electionTimeout - maxClockDrift, now: readLeaseClock}
+}
+
+func readLeaseClock() time.Time {
+	now := time.Now()
+	// gofail: var leaseReadClockSkew string
+	// if skew, err := time.ParseDuration(leaseReadClockSkew); err == nil {
+	// 	now = now.Add(skew)
+	// }
+	return now
+}
+
+// renew extends the lease with the read state confirmed by the given leader
+// in the given term for a ReadIndex request sent at start.
+func (l *readLease) renew(lead, term, index uint64, start time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.suspended > 0 || !start.After(l.notBefore) {
+		return
+	}
+	if lead != l.lead || term != l.term {
+		l.lead, l.term, l.index, l.start = lead, term, index, start
+		return
+	}
+	if start.After(l.start) {
+		l.start = start
+	}
+	if index > l.index {
+		l.index = index
+	}
+}
+
+// confirmedIndex returns the read state confirmed by the lease, and whether
+// the lease holds for the current leader and term.
+func (l *readLease) confirmedIndex(lead, term uint64) (uint64, bool) {
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if lead == 0 || lead != l.lead || term != l.term || l.start.IsZero() {
+		return 0, false
+	}
+	// a clock going backwards cannot tell how long the lease has been held.
+	if elapsed := now.Sub(l.start); elapsed < 0 || elapsed >= l.duration {
+		return 0, false
+	}
+	return l.index, true
+}
+
+// invalidate drops the lease, which is no longer renewed by the ReadIndex
+// requests sent before. It is a no-op if lease reads are disabled.
+func (l *readLease) invalidate() {
+	if l == nil {
+		return
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.invalidateLocked(now)
+}
+
+func (l *readLease) invalidateLocked(now time.Time) {
+	l.lead, l.term, l.index, l.start = 0, 0, 0, time.Time{}
+	if now.After(l.notBefore) {
+		l.notBefore = now
+	}
+}
+
+// suspend invalidates the lease and keeps it from being renewed until resume
+// is called, while the local leader transfers the leadership. It is a no-op
+// if lease reads are disabled.
+func (l *readLease) suspend() {
+	if l == nil {
+		return
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.suspended++
+	l.invalidateLocked(now)
+}
+
+// resume lets the lease be renewed again by the ReadIndex requests sent from
+// now on, once a leadership transfer is over.
+func (l *readLease) resume() {
+	if l == nil {
+		return
+	}
+	now := l.now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.suspended--
+	l.invalidateLocked(now)
+}
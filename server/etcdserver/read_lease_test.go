@@ -0,0 +1,169 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestReadLease returns a read lease of a one second election timeout and
+// 100ms maximum clock drift, whose clock is set by the returned function.
+func newTestReadLease() (*readLease, func(time.Time)) {
+	l := newReadLease(time.Second, 100*time.Millisecond)
+	now := time.Now()
+	l.now = func() time.Time { return now }
+	return l, func(t time.Time) { now = t }
+}
+
+func TestReadLease(t *testing.T) {
+	l, setNow := newTestReadLease()
+	start := time.Now()
+	setNow(start)
+
+	_, ok := l.confirmedIndex(1, 2)
+	assert.Falsef(t, ok, "lease held before being renewed")
+
+	l.renew(1, 2, 10, start)
+	index, ok := l.confirmedIndex(1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(10), index)
+
+	// a later read state extends the lease, an earlier one does not lower it.
+	l.renew(1, 2, 12, start.Add(500*time.Millisecond))
+	l.renew(1, 2, 11, start.Add(100*time.Millisecond))
+	setNow(start.Add(time.Second))
+	index, ok = l.confirmedIndex(1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12), index)
+
+	tests := []struct {
+		name string
+		lead uint64
+		term uint64
+		now  time.Time
+		wok  bool
+	}{
+		{"within lease", 1, 2, start.Add(1399 * time.Millisecond), true},
+		{"lease expired", 1, 2, start.Add(1400 * time.Millisecond), false},
+		{"no leader", 0, 2, start.Add(600 * time.Millisecond), false},
+		{"leader changed", 3, 2, start.Add(600 * time.Millisecond), false},
+		{"term changed", 1, 3, start.Add(600 * time.Millisecond), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setNow(tt.now)
+			_, ok := l.confirmedIndex(tt.lead, tt.term)
+			assert.Equal(t, tt.wok, ok)
+		})
+	}
+
+	// a read state confirmed by a new leader replaces the lease.
+	setNow(start.Add(600 * time.Millisecond))
+	l.renew(3, 3, 5, start.Add(600*time.Millisecond))
+	index, ok = l.confirmedIndex(3, 3)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(5), index)
+	_, ok = l.confirmedIndex(1, 2)
+	assert.False(t, ok)
+}
+
+// TestReadLeaseClockSkew injects clock skews on the member holding the lease,
+// which must fall back to ReadIndex once the skewed clock can no longer tell
+// the lease holds.
+func TestReadLeaseClockSkew(t *testing.T) {
+	tests := []struct {
+		name string
+		skew time.Duration
+		wok  bool
+	}{
+		{"no skew", 0, true},
+		{"skew within lease", 700 * time.Millisecond, true},
+		{"clock jumped past lease", 800 * time.Millisecond, false},
+		{"clock jumped backwards", -200 * time.Millisecond, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, setNow := newTestReadLease()
+			start := time.Now()
+			setNow(start)
+			l.renew(1, 2, 10, start)
+
+			setNow(start.Add(100*time.Millisecond + tt.skew))
+			_, ok := l.confirmedIndex(1, 2)
+			assert.Equal(t, tt.wok, ok)
+		})
+	}
+}
+
+func TestReadLeaseInvalidate(t *testing.T) {
+	l, setNow := newTestReadLease()
+	start := time.Now()
+	setNow(start)
+	l.renew(1, 2, 10, start)
+
+	setNow(start.Add(100 * time.Millisecond))
+	l.invalidate()
+	_, ok := l.confirmedIndex(1, 2)
+	assert.Falsef(t, ok, "lease held after being invalidated")
+
+	// a ReadIndex request sent before the lease was invalidated does not
+	// renew it.
+	l.renew(1, 2, 11, start.Add(50*time.Millisecond))
+	_, ok = l.confirmedIndex(1, 2)
+	assert.False(t, ok)
+
+	setNow(start.Add(200 * time.Millisecond))
+	l.renew(1, 2, 11, start.Add(200*time.Millisecond))
+	index, ok := l.confirmedIndex(1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(11), index)
+}
+
+func TestReadLeaseSuspend(t *testing.T) {
+	l, setNow := newTestReadLease()
+	start := time.Now()
+	setNow(start)
+	l.renew(1, 2, 10, start)
+
+	// the lease is not renewed during a leadership transfer.
+	setNow(start.Add(100 * time.Millisecond))
+	l.suspend()
+	l.renew(1, 2, 11, start.Add(200*time.Millisecond))
+	_, ok := l.confirmedIndex(1, 2)
+	assert.Falsef(t, ok, "lease held during a leadership transfer")
+
+	// nor by the ReadIndex requests sent during the transfer once it is over.
+	setNow(start.Add(300 * time.Millisecond))
+	l.resume()
+	l.renew(1, 2, 11, start.Add(200*time.Millisecond))
+	_, ok = l.confirmedIndex(1, 2)
+	assert.False(t, ok)
+
+	setNow(start.Add(400 * time.Millisecond))
+	l.renew(1, 2, 12, start.Add(400*time.Millisecond))
+	index, ok := l.confirmedIndex(1, 2)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(12), index)
+}
+
+func TestReadLeaseDisabled(t *testing.T) {
+	var l *readLease
+	l.invalidate()
+	l.suspend()
+	l.resume()
+}
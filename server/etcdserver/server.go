@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"sync"
 	"sync/atomic"
@@ -108,6 +109,14 @@ const (
 	// whether a learner is ready for a transition into a full voting member or not.
 	readyPercentThreshold = 0.9
 
+	// replaceRetryInterval is how often a member replacement checks again
+	// whether the replacing learner has caught up with the leader.
+	replaceRetryInterval = 500 * time.Millisecond
+
+	// learnerAutoPromoteCheckInterval is how often the leader checks whether
+	// learners added with auto promote are ready to be promoted.
+	learnerAutoPromoteCheckInterval = time.Second
+
 	DowngradeEnabledPath = "/downgrade/enabled"
 	memorySnapshotCount  = 100
 )
@@ -173,6 +182,13 @@ type Server interface {
 	// return ErrLearnerNotReady if the member are not ready.
 	// return ErrMemberNotLearner if the member is not a learner.
 	PromoteMember(ctx context.Context, id uint64) ([]*membership.Member, error)
+	// PromoteReplacement attempts to promote a non-voting node to a voting node
+	// in place of another voting node, in a single configuration change. It will
+	// return ErrIDNotFound if either member ID does not exist.
+	// return ErrLearnerNotReady if the learner is not ready.
+	// return ErrMemberNotLearner if the replacing member is not a learner.
+	// return ErrMemberIsLearner if the replaced member is a learner.
+	PromoteReplacement(ctx context.Context, id, learnerID uint64) ([]*membership.Member, error)
 
 	// ClusterVersion is the cluster-wide minimum major.minor version.
 	// Cluster version is set to the min version that an etcd member is
@@ -232,6 +248,10 @@ type EtcdServer struct {
 	// readNotifier is used to notify the read routine that it can process the request
 	// when there is no error
 	readNotifier *notifier
+	// readLease confirms the read state of linearizable reads without a
+	// ReadIndex round trip while it holds. It is nil unless lease reads are
+	// enabled.
+	readLease *readLease
 
 	// stop signals the run goroutine should shutdown.
 	stop chan struct{}
@@ -241,6 +261,8 @@ type EtcdServer struct {
 	done chan struct{}
 	// leaderChanged is used to notify the linearizable read loop to drop the old read requests.
 	leaderChanged *notify.Notifier
+	// drainStarted is used to notify the client servers that the local member started draining.
+	drainStarted *notify.Notifier
 
 	errorc     chan error
 	memberID   types.ID
@@ -328,7 +350,7 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 		snapshotter:           b.ss,
 		r:                     *b.raft.newRaftNode(b.ss, b.storage.wal.w, b.cluster.cl),
 		memberID:              b.cluster.nodeID,
-		attributes:            membership.Attributes{Name: cfg.Name, ClientURLs: cfg.ClientURLs.StringSlice()},
+		attributes:            membership.Attributes{Name: cfg.Name, ClientURLs: cfg.ClientURLs.StringSlice(), Labels: cfg.MemberLabels},
 		cluster:               b.cluster.cl,
 		stats:                 sstats,
 		lstats:                lstats,
@@ -418,17 +440,19 @@ func NewServer(cfg config.ServerConfig) (srv *EtcdServer, err error) {
 
 	// TODO: move transport initialization near the definition of remote
 	tr := &rafthttp.Transport{
-		Logger:      cfg.Logger,
-		TLSInfo:     cfg.PeerTLSInfo,
-		DialTimeout: cfg.PeerDialTimeout(),
-		ID:          b.cluster.nodeID,
-		URLs:        cfg.PeerURLs,
-		ClusterID:   b.cluster.cl.ID(),
-		Raft:        srv,
-		Snapshotter: b.ss,
-		ServerStats: sstats,
-		LeaderStats: lstats,
-		ErrorC:      srv.errorc,
+		Logger:        cfg.Logger,
+		TLSInfo:       cfg.PeerTLSInfo,
+		DialTimeout:   cfg.PeerDialTimeout(),
+		PeerTransport: cfg.PeerTransport,
+		Compression:   cfg.PeerCompression,
+		ID:            b.cluster.nodeID,
+		URLs:          cfg.PeerURLs,
+		ClusterID:     b.cluster.cl.ID(),
+		Raft:          srv,
+		Snapshotter:   b.ss,
+		ServerStats:   sstats,
+		LeaderStats:   lstats,
+		ErrorC:        srv.errorc,
 	}
 	if err = tr.Start(); err != nil {
 		return nil, err
@@ -546,7 +570,10 @@ func (s *EtcdServer) Start() {
 	s.GoAttach(s.linearizableReadLoop)
 	s.GoAttach(s.monitorKVHash)
 	s.GoAttach(s.monitorCompactHash)
+	s.GoAttach(s.monitorRevisionTime)
 	s.GoAttach(s.monitorDowngrade)
+	s.GoAttach(s.monitorLearnerPromotion)
+	s.GoAttach(s.monitorLeaderPlacement)
 }
 
 // start prepares and starts server in a new goroutine. It is no longer safe to
@@ -580,7 +607,11 @@ func (s *EtcdServer) start() {
 	s.ctx, s.cancel = context.WithCancel(context.Background())
 	s.readwaitc = make(chan struct{}, 1)
 	s.readNotifier = newNotifier()
+	if s.Cfg.LeaseRead {
+		s.readLease = newReadLease(s.Cfg.ElectionTimeout(), s.Cfg.LeaseReadMaxClockDrift)
+	}
 	s.leaderChanged = notify.NewNotifier()
+	s.drainStarted = notify.NewNotifier()
 	if s.ClusterVersion() != nil {
 		lg.Info(
 			"starting etcd server",
@@ -726,6 +757,11 @@ func (s *EtcdServer) Process(ctx context.Context, m raftpb.Message) error {
 		)
 		return httptypes.NewHTTPError(http.StatusForbidden, "cannot process message to mismatch member")
 	}
+	if m.Type == raftpb.MsgTimeoutNow || (m.Type == raftpb.MsgVote && string(m.Context) == campaignTransfer) {
+		// a leadership transfer elects the transferee without waiting for
+		// the leader lease to expire.
+		s.readLease.invalidate()
+	}
 	if m.Type == raftpb.MsgApp {
 		s.stats.RecvAppendReq(types.ID(m.From).String(), m.Size())
 	}
@@ -789,7 +825,16 @@ func (s *EtcdServer) run() {
 					s.leadElectedTime = t
 					s.leadTimeMu.Unlock()
 				}
-				if s.compactor != nil {
+				if newLeader && s.IsWitness() {
+					// a witness has no data to serve; it stays leader only
+					// until a member keeping data has caught up with its log.
+					s.GoAttach(func() {
+						if err := s.transferLeadershipAway(s.ctx); err != nil {
+							lg.Warn("failed to transfer leadership from witness", zap.Error(err))
+						}
+					})
+				}
+				if s.compactor != nil && !s.IsWitness() {
 					s.compactor.Resume()
 				}
 			}
@@ -860,6 +905,20 @@ func (s *EtcdServer) run() {
 	}
 }
 
+// leaseExpire revokes an expired lease. Clusters that understand
+// LeaseExpire record it as an expiry so watchers can tell it apart from
+// an explicit revoke; older clusters fall back to LeaseRevoke.
+func (s *EtcdServer) leaseExpire(ctx context.Context, r *pb.LeaseRevokeRequest) (*pb.LeaseRevokeResponse, error) {
+	if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+		return s.LeaseRevoke(ctx, r)
+	}
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{LeaseExpire: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.LeaseRevokeResponse), nil
+}
+
 func (s *EtcdServer) revokeExpiredLeases(leases []*lease.Lease) {
 	s.GoAttach(func() {
 		// We shouldn't revoke any leases if current member isn't a leader,
@@ -887,7 +946,7 @@ func (s *EtcdServer) revokeExpiredLeases(leases []*lease.Lease) {
 			f := func(lid int64) {
 				s.GoAttach(func() {
 					ctx := s.authStore.WithRoot(s.ctx)
-					_, lerr := s.LeaseRevoke(ctx, &pb.LeaseRevokeRequest{ID: lid})
+					_, lerr := s.leaseExpire(ctx, &pb.LeaseRevokeRequest{ID: lid})
 					if lerr == nil {
 						leaseExpired.Inc()
 					} else {
@@ -985,6 +1044,17 @@ func (s *EtcdServer) applyAll(ep *etcdProgress, apply *toApply) {
 	select {
 	// snapshot requested via send()
 	case m := <-s.r.msgSnapC:
+		if s.IsWitness() && !s.isWitnessMember(types.ID(m.To)) {
+			// the backend of a witness is empty and would wipe the data of
+			// the receiver.
+			s.Logger().Warn(
+				"witness refused to send snapshot",
+				zap.String("local-member-id", s.MemberID().String()),
+				zap.String("remote-peer-id", types.ID(m.To).String()),
+			)
+			s.r.ReportSnapshot(m.To, raft.SnapshotFailure)
+			break
+		}
 		merged := s.createMergedSnapshotMessage(m, ep.appliedt, ep.appliedi, ep.confState)
 		s.sendMergedSnap(merged)
 	default:
@@ -1029,8 +1099,53 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	// wait for raftNode to persist snapshot onto the disk
 	<-toApply.notifyc
 
+	if s.IsWitness() {
+		// a witness keeps no key-value data, so its snapshots come without a backend.
+		s.recoverWitnessFromSnapshot(toApply.snapshot)
+	} else {
+		s.recoverBackendFromSnapshot(toApply.snapshot)
+	}
+
+	lg.Info("restoring cluster configuration")
+
+	s.cluster.Recover(api.UpdateCapability)
+
+	lg.Info("restored cluster configuration")
+	lg.Info("removing old peers from network")
+
+	// recover raft transport
+	s.r.transport.RemoveAllPeers()
+
+	lg.Info("removed old peers from network")
+	lg.Info("adding peers from new cluster configuration")
+
+	for _, m := range s.cluster.Members() {
+		if m.ID == s.MemberID() {
+			continue
+		}
+		s.r.transport.AddPeer(m.ID, m.PeerURLs)
+	}
+
+	lg.Info("added peers from new cluster configuration")
+
+	ep.appliedt = toApply.snapshot.Metadata.Term
+	ep.appliedi = toApply.snapshot.Metadata.Index
+	ep.diskSnapshotIndex = ep.appliedi
+	ep.memorySnapshotIndex = ep.appliedi
+	ep.confState = toApply.snapshot.Metadata.ConfState
+
+	// As backends and implementations like alarmsStore changed, we need
+	// to re-bootstrap Appliers.
+	s.uberApply = s.NewUberApplier()
+}
+
+// recoverBackendFromSnapshot replaces the backend with the one received with
+// the snapshot and recovers the stores kept in it.
+func (s *EtcdServer) recoverBackendFromSnapshot(snapshot raftpb.Snapshot) {
+	lg := s.Logger()
+
 	// gofail: var applyBeforeOpenSnapshot struct{}
-	newbe, err := serverstorage.OpenSnapshotBackend(s.Cfg, s.snapshotter, toApply.snapshot, s.beHooks)
+	newbe, err := serverstorage.OpenSnapshotBackend(s.Cfg, s.snapshotter, snapshot, s.beHooks)
 	if err != nil {
 		lg.Panic("failed to open snapshot backend", zap.Error(err))
 	}
@@ -1041,7 +1156,7 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	// Eventually the new consistent_index value coming from snapshot is overwritten
 	// by the old value.
 	s.consistIndex.SetBackend(newbe)
-	verifySnapshotIndex(toApply.snapshot, s.consistIndex.ConsistentIndex())
+	verifySnapshotIndex(snapshot, s.consistIndex.ConsistentIndex())
 
 	// always recover lessor before kv. When we recover the mvcc.KV it will reattach keys to its leases.
 	// If we recover mvcc.KV first, it will attach the keys to the wrong lessor before it recovers.
@@ -1098,7 +1213,7 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	}
 
 	lg.Info("restoring v2 store")
-	if err := s.v2store.Recovery(toApply.snapshot.Data); err != nil {
+	if err := s.v2store.Recovery(snapshot.Data); err != nil {
 		lg.Panic("failed to restore v2 store", zap.Error(err))
 	}
 
@@ -1109,38 +1224,6 @@ func (s *EtcdServer) applySnapshot(ep *etcdProgress, toApply *toApply) {
 	lg.Info("restored v2 store")
 
 	s.cluster.SetBackend(schema.NewMembershipBackend(lg, newbe))
-
-	lg.Info("restoring cluster configuration")
-
-	s.cluster.Recover(api.UpdateCapability)
-
-	lg.Info("restored cluster configuration")
-	lg.Info("removing old peers from network")
-
-	// recover raft transport
-	s.r.transport.RemoveAllPeers()
-
-	lg.Info("removed old peers from network")
-	lg.Info("adding peers from new cluster configuration")
-
-	for _, m := range s.cluster.Members() {
-		if m.ID == s.MemberID() {
-			continue
-		}
-		s.r.transport.AddPeer(m.ID, m.PeerURLs)
-	}
-
-	lg.Info("added peers from new cluster configuration")
-
-	ep.appliedt = toApply.snapshot.Metadata.Term
-	ep.appliedi = toApply.snapshot.Metadata.Index
-	ep.diskSnapshotIndex = ep.appliedi
-	ep.memorySnapshotIndex = ep.appliedi
-	ep.confState = toApply.snapshot.Metadata.ConfState
-
-	// As backends and implementations like alarmsStore changed, we need
-	// to re-bootstrap Appliers.
-	s.uberApply = s.NewUberApplier()
 }
 
 func (s *EtcdServer) NewUberApplier() apply.UberApplier {
@@ -1238,7 +1321,7 @@ func (s *EtcdServer) isLeader() bool {
 // MoveLeader transfers the leader to the given transferee.
 func (s *EtcdServer) MoveLeader(ctx context.Context, lead, transferee uint64) error {
 	member := s.cluster.Member(types.ID(transferee))
-	if member == nil || member.IsLearner {
+	if member == nil || member.IsLearner || member.IsWitness {
 		return errors.ErrBadLeaderTransferee
 	}
 
@@ -1253,6 +1336,10 @@ func (s *EtcdServer) MoveLeader(ctx context.Context, lead, transferee uint64) er
 		zap.String("transferee-member-id", types.ID(transferee).String()),
 	)
 
+	// the transferee is elected without waiting for the leader lease to
+	// expire, so reads are no longer served within the read lease.
+	s.readLease.suspend()
+	defer s.readLease.resume()
 	s.r.TransferLeadership(ctx, lead, transferee)
 	for s.Lead() != transferee {
 		select {
@@ -1294,7 +1381,13 @@ func (s *EtcdServer) TryTransferLeadershipOnShutdown() error {
 		return nil
 	}
 
-	transferee, ok := longestConnected(s.r.transport, s.cluster.VotingMemberIDs())
+	var candidates []types.ID
+	for _, m := range s.cluster.VotingMembers() {
+		if !m.IsWitness {
+			candidates = append(candidates, m.ID)
+		}
+	}
+	transferee, ok := longestConnected(s.r.transport, candidates)
 	if !ok {
 		return errors.ErrUnhealthy
 	}
@@ -1379,6 +1472,12 @@ func (s *EtcdServer) AddMember(ctx context.Context, memb membership.Member) ([]*
 		return nil, err
 	}
 
+	if memb.AutoPromote || memb.IsWitness {
+		if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+			return nil, errors.ErrNotCapable
+		}
+	}
+
 	// TODO: move Member to protobuf type
 	b, err := json.Marshal(memb)
 	if err != nil {
@@ -1501,7 +1600,12 @@ func (s *EtcdServer) promoteMember(ctx context.Context, id uint64) ([]*membershi
 	if err := s.checkMembershipOperationPermission(ctx); err != nil {
 		return nil, err
 	}
+	return s.promoteLearner(ctx, id)
+}
 
+// promoteLearner promotes the learner without checking the permission of the
+// caller. It is used directly by the leader to promote auto promote learners.
+func (s *EtcdServer) promoteLearner(ctx context.Context, id uint64) ([]*membership.Member, error) {
 	// check if we can promote this learner.
 	if err := s.mayPromoteMember(types.ID(id)); err != nil {
 		return nil, err
@@ -1566,17 +1670,7 @@ func (s *EtcdServer) isLearnerReady(lg *zap.Logger, id uint64) error {
 		return errors.ErrNotLeader
 	}
 
-	var learnerMatch uint64
-	isFound := false
-	leaderID := rs.ID
-	for memberID, progress := range rs.Progress {
-		if id == memberID {
-			// check its status
-			learnerMatch = progress.Match
-			isFound = true
-			break
-		}
-	}
+	learnerReadyPercent, isFound := learnerProgressPercent(rs, id)
 
 	// We should return an error in API directly, to avoid the request
 	// being unnecessarily delivered to raft.
@@ -1584,10 +1678,6 @@ func (s *EtcdServer) isLearnerReady(lg *zap.Logger, id uint64) error {
 		return membership.ErrIDNotFound
 	}
 
-	leaderMatch := rs.Progress[leaderID].Match
-
-	learnerReadyPercent := float64(learnerMatch) / float64(leaderMatch)
-
 	// the learner's Match not caught up with leader yet
 	if learnerReadyPercent < readyPercentThreshold {
 		lg.Error(
@@ -1601,6 +1691,16 @@ func (s *EtcdServer) isLearnerReady(lg *zap.Logger, id uint64) error {
 	return nil
 }
 
+// learnerProgressPercent returns the ratio of the learner's match index to the
+// leader's, or false if the learner is not tracked in the leader's progress.
+func learnerProgressPercent(rs raft.Status, id uint64) (float64, bool) {
+	progress, ok := rs.Progress[id]
+	if !ok {
+		return 0, false
+	}
+	return float64(progress.Match) / float64(rs.Progress[rs.ID].Match), true
+}
+
 func (s *EtcdServer) mayRemoveMember(id types.ID) error {
 	if !s.Cfg.StrictReconfigCheck {
 		return nil
@@ -1645,6 +1745,175 @@ func (s *EtcdServer) mayRemoveMember(id types.ID) error {
 	return nil
 }
 
+// ReplaceMember replaces the voting member id with memb in a single joint
+// consensus configuration change, so the cluster keeps its fault tolerance
+// throughout. memb is added as a learner first, unless a learner with the
+// same peer URLs is already a member, and is promoted in place of the
+// replaced member once it has caught up with the leader. If it does not catch
+// up before ctx is done, ErrLearnerNotReady is returned and the learner stays
+// in the cluster, so that the replacement can be retried.
+func (s *EtcdServer) ReplaceMember(ctx context.Context, id uint64, memb membership.Member) (types.ID, []*membership.Member, error) {
+	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+		return 0, nil, err
+	}
+	// older members cannot apply ConfChangeV2 entries.
+	if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+		return 0, nil, errors.ErrNotCapable
+	}
+	if m := s.cluster.Member(types.ID(id)); m == nil {
+		return 0, nil, membership.ErrIDNotFound
+	} else if m.IsLearner {
+		return 0, nil, membership.ErrMemberIsLearner
+	}
+
+	learnerID := memb.ID
+	if m := s.memberByPeerURLs(memb.PeerURLs); m != nil {
+		if !m.IsLearner {
+			return 0, nil, membership.ErrPeerURLexists
+		}
+		learnerID = m.ID
+	} else {
+		memb.IsLearner = true
+		if _, err := s.AddMember(ctx, memb); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	for {
+		membs, err := s.PromoteReplacement(ctx, id, uint64(learnerID))
+		if !errorspkg.Is(err, errors.ErrLearnerNotReady) {
+			return learnerID, membs, err
+		}
+		select {
+		case <-time.After(replaceRetryInterval):
+		case <-ctx.Done():
+			return 0, nil, err
+		case <-s.stopping:
+			return 0, nil, errors.ErrStopped
+		}
+	}
+}
+
+func (s *EtcdServer) memberByPeerURLs(urls []string) *membership.Member {
+	urls = slices.Sorted(slices.Values(urls))
+	for _, m := range s.cluster.Members() {
+		if slices.Equal(urls, slices.Sorted(slices.Values(m.PeerURLs))) {
+			return m
+		}
+	}
+	return nil
+}
+
+// PromoteReplacement promotes the learner learnerID to a voting node in place
+// of the voting node id. As with PromoteMember, only the raft leader knows
+// whether the learner is ready, so the request is forwarded to the leader
+// via HTTP if the local node is not the leader.
+func (s *EtcdServer) PromoteReplacement(ctx context.Context, id, learnerID uint64) ([]*membership.Member, error) {
+	resp, err := s.promoteReplacement(ctx, id, learnerID)
+	if err == nil || !errorspkg.Is(err, errors.ErrNotLeader) {
+		return resp, err
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+	// forward to leader
+	for cctx.Err() == nil {
+		leader, err := s.waitLeader(cctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, url := range leader.PeerURLs {
+			resp, err := promoteReplacementHTTP(cctx, url, id, learnerID, s.peerRt)
+			if err == nil {
+				return resp, nil
+			}
+			// If the replacement failed, return early. Otherwise keep retry.
+			if errorspkg.Is(err, errors.ErrLearnerNotReady) || errorspkg.Is(err, membership.ErrIDNotFound) ||
+				errorspkg.Is(err, membership.ErrMemberNotLearner) || errorspkg.Is(err, membership.ErrMemberIsLearner) {
+				return nil, err
+			}
+		}
+	}
+
+	if errorspkg.Is(cctx.Err(), context.DeadlineExceeded) {
+		return nil, errors.ErrTimeout
+	}
+	return nil, errors.ErrCanceled
+}
+
+// promoteReplacement checks whether the learner is ready to replace the voting
+// member before sending both changes to raft as a single ConfChangeV2. Like
+// promoteMember, it returns ErrNotLeader if the local node is not the leader.
+func (s *EtcdServer) promoteReplacement(ctx context.Context, id, learnerID uint64) ([]*membership.Member, error) {
+	if err := s.checkMembershipOperationPermission(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.mayPromoteReplacement(types.ID(id), types.ID(learnerID)); err != nil {
+		return nil, err
+	}
+
+	promoteChangeContext := membership.ConfigChangeContext{
+		Member: membership.Member{
+			ID: types.ID(learnerID),
+		},
+		IsPromote: true,
+	}
+	b, err := json.Marshal(promoteChangeContext)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.configureJoint(ctx, []raftpb.ConfChange{
+		{Type: raftpb.ConfChangeAddNode, NodeID: learnerID, Context: b},
+		{Type: raftpb.ConfChangeRemoveNode, NodeID: id},
+	})
+}
+
+func (s *EtcdServer) mayPromoteReplacement(id, learnerID types.ID) error {
+	lg := s.Logger()
+	if m := s.cluster.Member(id); m == nil {
+		return membership.ErrIDNotFound
+	} else if m.IsLearner {
+		return membership.ErrMemberIsLearner
+	}
+	learner := s.cluster.Member(learnerID)
+	if learner == nil {
+		return membership.ErrIDNotFound
+	}
+	if !learner.IsLearner {
+		return membership.ErrMemberNotLearner
+	}
+	if err := s.isLearnerReady(lg, uint64(learnerID)); err != nil {
+		return err
+	}
+
+	if !s.Cfg.StrictReconfigCheck {
+		return nil
+	}
+	// the new configuration needs an active quorum of its own, with the
+	// learner in place of the replaced member.
+	voters := []*membership.Member{learner}
+	for _, m := range s.cluster.VotingMembers() {
+		if m.ID != id {
+			voters = append(voters, m)
+		}
+	}
+	active := numConnectedSince(s.r.transport, time.Now().Add(-HealthInterval), s.MemberID(), voters)
+	if active < len(voters)/2+1 {
+		lg.Warn(
+			"rejecting member replace request; not enough healthy members in the new configuration",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.String("requested-member-replace-id", id.String()),
+			zap.String("requested-learner-id", learnerID.String()),
+			zap.Int("active-members", active),
+			zap.Error(errors.ErrUnhealthy),
+		)
+		return errors.ErrUnhealthy
+	}
+	return nil
+}
+
 func (s *EtcdServer) UpdateMember(ctx context.Context, memb membership.Member) ([]*membership.Member, error) {
 	b, merr := json.Marshal(memb)
 	if merr != nil {
@@ -1698,6 +1967,12 @@ func (s *EtcdServer) LeaderChangedNotify() <-chan struct{} {
 	return s.leaderChanged.Receive()
 }
 
+// DrainNotify returns a channel that is closed when the local member starts
+// draining.
+func (s *EtcdServer) DrainNotify() <-chan struct{} {
+	return s.drainStarted.Receive()
+}
+
 // FirstCommitInTermNotify returns channel that will be unlocked on first
 // entry committed in new term, which is necessary for new leader to answer
 // read-only requests (leader is not able to respond any read-only requests
@@ -1774,6 +2049,92 @@ func (s *EtcdServer) configure(ctx context.Context, cc raftpb.ConfChange) ([]*me
 	}
 }
 
+// confChangeV2Context is the context of a ConfChangeV2 proposed by
+// configureJoint. ConfChangeV2 has no ID of its own, so the ID to trigger
+// once it is applied is kept here along with the context of each change.
+type confChangeV2Context struct {
+	ID       uint64   `json:"id"`
+	Contexts [][]byte `json:"contexts"`
+}
+
+func newConfChangeV2(id uint64, ccs []raftpb.ConfChange) (raftpb.ConfChangeV2, error) {
+	cc := raftpb.ConfChangeV2{Transition: raftpb.ConfChangeTransitionJointImplicit}
+	ctx := confChangeV2Context{ID: id}
+	for _, c := range ccs {
+		cc.Changes = append(cc.Changes, raftpb.ConfChangeSingle{Type: c.Type, NodeID: c.NodeID})
+		ctx.Contexts = append(ctx.Contexts, c.Context)
+	}
+	b, err := json.Marshal(ctx)
+	if err != nil {
+		return cc, err
+	}
+	cc.Context = b
+	return cc, nil
+}
+
+// confChangesFromV2 returns the ID of a ConfChangeV2 built by newConfChangeV2
+// and its changes as single ConfChanges.
+func confChangesFromV2(lg *zap.Logger, cc raftpb.ConfChangeV2) (uint64, []raftpb.ConfChange) {
+	var ctx confChangeV2Context
+	if err := json.Unmarshal(cc.Context, &ctx); err != nil {
+		lg.Panic("failed to unmarshal conf change context", zap.Error(err))
+	}
+	if len(ctx.Contexts) != len(cc.Changes) {
+		lg.Panic(
+			"got different number of changes and contexts",
+			zap.Int("changes", len(cc.Changes)),
+			zap.Int("contexts", len(ctx.Contexts)),
+		)
+	}
+	ccs := make([]raftpb.ConfChange, len(cc.Changes))
+	for i, c := range cc.Changes {
+		ccs[i] = raftpb.ConfChange{Type: c.Type, NodeID: c.NodeID, Context: ctx.Contexts[i]}
+	}
+	return ctx.ID, ccs
+}
+
+// configureJoint sends the given configuration changes through consensus as
+// a single ConfChangeV2 and waits for it to be applied to the server. The
+// changes take effect through a joint configuration, which raft leaves by
+// itself once it is applied.
+func (s *EtcdServer) configureJoint(ctx context.Context, ccs []raftpb.ConfChange) ([]*membership.Member, error) {
+	lg := s.Logger()
+	id := s.reqIDGen.Next()
+	cc, err := newConfChangeV2(id, ccs)
+	if err != nil {
+		return nil, err
+	}
+	ch := s.w.Register(id)
+
+	start := time.Now()
+	if err := s.r.ProposeConfChange(ctx, cc); err != nil {
+		s.w.Trigger(id, nil)
+		return nil, err
+	}
+
+	select {
+	case x := <-ch:
+		if x == nil {
+			lg.Panic("failed to configure")
+		}
+		resp := x.(*confChangeResponse)
+		<-resp.raftAdvanceC
+		lg.Info(
+			"applied a joint configuration change through raft",
+			zap.String("local-member-id", s.MemberID().String()),
+			zap.String("raft-conf-change", cc.String()),
+		)
+		return resp.membs, resp.err
+
+	case <-ctx.Done():
+		s.w.Trigger(id, nil) // GC wait
+		return nil, s.parseProposeCtxErr(ctx.Err(), start)
+
+	case <-s.stopping:
+		return nil, errors.ErrStopped
+	}
+}
+
 // publishV3 registers server information into the cluster using v3 request. The
 // information is the JSON representation of this server's member struct, updated
 // with the static clientURLs of the server.
@@ -1785,6 +2146,7 @@ func (s *EtcdServer) publishV3(timeout time.Duration) {
 		MemberAttributes: &membershippb.Attributes{
 			Name:       s.attributes.Name,
 			ClientUrls: s.attributes.ClientURLs,
+			Labels:     s.attributes.Labels,
 		},
 	}
 	// gofail: var beforePublishing struct{}
@@ -1913,10 +2275,19 @@ func (s *EtcdServer) apply(
 			shouldStop = shouldStop || removedSelf
 			s.w.Trigger(cc.ID, &confChangeResponse{s.cluster.Members(), raftAdvancedC, err})
 
+		case raftpb.EntryConfChangeV2:
+			var cc raftpb.ConfChangeV2
+			pbutil.MustUnmarshal(&cc, e.Data)
+			id, removedSelf, err := s.applyConfChangeV2(cc, confState, shouldApplyV3)
+			s.setAppliedIndex(e.Index)
+			s.setTerm(e.Term)
+			shouldStop = shouldStop || removedSelf
+			s.w.Trigger(id, &confChangeResponse{s.cluster.Members(), raftAdvancedC, err})
+
 		default:
 			lg := s.Logger()
 			lg.Panic(
-				"unknown entry type; must be either EntryNormal or EntryConfChange",
+				"unknown entry type; must be either EntryNormal, EntryConfChange or EntryConfChangeV2",
 				zap.String("type", e.Type.String()),
 			)
 		}
@@ -1945,8 +2316,8 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 		s.firstCommitInTerm.Notify()
 
 		// promote lessor when the local member is leader and finished
-		// applying all entries from the last term.
-		if s.isLeader() {
+		// applying all entries from the last term. A witness has no leases.
+		if s.isLeader() && !s.IsWitness() {
 			s.lessor.Promote(s.Cfg.ElectionTimeout())
 		}
 		return
@@ -1975,6 +2346,14 @@ func (s *EtcdServer) applyEntryNormal(e *raftpb.Entry, shouldApplyV3 membership.
 		id = raftReq.Header.ID
 	}
 
+	// a witness keeps no key-value data and applies only membership changes.
+	if !appliesToWitness(&raftReq) && s.IsWitness() {
+		if shouldApplyV3 {
+			s.w.Trigger(id, &apply.Result{Err: errors.ErrNotSupportedForWitness})
+		}
+		return
+	}
+
 	needResult := s.w.IsRegistered(id)
 	if needResult || !noSideEffect(&raftReq) {
 		if !needResult && raftReq.Txn != nil {
@@ -2046,18 +2425,62 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 		lg.Error("Validation on configuration change failed", zap.Bool("shouldApplyV3", bool(shouldApplyV3)), zap.Error(err))
 		cc.NodeID = raft.None
 		s.r.ApplyConfChange(cc)
+		s.setConsistentIndexDirectly(shouldApplyV3)
+		return false, err
+	}
 
-		// The txPostLock callback will not get called in this case,
-		// so we should set the consistent index directly.
-		if s.consistIndex != nil && membership.ApplyBoth == shouldApplyV3 {
-			applyingIndex, applyingTerm := s.consistIndex.ConsistentApplyingIndex()
-			s.consistIndex.SetConsistentIndex(applyingIndex, applyingTerm)
+	*confState = *s.r.ApplyConfChange(cc)
+	s.beHooks.SetConfState(confState)
+	return s.applyMembershipChange(cc, shouldApplyV3), nil
+}
+
+// applyConfChangeV2 applies a ConfChangeV2 to the server. The changes that
+// enter a joint configuration are validated and applied to the membership
+// together; leaving the joint configuration only concerns raft. It returns
+// the ID the ConfChangeV2 was proposed with, if any.
+func (s *EtcdServer) applyConfChangeV2(cc raftpb.ConfChangeV2, confState *raftpb.ConfState, shouldApplyV3 membership.ShouldApplyV3) (uint64, bool, error) {
+	lg := s.Logger()
+	if cc.LeaveJoint() {
+		*confState = *s.r.ApplyConfChange(cc)
+		s.beHooks.SetConfState(confState)
+		s.setConsistentIndexDirectly(shouldApplyV3)
+		return 0, false, nil
+	}
+
+	id, ccs := confChangesFromV2(lg, cc)
+	for _, c := range ccs {
+		if err := s.cluster.ValidateConfigurationChange(c, shouldApplyV3); err != nil {
+			lg.Error("Validation on configuration change failed", zap.Bool("shouldApplyV3", bool(shouldApplyV3)), zap.Error(err))
+			s.r.ApplyConfChange(raftpb.ConfChange{NodeID: raft.None})
+			s.setConsistentIndexDirectly(shouldApplyV3)
+			return id, false, err
 		}
-		return false, err
 	}
 
 	*confState = *s.r.ApplyConfChange(cc)
 	s.beHooks.SetConfState(confState)
+	removedSelf := false
+	for _, c := range ccs {
+		removedSelf = s.applyMembershipChange(c, shouldApplyV3) || removedSelf
+	}
+	return id, removedSelf, nil
+}
+
+// setConsistentIndexDirectly moves the consistent index to the applying
+// entry for configuration changes that do not write to the backend, since
+// the txPostLock callback will not get called in this case.
+func (s *EtcdServer) setConsistentIndexDirectly(shouldApplyV3 membership.ShouldApplyV3) {
+	if s.consistIndex != nil && membership.ApplyBoth == shouldApplyV3 {
+		applyingIndex, applyingTerm := s.consistIndex.ConsistentApplyingIndex()
+		s.consistIndex.SetConsistentIndex(applyingIndex, applyingTerm)
+	}
+}
+
+// applyMembershipChange applies a ConfChange that raft has already applied
+// to the membership of the cluster. It returns true if the local member was
+// removed.
+func (s *EtcdServer) applyMembershipChange(cc raftpb.ConfChange, shouldApplyV3 membership.ShouldApplyV3) bool {
+	lg := s.Logger()
 	switch cc.Type {
 	case raftpb.ConfChangeAddNode, raftpb.ConfChangeAddLearnerNode:
 		confChangeContext := new(membership.ConfigChangeContext)
@@ -2085,7 +2508,7 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 		id := types.ID(cc.NodeID)
 		s.cluster.RemoveMember(id, shouldApplyV3)
 		if id == s.MemberID() {
-			return true, nil
+			return true
 		}
 		s.r.transport.RemovePeer(id)
 
@@ -2106,7 +2529,7 @@ func (s *EtcdServer) applyConfChange(cc raftpb.ConfChange, confState *raftpb.Con
 			s.r.transport.UpdatePeer(m.ID, m.PeerURLs)
 		}
 	}
-	return false, nil
+	return false
 }
 
 // TODO: non-blocking snapshot
@@ -2300,7 +2723,7 @@ func (s *EtcdServer) monitorKVHash() {
 		case <-checkTicker.C:
 		}
 		backend.VerifyBackendConsistency(s.be, lg, false, schema.AllBuckets...)
-		if !s.isLeader() {
+		if !s.isLeader() || s.IsWitness() {
 			continue
 		}
 		if err := s.corruptionChecker.PeriodicCheck(); err != nil {
@@ -2322,13 +2745,56 @@ func (s *EtcdServer) monitorCompactHash() {
 			lg.Info("server has stopped; stopping compact hash's monitor")
 			return
 		}
-		if !s.isLeader() {
+		if !s.isLeader() || s.IsWitness() {
 			continue
 		}
 		s.corruptionChecker.CompactHashCheck()
 	}
 }
 
+// monitorRevisionTime proposes a sample of the time to revision index every
+// RevisionTimeSampleInterval while the local member is the leader, if the
+// revision changed since the previous sample. The sample carries the time of
+// the leader, so that every member records the same time for a revision.
+func (s *EtcdServer) monitorRevisionTime() {
+	t := s.Cfg.RevisionTimeSampleInterval
+	if t == 0 {
+		return
+	}
+	ticker := time.NewTicker(t)
+	defer ticker.Stop()
+
+	lg := s.Logger()
+	var sampledRev int64
+	for {
+		select {
+		case <-s.stopping:
+			lg.Info("server has stopped; stopping revision time's monitor")
+			return
+		case <-ticker.C:
+		}
+		if !s.isLeader() || s.IsWitness() {
+			continue
+		}
+		if cv := s.ClusterVersion(); cv == nil || cv.LessThan(version.V3_7) {
+			continue
+		}
+		rev := s.KV().Rev()
+		if rev == sampledRev {
+			continue
+		}
+		req := &pb.RevisionTimeRequest{Timestamp: time.Now().UnixNano()}
+		ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+		_, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{RevisionTime: req})
+		cancel()
+		if err != nil {
+			lg.Warn("failed to sample revision time", zap.Error(err))
+			continue
+		}
+		sampledRev = rev
+	}
+}
+
 func (s *EtcdServer) updateClusterVersionV3(ver string) {
 	lg := s.Logger()
 
@@ -2386,6 +2852,116 @@ func (s *EtcdServer) monitorDowngrade() {
 	}
 }
 
+// monitorLearnerPromotion promotes learners that were added with auto promote
+// once they have caught up with the leader and stayed connected to it for
+// LearnerAutoPromoteWait. Only the leader promotes learners.
+func (s *EtcdServer) monitorLearnerPromotion() {
+	lg := s.Logger()
+	readySince := make(map[types.ID]time.Time)
+	for {
+		select {
+		case <-time.After(learnerAutoPromoteCheckInterval):
+		case <-s.stopping:
+			return
+		}
+
+		if !s.isLeader() {
+			clear(readySince)
+			learnerAutoPromotePending.Set(0)
+			continue
+		}
+
+		rs := s.raftStatus()
+		if rs.Progress == nil {
+			continue
+		}
+		pending := make(map[types.ID]struct{})
+		for _, m := range s.cluster.Members() {
+			if !m.IsLearner || !m.AutoPromote {
+				continue
+			}
+			pending[m.ID] = struct{}{}
+
+			percent, ok := learnerProgressPercent(rs, uint64(m.ID))
+			activeSince := s.r.transport.ActiveSince(m.ID)
+			if !ok || percent < readyPercentThreshold || activeSince.IsZero() {
+				if _, wasReady := readySince[m.ID]; wasReady {
+					lg.Info(
+						"auto promote learner is no longer ready",
+						zap.String("learner-id", m.ID.String()),
+						zap.Float64("learner-ready-percent", percent),
+						zap.Bool("active", !activeSince.IsZero()),
+					)
+					delete(readySince, m.ID)
+				}
+				continue
+			}
+
+			// restart the wait if the learner reconnected since it became ready.
+			since, wasReady := readySince[m.ID]
+			if !wasReady || since.Before(activeSince) {
+				since = time.Now()
+				readySince[m.ID] = since
+				lg.Info(
+					"auto promote learner caught up with leader",
+					zap.String("learner-id", m.ID.String()),
+					zap.Float64("learner-ready-percent", percent),
+					zap.Duration("promote-wait", s.Cfg.LearnerAutoPromoteWait),
+				)
+			}
+			if time.Since(since) < s.Cfg.LearnerAutoPromoteWait {
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(s.ctx, s.Cfg.ReqTimeout())
+			_, err := s.promoteLearner(ctx, uint64(m.ID))
+			cancel()
+			delete(readySince, m.ID)
+			if err != nil {
+				lg.Warn(
+					"failed to auto promote learner",
+					zap.String("learner-id", m.ID.String()),
+					zap.Error(err),
+				)
+				learnerAutoPromoteFailed.WithLabelValues(learnerAutoPromoteFailReason(err)).Inc()
+				continue
+			}
+			delete(pending, m.ID)
+			lg.Info(
+				"auto promoted learner",
+				zap.String("learner-id", m.ID.String()),
+			)
+			learnerAutoPromoteSucceed.Inc()
+		}
+
+		for id := range readySince {
+			if _, ok := pending[id]; !ok {
+				delete(readySince, id)
+			}
+		}
+		learnerAutoPromotePending.Set(float64(len(pending)))
+	}
+}
+
+// learnerAutoPromoteFailReason maps the error of an automatic learner
+// promotion to one of a fixed set of reasons, to bound the cardinality of the
+// failure metric.
+func learnerAutoPromoteFailReason(err error) string {
+	switch {
+	case errorspkg.Is(err, errors.ErrLearnerNotReady):
+		return "not_ready"
+	case errorspkg.Is(err, membership.ErrTooManyLearners):
+		return "too_many_learners"
+	case errorspkg.Is(err, errors.ErrTimeout),
+		errorspkg.Is(err, errors.ErrTimeoutDueToLeaderFail),
+		errorspkg.Is(err, errors.ErrTimeoutDueToConnectionLost),
+		errorspkg.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "other"
+	}
+}
+
 func (s *EtcdServer) parseProposeCtxErr(err error, start time.Time) error {
 	switch {
 	case errorspkg.Is(err, context.Canceled):
@@ -2420,6 +2996,7 @@ func (s *EtcdServer) parseProposeCtxErr(err error, start time.Time) error {
 }
 
 func (s *EtcdServer) KV() mvcc.WatchableKV { return s.kv }
+func (s *EtcdServer) Lessor() lease.Lessor { return s.lessor }
 func (s *EtcdServer) Backend() backend.Backend {
 	s.bemu.RLock()
 	defer s.bemu.RUnlock()
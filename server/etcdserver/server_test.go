@@ -639,6 +639,94 @@ func TestApplyMultiConfChangeShouldStop(t *testing.T) {
 	}
 }
 
+// TestApplyConfChangeV2 ensures the changes of a joint configuration change
+// are applied to the membership together, or not at all.
+func TestApplyConfChangeV2(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewDefaultTmpBackend(t)
+	defer betesting.Close(t, be)
+
+	cl := membership.NewCluster(lg)
+	cl.SetBackend(schema.NewMembershipBackend(lg, be))
+	cl.SetStore(v2store.New())
+	for i := 1; i <= 2; i++ {
+		cl.AddMember(&membership.Member{ID: types.ID(i)}, true)
+	}
+	cl.AddMember(&membership.Member{ID: 3, RaftAttributes: membership.RaftAttributes{IsLearner: true}}, true)
+
+	n := newNodeRecorder()
+	srv := &EtcdServer{
+		lgMu:     new(sync.RWMutex),
+		lg:       lg,
+		memberID: 1,
+		r:        *newRaftNode(raftNodeConfig{lg: lg, Node: n, transport: newNopTransporter()}),
+		cluster:  cl,
+		beHooks:  serverstorage.NewBackendHooks(lg, nil),
+	}
+	replace := func(id, learnerID uint64) raftpb.ConfChangeV2 {
+		b, err := json.Marshal(&membership.ConfigChangeContext{Member: membership.Member{ID: types.ID(learnerID)}, IsPromote: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		cc, err := newConfChangeV2(7, []raftpb.ConfChange{
+			{Type: raftpb.ConfChangeAddNode, NodeID: learnerID, Context: b},
+			{Type: raftpb.ConfChangeRemoveNode, NodeID: id},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cc
+	}
+
+	// replacing an unknown member changes nothing
+	id, shouldStop, err := srv.applyConfChangeV2(replace(5, 3), &raftpb.ConfState{}, true)
+	if !errorspkg.Is(err, membership.ErrIDNotFound) {
+		t.Fatalf("applyConfChangeV2 error = %v, want %v", err, membership.ErrIDNotFound)
+	}
+	if id != 7 || shouldStop {
+		t.Errorf("id, shouldStop = %d, %t, want 7, false", id, shouldStop)
+	}
+	if m := cl.Member(3); m == nil || !m.IsLearner {
+		t.Errorf("member 3 = %+v, want learner", m)
+	}
+	w := []testutil.Action{{Name: "ApplyConfChange", Params: []any{raftpb.ConfChange{NodeID: raft.None}}}}
+	if g, _ := n.Wait(1); !reflect.DeepEqual(g, w) {
+		t.Errorf("action = %+v, want %+v", g, w)
+	}
+
+	cc := replace(2, 3)
+	id, shouldStop, err = srv.applyConfChangeV2(cc, &raftpb.ConfState{}, true)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if id != 7 || shouldStop {
+		t.Errorf("id, shouldStop = %d, %t, want 7, false", id, shouldStop)
+	}
+	if m := cl.Member(3); m == nil || m.IsLearner {
+		t.Errorf("member 3 = %+v, want voting member", m)
+	}
+	if m := cl.Member(2); m != nil {
+		t.Errorf("member 2 = %+v, want removed", m)
+	}
+
+	// leaving the joint configuration only concerns raft
+	id, shouldStop, err = srv.applyConfChangeV2(raftpb.ConfChangeV2{}, &raftpb.ConfState{}, true)
+	if err != nil || id != 0 || shouldStop {
+		t.Errorf("applyConfChangeV2 = %d, %t, %v, want 0, false, nil", id, shouldStop, err)
+	}
+	w = []testutil.Action{
+		{Name: "ApplyConfChange", Params: []any{raftpb.ConfChange{NodeID: raft.None}}},
+		{Name: "ApplyConfChange", Params: []any{cc}},
+		{Name: "ApplyConfChange", Params: []any{raftpb.ConfChangeV2{}}},
+	}
+	if g := n.Action(); !reflect.DeepEqual(g, w) {
+		t.Errorf("action = %+v, want %+v", g, w)
+	}
+	if len(cl.Members()) != 2 {
+		t.Errorf("len(members) = %d, want 2", len(cl.Members()))
+	}
+}
+
 // TestSnapshotDisk should save the snapshot to disk and release old snapshots
 func TestSnapshotDisk(t *testing.T) {
 	revertFunc := verify.DisableVerifications()
@@ -1704,3 +1792,20 @@ func TestAddFeatureGateMetrics(t *testing.T) {
 	err := ptestutil.GatherAndCompare(prometheus.DefaultGatherer, strings.NewReader(expected), "etcd_server_feature_enabled")
 	require.NoErrorf(t, err, "unexpected metric collection result: \n%s", err)
 }
+
+func TestLearnerAutoPromoteFailReason(t *testing.T) {
+	tests := []struct {
+		err     error
+		wreason string
+	}{
+		{errors.ErrLearnerNotReady, "not_ready"},
+		{membership.ErrTooManyLearners, "too_many_learners"},
+		{errors.ErrTimeout, "timeout"},
+		{fmt.Errorf("promote: %w", context.DeadlineExceeded), "timeout"},
+		{errors.ErrNotLeader, "other"},
+		{errorspkg.New("unexpected"), "other"},
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.wreason, learnerAutoPromoteFailReason(tt.err), "%v", tt.err)
+	}
+}
@@ -15,11 +15,14 @@
 package etcdserver
 
 import (
+	"bytes"
 	"io"
 
 	humanize "github.com/dustin/go-humanize"
 	"go.uber.org/zap"
 
+	"go.etcd.io/etcd/api/v3/version"
+	"go.etcd.io/etcd/client/pkg/v3/types"
 	"go.etcd.io/etcd/server/v3/etcdserver/api/snap"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/raft/v3/raftpb"
@@ -27,17 +30,32 @@ import (
 
 // createMergedSnapshotMessage creates a snapshot message that contains: raft status (term, conf),
 // a snapshot of v2 store inside raft.Snapshot as []byte, a snapshot of v3 KV in the top level message
-// as ReadCloser.
+// as ReadCloser. A witness keeps no key-value data, so the message sent to it has an empty v3 KV.
 func (s *EtcdServer) createMergedSnapshotMessage(m raftpb.Message, snapt, snapi uint64, confState raftpb.ConfState) snap.Message {
 	lg := s.Logger()
 	// get a snapshot of v2 store as []byte
 	d := GetMembershipInfoInV2Format(lg, s.cluster)
 
-	// commit kv to write metadata(for example: consistent index).
-	s.KV().Commit()
-	dbsnap := s.be.Snapshot()
-	// get a snapshot of v3 KV as readCloser
-	rc := newSnapshotReaderCloser(lg, dbsnap)
+	var (
+		rc   io.ReadCloser
+		size int64
+	)
+	if s.isWitnessMember(types.ID(m.To)) {
+		rc = io.NopCloser(bytes.NewReader(nil))
+	} else {
+		// commit kv to write metadata(for example: consistent index).
+		s.KV().Commit()
+		// the members of older versions can only restore bbolt snapshots
+		var dbsnap backend.Snapshot
+		if cv := s.ClusterVersion(); cv != nil && !cv.LessThan(version.V3_7) {
+			dbsnap = s.be.NativeSnapshot()
+		} else {
+			dbsnap = s.be.Snapshot()
+		}
+		// get a snapshot of v3 KV as readCloser
+		rc = newSnapshotReaderCloser(lg, dbsnap)
+		size = dbsnap.Size()
+	}
 
 	// put the []byte snapshot of store into raft snapshot and return the merged snapshot with
 	// KV readCloser snapshot.
@@ -53,7 +71,7 @@ func (s *EtcdServer) createMergedSnapshotMessage(m raftpb.Message, snapt, snapi
 
 	verifySnapshotIndex(snapshot, s.consistIndex.ConsistentIndex())
 
-	return *snap.NewMessage(m, rc, dbsnap.Size())
+	return *snap.NewMessage(m, rc, size)
 }
 
 func newSnapshotReaderCloser(lg *zap.Logger, snapshot backend.Snapshot) io.ReadCloser {
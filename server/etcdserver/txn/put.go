@@ -49,12 +49,14 @@ func put(ctx context.Context, txnWrite mvcc.TxnWrite, p *pb.PutRequest, prevKV *
 	resp := &pb.PutResponse{}
 	resp.Header = &pb.ResponseHeader{}
 	val, leaseID := p.Value, lease.LeaseID(p.Lease)
+	extraLeases, leaseMode := p.ExtraLeases, p.LeaseMode
 
 	if p.IgnoreValue {
 		val = prevKV.KVs[0].Value
 	}
 	if p.IgnoreLease {
 		leaseID = lease.LeaseID(prevKV.KVs[0].Lease)
+		extraLeases, leaseMode = prevKV.KVs[0].ExtraLeases, prevKV.KVs[0].LeaseMode
 	}
 	if p.PrevKv {
 		if prevKV != nil && len(prevKV.KVs) != 0 {
@@ -62,7 +64,16 @@ func put(ctx context.Context, txnWrite mvcc.TxnWrite, p *pb.PutRequest, prevKV *
 		}
 	}
 
-	resp.Header.Revision = txnWrite.Put(p.Key, val, leaseID)
+	var opts []mvcc.PutOption
+	if len(extraLeases) > 0 {
+		ids := make([]lease.LeaseID, len(extraLeases))
+		for i, id := range extraLeases {
+			ids[i] = lease.LeaseID(id)
+		}
+		opts = append(opts, mvcc.WithExtraLeases(leaseMode, ids...))
+	}
+
+	resp.Header.Revision = txnWrite.Put(p.Key, val, leaseID, opts...)
 	trace.AddField(traceutil.Field{Key: "response_revision", Value: resp.Header.Revision})
 	return resp
 }
@@ -83,6 +94,11 @@ func checkLease(lessor lease.Lessor, p *pb.PutRequest) error {
 			return lease.ErrLeaseNotFound
 		}
 	}
+	for _, id := range p.ExtraLeases {
+		if l := lessor.Lookup(lease.LeaseID(id)); l == nil {
+			return lease.ErrLeaseNotFound
+		}
+	}
 	return nil
 }
 
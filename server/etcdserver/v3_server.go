@@ -20,6 +20,7 @@ import (
 	"encoding/base64"
 	"encoding/binary"
 	errorspkg "errors"
+	"sort"
 	"strconv"
 	"time"
 
@@ -62,6 +63,7 @@ type RaftKV interface {
 	DeleteRange(ctx context.Context, r *pb.DeleteRangeRequest) (*pb.DeleteRangeResponse, error)
 	Txn(ctx context.Context, r *pb.TxnRequest) (*pb.TxnResponse, error)
 	Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.CompactionResponse, error)
+	RevisionAt(ctx context.Context, r *pb.RevisionAtRequest) (*pb.RevisionAtResponse, error)
 }
 
 type Lessor interface {
@@ -79,6 +81,19 @@ type Lessor interface {
 
 	// LeaseLeases lists all leases.
 	LeaseLeases(ctx context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error)
+
+	// LeaseGroupGrant sends LeaseGroupGrant request to raft and applies it after committed.
+	LeaseGroupGrant(ctx context.Context, r *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error)
+	// LeaseGroupRevoke sends LeaseGroupRevoke request to raft and applies it after committed.
+	LeaseGroupRevoke(ctx context.Context, r *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error)
+	// LeaseGroupAttach sends LeaseGroupAttach request to raft and applies it after committed.
+	LeaseGroupAttach(ctx context.Context, r *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error)
+	// LeaseGroupDetach sends LeaseGroupDetach request to raft and applies it after committed.
+	LeaseGroupDetach(ctx context.Context, r *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error)
+
+	// LeaseGroupRenew renews all the leases of the lease group with given ID. The response
+	// holds the number of renewed leases and their smallest TTL. Or an error is returned.
+	LeaseGroupRenew(ctx context.Context, id lease.LeaseGroupID) (*pb.LeaseGroupKeepAliveResponse, error)
 }
 
 type Authenticator interface {
@@ -245,12 +260,56 @@ func (s *EtcdServer) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.
 	return resp, nil
 }
 
+func (s *EtcdServer) RevisionAt(ctx context.Context, r *pb.RevisionAtRequest) (*pb.RevisionAtResponse, error) {
+	if !r.Serializable {
+		if err := s.linearizableReadNotify(ctx); err != nil {
+			return nil, err
+		}
+	}
+	var (
+		resp *pb.RevisionAtResponse
+		err  error
+	)
+	chk := func(ai *auth.AuthInfo) error {
+		// the revisions of the whole key space are revealed to any
+		// authenticated user.
+		if s.AuthStore().IsAuthEnabled() && ai.Username == "" {
+			return auth.ErrUserEmpty
+		}
+		return nil
+	}
+	get := func() {
+		var (
+			rev int64
+			at  time.Time
+		)
+		if rev, at, err = s.KV().RevisionAt(time.Unix(0, r.Timestamp)); err != nil {
+			return
+		}
+		resp = &pb.RevisionAtResponse{
+			Header:    &pb.ResponseHeader{Revision: s.KV().Rev()},
+			Revision:  rev,
+			Timestamp: at.UnixNano(),
+		}
+	}
+	if serr := s.doSerialize(ctx, chk, get); serr != nil {
+		return nil, serr
+	}
+	return resp, err
+}
+
 func (s *EtcdServer) LeaseGrant(ctx context.Context, r *pb.LeaseGrantRequest) (*pb.LeaseGrantResponse, error) {
 	// no id given? choose one
 	for r.ID == int64(lease.NoLease) {
 		// only use positive int64 id's
 		r.ID = int64(s.reqIDGen.Next() & ((1 << 63) - 1))
 	}
+	// the max lifetime runs from the grant time recorded in the raft log, so
+	// that it is not restarted by restarts and leader changes
+	r.GrantTime = 0
+	if r.MaxLifetime > 0 {
+		r.GrantTime = time.Now().Unix()
+	}
 	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{LeaseGrant: r})
 	if err != nil {
 		return nil, err
@@ -329,6 +388,88 @@ func (s *EtcdServer) LeaseRenew(ctx context.Context, id lease.LeaseID) (int64, e
 	return -1, errors.ErrCanceled
 }
 
+func (s *EtcdServer) LeaseGroupGrant(ctx context.Context, r *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error) {
+	// no id given? choose one
+	for r.ID == int64(lease.NoLeaseGroup) {
+		// only use positive int64 id's
+		r.ID = int64(s.reqIDGen.Next() & ((1 << 63) - 1))
+	}
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{LeaseGroupGrant: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.LeaseGroupGrantResponse), nil
+}
+
+func (s *EtcdServer) LeaseGroupRevoke(ctx context.Context, r *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error) {
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{LeaseGroupRevoke: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.LeaseGroupRevokeResponse), nil
+}
+
+func (s *EtcdServer) LeaseGroupAttach(ctx context.Context, r *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error) {
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{LeaseGroupAttach: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.LeaseGroupAttachResponse), nil
+}
+
+func (s *EtcdServer) LeaseGroupDetach(ctx context.Context, r *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error) {
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{LeaseGroupDetach: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.LeaseGroupDetachResponse), nil
+}
+
+func (s *EtcdServer) LeaseGroupRenew(ctx context.Context, id lease.LeaseGroupID) (*pb.LeaseGroupKeepAliveResponse, error) {
+	if s.isLeader() {
+		// see LeaseRenew for why leadership is ensured first
+		if !s.ensureLeadership() {
+			return nil, lease.ErrNotPrimary
+		}
+		if err := s.waitAppliedIndex(); err != nil {
+			return nil, err
+		}
+
+		renewed, ttl, err := s.lessor.GroupRenew(id)
+		if err == nil { // already requested to primary lessor(leader)
+			return &pb.LeaseGroupKeepAliveResponse{ID: int64(id), TTL: ttl, Renewed: int64(renewed)}, nil
+		}
+		if !errorspkg.Is(err, lease.ErrNotPrimary) {
+			return nil, err
+		}
+	}
+
+	cctx, cancel := context.WithTimeout(ctx, s.Cfg.ReqTimeout())
+	defer cancel()
+
+	// renewals don't go through raft; forward to leader manually
+	for cctx.Err() == nil {
+		leader, lerr := s.waitLeader(cctx)
+		if lerr != nil {
+			return nil, lerr
+		}
+		for _, url := range leader.PeerURLs {
+			lurl := url + leasehttp.LeaseGroupPrefix
+			resp, err := leasehttp.RenewGroupHTTP(cctx, id, lurl, s.peerRt)
+			if err == nil || errorspkg.Is(err, lease.ErrLeaseGroupNotFound) || errorspkg.Is(err, lease.ErrLeaseGroupEmpty) {
+				return resp, err
+			}
+		}
+		// Throttle in case of e.g. connection problems.
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if errorspkg.Is(cctx.Err(), context.DeadlineExceeded) {
+		return nil, errors.ErrTimeout
+	}
+	return nil, errors.ErrCanceled
+}
+
 func (s *EtcdServer) checkLeaseTimeToLive(ctx context.Context, leaseID lease.LeaseID) (uint64, error) {
 	rev := s.AuthStore().Revision()
 	if !s.AuthStore().IsAuthEnabled() {
@@ -450,13 +591,48 @@ func (s *EtcdServer) newHeader() *pb.ResponseHeader {
 }
 
 // LeaseLeases is really ListLeases !???
-func (s *EtcdServer) LeaseLeases(_ context.Context, _ *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
+func (s *EtcdServer) LeaseLeases(_ context.Context, r *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
 	ls := s.lessor.Leases()
-	lss := make([]*pb.LeaseStatus, len(ls))
-	for i := range ls {
-		lss[i] = &pb.LeaseStatus{ID: int64(ls[i].ID)}
+	sort.Slice(ls, func(i, j int) bool { return ls[i].ID < ls[j].ID })
+
+	resp := &pb.LeaseLeasesResponse{Header: s.newHeader()}
+	for _, l := range ls {
+		if r.After != 0 && int64(l.ID) <= r.After {
+			continue
+		}
+		if r.Owner != "" && l.Owner() != r.Owner {
+			continue
+		}
+		if !leaseHasLabels(l, r.Labels) {
+			continue
+		}
+		ttl := l.RemainingTTL()
+		if ttl < r.MinTtl || (r.MaxTtl > 0 && ttl > r.MaxTtl) {
+			continue
+		}
+		if r.Limit > 0 && int64(len(resp.Leases)) == r.Limit {
+			resp.More = true
+			break
+		}
+		resp.Leases = append(resp.Leases, &pb.LeaseStatus{
+			ID:     int64(l.ID),
+			TTL:    ttl,
+			Labels: l.Labels(),
+			Owner:  l.Owner(),
+		})
 	}
-	return &pb.LeaseLeasesResponse{Header: s.newHeader(), Leases: lss}, nil
+	return resp, nil
+}
+
+// leaseHasLabels returns true if the lease carries all the given labels.
+func leaseHasLabels(l *lease.Lease, labels map[string]string) bool {
+	ll := l.Labels()
+	for k, v := range labels {
+		if lv, ok := ll[k]; !ok || lv != v {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *EtcdServer) waitLeader(ctx context.Context) (*membership.Member, error) {
@@ -487,6 +663,14 @@ func (s *EtcdServer) Alarm(ctx context.Context, r *pb.AlarmRequest) (*pb.AlarmRe
 	return resp.(*pb.AlarmResponse), nil
 }
 
+func (s *EtcdServer) CompactionPolicy(ctx context.Context, r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error) {
+	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{CompactionPolicy: r})
+	if err != nil {
+		return nil, err
+	}
+	return resp.(*pb.CompactionPolicyResponse), nil
+}
+
 func (s *EtcdServer) AuthEnable(ctx context.Context, r *pb.AuthEnableRequest) (*pb.AuthEnableResponse, error) {
 	resp, err := s.raftRequestOnce(ctx, pb.InternalRaftRequest{AuthEnable: r})
 	if err != nil {
@@ -824,7 +1008,7 @@ func (s *EtcdServer) linearizableReadLoop() {
 		s.readNotifier = nextnr
 		s.readMu.Unlock()
 
-		confirmedIndex, err := s.requestCurrentIndex(leaderChangedNotifier, requestID)
+		confirmedIndex, err := s.confirmReadIndex(leaderChangedNotifier, requestID, trace)
 		if isStopped(err) {
 			return
 		}
@@ -833,8 +1017,6 @@ func (s *EtcdServer) linearizableReadLoop() {
 			continue
 		}
 
-		trace.Step("read index received")
-
 		trace.AddField(traceutil.Field{Key: "readStateIndex", Value: confirmedIndex})
 
 		appliedIndex := s.getAppliedIndex()
@@ -855,6 +1037,36 @@ func (s *EtcdServer) linearizableReadLoop() {
 	}
 }
 
+// confirmReadIndex returns the index linearizable reads must wait to be
+// applied. On the leader, it is confirmed by the read lease while it holds,
+// and requested from raft otherwise. Followers always request it from the
+// leader, as their committed index may lag behind the writes the leader
+// already acknowledged.
+func (s *EtcdServer) confirmReadIndex(leaderChangedNotifier <-chan struct{}, requestID uint64, trace *traceutil.Trace) (uint64, error) {
+	lead, term := s.getLead(), s.getTerm()
+	leaseRead := s.readLease != nil && lead == uint64(s.MemberID())
+	var start time.Time
+	if leaseRead {
+		if index, ok := s.readLease.confirmedIndex(lead, term); ok {
+			leaseReads.Inc()
+			trace.Step("read index confirmed by read lease")
+			return max(index, s.getCommittedIndex()), nil
+		}
+		leaseReadFallbacks.Inc()
+		start = s.readLease.now()
+	}
+
+	index, err := s.requestCurrentIndex(leaderChangedNotifier, requestID)
+	if err != nil {
+		return 0, err
+	}
+	trace.Step("read index received")
+	if leaseRead {
+		s.readLease.renew(lead, term, index, start)
+	}
+	return index, nil
+}
+
 func isStopped(err error) bool {
 	return errorspkg.Is(err, raft.ErrStopped) || errorspkg.Is(err, errors.ErrStopped)
 }
@@ -0,0 +1,79 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package etcdserver
+
+import (
+	"os"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/client/pkg/v3/types"
+	serverstorage "go.etcd.io/etcd/server/v3/storage"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+// IsWitness returns true if the local member is a witness. A witness votes in
+// elections and log commitment like any voting member, but keeps no key-value
+// data: it applies only membership changes and does not serve clients. A
+// witness may be elected leader, so that the cluster stays available when a
+// member keeping data is lost; it then replicates its log to the most up to
+// date member keeping data and transfers the leadership to it. A witness never
+// sends snapshots to members keeping data.
+func (s *EtcdServer) IsWitness() bool {
+	return s.cluster.IsLocalMemberWitness()
+}
+
+// isWitnessMember returns true if the member with the given id is a witness.
+func (s *EtcdServer) isWitnessMember(id types.ID) bool {
+	m := s.cluster.Member(id)
+	return m != nil && m.IsWitness
+}
+
+// appliesToWitness returns true if the request changes the cluster membership
+// or version, which a witness applies as well.
+func appliesToWitness(r *pb.InternalRaftRequest) bool {
+	return r.ClusterVersionSet != nil || r.ClusterMemberAttrSet != nil || r.DowngradeInfoSet != nil
+}
+
+// recoverWitnessFromSnapshot recovers the membership of a snapshot sent to the
+// local witness without a backend, and moves its consistent index to the
+// snapshot.
+func (s *EtcdServer) recoverWitnessFromSnapshot(snapshot raftpb.Snapshot) {
+	lg := s.Logger()
+
+	lg.Info("restoring v2 store")
+	if err := s.v2store.Recovery(snapshot.Data); err != nil {
+		lg.Panic("failed to restore v2 store", zap.Error(err))
+	}
+
+	if err := serverstorage.AssertNoV2StoreContent(lg, s.v2store, s.Cfg.V2Deprecation); err != nil {
+		lg.Panic("illegal v2store content", zap.Error(err))
+	}
+
+	lg.Info("restored v2 store")
+
+	s.cluster.SyncBackendWithStore()
+	s.consistIndex.SetConsistentIndex(snapshot.Metadata.Index, snapshot.Metadata.Term)
+	// persist the consistent index, so that the empty database received with
+	// the snapshot is never opened on restart.
+	s.KV().Commit()
+
+	if fn, err := s.snapshotter.DBFilePath(snapshot.Metadata.Index); err == nil {
+		if err = os.Remove(fn); err != nil {
+			lg.Warn("failed to remove empty snapshot database", zap.String("path", fn), zap.Error(err))
+		}
+	}
+}
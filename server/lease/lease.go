@@ -16,9 +16,11 @@ package lease
 
 import (
 	"math"
+	"sort"
 	"sync"
 	"time"
 
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/schema"
@@ -32,11 +34,27 @@ type Lease struct {
 	expiryMu sync.RWMutex
 	// expiry is time when lease should expire. no expiration when expiry.IsZero() is true
 	expiry time.Time
+	// deadline is time when lease reaches its max lifetime, protected by expiryMu.
+	// no deadline when deadline.IsZero() is true
+	deadline time.Time
+
+	maxLifetime       int64 // maximum lifetime of the lease in seconds, if zero valued the lease has no maximum lifetime
+	remainingLifetime int64 // remaining lifetime in seconds, if zero valued it is considered unset and the full maxLifetime should be used
+	grantTime         int64 // unix time in seconds the lease was granted at, if zero valued the max lifetime runs from the remaining lifetime
+	renewOnWrite      bool  // renew the lease on puts to its keys
+
+	labels map[string]string // labels the lease was granted with, never modified
+	owner  string            // user who granted the lease
 
 	// mu protects concurrent accesses to itemSet
 	mu      sync.RWMutex
 	itemSet map[LeaseItem]struct{}
 	revokec chan struct{}
+
+	// groupID is the lease group the lease is attached to, protected by the lessor.
+	groupID LeaseGroupID
+	// revokeReason is why the lease was revoked, set before revokec is closed.
+	revokeReason mvccpb.Event_RevokeReason
 }
 
 func NewLease(id LeaseID, ttl int64) *Lease {
@@ -53,10 +71,31 @@ func (l *Lease) expired() bool {
 }
 
 func (l *Lease) persistTo(b backend.Backend) {
-	lpb := leasepb.Lease{ID: int64(l.ID), TTL: l.ttl, RemainingTTL: l.remainingTTL}
 	tx := b.BatchTx()
 	tx.LockInsideApply()
 	defer tx.Unlock()
+	l.unsafePersistTo(tx)
+}
+
+func (l *Lease) unsafePersistTo(tx backend.UnsafeWriter) {
+	lpb := leasepb.Lease{
+		ID:                int64(l.ID),
+		TTL:               l.ttl,
+		RemainingTTL:      l.remainingTTL,
+		GroupID:           int64(l.groupID),
+		MaxLifetime:       l.maxLifetime,
+		RemainingLifetime: l.remainingLifetime,
+		RenewOnWrite:      l.renewOnWrite,
+		Owner:             l.owner,
+		GrantTime:         l.grantTime,
+	}
+	if len(l.labels) > 0 {
+		lpb.Labels = make([]*leasepb.Label, 0, len(l.labels))
+		for k, v := range l.labels {
+			lpb.Labels = append(lpb.Labels, &leasepb.Label{Key: k, Value: v})
+		}
+		sort.Slice(lpb.Labels, func(i, j int) bool { return lpb.Labels[i].Key < lpb.Labels[j].Key })
+	}
 	schema.MustUnsafePutLease(tx, &lpb)
 }
 
@@ -80,11 +119,72 @@ func (l *Lease) getRemainingTTL() int64 {
 	return l.ttl
 }
 
-// refresh refreshes the expiry of the lease.
+// MaxLifetime returns the maximum lifetime of the Lease in seconds, or 0 if it has none.
+func (l *Lease) MaxLifetime() int64 {
+	return l.maxLifetime
+}
+
+// RenewOnWrite returns true if the Lease is renewed on puts to its keys.
+func (l *Lease) RenewOnWrite() bool {
+	return l.renewOnWrite
+}
+
+// Labels returns the labels the Lease was granted with. The returned map
+// must not be modified.
+func (l *Lease) Labels() map[string]string {
+	return l.labels
+}
+
+// Owner returns the user who granted the Lease.
+func (l *Lease) Owner() string {
+	return l.owner
+}
+
+// RemainingTTL returns the remaining time to live of the Lease in seconds.
+// Leases of a lessor that is not the primary have no expiry; the last
+// checkpointed remaining TTL is returned for them instead.
+func (l *Lease) RemainingTTL() int64 {
+	if l.Demoted() {
+		return l.getRemainingTTL()
+	}
+	return int64(l.Remaining().Seconds())
+}
+
+// getRemainingLifetime returns the last checkpointed remaining lifetime of the lease.
+func (l *Lease) getRemainingLifetime() int64 {
+	if l.remainingLifetime > 0 {
+		return l.remainingLifetime
+	}
+	return l.maxLifetime
+}
+
+// startLifetime sets the deadline of a lease with a max lifetime, from its
+// grant time if known and from its remaining lifetime otherwise. It must be
+// called before refresh when the lessor becomes primary.
+func (l *Lease) startLifetime(extend time.Duration) {
+	if l.maxLifetime <= 0 {
+		return
+	}
+	var newDeadline time.Time
+	if l.grantTime > 0 {
+		// the deadline is absolute, so it is not extended on leader changes
+		newDeadline = time.Unix(l.grantTime+l.maxLifetime, 0)
+	} else {
+		newDeadline = time.Now().Add(extend + time.Duration(l.getRemainingLifetime())*time.Second)
+	}
+	l.expiryMu.Lock()
+	defer l.expiryMu.Unlock()
+	l.deadline = newDeadline
+}
+
+// refresh refreshes the expiry of the lease, which never passes its deadline.
 func (l *Lease) refresh(extend time.Duration) {
 	newExpiry := time.Now().Add(extend + time.Duration(l.getRemainingTTL())*time.Second)
 	l.expiryMu.Lock()
 	defer l.expiryMu.Unlock()
+	if !l.deadline.IsZero() && newExpiry.After(l.deadline) {
+		newExpiry = l.deadline
+	}
 	l.expiry = newExpiry
 }
 
@@ -93,6 +193,27 @@ func (l *Lease) forever() {
 	l.expiryMu.Lock()
 	defer l.expiryMu.Unlock()
 	l.expiry = forever
+	l.deadline = time.Time{}
+}
+
+// checkpointsLifetime returns true if the remaining lifetime of the lease has to be
+// checkpointed, as its max lifetime does not run from a known grant time.
+func (l *Lease) checkpointsLifetime() bool {
+	return l.maxLifetime > 0 && l.grantTime == 0
+}
+
+// remainingLifetimeAt returns the remaining lifetime in seconds at the given time,
+// or 0 if the lease has no deadline to checkpoint.
+func (l *Lease) remainingLifetimeAt(now time.Time) int64 {
+	if !l.checkpointsLifetime() {
+		return 0
+	}
+	l.expiryMu.RLock()
+	defer l.expiryMu.RUnlock()
+	if l.deadline.IsZero() || !now.Before(l.deadline) {
+		return 0
+	}
+	return int64(math.Ceil(l.deadline.Sub(now).Seconds()))
 }
 
 // Demoted returns true if the lease's expiry has been reset to forever.
@@ -102,6 +223,22 @@ func (l *Lease) Demoted() bool {
 	return l.expiry == forever
 }
 
+// Done returns a channel that is closed once the lease is revoked.
+func (l *Lease) Done() <-chan struct{} {
+	return l.revokec
+}
+
+// RevokeReason returns why the lease was revoked. It is only set once the
+// channel returned by Done is closed.
+func (l *Lease) RevokeReason() mvccpb.Event_RevokeReason {
+	select {
+	case <-l.revokec:
+		return l.revokeReason
+	default:
+		return mvccpb.NOT_REVOKED
+	}
+}
+
 // Keys returns all the keys attached to the lease.
 func (l *Lease) Keys() []string {
 	l.mu.RLock()
@@ -127,6 +264,13 @@ type LeaseItem struct {
 	Key string
 }
 
+// itemLeases are the leases an item attached to more than one lease is
+// attached to, the primary lease first.
+type itemLeases struct {
+	ids  []LeaseID
+	mode mvccpb.LeaseMode
+}
+
 // leasesByExpiry implements the sort.Interface.
 type leasesByExpiry []*Lease
 
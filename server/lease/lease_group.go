@@ -0,0 +1,219 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"sort"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/lease/leasepb"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+// NoLeaseGroup is a special LeaseGroupID representing the absence of a lease group.
+const NoLeaseGroup = LeaseGroupID(0)
+
+type LeaseGroupID int64
+
+// LeaseGroup is a set of leases that are renewed together by a single
+// keep alive. A lease belongs to at most one group.
+type LeaseGroup struct {
+	ID LeaseGroupID
+	// leases is protected by the lessor.
+	leases map[LeaseID]struct{}
+}
+
+func newLeaseGroup(id LeaseGroupID) *LeaseGroup {
+	return &LeaseGroup{ID: id, leases: make(map[LeaseID]struct{})}
+}
+
+func (le *lessor) GroupGrant(id LeaseGroupID) error {
+	if id == NoLeaseGroup {
+		return ErrLeaseGroupNotFound
+	}
+
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if _, ok := le.groupMap[id]; ok {
+		return ErrLeaseGroupExists
+	}
+	le.groupMap[id] = newLeaseGroup(id)
+
+	tx := le.b.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	schema.UnsafeCreateLeaseGroupBucket(tx)
+	schema.MustUnsafePutLeaseGroup(tx, &leasepb.LeaseGroup{ID: int64(id)})
+	return nil
+}
+
+func (le *lessor) GroupRevoke(id LeaseGroupID) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	g := le.groupMap[id]
+	if g == nil {
+		return ErrLeaseGroupNotFound
+	}
+
+	tx := le.b.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	for lid := range g.leases {
+		if l := le.leaseMap[lid]; l != nil {
+			l.groupID = NoLeaseGroup
+			l.unsafePersistTo(tx)
+		}
+	}
+	delete(le.groupMap, id)
+	schema.UnsafeDeleteLeaseGroup(tx, &leasepb.LeaseGroup{ID: int64(id)})
+	return nil
+}
+
+func (le *lessor) GroupAttach(id LeaseGroupID, leases []LeaseID) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	g := le.groupMap[id]
+	if g == nil {
+		return ErrLeaseGroupNotFound
+	}
+	// check all leases first so that a failed attach changes nothing
+	for _, lid := range leases {
+		if le.leaseMap[lid] == nil {
+			return ErrLeaseNotFound
+		}
+	}
+
+	tx := le.b.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	for _, lid := range leases {
+		l := le.leaseMap[lid]
+		if l.groupID == id {
+			continue
+		}
+		le.unsafeRemoveFromGroup(l)
+		l.groupID = id
+		g.leases[lid] = struct{}{}
+		l.unsafePersistTo(tx)
+	}
+	return nil
+}
+
+func (le *lessor) GroupDetach(id LeaseGroupID, leases []LeaseID) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if le.groupMap[id] == nil {
+		return ErrLeaseGroupNotFound
+	}
+
+	tx := le.b.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	for _, lid := range leases {
+		// leases that are not attached to the group, or were revoked, are ignored
+		l := le.leaseMap[lid]
+		if l == nil || l.groupID != id {
+			continue
+		}
+		le.unsafeRemoveFromGroup(l)
+		l.unsafePersistTo(tx)
+	}
+	return nil
+}
+
+// GroupRenew renews all the unexpired leases of a lease group with a single
+// update of the expiry queue. It returns the number of renewed leases and
+// the smallest TTL among them, or ErrLeaseGroupEmpty if there is no lease
+// to renew.
+func (le *lessor) GroupRenew(id LeaseGroupID) (int, int64, error) {
+	le.mu.RLock()
+	if !le.isPrimary() {
+		le.mu.RUnlock()
+		return 0, -1, ErrNotPrimary
+	}
+
+	g := le.groupMap[id]
+	if g == nil {
+		le.mu.RUnlock()
+		return 0, -1, ErrLeaseGroupNotFound
+	}
+	ls := make([]*Lease, 0, len(g.leases))
+	var cps []*pb.LeaseCheckpoint
+	for lid := range g.leases {
+		l := le.leaseMap[lid]
+		// expired leases are pending revocation and are not renewed
+		if l == nil || l.expired() {
+			continue
+		}
+		ls = append(ls, l)
+		if le.cp != nil && l.remainingTTL > 0 {
+			cps = append(cps, &pb.LeaseCheckpoint{ID: int64(l.ID), Remaining_TTL: 0})
+		}
+	}
+	le.mu.RUnlock()
+
+	// Clear remaining TTLs as Renew does, batched into as few raft entries as possible.
+	if len(cps) > 0 {
+		sort.Slice(cps, func(i, j int) bool { return cps[i].ID < cps[j].ID })
+	}
+	for len(cps) > 0 {
+		n := min(len(cps), maxLeaseCheckpointBatchSize)
+		if err := le.cp(context.Background(), &pb.LeaseCheckpointRequest{Checkpoints: cps[:n]}); err != nil {
+			return 0, -1, err
+		}
+		cps = cps[n:]
+	}
+
+	le.mu.Lock()
+	if !le.isPrimary() {
+		le.mu.Unlock()
+		return 0, -1, ErrNotPrimary
+	}
+	items := make([]*LeaseWithTime, 0, len(ls))
+	var ttl int64
+	for _, l := range ls {
+		// skip leases revoked or detached in the meantime
+		if le.leaseMap[l.ID] != l || l.groupID != id {
+			continue
+		}
+		l.refresh(0)
+		items = append(items, &LeaseWithTime{id: l.ID, time: l.expiry})
+		if ttl == 0 || l.ttl < ttl {
+			ttl = l.ttl
+		}
+	}
+	le.leaseExpiredNotifier.RegisterOrUpdateAll(items)
+	le.mu.Unlock()
+	if len(items) == 0 {
+		return 0, -1, ErrLeaseGroupEmpty
+	}
+
+	leaseRenewed.Add(float64(len(items)))
+	leaseGroupRenewed.Inc()
+	return len(items), ttl, nil
+}
+
+// unsafeRemoveFromGroup removes the lease from its lease group, if any.
+func (le *lessor) unsafeRemoveFromGroup(l *Lease) {
+	if g := le.groupMap[l.groupID]; g != nil {
+		delete(g.leases, l.ID)
+	}
+	l.groupID = NoLeaseGroup
+}
@@ -0,0 +1,226 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lease
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+)
+
+func TestLessorGroupAttachDetach(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	le.SetRangeDeleter(func() TxnDelete { return newFakeDeleter(be) })
+
+	if err := le.GroupAttach(1, nil); !errors.Is(err, ErrLeaseGroupNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseGroupNotFound)
+	}
+	if err := le.GroupGrant(1); err != nil {
+		t.Fatalf("failed to grant lease group (%v)", err)
+	}
+	if err := le.GroupGrant(1); !errors.Is(err, ErrLeaseGroupExists) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseGroupExists)
+	}
+	if err := le.GroupGrant(2); err != nil {
+		t.Fatalf("failed to grant lease group (%v)", err)
+	}
+	for id := LeaseID(1); id <= 3; id++ {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatalf("failed to grant lease (%v)", err)
+		}
+	}
+
+	// attaching an unknown lease attaches nothing
+	if err := le.GroupAttach(1, []LeaseID{1, 4}); !errors.Is(err, ErrLeaseNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseNotFound)
+	}
+	if l := le.Lookup(1); l.groupID != NoLeaseGroup {
+		t.Fatalf("lease 1 group = %d, want none", l.groupID)
+	}
+
+	if err := le.GroupAttach(1, []LeaseID{1, 2, 3}); err != nil {
+		t.Fatalf("failed to attach leases (%v)", err)
+	}
+	// a lease moves to the last group it is attached to
+	if err := le.GroupAttach(2, []LeaseID{3}); err != nil {
+		t.Fatalf("failed to attach leases (%v)", err)
+	}
+	if err := le.GroupDetach(1, []LeaseID{2, 3}); err != nil {
+		t.Fatalf("failed to detach leases (%v)", err)
+	}
+	if err := le.Revoke(1); err != nil {
+		t.Fatalf("failed to revoke lease (%v)", err)
+	}
+
+	if n := len(le.groupMap[1].leases); n != 0 {
+		t.Errorf("len(group 1 leases) = %d, want 0", n)
+	}
+	if _, ok := le.groupMap[2].leases[3]; !ok || le.Lookup(3).groupID != 2 {
+		t.Errorf("lease 3 is not attached to group 2")
+	}
+	if l := le.Lookup(2); l.groupID != NoLeaseGroup {
+		t.Errorf("lease 2 group = %d, want none", l.groupID)
+	}
+
+	if err := le.GroupRevoke(2); err != nil {
+		t.Fatalf("failed to revoke lease group (%v)", err)
+	}
+	if l := le.Lookup(3); l == nil || l.groupID != NoLeaseGroup {
+		t.Errorf("lease 3 should outlive its group and be detached from it")
+	}
+	if err := le.GroupRevoke(2); !errors.Is(err, ErrLeaseGroupNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseGroupNotFound)
+	}
+}
+
+func TestLessorGroupRenew(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer be.Close()
+	defer os.RemoveAll(dir)
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	var checkpoints []*pb.LeaseCheckpoint
+	le.SetCheckpointer(func(ctx context.Context, cp *pb.LeaseCheckpointRequest) error {
+		checkpoints = append(checkpoints, cp.Checkpoints...)
+		for _, cp := range cp.GetCheckpoints() {
+			le.Checkpoint(LeaseID(cp.GetID()), cp.GetRemaining_TTL())
+		}
+		return nil
+	})
+	defer le.Stop()
+
+	if err := le.GroupGrant(1); err != nil {
+		t.Fatalf("failed to grant lease group (%v)", err)
+	}
+	if _, _, err := le.GroupRenew(1); !errors.Is(err, ErrNotPrimary) {
+		t.Fatalf("err = %v, want %v", err, ErrNotPrimary)
+	}
+	le.Promote(0)
+
+	var ids []LeaseID
+	for id := LeaseID(1); id <= 100; id++ {
+		if _, err := le.Grant(id, 10+int64(id)); err != nil {
+			t.Fatalf("failed to grant lease (%v)", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := le.GroupAttach(1, ids[:99]); err != nil {
+		t.Fatalf("failed to attach leases (%v)", err)
+	}
+
+	// leases run out and have a remaining TTL checkpointed
+	le.mu.Lock()
+	for _, l := range le.leaseMap {
+		l.ttl = 1
+		l.remainingTTL = 1
+		l.refresh(0)
+	}
+	le.leaseMap[1].ttl = 10
+	le.leaseMap[2].ttl = 20
+	le.mu.Unlock()
+
+	n, ttl, err := le.GroupRenew(1)
+	if err != nil {
+		t.Fatalf("failed to renew lease group (%v)", err)
+	}
+	if n != 99 {
+		t.Errorf("renewed = %d, want 99", n)
+	}
+	if ttl != 1 {
+		t.Errorf("ttl = %d, want 1", ttl)
+	}
+	if len(checkpoints) != 99 {
+		t.Errorf("len(checkpoints) = %d, want 99", len(checkpoints))
+	}
+	for _, l := range le.Leases() {
+		if l.ID <= 99 && l.remainingTTL != 0 {
+			t.Errorf("lease %d remainingTTL = %d, want 0", l.ID, l.remainingTTL)
+		}
+	}
+	if l := le.Lookup(2); l.Remaining() < 19*time.Second {
+		t.Errorf("failed to renew lease 2, remaining %v", l.Remaining())
+	}
+	if l := le.Lookup(100); l.Remaining() > time.Second {
+		t.Errorf("lease 100 is not in the group and should not be renewed, remaining %v", l.Remaining())
+	}
+
+	// the expiry queue orders the renewed leases by their new expiry
+	le.mu.Lock()
+	first := le.leaseExpiredNotifier.Peek().id
+	le.mu.Unlock()
+	if first == 1 || first == 2 {
+		t.Errorf("lease %d with the longest TTL expires first", first)
+	}
+
+	if _, _, err := le.GroupRenew(2); !errors.Is(err, ErrLeaseGroupNotFound) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseGroupNotFound)
+	}
+	if err := le.GroupGrant(2); err != nil {
+		t.Fatalf("failed to grant lease group (%v)", err)
+	}
+	if _, _, err := le.GroupRenew(2); !errors.Is(err, ErrLeaseGroupEmpty) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseGroupEmpty)
+	}
+}
+
+// TestLessorGroupRecover ensures lease groups and their leases are recovered
+// from the backend.
+func TestLessorGroupRecover(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	for _, gid := range []LeaseGroupID{1, 2} {
+		if err := le.GroupGrant(gid); err != nil {
+			t.Fatalf("failed to grant lease group (%v)", err)
+		}
+	}
+	for id := LeaseID(1); id <= 3; id++ {
+		if _, err := le.Grant(id, 10); err != nil {
+			t.Fatalf("failed to grant lease (%v)", err)
+		}
+	}
+	if err := le.GroupAttach(1, []LeaseID{1, 2}); err != nil {
+		t.Fatalf("failed to attach leases (%v)", err)
+	}
+
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	if len(nle.groupMap) != 2 {
+		t.Fatalf("len(groupMap) = %d, want 2", len(nle.groupMap))
+	}
+	if n := len(nle.groupMap[1].leases); n != 2 {
+		t.Errorf("len(group 1 leases) = %d, want 2", n)
+	}
+	if nle.Lookup(2).groupID != 1 || nle.Lookup(3).groupID != NoLeaseGroup {
+		t.Errorf("lease groups were not recovered")
+	}
+}
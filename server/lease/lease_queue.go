@@ -16,6 +16,7 @@ package lease
 
 import (
 	"container/heap"
+	"math/bits"
 	"time"
 )
 
@@ -90,6 +91,28 @@ func (mq *LeaseExpiredNotifier) RegisterOrUpdate(item *LeaseWithTime) {
 	}
 }
 
+// RegisterOrUpdateAll registers or updates many items at once. For large
+// batches the queue is rebuilt in a single pass instead of fixing up the
+// heap once per item.
+func (mq *LeaseExpiredNotifier) RegisterOrUpdateAll(items []*LeaseWithTime) {
+	if len(items)*bits.Len(uint(len(mq.queue))) < len(mq.queue) {
+		for _, item := range items {
+			mq.RegisterOrUpdate(item)
+		}
+		return
+	}
+	for _, item := range items {
+		if old, ok := mq.m[item.id]; ok {
+			old.time = item.time
+		} else {
+			item.index = len(mq.queue)
+			mq.queue = append(mq.queue, item)
+			mq.m[item.id] = item
+		}
+	}
+	heap.Init(&mq.queue)
+}
+
 func (mq *LeaseExpiredNotifier) Unregister() *LeaseWithTime {
 	item := heap.Pop(&mq.queue).(*LeaseWithTime)
 	delete(mq.m, item.id)
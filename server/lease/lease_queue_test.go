@@ -81,3 +81,44 @@ func TestLeaseQueue(t *testing.T) {
 	time.Sleep(expiredRetryInterval)
 	existExpiredEvent() // acquire after retry interval
 }
+
+func TestLeaseExpiredNotifierRegisterOrUpdateAll(t *testing.T) {
+	now := time.Now()
+	for _, n := range []int{1, 50} {
+		mq := newLeaseExpiredNotifier()
+		for i := 1; i <= 100; i++ {
+			mq.RegisterOrUpdate(&LeaseWithTime{id: LeaseID(i), time: now.Add(time.Duration(i) * time.Second)})
+		}
+
+		// push the first n leases to the back and add n new ones to the front
+		var items []*LeaseWithTime
+		for i := 1; i <= n; i++ {
+			items = append(items, &LeaseWithTime{id: LeaseID(i), time: now.Add(time.Hour)})
+			items = append(items, &LeaseWithTime{id: LeaseID(1000 + i), time: now.Add(-time.Duration(i) * time.Second)})
+		}
+		mq.RegisterOrUpdateAll(items)
+
+		if mq.Len() != 100+n {
+			t.Fatalf("n=%d: expected %d items, got %d", n, 100+n, mq.Len())
+		}
+		for i, item := range mq.queue {
+			if item.index != i {
+				t.Fatalf("n=%d: item %d has index %d", n, i, item.index)
+			}
+		}
+		var last time.Time
+		for i := 0; mq.Len() > 0; i++ {
+			item := mq.Unregister()
+			if item.time.Before(last) {
+				t.Fatalf("n=%d: item %d (lease %d) out of order", n, i, item.id)
+			}
+			last = item.time
+			switch {
+			case i < n && item.id != LeaseID(1000+n-i):
+				t.Fatalf("n=%d: item %d expected lease %d, got %d", n, i, 1000+n-i, item.id)
+			case i >= 100 && item.id > LeaseID(n):
+				t.Fatalf("n=%d: item %d expected a renewed lease, got %d", n, i, item.id)
+			}
+		}
+	}
+}
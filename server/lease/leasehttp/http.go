@@ -32,6 +32,7 @@ import (
 var (
 	LeasePrefix         = "/leases"
 	LeaseInternalPrefix = "/leases/internal"
+	LeaseGroupPrefix    = "/leases/group"
 	applyTimeout        = time.Second
 	ErrLeaseHTTPTimeout = errors.New("waiting for node to catch up its applied index has timed out")
 )
@@ -91,6 +92,39 @@ func (h *leaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+	case LeaseGroupPrefix:
+		greq := pb.LeaseGroupKeepAliveRequest{}
+		if uerr := greq.Unmarshal(b); uerr != nil {
+			http.Error(w, "error unmarshalling request", http.StatusBadRequest)
+			return
+		}
+		select {
+		case <-h.waitch():
+		case <-time.After(applyTimeout):
+			http.Error(w, ErrLeaseHTTPTimeout.Error(), http.StatusRequestTimeout)
+			return
+		}
+		renewed, ttl, rerr := h.l.GroupRenew(lease.LeaseGroupID(greq.ID))
+		if rerr != nil {
+			if errors.Is(rerr, lease.ErrLeaseGroupNotFound) {
+				http.Error(w, rerr.Error(), http.StatusNotFound)
+				return
+			}
+			if errors.Is(rerr, lease.ErrLeaseGroupEmpty) {
+				http.Error(w, rerr.Error(), http.StatusPreconditionFailed)
+				return
+			}
+
+			http.Error(w, rerr.Error(), http.StatusBadRequest)
+			return
+		}
+		resp := &pb.LeaseGroupKeepAliveResponse{ID: greq.ID, TTL: ttl, Renewed: int64(renewed)}
+		v, err = resp.Marshal()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
 	case LeaseInternalPrefix:
 		lreq := leasepb.LeaseInternalRequest{}
 		if lerr := lreq.Unmarshal(b); lerr != nil {
@@ -205,6 +239,60 @@ func RenewHTTP(ctx context.Context, id lease.LeaseID, url string, rt http.RoundT
 	return lresp.TTL, nil
 }
 
+// RenewGroupHTTP renews all the leases of a lease group at a given primary server.
+func RenewGroupHTTP(ctx context.Context, id lease.LeaseGroupID, url string, rt http.RoundTripper) (*pb.LeaseGroupKeepAliveResponse, error) {
+	greq, err := (&pb.LeaseGroupKeepAliveRequest{ID: int64(id)}).Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	cc := &http.Client{
+		Transport: rt,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(greq))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/protobuf")
+
+	resp, err := cc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	b, err := readResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusRequestTimeout {
+		return nil, ErrLeaseHTTPTimeout
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, lease.ErrLeaseGroupNotFound
+	}
+
+	if resp.StatusCode == http.StatusPreconditionFailed {
+		return nil, lease.ErrLeaseGroupEmpty
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("lease: unknown error(%s)", b)
+	}
+
+	gresp := &pb.LeaseGroupKeepAliveResponse{}
+	if err := gresp.Unmarshal(b); err != nil {
+		return nil, fmt.Errorf(`lease: %w. data = "%s"`, err, b)
+	}
+	if gresp.ID != int64(id) {
+		return nil, fmt.Errorf("lease: renew group id mismatch")
+	}
+	return gresp, nil
+}
+
 // TimeToLiveHTTP retrieves lease information of the given lease ID.
 func TimeToLiveHTTP(ctx context.Context, id lease.LeaseID, keys bool, url string, rt http.RoundTripper) (*leasepb.LeaseInternalResponse, error) {
 	// will post lreq protobuf to leader
@@ -15,6 +15,7 @@
 package leasehttp
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -50,6 +51,42 @@ func TestRenewHTTP(t *testing.T) {
 	}
 }
 
+func TestRenewGroupHTTP(t *testing.T) {
+	lg := zaptest.NewLogger(t)
+	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
+	defer betesting.Close(t, be)
+
+	le := lease.NewLessor(lg, be, nil, lease.LessorConfig{MinLeaseTTL: int64(5)})
+	le.Promote(time.Second)
+	for id := lease.LeaseID(1); id <= 2; id++ {
+		if _, err := le.Grant(id, int64(5*id)); err != nil {
+			t.Fatalf("failed to create lease: %v", err)
+		}
+	}
+	if err := le.GroupGrant(1); err != nil {
+		t.Fatalf("failed to create lease group: %v", err)
+	}
+	if err := le.GroupAttach(1, []lease.LeaseID{1, 2}); err != nil {
+		t.Fatalf("failed to attach leases: %v", err)
+	}
+
+	ts := httptest.NewServer(NewHandler(le, waitReady))
+	defer ts.Close()
+
+	resp, err := RenewGroupHTTP(t.Context(), 1, ts.URL+LeaseGroupPrefix, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.TTL != 5 || resp.Renewed != 2 {
+		t.Fatalf("expected 2 leases renewed with ttl 5, got %d with ttl %d", resp.Renewed, resp.TTL)
+	}
+
+	_, err = RenewGroupHTTP(t.Context(), 2, ts.URL+LeaseGroupPrefix, http.DefaultTransport)
+	if !errors.Is(err, lease.ErrLeaseGroupNotFound) {
+		t.Fatalf("expected (%v), got (%v)", lease.ErrLeaseGroupNotFound, err)
+	}
+}
+
 func TestTimeToLiveHTTP(t *testing.T) {
 	lg := zaptest.NewLogger(t)
 	be, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
@@ -26,9 +26,25 @@ var _ = math.Inf
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
 type Lease struct {
-	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	TTL                  int64    `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
-	RemainingTTL         int64    `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	ID           int64 `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TTL          int64 `protobuf:"varint,2,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	RemainingTTL int64 `protobuf:"varint,3,opt,name=RemainingTTL,proto3" json:"RemainingTTL,omitempty"`
+	// GroupID is the ID of the lease group the lease is attached to, if any.
+	GroupID int64 `protobuf:"varint,4,opt,name=GroupID,proto3" json:"GroupID,omitempty"`
+	// MaxLifetime is the maximum lifetime of the lease in seconds, if any.
+	MaxLifetime int64 `protobuf:"varint,5,opt,name=MaxLifetime,proto3" json:"MaxLifetime,omitempty"`
+	// RemainingLifetime is the last checkpointed remaining lifetime in seconds.
+	RemainingLifetime int64 `protobuf:"varint,6,opt,name=RemainingLifetime,proto3" json:"RemainingLifetime,omitempty"`
+	// RenewOnWrite renews the lease on puts to its keys.
+	RenewOnWrite bool `protobuf:"varint,7,opt,name=RenewOnWrite,proto3" json:"RenewOnWrite,omitempty"`
+	// Labels are the labels the lease was granted with, sorted by key so that
+	// the persisted lease is the same on every member.
+	Labels []*Label `protobuf:"bytes,8,rep,name=Labels,proto3" json:"Labels,omitempty"`
+	// Owner is the user who granted the lease.
+	Owner string `protobuf:"bytes,9,opt,name=Owner,proto3" json:"Owner,omitempty"`
+	// GrantTime is the unix time in seconds the lease was granted at, from
+	// which its MaxLifetime runs, if known.
+	GrantTime            int64    `protobuf:"varint,10,opt,name=GrantTime,proto3" json:"GrantTime,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -67,6 +83,87 @@ func (m *Lease) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_Lease proto.InternalMessageInfo
 
+type Label struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=Key,proto3" json:"Key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=Value,proto3" json:"Value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Label) Reset()         { *m = Label{} }
+func (m *Label) String() string { return proto.CompactTextString(m) }
+func (*Label) ProtoMessage()    {}
+func (*Label) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3dd57e402472b33a, []int{1}
+}
+func (m *Label) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Label) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Label.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Label) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Label.Merge(m, src)
+}
+func (m *Label) XXX_Size() int {
+	return m.Size()
+}
+func (m *Label) XXX_DiscardUnknown() {
+	xxx_messageInfo_Label.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Label proto.InternalMessageInfo
+
+type LeaseGroup struct {
+	ID                   int64    `protobuf:"varint,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LeaseGroup) Reset()         { *m = LeaseGroup{} }
+func (m *LeaseGroup) String() string { return proto.CompactTextString(m) }
+func (*LeaseGroup) ProtoMessage()    {}
+func (*LeaseGroup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_3dd57e402472b33a, []int{2}
+}
+func (m *LeaseGroup) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LeaseGroup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LeaseGroup.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LeaseGroup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeaseGroup.Merge(m, src)
+}
+func (m *LeaseGroup) XXX_Size() int {
+	return m.Size()
+}
+func (m *LeaseGroup) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeaseGroup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeaseGroup proto.InternalMessageInfo
+
 type LeaseInternalRequest struct {
 	LeaseTimeToLiveRequest *etcdserverpb.LeaseTimeToLiveRequest `protobuf:"bytes,1,opt,name=LeaseTimeToLiveRequest,proto3" json:"LeaseTimeToLiveRequest,omitempty"`
 	XXX_NoUnkeyedLiteral   struct{}                             `json:"-"`
@@ -78,7 +175,7 @@ func (m *LeaseInternalRequest) Reset()         { *m = LeaseInternalRequest{} }
 func (m *LeaseInternalRequest) String() string { return proto.CompactTextString(m) }
 func (*LeaseInternalRequest) ProtoMessage()    {}
 func (*LeaseInternalRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3dd57e402472b33a, []int{1}
+	return fileDescriptor_3dd57e402472b33a, []int{3}
 }
 func (m *LeaseInternalRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -118,7 +215,7 @@ func (m *LeaseInternalResponse) Reset()         { *m = LeaseInternalResponse{} }
 func (m *LeaseInternalResponse) String() string { return proto.CompactTextString(m) }
 func (*LeaseInternalResponse) ProtoMessage()    {}
 func (*LeaseInternalResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_3dd57e402472b33a, []int{2}
+	return fileDescriptor_3dd57e402472b33a, []int{4}
 }
 func (m *LeaseInternalResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -149,6 +246,8 @@ var xxx_messageInfo_LeaseInternalResponse proto.InternalMessageInfo
 
 func init() {
 	proto.RegisterType((*Lease)(nil), "leasepb.Lease")
+	proto.RegisterType((*Label)(nil), "leasepb.Label")
+	proto.RegisterType((*LeaseGroup)(nil), "leasepb.LeaseGroup")
 	proto.RegisterType((*LeaseInternalRequest)(nil), "leasepb.LeaseInternalRequest")
 	proto.RegisterType((*LeaseInternalResponse)(nil), "leasepb.LeaseInternalResponse")
 }
@@ -156,25 +255,35 @@ func init() {
 func init() { proto.RegisterFile("lease.proto", fileDescriptor_3dd57e402472b33a) }
 
 var fileDescriptor_3dd57e402472b33a = []byte{
-	// 283 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0xe2, 0xce, 0x49, 0x4d, 0x2c,
-	0x4e, 0xd5, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x62, 0x07, 0x73, 0x0a, 0x92, 0xa4, 0x44, 0xd2,
-	0xf3, 0xd3, 0xf3, 0xc1, 0x62, 0xfa, 0x20, 0x16, 0x44, 0x5a, 0x4a, 0x3e, 0xb5, 0x24, 0x39, 0x45,
-	0x3f, 0xb1, 0x20, 0x53, 0x1f, 0xc4, 0x28, 0x4e, 0x2d, 0x2a, 0x4b, 0x2d, 0x2a, 0x48, 0xd2, 0x2f,
-	0x2a, 0x48, 0x86, 0x28, 0x50, 0xf2, 0xe5, 0x62, 0xf5, 0x01, 0x99, 0x20, 0xc4, 0xc7, 0xc5, 0xe4,
-	0xe9, 0x22, 0xc1, 0xa8, 0xc0, 0xa8, 0xc1, 0x1c, 0xc4, 0xe4, 0xe9, 0x22, 0x24, 0xc0, 0xc5, 0x1c,
-	0x12, 0xe2, 0x23, 0xc1, 0x04, 0x16, 0x00, 0x31, 0x85, 0x94, 0xb8, 0x78, 0x82, 0x52, 0x73, 0x13,
-	0x33, 0xf3, 0x32, 0xf3, 0xd2, 0x41, 0x52, 0xcc, 0x60, 0x29, 0x14, 0x31, 0xa5, 0x12, 0x2e, 0x11,
-	0xb0, 0x71, 0x9e, 0x79, 0x25, 0xa9, 0x45, 0x79, 0x89, 0x39, 0x41, 0xa9, 0x85, 0xa5, 0xa9, 0xc5,
-	0x25, 0x42, 0x31, 0x5c, 0x62, 0x60, 0xf1, 0x90, 0xcc, 0xdc, 0xd4, 0x90, 0x7c, 0x9f, 0xcc, 0xb2,
-	0x54, 0xa8, 0x0c, 0xd8, 0x46, 0x6e, 0x23, 0x15, 0x3d, 0x64, 0xf7, 0xe9, 0x61, 0x57, 0x1b, 0x84,
-	0xc3, 0x0c, 0xa5, 0x0a, 0x2e, 0x51, 0x34, 0x5b, 0x8b, 0x0b, 0xf2, 0xf3, 0x8a, 0x53, 0x85, 0xe2,
-	0xb9, 0xc4, 0x31, 0xb4, 0x40, 0xa4, 0xa0, 0xf6, 0xaa, 0x12, 0xb0, 0x17, 0xa2, 0x38, 0x08, 0x97,
-	0x29, 0x4e, 0x9e, 0x27, 0x1e, 0xca, 0x31, 0x5c, 0x78, 0x28, 0xc7, 0x70, 0xe2, 0x91, 0x1c, 0xe3,
-	0x85, 0x47, 0x72, 0x8c, 0x0f, 0x1e, 0xc9, 0x31, 0xce, 0x78, 0x2c, 0xc7, 0x10, 0xa5, 0x9f, 0x9e,
-	0x0f, 0x36, 0x5b, 0x2f, 0x33, 0x1f, 0x1c, 0xf6, 0xfa, 0x10, 0x4b, 0xf4, 0xcb, 0x8c, 0xf5, 0xc1,
-	0x51, 0xa6, 0x0f, 0x8d, 0x38, 0x6b, 0x28, 0x9d, 0xc4, 0x06, 0x8e, 0x10, 0x63, 0x40, 0x00, 0x00,
-	0x00, 0xff, 0xff, 0x0e, 0x16, 0x3b, 0xc4, 0xdf, 0x01, 0x00, 0x00,
+	// 437 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0xef, 0x6a, 0x13, 0x41,
+	0x10, 0xcf, 0x25, 0x26, 0xe9, 0x4d, 0xa4, 0xe8, 0x12, 0xf5, 0x28, 0xe5, 0x3c, 0x0e, 0x95, 0x7c,
+	0x90, 0x5b, 0x68, 0x3f, 0xfa, 0x4d, 0x02, 0xe5, 0xf0, 0xa4, 0xb0, 0x04, 0x05, 0x11, 0x64, 0x53,
+	0xc7, 0xb0, 0x90, 0xee, 0x9e, 0x7b, 0x9b, 0xb4, 0xbe, 0x89, 0x6f, 0xe2, 0x2b, 0xf4, 0x63, 0x1f,
+	0xc1, 0xc6, 0x17, 0x91, 0x9d, 0x3d, 0x6b, 0xff, 0x58, 0xfc, 0xb4, 0x33, 0xbf, 0xdf, 0xcc, 0x6f,
+	0x86, 0xdf, 0x2c, 0x8c, 0x96, 0x28, 0x1b, 0x2c, 0x6a, 0x6b, 0x9c, 0x61, 0x43, 0x4a, 0xea, 0xf9,
+	0xce, 0x78, 0x61, 0x16, 0x86, 0x30, 0xee, 0xa3, 0x40, 0xef, 0x3c, 0x45, 0x77, 0xf4, 0x99, 0xcb,
+	0x5a, 0x71, 0x1f, 0x34, 0x68, 0xd7, 0x68, 0xeb, 0x39, 0xb7, 0xf5, 0x51, 0x28, 0xc8, 0x7f, 0x74,
+	0xa1, 0x5f, 0x79, 0x09, 0xb6, 0x0d, 0xdd, 0x72, 0x9a, 0x44, 0x59, 0x34, 0xe9, 0x89, 0x6e, 0x39,
+	0x65, 0x0f, 0xa0, 0x37, 0x9b, 0x55, 0x49, 0x97, 0x00, 0x1f, 0xb2, 0x1c, 0xee, 0x0b, 0x3c, 0x96,
+	0x4a, 0x2b, 0xbd, 0xf0, 0x54, 0x8f, 0xa8, 0x6b, 0x18, 0x4b, 0x60, 0x78, 0x60, 0xcd, 0xaa, 0x2e,
+	0xa7, 0xc9, 0x3d, 0xa2, 0xff, 0xa4, 0x2c, 0x83, 0xd1, 0x5b, 0x79, 0x5a, 0xa9, 0x2f, 0xe8, 0xd4,
+	0x31, 0x26, 0x7d, 0x62, 0xaf, 0x42, 0xec, 0x25, 0x3c, 0xbc, 0xd4, 0xba, 0xac, 0x1b, 0x50, 0xdd,
+	0x6d, 0x22, 0x6c, 0xa3, 0xf1, 0xe4, 0x50, 0xbf, 0xb7, 0xca, 0x61, 0x32, 0xcc, 0xa2, 0xc9, 0x96,
+	0xb8, 0x86, 0xb1, 0x17, 0x30, 0xa8, 0xe4, 0x1c, 0x97, 0x4d, 0xb2, 0x95, 0xf5, 0x26, 0xa3, 0xbd,
+	0xed, 0xa2, 0xb5, 0xab, 0x20, 0x58, 0xb4, 0x2c, 0x1b, 0x43, 0xff, 0xf0, 0x44, 0xa3, 0x4d, 0xe2,
+	0x2c, 0x9a, 0xc4, 0x22, 0x24, 0x6c, 0x17, 0xe2, 0x03, 0x2b, 0xb5, 0x9b, 0xf9, 0x3d, 0x80, 0xf6,
+	0xf8, 0x0b, 0xe4, 0x1c, 0xfa, 0xd4, 0xed, 0x8d, 0x7a, 0x83, 0xdf, 0xc8, 0xb9, 0x58, 0xf8, 0xd0,
+	0xcb, 0xbd, 0x93, 0xcb, 0x15, 0x92, 0x79, 0xb1, 0x08, 0x49, 0xbe, 0x0b, 0x40, 0x4e, 0x93, 0x21,
+	0x37, 0xed, 0xce, 0x1d, 0x8c, 0x89, 0x2d, 0xb5, 0x43, 0xab, 0xe5, 0x52, 0xe0, 0xd7, 0x15, 0x36,
+	0x8e, 0x7d, 0x84, 0xc7, 0x84, 0xfb, 0x99, 0x33, 0x53, 0xa9, 0x35, 0xb6, 0x0c, 0xf5, 0x8e, 0xf6,
+	0x9e, 0x15, 0x57, 0x2f, 0x5b, 0xfc, 0xbb, 0x56, 0xdc, 0xa1, 0x91, 0x9f, 0xc2, 0xa3, 0x1b, 0x53,
+	0x9b, 0xda, 0xe8, 0x06, 0xd9, 0x27, 0x78, 0x72, 0xab, 0x25, 0x50, 0xed, 0xdc, 0xe7, 0xff, 0x99,
+	0x1b, 0x8a, 0xc5, 0x5d, 0x2a, 0xaf, 0xcb, 0xb3, 0x8b, 0xb4, 0x73, 0x7e, 0x91, 0x76, 0xce, 0x36,
+	0x69, 0x74, 0xbe, 0x49, 0xa3, 0x9f, 0x9b, 0x34, 0xfa, 0xfe, 0x2b, 0xed, 0x7c, 0xe0, 0x0b, 0x43,
+	0xda, 0x85, 0x32, 0xf4, 0x6b, 0x79, 0x18, 0xc2, 0xd7, 0xfb, 0x9c, 0xae, 0xc7, 0xdb, 0x1b, 0xbe,
+	0x6a, 0xdf, 0xf9, 0x80, 0xbe, 0xf2, 0xfe, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0x2e, 0x29, 0x8b,
+	0x5c, 0x19, 0x03, 0x00, 0x00,
 }
 
 func (m *Lease) Marshal() (dAtA []byte, err error) {
@@ -201,6 +310,57 @@ func (m *Lease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if m.GrantTime != 0 {
+		i = encodeVarintLease(dAtA, i, uint64(m.GrantTime))
+		i--
+		dAtA[i] = 0x50
+	}
+	if len(m.Owner) > 0 {
+		i -= len(m.Owner)
+		copy(dAtA[i:], m.Owner)
+		i = encodeVarintLease(dAtA, i, uint64(len(m.Owner)))
+		i--
+		dAtA[i] = 0x4a
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Labels[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintLease(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if m.RenewOnWrite {
+		i--
+		if m.RenewOnWrite {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.RemainingLifetime != 0 {
+		i = encodeVarintLease(dAtA, i, uint64(m.RemainingLifetime))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.MaxLifetime != 0 {
+		i = encodeVarintLease(dAtA, i, uint64(m.MaxLifetime))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.GroupID != 0 {
+		i = encodeVarintLease(dAtA, i, uint64(m.GroupID))
+		i--
+		dAtA[i] = 0x20
+	}
 	if m.RemainingTTL != 0 {
 		i = encodeVarintLease(dAtA, i, uint64(m.RemainingTTL))
 		i--
@@ -219,6 +379,79 @@ func (m *Lease) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *Label) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Label) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Label) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintLease(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Key) > 0 {
+		i -= len(m.Key)
+		copy(dAtA[i:], m.Key)
+		i = encodeVarintLease(dAtA, i, uint64(len(m.Key)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LeaseGroup) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LeaseGroup) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LeaseGroup) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.XXX_unrecognized != nil {
+		i -= len(m.XXX_unrecognized)
+		copy(dAtA[i:], m.XXX_unrecognized)
+	}
+	if m.ID != 0 {
+		i = encodeVarintLease(dAtA, i, uint64(m.ID))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func (m *LeaseInternalRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
@@ -323,6 +556,66 @@ func (m *Lease) Size() (n int) {
 	if m.RemainingTTL != 0 {
 		n += 1 + sovLease(uint64(m.RemainingTTL))
 	}
+	if m.GroupID != 0 {
+		n += 1 + sovLease(uint64(m.GroupID))
+	}
+	if m.MaxLifetime != 0 {
+		n += 1 + sovLease(uint64(m.MaxLifetime))
+	}
+	if m.RemainingLifetime != 0 {
+		n += 1 + sovLease(uint64(m.RemainingLifetime))
+	}
+	if m.RenewOnWrite {
+		n += 2
+	}
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovLease(uint64(l))
+		}
+	}
+	l = len(m.Owner)
+	if l > 0 {
+		n += 1 + l + sovLease(uint64(l))
+	}
+	if m.GrantTime != 0 {
+		n += 1 + sovLease(uint64(m.GrantTime))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *Label) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Key)
+	if l > 0 {
+		n += 1 + l + sovLease(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovLease(uint64(l))
+	}
+	if m.XXX_unrecognized != nil {
+		n += len(m.XXX_unrecognized)
+	}
+	return n
+}
+
+func (m *LeaseGroup) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.ID != 0 {
+		n += 1 + sovLease(uint64(m.ID))
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -453,6 +746,353 @@ func (m *Lease) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupID", wireType)
+			}
+			m.GroupID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GroupID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxLifetime", wireType)
+			}
+			m.MaxLifetime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxLifetime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RemainingLifetime", wireType)
+			}
+			m.RemainingLifetime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RemainingLifetime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RenewOnWrite", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.RenewOnWrite = bool(v != 0)
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Labels", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthLease
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Labels = append(m.Labels, &Label{})
+			if err := m.Labels[len(m.Labels)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Owner", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLease
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Owner = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GrantTime", wireType)
+			}
+			m.GrantTime = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GrantTime |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLease(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLease
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Label) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLease
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Label: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Label: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Key", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLease
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Key = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthLease
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthLease
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipLease(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthLease
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.XXX_unrecognized = append(m.XXX_unrecognized, dAtA[iNdEx:iNdEx+skippy]...)
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LeaseGroup) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowLease
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LeaseGroup: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LeaseGroup: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ID", wireType)
+			}
+			m.ID = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowLease
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ID |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipLease(dAtA[iNdEx:])
@@ -27,6 +27,7 @@ import (
 	"go.uber.org/zap"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/version"
 	"go.etcd.io/etcd/server/v3/lease/leasepb"
 	"go.etcd.io/etcd/server/v3/storage/backend"
@@ -61,6 +62,10 @@ var (
 	ErrLeaseNotFound    = errors.New("lease not found")
 	ErrLeaseExists      = errors.New("lease already exists")
 	ErrLeaseTTLTooLarge = errors.New("too large lease TTL")
+
+	ErrLeaseGroupNotFound = errors.New("lease group not found")
+	ErrLeaseGroupExists   = errors.New("lease group already exists")
+	ErrLeaseGroupEmpty    = errors.New("lease group has no leases to renew")
 )
 
 // TxnDelete is a TxnWrite that only permits deletes. Defined here
@@ -73,6 +78,12 @@ type TxnDelete interface {
 // RangeDeleter is a TxnDelete constructor.
 type RangeDeleter func() TxnDelete
 
+// revokeReasonSetter is implemented by TxnDeletes that report why the keys
+// of a revoked lease are deleted to the watchers of the keys.
+type revokeReasonSetter interface {
+	SetRevokeReason(reason mvccpb.Event_RevokeReason)
+}
+
 // Checkpointer permits checkpointing of lease remaining TTLs to the consensus log. Defined here to
 // avoid circular dependency with mvcc.
 type Checkpointer func(ctx context.Context, lc *pb.LeaseCheckpointRequest) error
@@ -89,28 +100,71 @@ type Lessor interface {
 	SetCheckpointer(cp Checkpointer)
 
 	// Grant grants a lease that expires at least after TTL seconds.
-	Grant(id LeaseID, ttl int64) (*Lease, error)
+	Grant(id LeaseID, ttl int64, opts ...GrantOption) (*Lease, error)
 	// Revoke revokes a lease with given ID. The item attached to the
 	// given lease will be removed. If the ID does not exist, an error
 	// will be returned.
 	Revoke(id LeaseID) error
 
+	// Expire revokes an expired lease with given ID. It differs from Revoke
+	// only in the reason reported to the watchers of the keys of the lease.
+	Expire(id LeaseID) error
+
 	// Checkpoint applies the remainingTTL of a lease. The remainingTTL is used in Promote to set
 	// the expiry of leases to less than the full TTL when possible.
 	Checkpoint(id LeaseID, remainingTTL int64) error
 
+	// CheckpointLifetime applies the remainingLifetime of a lease with a max lifetime. The
+	// remainingLifetime is used in Promote to set the deadline of the lease.
+	CheckpointLifetime(id LeaseID, remainingLifetime int64) error
+
 	// Attach attaches given leaseItem to the lease with given LeaseID.
 	// If the lease does not exist, an error will be returned.
 	Attach(id LeaseID, items []LeaseItem) error
 
+	// AttachLeases attaches given leaseItem to all the leases with given LeaseIDs.
+	// The first lease is the primary lease of the items, returned by GetLease.
+	// The mode tells whether the items are deleted when any or all of the leases
+	// are revoked. Leases that do not exist are skipped; if none of the leases
+	// exist, an error will be returned.
+	AttachLeases(ids []LeaseID, mode mvccpb.LeaseMode, items []LeaseItem) error
+
 	// GetLease returns LeaseID for given item.
 	// If no lease found, NoLease value will be returned.
 	GetLease(item LeaseItem) LeaseID
 
+	// GetLeases returns the LeaseIDs of all the leases given item is attached
+	// to, the primary lease first. If no lease found, nil will be returned.
+	GetLeases(item LeaseItem) []LeaseID
+
 	// Detach detaches given leaseItem from the lease with given LeaseID.
 	// If the lease does not exist, an error will be returned.
 	Detach(id LeaseID, items []LeaseItem) error
 
+	// RenewOnWrite renews the lease with given LeaseID if it was granted to be
+	// renewed on writes. It is called on puts to the keys attached to the lease
+	// while the backend transaction is locked.
+	RenewOnWrite(id LeaseID)
+
+	// GroupGrant creates a lease group with the given ID.
+	GroupGrant(id LeaseGroupID) error
+
+	// GroupRevoke removes the lease group with the given ID. The leases attached to
+	// the group are detached from it but not revoked.
+	GroupRevoke(id LeaseGroupID) error
+
+	// GroupAttach attaches the given leases to the lease group, detaching them from
+	// any other group. If the group or any of the leases does not exist, an error
+	// will be returned and nothing is attached.
+	GroupAttach(id LeaseGroupID, leases []LeaseID) error
+
+	// GroupDetach detaches the given leases from the lease group.
+	GroupDetach(id LeaseGroupID, leases []LeaseID) error
+
+	// GroupRenew renews all the leases attached to the lease group. It returns the
+	// number of renewed leases and the smallest TTL among them.
+	GroupRenew(id LeaseGroupID) (int, int64, error)
+
 	// Promote promotes the lessor to be the primary lessor. Primary lessor manages
 	// the expiration and renew of leases.
 	// Newly promoted lessor renew the TTL of all lease to extend + previous TTL.
@@ -153,6 +207,10 @@ type lessor struct {
 	leaseExpiredNotifier *LeaseExpiredNotifier
 	leaseCheckpointHeap  LeaseQueue
 	itemMap              map[LeaseItem]LeaseID
+	// itemLeases holds the leases of the items attached to more than one
+	// lease. The primary lease of such an item is also kept in itemMap.
+	itemLeases map[LeaseItem]*itemLeases
+	groupMap   map[LeaseGroupID]*LeaseGroup
 
 	// When a lease expires, the lessor will delete the
 	// leased range (or key) by the RangeDeleter.
@@ -225,6 +283,8 @@ func newLessor(lg *zap.Logger, b backend.Backend, cluster cluster, cfg LessorCon
 	l := &lessor{
 		leaseMap:                  make(map[LeaseID]*Lease),
 		itemMap:                   make(map[LeaseItem]LeaseID),
+		itemLeases:                make(map[LeaseItem]*itemLeases),
+		groupMap:                  make(map[LeaseGroupID]*LeaseGroup),
 		leaseExpiredNotifier:      newLeaseExpiredNotifier(),
 		leaseCheckpointHeap:       make(LeaseQueue, 0),
 		b:                         b,
@@ -278,7 +338,45 @@ func (le *lessor) SetCheckpointer(cp Checkpointer) {
 	le.cp = cp
 }
 
-func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
+// GrantOption configures a lease when it is granted.
+type GrantOption func(l *Lease)
+
+// WithMaxLifetime revokes the lease once it has lived for the given number of
+// seconds, however often it is renewed.
+func WithMaxLifetime(maxLifetime int64) GrantOption {
+	return func(l *Lease) { l.maxLifetime = max(maxLifetime, 0) }
+}
+
+// WithGrantTime sets the unix time in seconds the lease was granted at, from
+// which its max lifetime runs regardless of restarts and leader changes.
+func WithGrantTime(grantTime int64) GrantOption {
+	return func(l *Lease) { l.grantTime = max(grantTime, 0) }
+}
+
+// WithRenewOnWrite renews the lease on every put to a key attached to it.
+func WithRenewOnWrite() GrantOption {
+	return func(l *Lease) { l.renewOnWrite = true }
+}
+
+// WithLabels attaches the given labels to the lease.
+func WithLabels(labels map[string]string) GrantOption {
+	return func(l *Lease) {
+		if len(labels) == 0 {
+			return
+		}
+		l.labels = make(map[string]string, len(labels))
+		for k, v := range labels {
+			l.labels[k] = v
+		}
+	}
+}
+
+// WithOwner records the user who granted the lease.
+func WithOwner(owner string) GrantOption {
+	return func(l *Lease) { l.owner = owner }
+}
+
+func (le *lessor) Grant(id LeaseID, ttl int64, opts ...GrantOption) (*Lease, error) {
 	if id == NoLease {
 		return nil, ErrLeaseNotFound
 	}
@@ -290,6 +388,12 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	// TODO: when lessor is under high load, it should give out lease
 	// with longer TTL to reduce renew load.
 	l := NewLease(id, ttl)
+	for _, opt := range opts {
+		opt(l)
+	}
+	if l.maxLifetime > MaxLeaseTTL {
+		return nil, ErrLeaseTTLTooLarge
+	}
 
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -303,6 +407,7 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 	}
 
 	if le.isPrimary() {
+		l.startLifetime(0)
 		l.refresh(0)
 	} else {
 		l.forever()
@@ -324,6 +429,14 @@ func (le *lessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
 }
 
 func (le *lessor) Revoke(id LeaseID) error {
+	return le.revoke(id, mvccpb.REVOKED)
+}
+
+func (le *lessor) Expire(id LeaseID) error {
+	return le.revoke(id, mvccpb.EXPIRED)
+}
+
+func (le *lessor) revoke(id LeaseID, reason mvccpb.Event_RevokeReason) error {
 	le.mu.Lock()
 
 	l := le.leaseMap[id]
@@ -332,7 +445,9 @@ func (le *lessor) Revoke(id LeaseID) error {
 		return ErrLeaseNotFound
 	}
 
+	l.revokeReason = reason
 	defer close(l.revokec)
+	shared := le.unsafeSharedItems(l)
 	// unlock before doing external work
 	le.mu.Unlock()
 
@@ -341,18 +456,28 @@ func (le *lessor) Revoke(id LeaseID) error {
 	}
 
 	txn := le.rd()
+	if rs, ok := txn.(revokeReasonSetter); ok {
+		rs.SetRevokeReason(reason)
+	}
 
 	// sort keys so deletes are in same order among all members,
 	// otherwise the backend hashes will be different
 	keys := l.Keys()
 	sort.StringSlice(keys).Sort()
 	for _, key := range keys {
+		if _, ok := shared[LeaseItem{Key: key}]; ok {
+			continue
+		}
 		txn.DeleteRange([]byte(key), nil)
 	}
 
 	le.mu.Lock()
 	defer le.mu.Unlock()
+	for it := range shared {
+		le.unsafeDetach(l, it)
+	}
 	delete(le.leaseMap, l.ID)
+	le.unsafeRemoveFromGroup(l)
 	// lease deletion needs to be in the same backend transaction with the
 	// kv deletion. Or we might end up with not executing the revoke or not
 	// deleting the keys if etcdserver fails in between.
@@ -382,6 +507,20 @@ func (le *lessor) Checkpoint(id LeaseID, remainingTTL int64) error {
 	return nil
 }
 
+func (le *lessor) CheckpointLifetime(id LeaseID, remainingLifetime int64) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	if l, ok := le.leaseMap[id]; ok && l.maxLifetime > 0 {
+		// as with the remainingTTL, Promote is responsible for applying this to the lease deadline
+		l.remainingLifetime = remainingLifetime
+		if le.shouldPersistCheckpoints() {
+			l.persistTo(le.b)
+		}
+	}
+	return nil
+}
+
 func (le *lessor) shouldPersistCheckpoints() bool {
 	cv := le.cluster.Version()
 	return le.checkpointPersist || (cv != nil && greaterOrEqual(*cv, version.V3_6))
@@ -477,6 +616,7 @@ func (le *lessor) Promote(extend time.Duration) {
 
 	// refresh the expiries of all leases.
 	for _, l := range le.leaseMap {
+		l.startLifetime(extend)
 		l.refresh(extend)
 		item := &LeaseWithTime{id: l.ID, time: l.expiry}
 		le.leaseExpiredNotifier.RegisterOrUpdate(item)
@@ -557,11 +697,52 @@ func (le *lessor) Attach(id LeaseID, items []LeaseItem) error {
 	for _, it := range items {
 		l.itemSet[it] = struct{}{}
 		le.itemMap[it] = id
+		delete(le.itemLeases, it)
 	}
 	l.mu.Unlock()
 	return nil
 }
 
+// AttachLeases attaches items to all the leases with given IDs. Depending on
+// the mode, the items are removed when any or all of the leases expire.
+// Leases that do not exist are skipped. If none of the leases exist, an error
+// will be returned.
+func (le *lessor) AttachLeases(ids []LeaseID, mode mvccpb.LeaseMode, items []LeaseItem) error {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	var ls []*Lease
+	for _, id := range ids {
+		if l := le.leaseMap[id]; l != nil {
+			ls = append(ls, l)
+		}
+	}
+	if len(ls) == 0 {
+		return ErrLeaseNotFound
+	}
+
+	for _, l := range ls {
+		l.mu.Lock()
+		for _, it := range items {
+			l.itemSet[it] = struct{}{}
+		}
+		l.mu.Unlock()
+	}
+	for _, it := range items {
+		le.itemMap[it] = ls[0].ID
+		if len(ls) == 1 {
+			delete(le.itemLeases, it)
+			continue
+		}
+		il := &itemLeases{ids: make([]LeaseID, len(ls)), mode: mode}
+		for i, l := range ls {
+			il.ids[i] = l.ID
+		}
+		le.itemLeases[it] = il
+	}
+	return nil
+}
+
 func (le *lessor) GetLease(item LeaseItem) LeaseID {
 	le.mu.RLock()
 	id := le.itemMap[item]
@@ -569,6 +750,18 @@ func (le *lessor) GetLease(item LeaseItem) LeaseID {
 	return id
 }
 
+func (le *lessor) GetLeases(item LeaseItem) []LeaseID {
+	le.mu.RLock()
+	defer le.mu.RUnlock()
+	if il := le.itemLeases[item]; il != nil {
+		return append([]LeaseID(nil), il.ids...)
+	}
+	if id, ok := le.itemMap[item]; ok {
+		return []LeaseID{id}
+	}
+	return nil
+}
+
 // Detach detaches items from the lease with given ID.
 // If the given lease does not exist, an error will be returned.
 func (le *lessor) Detach(id LeaseID, items []LeaseItem) error {
@@ -580,15 +773,89 @@ func (le *lessor) Detach(id LeaseID, items []LeaseItem) error {
 		return ErrLeaseNotFound
 	}
 
-	l.mu.Lock()
 	for _, it := range items {
-		delete(l.itemSet, it)
-		delete(le.itemMap, it)
+		le.unsafeDetach(l, it)
 	}
-	l.mu.Unlock()
 	return nil
 }
 
+// unsafeDetach detaches the item from the lease. If the item is attached to
+// other leases, the next one becomes its primary lease.
+func (le *lessor) unsafeDetach(l *Lease, it LeaseItem) {
+	l.mu.Lock()
+	delete(l.itemSet, it)
+	l.mu.Unlock()
+
+	il := le.itemLeases[it]
+	if il == nil {
+		delete(le.itemMap, it)
+		return
+	}
+	for i, id := range il.ids {
+		if id == l.ID {
+			il.ids = append(il.ids[:i], il.ids[i+1:]...)
+			break
+		}
+	}
+	switch len(il.ids) {
+	case 0:
+		delete(le.itemLeases, it)
+		delete(le.itemMap, it)
+	case 1:
+		delete(le.itemLeases, it)
+		le.itemMap[it] = il.ids[0]
+	default:
+		le.itemMap[it] = il.ids[0]
+	}
+}
+
+// unsafeSharedItems returns the items of the lease that are attached to other
+// leases in ALL mode, which are kept when the lease is revoked.
+func (le *lessor) unsafeSharedItems(l *Lease) map[LeaseItem]struct{} {
+	var shared map[LeaseItem]struct{}
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for it := range l.itemSet {
+		il := le.itemLeases[it]
+		if il == nil || il.mode != mvccpb.ALL || len(il.ids) < 2 {
+			continue
+		}
+		if shared == nil {
+			shared = make(map[LeaseItem]struct{})
+		}
+		shared[it] = struct{}{}
+	}
+	return shared
+}
+
+func (le *lessor) RenewOnWrite(id LeaseID) {
+	le.mu.Lock()
+	defer le.mu.Unlock()
+
+	l := le.leaseMap[id]
+	if l == nil || !l.renewOnWrite {
+		return
+	}
+
+	// Writes are applied by every member, so unlike Renew the remaining TTL
+	// is cleared without a checkpoint.
+	if l.remainingTTL > 0 {
+		l.remainingTTL = 0
+		if le.shouldPersistCheckpoints() {
+			// the caller holds the lock of the backend transaction
+			l.unsafePersistTo(le.b.BatchTx())
+		}
+	}
+
+	// an expired lease is pending revocation and is not renewed
+	if le.isPrimary() && !l.expired() {
+		l.refresh(0)
+		item := &LeaseWithTime{id: l.ID, time: l.expiry}
+		le.leaseExpiredNotifier.RegisterOrUpdate(item)
+		leaseRenewed.Inc()
+	}
+}
+
 func (le *lessor) Recover(b backend.Backend, rd RangeDeleter) {
 	le.mu.Lock()
 	defer le.mu.Unlock()
@@ -597,6 +864,8 @@ func (le *lessor) Recover(b backend.Backend, rd RangeDeleter) {
 	le.rd = rd
 	le.leaseMap = make(map[LeaseID]*Lease)
 	le.itemMap = make(map[LeaseItem]LeaseID)
+	le.itemLeases = make(map[LeaseItem]*itemLeases)
+	le.groupMap = make(map[LeaseGroupID]*LeaseGroup)
 	le.initAndRecover()
 }
 
@@ -747,7 +1016,8 @@ func (le *lessor) scheduleCheckpointIfNeeded(lease *Lease) {
 		return
 	}
 
-	if lease.getRemainingTTL() > int64(le.checkpointInterval.Seconds()) {
+	interval := int64(le.checkpointInterval.Seconds())
+	if lease.getRemainingTTL() > interval || (lease.checkpointsLifetime() && lease.getRemainingLifetime() > interval) {
 		if le.lg != nil {
 			le.lg.Debug("Scheduling lease checkpoint",
 				zap.Int64("leaseID", int64(lease.ID)),
@@ -783,16 +1053,22 @@ func (le *lessor) findDueScheduledCheckpoints(checkpointLimit int) []*pb.LeaseCh
 			continue
 		}
 		remainingTTL := int64(math.Ceil(l.expiry.Sub(now).Seconds()))
+		remainingLifetime := l.remainingLifetimeAt(now)
 		if remainingTTL >= l.ttl {
-			continue
+			if remainingLifetime == 0 {
+				continue
+			}
+			// the lease was just renewed, only its lifetime needs a checkpoint
+			remainingTTL = 0
 		}
 		if le.lg != nil {
 			le.lg.Debug("Checkpointing lease",
 				zap.Int64("leaseID", int64(lt.id)),
 				zap.Int64("remainingTTL", remainingTTL),
+				zap.Int64("remainingLifetime", remainingLifetime),
 			)
 		}
-		cps = append(cps, &pb.LeaseCheckpoint{ID: int64(lt.id), Remaining_TTL: remainingTTL})
+		cps = append(cps, &pb.LeaseCheckpoint{ID: int64(lt.id), Remaining_TTL: remainingTTL, RemainingLifetime: remainingLifetime})
 	}
 	return cps
 }
@@ -803,7 +1079,11 @@ func (le *lessor) initAndRecover() {
 	tx.LockOutsideApply()
 	schema.UnsafeCreateLeaseBucket(tx)
 	lpbs := schema.MustUnsafeGetAllLeases(tx)
+	gpbs := schema.MustUnsafeGetAllLeaseGroups(tx)
 	tx.Unlock()
+	for _, gpb := range gpbs {
+		le.groupMap[LeaseGroupID(gpb.ID)] = newLeaseGroup(LeaseGroupID(gpb.ID))
+	}
 	for _, lpb := range lpbs {
 		ID := LeaseID(lpb.ID)
 		if lpb.TTL < le.minLeaseTTL {
@@ -818,6 +1098,23 @@ func (le *lessor) initAndRecover() {
 			expiry:       forever,
 			revokec:      make(chan struct{}),
 			remainingTTL: lpb.RemainingTTL,
+
+			maxLifetime:       lpb.MaxLifetime,
+			remainingLifetime: lpb.RemainingLifetime,
+			grantTime:         lpb.GrantTime,
+			renewOnWrite:      lpb.RenewOnWrite,
+			owner:             lpb.Owner,
+		}
+		if len(lpb.Labels) > 0 {
+			labels := make(map[string]string, len(lpb.Labels))
+			for _, label := range lpb.Labels {
+				labels[label.Key] = label.Value
+			}
+			le.leaseMap[ID].labels = labels
+		}
+		if g := le.groupMap[LeaseGroupID(lpb.GroupID)]; g != nil {
+			le.leaseMap[ID].groupID = g.ID
+			g.leases[ID] = struct{}{}
 		}
 	}
 	le.leaseExpiredNotifier.Init()
@@ -836,20 +1133,41 @@ func (fl *FakeLessor) SetRangeDeleter(dr RangeDeleter) {}
 
 func (fl *FakeLessor) SetCheckpointer(cp Checkpointer) {}
 
-func (fl *FakeLessor) Grant(id LeaseID, ttl int64) (*Lease, error) {
+func (fl *FakeLessor) Grant(id LeaseID, ttl int64, opts ...GrantOption) (*Lease, error) {
 	fl.LeaseSet[id] = struct{}{}
 	return nil, nil
 }
 
 func (fl *FakeLessor) Revoke(id LeaseID) error { return nil }
 
+func (fl *FakeLessor) Expire(id LeaseID) error { return nil }
+
 func (fl *FakeLessor) Checkpoint(id LeaseID, remainingTTL int64) error { return nil }
 
+func (fl *FakeLessor) CheckpointLifetime(id LeaseID, remainingLifetime int64) error { return nil }
+
 func (fl *FakeLessor) Attach(id LeaseID, items []LeaseItem) error { return nil }
 
+func (fl *FakeLessor) AttachLeases(ids []LeaseID, mode mvccpb.LeaseMode, items []LeaseItem) error {
+	return nil
+}
+
 func (fl *FakeLessor) GetLease(item LeaseItem) LeaseID            { return 0 }
+func (fl *FakeLessor) GetLeases(item LeaseItem) []LeaseID         { return nil }
 func (fl *FakeLessor) Detach(id LeaseID, items []LeaseItem) error { return nil }
 
+func (fl *FakeLessor) RenewOnWrite(id LeaseID) {}
+
+func (fl *FakeLessor) GroupGrant(id LeaseGroupID) error { return nil }
+
+func (fl *FakeLessor) GroupRevoke(id LeaseGroupID) error { return nil }
+
+func (fl *FakeLessor) GroupAttach(id LeaseGroupID, leases []LeaseID) error { return nil }
+
+func (fl *FakeLessor) GroupDetach(id LeaseGroupID, leases []LeaseID) error { return nil }
+
+func (fl *FakeLessor) GroupRenew(id LeaseGroupID) (int, int64, error) { return 0, 10, nil }
+
 func (fl *FakeLessor) Promote(extend time.Duration) {}
 
 func (fl *FakeLessor) Demote() {}
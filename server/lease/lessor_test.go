@@ -31,6 +31,7 @@ import (
 	"go.uber.org/zap/zaptest"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/api/v3/version"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	"go.etcd.io/etcd/server/v3/storage/schema"
@@ -101,7 +102,7 @@ func TestLessorGrant(t *testing.T) {
 	defer tx.Unlock()
 	lpb := schema.MustUnsafeGetLease(tx, int64(l.ID))
 	if lpb == nil {
-		t.Errorf("lpb = %d, want not nil", lpb)
+		t.Errorf("lpb = %v, want not nil", lpb)
 	}
 }
 
@@ -205,7 +206,62 @@ func TestLessorRevoke(t *testing.T) {
 	defer tx.Unlock()
 	lpb := schema.MustUnsafeGetLease(tx, int64(l.ID))
 	if lpb != nil {
-		t.Errorf("lpb = %d, want nil", lpb)
+		t.Errorf("lpb = %v, want nil", lpb)
+	}
+}
+
+// TestLessorRevokeReason ensures that the reason a lease was revoked is reported
+// to the deleter of its keys and through the lease itself.
+func TestLessorRevokeReason(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	var fd *fakeDeleter
+	le.SetRangeDeleter(func() TxnDelete {
+		fd = newFakeDeleter(be)
+		return fd
+	})
+
+	tests := []struct {
+		revoke func(id LeaseID) error
+		reason mvccpb.Event_RevokeReason
+	}{
+		{le.Expire, mvccpb.EXPIRED},
+		{le.Revoke, mvccpb.REVOKED},
+	}
+	for i, tt := range tests {
+		l, err := le.Grant(LeaseID(i+1), 100)
+		if err != nil {
+			t.Fatalf("#%d: could not grant lease (%v)", i, err)
+		}
+		if r := l.RevokeReason(); r != mvccpb.NOT_REVOKED {
+			t.Errorf("#%d: reason = %v, want %v", i, r, mvccpb.NOT_REVOKED)
+		}
+		if err = le.Attach(l.ID, []LeaseItem{{"foo"}}); err != nil {
+			t.Fatalf("#%d: failed to attach items to the lease: %v", i, err)
+		}
+
+		if err = tt.revoke(l.ID); err != nil {
+			t.Fatalf("#%d: failed to revoke lease: %v", i, err)
+		}
+		select {
+		case <-l.Done():
+		default:
+			t.Fatalf("#%d: lease %x is not done after revoke", i, l.ID)
+		}
+		if r := l.RevokeReason(); r != tt.reason {
+			t.Errorf("#%d: lease reason = %v, want %v", i, r, tt.reason)
+		}
+		if fd.reason != tt.reason {
+			t.Errorf("#%d: deleter reason = %v, want %v", i, fd.reason, tt.reason)
+		}
+	}
+	if err := le.Expire(1); !errors.Is(err, ErrLeaseNotFound) {
+		t.Errorf("err = %v, want %v", err, ErrLeaseNotFound)
 	}
 }
 
@@ -396,6 +452,75 @@ func TestLessorDetach(t *testing.T) {
 	}
 }
 
+// TestLessorAttachLeases ensures that a key attached to several leases is
+// deleted when any or all of its leases are revoked, depending on its mode.
+func TestLessorAttachLeases(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	var fd *fakeDeleter
+	le.SetRangeDeleter(func() TxnDelete {
+		fd = newFakeDeleter(be)
+		return fd
+	})
+
+	for id := LeaseID(1); id <= 3; id++ {
+		if _, err := le.Grant(id, 100); err != nil {
+			t.Fatalf("could not grant lease %d (%v)", id, err)
+		}
+	}
+	if err := le.AttachLeases([]LeaseID{1, 2, 3}, mvccpb.ALL, []LeaseItem{{"all"}}); err != nil {
+		t.Fatalf("failed to attach all item: %v", err)
+	}
+	if err := le.AttachLeases([]LeaseID{2, 3}, mvccpb.ANY, []LeaseItem{{"any"}}); err != nil {
+		t.Fatalf("failed to attach any item: %v", err)
+	}
+	if err := le.AttachLeases([]LeaseID{4, 5}, mvccpb.ANY, []LeaseItem{{"none"}}); !errors.Is(err, ErrLeaseNotFound) {
+		t.Errorf("err = %v, want %v", err, ErrLeaseNotFound)
+	}
+	if ids := le.GetLeases(LeaseItem{"all"}); !reflect.DeepEqual(ids, []LeaseID{1, 2, 3}) {
+		t.Errorf("leases = %v, want [1 2 3]", ids)
+	}
+
+	// revoking the primary lease keeps the all item and promotes the next lease
+	if err := le.Revoke(1); err != nil {
+		t.Fatalf("failed to revoke lease: %v", err)
+	}
+	if len(fd.deleted) != 0 {
+		t.Errorf("deleted = %v, want none", fd.deleted)
+	}
+	if id := le.GetLease(LeaseItem{"all"}); id != 2 {
+		t.Errorf("primary lease = %d, want 2", id)
+	}
+
+	// revoking a lease of the any item deletes it
+	if err := le.Revoke(3); err != nil {
+		t.Fatalf("failed to revoke lease: %v", err)
+	}
+	if !reflect.DeepEqual(fd.deleted, []string{"any_"}) {
+		t.Errorf("deleted = %v, want [any_]", fd.deleted)
+	}
+	// the store detaches deleted keys from all their leases
+	if err := le.Detach(2, []LeaseItem{{"any"}}); err != nil {
+		t.Fatalf("failed to detach item: %v", err)
+	}
+	if ids := le.GetLeases(LeaseItem{"all"}); !reflect.DeepEqual(ids, []LeaseID{2}) {
+		t.Errorf("leases = %v, want [2]", ids)
+	}
+
+	// revoking the last lease of the all item deletes it
+	if err := le.Revoke(2); err != nil {
+		t.Fatalf("failed to revoke lease: %v", err)
+	}
+	if !reflect.DeepEqual(fd.deleted, []string{"all_"}) {
+		t.Errorf("deleted = %v, want [all_]", fd.deleted)
+	}
+}
+
 // TestLessorRecover ensures Lessor recovers leases from
 // persist backend.
 func TestLessorRecover(t *testing.T) {
@@ -426,6 +551,40 @@ func TestLessorRecover(t *testing.T) {
 	}
 }
 
+// TestLessorRecoverLabels ensures the labels and owner of a lease are
+// persisted and recovered.
+func TestLessorRecoverLabels(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	labels := map[string]string{"owner": "svc-a", "purpose": "election"}
+	l, err := le.Grant(1, 10, WithLabels(labels), WithOwner("user1"))
+	if err != nil {
+		t.Fatalf("could not grant lease (%v)", err)
+	}
+	labels["owner"] = "svc-b"
+	if l.Labels()["owner"] != "svc-a" {
+		t.Errorf("labels = %v, want owner=svc-a", l.Labels())
+	}
+
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	nl := nle.Lookup(l.ID)
+	if nl == nil {
+		t.Fatalf("lease %x is not recovered", l.ID)
+	}
+	if !reflect.DeepEqual(nl.Labels(), l.Labels()) {
+		t.Errorf("labels = %v, want %v", nl.Labels(), l.Labels())
+	}
+	if nl.Owner() != "user1" {
+		t.Errorf("owner = %q, want %q", nl.Owner(), "user1")
+	}
+}
+
 func TestLessorExpire(t *testing.T) {
 	lg := zap.NewNop()
 	dir, be := NewTestBackend(t)
@@ -670,19 +829,169 @@ func TestLessorCheckpointPersistenceAfterRestart(t *testing.T) {
 	}
 }
 
+// TestLessorMaxLifetime ensures renewing a lease never extends it past its
+// max lifetime, and that the remaining lifetime is checkpointed and restored
+// on promotion.
+func TestLessorMaxLifetime(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, CheckpointInterval: time.Second})
+	defer le.Stop()
+	checkpointC := make(chan *pb.LeaseCheckpoint, 1)
+	le.SetCheckpointer(func(ctx context.Context, lc *pb.LeaseCheckpointRequest) error {
+		for _, cp := range lc.Checkpoints {
+			select {
+			case checkpointC <- cp:
+			default:
+			}
+		}
+		return nil
+	})
+	le.Promote(0)
+
+	if _, err := le.Grant(1, 10, WithMaxLifetime(MaxLeaseTTL+1)); !errors.Is(err, ErrLeaseTTLTooLarge) {
+		t.Fatalf("err = %v, want %v", err, ErrLeaseTTLTooLarge)
+	}
+	l, err := le.Grant(1, 10, WithMaxLifetime(5))
+	if err != nil {
+		t.Fatalf("failed to grant lease (%v)", err)
+	}
+	if remaining := l.Remaining(); remaining > 5*time.Second {
+		t.Errorf("remaining = %v, want at most the max lifetime of 5s", remaining)
+	}
+	if _, err = le.Renew(1); err != nil {
+		t.Fatalf("failed to renew lease (%v)", err)
+	}
+	if remaining := l.Remaining(); remaining > 5*time.Second {
+		t.Errorf("remaining = %v after renew, want at most the max lifetime of 5s", remaining)
+	}
+
+	select {
+	case cp := <-checkpointC:
+		if cp.RemainingLifetime != 4 {
+			t.Errorf("checkpointed remaining lifetime = %d, want 4", cp.RemainingLifetime)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected checkpointer to be called, but it was not")
+	}
+
+	le.Demote()
+	le.CheckpointLifetime(1, 2)
+	le.Promote(0)
+	if remaining := l.Remaining(); remaining > 2*time.Second {
+		t.Errorf("remaining = %v, want at most the checkpointed lifetime of 2s", remaining)
+	}
+
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	if nl := nle.Lookup(1); nl.MaxLifetime() != 5 || nl.getRemainingLifetime() != 2 {
+		t.Errorf("max lifetime = %d, remaining lifetime = %d, want 5 and 2", nl.MaxLifetime(), nl.getRemainingLifetime())
+	}
+}
+
+// TestLessorMaxLifetimeGrantTime ensures the max lifetime of a lease granted
+// at a known time runs from it, across leader changes and restarts, without
+// lifetime checkpoints.
+func TestLessorMaxLifetimeGrantTime(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL, CheckpointInterval: time.Second})
+	defer le.Stop()
+	le.Promote(0)
+
+	grantTime := time.Now().Add(-5 * time.Second).Unix()
+	l, err := le.Grant(1, 60, WithMaxLifetime(10), WithGrantTime(grantTime))
+	if err != nil {
+		t.Fatalf("failed to grant lease (%v)", err)
+	}
+	deadline := time.Unix(grantTime+10, 0)
+	if l.expiry.After(deadline) {
+		t.Errorf("expiry = %v, want at most %v", l.expiry, deadline)
+	}
+	if cps := le.findDueScheduledCheckpoints(10); len(cps) != 0 {
+		t.Errorf("checkpoints = %v, want none", cps)
+	}
+
+	le.Demote()
+	le.Promote(time.Minute)
+	if l.expiry.After(deadline) {
+		t.Errorf("expiry = %v after leader change, want at most %v", l.expiry, deadline)
+	}
+
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	nle.Promote(time.Minute)
+	if nl := nle.Lookup(1); nl.expiry.After(deadline) {
+		t.Errorf("expiry = %v after restart, want at most %v", nl.expiry, deadline)
+	}
+}
+
+// TestLessorRenewOnWrite ensures only leases granted with renew on write are
+// renewed on writes, and that their remaining TTL is cleared.
+func TestLessorRenewOnWrite(t *testing.T) {
+	lg := zap.NewNop()
+	dir, be := NewTestBackend(t)
+	defer os.RemoveAll(dir)
+	defer be.Close()
+
+	le := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer le.Stop()
+	le.Promote(0)
+
+	l1, err := le.Grant(1, 10, WithRenewOnWrite())
+	if err != nil {
+		t.Fatalf("failed to grant lease (%v)", err)
+	}
+	l2, err := le.Grant(2, 10)
+	if err != nil {
+		t.Fatalf("failed to grant lease (%v)", err)
+	}
+	le.Checkpoint(1, 1)
+	le.Checkpoint(2, 1)
+	le.Promote(0)
+
+	tx := be.BatchTx()
+	tx.LockInsideApply()
+	le.RenewOnWrite(1)
+	le.RenewOnWrite(2)
+	tx.Unlock()
+
+	if l1.remainingTTL != 0 || l1.Remaining() < 9*time.Second {
+		t.Errorf("lease 1 remaining = %v, want renewed", l1.Remaining())
+	}
+	if l2.remainingTTL != 1 || l2.Remaining() > time.Second {
+		t.Errorf("lease 2 remaining = %v, want not renewed", l2.Remaining())
+	}
+
+	nle := newLessor(lg, be, clusterLatest(), LessorConfig{MinLeaseTTL: minLeaseTTL})
+	defer nle.Stop()
+	if nl := nle.Lookup(1); !nl.RenewOnWrite() || nl.remainingTTL != 0 {
+		t.Errorf("renew on write = %v, remaining TTL = %d, want true and 0", nl.RenewOnWrite(), nl.remainingTTL)
+	}
+}
+
 type fakeDeleter struct {
 	deleted []string
+	reason  mvccpb.Event_RevokeReason
 	tx      backend.BatchTx
 }
 
 func newFakeDeleter(be backend.Backend) *fakeDeleter {
-	fd := &fakeDeleter{nil, be.BatchTx()}
+	fd := &fakeDeleter{tx: be.BatchTx()}
 	fd.tx.Lock()
 	return fd
 }
 
 func (fd *fakeDeleter) End() { fd.tx.Unlock() }
 
+func (fd *fakeDeleter) SetRevokeReason(reason mvccpb.Event_RevokeReason) { fd.reason = reason }
+
 func (fd *fakeDeleter) DeleteRange(key, end []byte) (int64, int64) {
 	fd.deleted = append(fd.deleted, string(key)+"_"+string(end))
 	return 0, 0
@@ -40,6 +40,13 @@ var (
 		Help:      "The number of renewed leases seen by the leader.",
 	})
 
+	leaseGroupRenewed = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "etcd_debugging",
+		Subsystem: "lease",
+		Name:      "group_renewed_total",
+		Help:      "The number of renewed lease groups seen by the leader.",
+	})
+
 	leaseTotalTTLs = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "etcd_debugging",
@@ -56,5 +63,6 @@ func init() {
 	prometheus.MustRegister(leaseGranted)
 	prometheus.MustRegister(leaseRevoked)
 	prometheus.MustRegister(leaseRenewed)
+	prometheus.MustRegister(leaseGroupRenewed)
 	prometheus.MustRegister(leaseTotalTTLs)
 }
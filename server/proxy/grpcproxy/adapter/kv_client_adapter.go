@@ -47,3 +47,7 @@ func (s *kvs2kvc) Txn(ctx context.Context, in *pb.TxnRequest, opts ...grpc.CallO
 func (s *kvs2kvc) Compact(ctx context.Context, in *pb.CompactionRequest, opts ...grpc.CallOption) (*pb.CompactionResponse, error) {
 	return s.kvs.Compact(ctx, in)
 }
+
+func (s *kvs2kvc) RevisionAt(ctx context.Context, in *pb.RevisionAtRequest, opts ...grpc.CallOption) (*pb.RevisionAtResponse, error) {
+	return s.kvs.RevisionAt(ctx, in)
+}
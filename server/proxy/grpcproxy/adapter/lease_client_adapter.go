@@ -53,6 +53,26 @@ func (c *ls2lc) LeaseLeases(ctx context.Context, in *pb.LeaseLeasesRequest, opts
 	return c.leaseServer.LeaseLeases(ctx, in)
 }
 
+func (c *ls2lc) LeaseGroupGrant(ctx context.Context, in *pb.LeaseGroupGrantRequest, opts ...grpc.CallOption) (*pb.LeaseGroupGrantResponse, error) {
+	return c.leaseServer.LeaseGroupGrant(ctx, in)
+}
+
+func (c *ls2lc) LeaseGroupRevoke(ctx context.Context, in *pb.LeaseGroupRevokeRequest, opts ...grpc.CallOption) (*pb.LeaseGroupRevokeResponse, error) {
+	return c.leaseServer.LeaseGroupRevoke(ctx, in)
+}
+
+func (c *ls2lc) LeaseGroupAttach(ctx context.Context, in *pb.LeaseGroupAttachRequest, opts ...grpc.CallOption) (*pb.LeaseGroupAttachResponse, error) {
+	return c.leaseServer.LeaseGroupAttach(ctx, in)
+}
+
+func (c *ls2lc) LeaseGroupDetach(ctx context.Context, in *pb.LeaseGroupDetachRequest, opts ...grpc.CallOption) (*pb.LeaseGroupDetachResponse, error) {
+	return c.leaseServer.LeaseGroupDetach(ctx, in)
+}
+
+func (c *ls2lc) LeaseGroupKeepAlive(ctx context.Context, in *pb.LeaseGroupKeepAliveRequest, opts ...grpc.CallOption) (*pb.LeaseGroupKeepAliveResponse, error) {
+	return c.leaseServer.LeaseGroupKeepAlive(ctx, in)
+}
+
 // ls2lcClientStream implements Lease_LeaseKeepAliveClient
 type ls2lcClientStream struct{ chanClientStream }
 
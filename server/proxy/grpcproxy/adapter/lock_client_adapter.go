@@ -35,3 +35,15 @@ func (s *ls2lsc) Lock(ctx context.Context, r *v3lockpb.LockRequest, opts ...grpc
 func (s *ls2lsc) Unlock(ctx context.Context, r *v3lockpb.UnlockRequest, opts ...grpc.CallOption) (*v3lockpb.UnlockResponse, error) {
 	return s.ls.Unlock(ctx, r)
 }
+
+func (s *ls2lsc) TryLock(ctx context.Context, r *v3lockpb.TryLockRequest, opts ...grpc.CallOption) (*v3lockpb.LockResponse, error) {
+	return s.ls.TryLock(ctx, r)
+}
+
+func (s *ls2lsc) LockInfo(ctx context.Context, r *v3lockpb.LockInfoRequest, opts ...grpc.CallOption) (*v3lockpb.LockInfoResponse, error) {
+	return s.ls.LockInfo(ctx, r)
+}
+
+func (s *ls2lsc) ForceUnlock(ctx context.Context, r *v3lockpb.ForceUnlockRequest, opts ...grpc.CallOption) (*v3lockpb.ForceUnlockResponse, error) {
+	return s.ls.ForceUnlock(ctx, r)
+}
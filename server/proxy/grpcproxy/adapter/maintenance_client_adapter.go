@@ -56,6 +56,18 @@ func (s *mts2mtc) Downgrade(ctx context.Context, r *pb.DowngradeRequest, opts ..
 	return s.mts.Downgrade(ctx, r)
 }
 
+func (s *mts2mtc) CompactionPolicy(ctx context.Context, r *pb.CompactionPolicyRequest, opts ...grpc.CallOption) (*pb.CompactionPolicyResponse, error) {
+	return s.mts.CompactionPolicy(ctx, r)
+}
+
+func (s *mts2mtc) Drain(ctx context.Context, r *pb.DrainRequest, opts ...grpc.CallOption) (*pb.DrainResponse, error) {
+	return s.mts.Drain(ctx, r)
+}
+
+func (s *mts2mtc) Undrain(ctx context.Context, r *pb.UndrainRequest, opts ...grpc.CallOption) (*pb.UndrainResponse, error) {
+	return s.mts.Undrain(ctx, r)
+}
+
 func (s *mts2mtc) Snapshot(ctx context.Context, in *pb.SnapshotRequest, opts ...grpc.CallOption) (pb.Maintenance_SnapshotClient, error) {
 	cs := newPipeStream(ctx, func(ss chanServerStream) error {
 		return s.mts.Snapshot(in, &ss2scServerStream{ss})
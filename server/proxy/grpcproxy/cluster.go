@@ -175,6 +175,10 @@ func (cp *clusterProxy) MemberList(ctx context.Context, r *pb.MemberListRequest)
 	return cp.clus.MemberList(ctx, r)
 }
 
+func (cp *clusterProxy) MemberReplace(ctx context.Context, r *pb.MemberReplaceRequest) (*pb.MemberReplaceResponse, error) {
+	return cp.clus.MemberReplace(ctx, r)
+}
+
 func (cp *clusterProxy) MemberPromote(ctx context.Context, r *pb.MemberPromoteRequest) (*pb.MemberPromoteResponse, error) {
 	// TODO: implement
 	return nil, errors.New("not implemented")
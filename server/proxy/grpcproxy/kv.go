@@ -17,6 +17,7 @@ package grpcproxy
 import (
 	"context"
 	"errors"
+	"time"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -140,6 +141,15 @@ func (p *kvProxy) Compact(ctx context.Context, r *pb.CompactionRequest) (*pb.Com
 	return (*pb.CompactionResponse)(resp), err
 }
 
+func (p *kvProxy) RevisionAt(ctx context.Context, r *pb.RevisionAtRequest) (*pb.RevisionAtResponse, error) {
+	var opts []clientv3.OpOption
+	if r.Serializable {
+		opts = append(opts, clientv3.WithSerializable())
+	}
+	resp, err := p.kv.RevisionAt(ctx, time.Unix(0, r.Timestamp), opts...)
+	return (*pb.RevisionAtResponse)(resp), err
+}
+
 func requestOpToOp(union *pb.RequestOp) clientv3.Op {
 	switch tv := union.Request.(type) {
 	case *pb.RequestOp_RequestRange:
@@ -116,19 +116,56 @@ func (lp *leaseProxy) LeaseTimeToLive(ctx context.Context, rr *pb.LeaseTimeToLiv
 }
 
 func (lp *leaseProxy) LeaseLeases(ctx context.Context, rr *pb.LeaseLeasesRequest) (*pb.LeaseLeasesResponse, error) {
-	r, err := lp.lessor.Leases(ctx)
+	rp, err := lp.leaseClient.LeaseLeases(ctx, rr, grpc.WaitForReady(true))
 	if err != nil {
 		return nil, err
 	}
-	leases := make([]*pb.LeaseStatus, len(r.Leases))
-	for i := range r.Leases {
-		leases[i] = &pb.LeaseStatus{ID: int64(r.Leases[i].ID)}
+	return rp, nil
+}
+
+func (lp *leaseProxy) LeaseGroupGrant(ctx context.Context, gr *pb.LeaseGroupGrantRequest) (*pb.LeaseGroupGrantResponse, error) {
+	rp, err := lp.leaseClient.LeaseGroupGrant(ctx, gr, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
 	}
-	rp := &pb.LeaseLeasesResponse{
-		Header: r.ResponseHeader,
-		Leases: leases,
+	lp.leader.gotLeader()
+	return rp, nil
+}
+
+func (lp *leaseProxy) LeaseGroupRevoke(ctx context.Context, gr *pb.LeaseGroupRevokeRequest) (*pb.LeaseGroupRevokeResponse, error) {
+	rp, err := lp.leaseClient.LeaseGroupRevoke(ctx, gr, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
 	}
-	return rp, err
+	lp.leader.gotLeader()
+	return rp, nil
+}
+
+func (lp *leaseProxy) LeaseGroupAttach(ctx context.Context, gr *pb.LeaseGroupAttachRequest) (*pb.LeaseGroupAttachResponse, error) {
+	rp, err := lp.leaseClient.LeaseGroupAttach(ctx, gr, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	lp.leader.gotLeader()
+	return rp, nil
+}
+
+func (lp *leaseProxy) LeaseGroupDetach(ctx context.Context, gr *pb.LeaseGroupDetachRequest) (*pb.LeaseGroupDetachResponse, error) {
+	rp, err := lp.leaseClient.LeaseGroupDetach(ctx, gr, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	lp.leader.gotLeader()
+	return rp, nil
+}
+
+func (lp *leaseProxy) LeaseGroupKeepAlive(ctx context.Context, gr *pb.LeaseGroupKeepAliveRequest) (*pb.LeaseGroupKeepAliveResponse, error) {
+	rp, err := lp.leaseClient.LeaseGroupKeepAlive(ctx, gr, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, err
+	}
+	lp.leader.gotLeader()
+	return rp, nil
 }
 
 func (lp *leaseProxy) LeaseKeepAlive(stream pb.Lease_LeaseKeepAliveServer) error {
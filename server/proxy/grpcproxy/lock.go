@@ -36,3 +36,15 @@ func (lp *lockProxy) Lock(ctx context.Context, req *v3lockpb.LockRequest) (*v3lo
 func (lp *lockProxy) Unlock(ctx context.Context, req *v3lockpb.UnlockRequest) (*v3lockpb.UnlockResponse, error) {
 	return lp.lockClient.Unlock(ctx, req)
 }
+
+func (lp *lockProxy) TryLock(ctx context.Context, req *v3lockpb.TryLockRequest) (*v3lockpb.LockResponse, error) {
+	return lp.lockClient.TryLock(ctx, req)
+}
+
+func (lp *lockProxy) LockInfo(ctx context.Context, req *v3lockpb.LockInfoRequest) (*v3lockpb.LockInfoResponse, error) {
+	return lp.lockClient.LockInfo(ctx, req)
+}
+
+func (lp *lockProxy) ForceUnlock(ctx context.Context, req *v3lockpb.ForceUnlockRequest) (*v3lockpb.ForceUnlockResponse, error) {
+	return lp.lockClient.ForceUnlock(ctx, req)
+}
@@ -86,3 +86,15 @@ func (mp *maintenanceProxy) MoveLeader(ctx context.Context, r *pb.MoveLeaderRequ
 func (mp *maintenanceProxy) Downgrade(ctx context.Context, r *pb.DowngradeRequest) (*pb.DowngradeResponse, error) {
 	return mp.maintenanceClient.Downgrade(ctx, r)
 }
+
+func (mp *maintenanceProxy) CompactionPolicy(ctx context.Context, r *pb.CompactionPolicyRequest) (*pb.CompactionPolicyResponse, error) {
+	return mp.maintenanceClient.CompactionPolicy(ctx, r)
+}
+
+func (mp *maintenanceProxy) Drain(ctx context.Context, r *pb.DrainRequest) (*pb.DrainResponse, error) {
+	return mp.maintenanceClient.Drain(ctx, r)
+}
+
+func (mp *maintenanceProxy) Undrain(ctx context.Context, r *pb.UndrainRequest) (*pb.UndrainResponse, error) {
+	return mp.maintenanceClient.Undrain(ctx, r)
+}
@@ -246,6 +246,18 @@ func (wps *watchProxyStream) recvLoop() error {
 				continue
 			}
 
+			if cr.Lease != 0 {
+				// lease watchers are not coalesced by the proxy
+				wps.watchCh <- &pb.WatchResponse{
+					Header:       &pb.ResponseHeader{},
+					WatchId:      clientv3.InvalidWatchID,
+					Created:      true,
+					Canceled:     true,
+					CancelReason: "grpcproxy: lease watch is not supported",
+				}
+				continue
+			}
+
 			wps.mu.Lock()
 			w := &watcher{
 				wr:  watchRange{string(cr.Key), string(cr.RangeEnd)},
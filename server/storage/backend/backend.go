@@ -15,11 +15,8 @@
 package backend
 
 import (
-	"fmt"
 	"hash/crc32"
 	"io"
-	"os"
-	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -53,7 +50,13 @@ type Backend interface {
 	// ConcurrentReadTx returns a non-blocking read transaction.
 	ConcurrentReadTx() ReadTx
 
+	// Snapshot returns a point-in-time copy of the data as a bbolt database
+	// file, which any engine and tool can restore.
 	Snapshot() Snapshot
+	// NativeSnapshot returns a point-in-time copy of the data in the format
+	// of the storage engine, which is cheaper to take than Snapshot. Only the
+	// backends of this etcd version or later can restore it.
+	NativeSnapshot() Snapshot
 	Hash(ignores func(bucketName, keyName []byte) bool) (uint32, error)
 	// Size returns the current size of the backend physically allocated.
 	// The backend can hold DB space that is not utilized at the moment,
@@ -104,9 +107,8 @@ type backend struct {
 	// mlock prevents backend database file to be swapped
 	mlock bool
 
-	mu    sync.RWMutex
-	bopts *bolt.Options
-	db    *bolt.DB
+	mu sync.RWMutex
+	db Engine
 
 	batchInterval time.Duration
 	batchLimit    int
@@ -133,6 +135,8 @@ type backend struct {
 type BackendConfig struct {
 	// Path is the file path to the backend file.
 	Path string
+	// Engine is the storage engine of the backend.
+	Engine EngineType
 	// BatchInterval is the maximum time before flushing the BatchTx.
 	BatchInterval time.Duration
 	// BatchLimit is the maximum puts before flushing the BatchTx.
@@ -173,6 +177,12 @@ func WithMmapSize(size uint64) BackendConfigOption {
 	}
 }
 
+func WithEngine(engine EngineType) BackendConfigOption {
+	return func(bcfg *BackendConfig) {
+		bcfg.Engine = engine
+	}
+}
+
 func NewDefaultBackend(lg *zap.Logger, path string, opts ...BackendConfigOption) Backend {
 	bcfg := DefaultBackendConfig(lg)
 	bcfg.Path = path
@@ -184,32 +194,19 @@ func NewDefaultBackend(lg *zap.Logger, path string, opts ...BackendConfigOption)
 }
 
 func newBackend(bcfg BackendConfig) *backend {
-	bopts := &bolt.Options{}
-	if boltOpenOptions != nil {
-		*bopts = *boltOpenOptions
-	}
-
 	if bcfg.Logger == nil {
 		bcfg.Logger = zap.NewNop()
 	}
 
-	bopts.InitialMmapSize = bcfg.mmapSize()
-	bopts.FreelistType = bcfg.BackendFreelistType
-	bopts.NoSync = bcfg.UnsafeNoFsync
-	bopts.NoGrowSync = bcfg.UnsafeNoFsync
-	bopts.Mlock = bcfg.Mlock
-	bopts.Logger = newBoltLoggerZap(bcfg)
-
-	db, err := bolt.Open(bcfg.Path, 0o600, bopts)
+	db, err := openEngine(bcfg)
 	if err != nil {
-		bcfg.Logger.Panic("failed to open database", zap.String("path", bcfg.Path), zap.Error(err))
+		bcfg.Logger.Panic("failed to open database", zap.String("path", bcfg.Path), zap.String("engine", string(bcfg.Engine)), zap.Error(err))
 	}
 
 	// In future, may want to make buffering optional for low-concurrency systems
 	// or dynamically swap between buffered/non-buffered depending on workload.
 	b := &backend{
-		bopts: bopts,
-		db:    db,
+		db: db,
 
 		batchInterval: bcfg.BatchInterval,
 		batchLimit:    bcfg.BatchLimit,
@@ -221,7 +218,7 @@ func newBackend(bcfg BackendConfig) *backend {
 					txBuffer:   txBuffer{make(map[BucketID]*bucketBuffer)},
 					bufVersion: 0,
 				},
-				buckets: make(map[BucketID]*bolt.Bucket),
+				buckets: make(map[BucketID]EngineBucket),
 				txWg:    new(sync.WaitGroup),
 				txMu:    new(sync.RWMutex),
 			},
@@ -246,6 +243,19 @@ func newBackend(bcfg BackendConfig) *backend {
 	return b
 }
 
+func openEngine(bcfg BackendConfig) (Engine, error) {
+	t := bcfg.Engine
+	if t == "" {
+		t = EngineBbolt
+	}
+	d, ok := engineDriver(t)
+	if !ok {
+		_, err := ParseEngineType(string(t))
+		return nil, err
+	}
+	return d.Open(bcfg)
+}
+
 // BatchTx returns the current batch tx in coalescer. The tx can be used for read and
 // write operations. The write result can be retrieved within the same tx immediately.
 // The write result is isolated with other txs until the current one get committed.
@@ -347,17 +357,32 @@ func (b *backend) ForceCommit() {
 }
 
 func (b *backend) Snapshot() Snapshot {
+	if _, ok := b.db.(*boltEngine); ok {
+		return b.NativeSnapshot()
+	}
+	return b.snapshot(func() (EngineSnapshot, error) {
+		return exportBoltSnapshot(b.db, defragLimit)
+	})
+}
+
+func (b *backend) NativeSnapshot() Snapshot {
+	return b.snapshot(func() (EngineSnapshot, error) {
+		return b.db.Snapshot()
+	})
+}
+
+func (b *backend) snapshot(take func() (EngineSnapshot, error)) Snapshot {
 	b.batchTx.Commit()
 
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	tx, err := b.db.Begin(false)
+	es, err := take()
 	if err != nil {
 		b.lg.Fatal("failed to begin tx", zap.Error(err))
 	}
 
 	stopc, donec := make(chan struct{}), make(chan struct{})
-	dbBytes := tx.Size()
+	dbBytes := es.Size()
 	go func() {
 		defer close(donec)
 		// sendRateBytes is based on transferring snapshot data over a 1 gigabit/s connection
@@ -387,7 +412,7 @@ func (b *backend) Snapshot() Snapshot {
 		}
 	}()
 
-	return &snapshot{tx, stopc, donec}
+	return &snapshot{es, stopc, donec}
 }
 
 func (b *backend) Hash(ignores func(bucketName, keyName []byte) bool) (uint32, error) {
@@ -395,23 +420,20 @@ func (b *backend) Hash(ignores func(bucketName, keyName []byte) bool) (uint32, e
 
 	b.mu.RLock()
 	defer b.mu.RUnlock()
-	err := b.db.View(func(tx *bolt.Tx) error {
-		c := tx.Cursor()
-		for next, _ := c.First(); next != nil; next, _ = c.Next() {
-			b := tx.Bucket(next)
-			if b == nil {
-				return fmt.Errorf("cannot get hash of bucket %s", next)
+	tx, err := b.db.Begin(false)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+	err = tx.ForEachBucket(func(next []byte, b EngineBucket) error {
+		h.Write(next)
+		return b.ForEach(func(k, v []byte) error {
+			if ignores != nil && !ignores(next, k) {
+				h.Write(k)
+				h.Write(v)
 			}
-			h.Write(next)
-			b.ForEach(func(k, v []byte) error {
-				if ignores != nil && !ignores(next, k) {
-					h.Write(k)
-					h.Write(v)
-				}
-				return nil
-			})
-		}
-		return nil
+			return nil
+		})
 	})
 	if err != nil {
 		return 0, err
@@ -483,40 +505,6 @@ func (b *backend) defrag() error {
 	b.readTx.Lock()
 	defer b.readTx.Unlock()
 
-	// Create a temporary file to ensure we start with a clean slate.
-	// Snapshotter.cleanupSnapdir cleans up any of these that are found during startup.
-	dir := filepath.Dir(b.db.Path())
-	temp, err := os.CreateTemp(dir, "db.tmp.*")
-	if err != nil {
-		return err
-	}
-
-	options := bolt.Options{}
-	if boltOpenOptions != nil {
-		options = *boltOpenOptions
-	}
-	options.OpenFile = func(_ string, _ int, _ os.FileMode) (file *os.File, err error) {
-		// gofail: var defragOpenFileError string
-		// return nil, fmt.Errorf(defragOpenFileError)
-		return temp, nil
-	}
-	// Don't load tmp db into memory regardless of opening options
-	options.Mlock = false
-	tdbp := temp.Name()
-	tmpdb, err := bolt.Open(tdbp, 0o600, &options)
-	if err != nil {
-		temp.Close()
-		if rmErr := os.Remove(temp.Name()); rmErr != nil {
-			b.lg.Error(
-				"failed to remove temporary file",
-				zap.String("path", temp.Name()),
-				zap.Error(rmErr),
-			)
-		}
-
-		return err
-	}
-
 	dbp := b.db.Path()
 	size1, sizeInUse1 := b.Size(), b.SizeInUse()
 	b.lg.Info(
@@ -542,48 +530,23 @@ func (b *backend) defrag() error {
 	b.batchTx.unsafeCommit(true)
 	b.batchTx.tx = nil
 
-	// gofail: var defragBeforeCopy struct{}
-	err = defragdb(b.db, tmpdb, defragLimit)
+	err := b.db.Defrag(b.lg, defragLimit)
 	if err != nil {
-		tmpdb.Close()
-		if rmErr := os.RemoveAll(tmpdb.Path()); rmErr != nil {
-			b.lg.Error("failed to remove db.tmp after defragmentation completed", zap.Error(rmErr))
-		}
-
-		// restore the bbolt transactions if defragmentation fails
+		// restore the transactions if defragmentation fails
 		b.batchTx.tx = b.unsafeBegin(true)
 		b.readTx.tx = b.unsafeBegin(false)
 
 		return err
 	}
 
-	err = b.db.Close()
-	if err != nil {
-		b.lg.Fatal("failed to close database", zap.Error(err))
-	}
-	err = tmpdb.Close()
-	if err != nil {
-		b.lg.Fatal("failed to close tmp database", zap.Error(err))
-	}
-	// gofail: var defragBeforeRename struct{}
-	err = os.Rename(tdbp, dbp)
-	if err != nil {
-		b.lg.Fatal("failed to rename tmp database", zap.Error(err))
-	}
-
-	b.db, err = bolt.Open(dbp, 0o600, b.bopts)
-	if err != nil {
-		b.lg.Fatal("failed to open database", zap.String("path", dbp), zap.Error(err))
-	}
 	b.batchTx.tx = b.unsafeBegin(true)
 
 	b.readTx.reset()
 	b.readTx.tx = b.unsafeBegin(false)
 
-	size := b.readTx.tx.Size()
-	db := b.readTx.tx.DB()
+	size, sizeInUse, _ := b.db.Stats(b.readTx.tx)
 	atomic.StoreInt64(&b.size, size)
-	atomic.StoreInt64(&b.sizeInUse, size-(int64(db.Stats().FreePageN)*int64(db.Info().PageSize)))
+	atomic.StoreInt64(&b.sizeInUse, sizeInUse)
 
 	took := time.Since(now)
 	defragSec.Observe(took.Seconds())
@@ -603,84 +566,20 @@ func (b *backend) defrag() error {
 	return nil
 }
 
-func defragdb(odb, tmpdb *bolt.DB, limit int) error {
-	// gofail: var defragdbFail string
-	// return fmt.Errorf(defragdbFail)
-
-	// open a tx on tmpdb for writes
-	tmptx, err := tmpdb.Begin(true)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		if err != nil {
-			tmptx.Rollback()
-		}
-	}()
-
-	// open a tx on old db for read
-	tx, err := odb.Begin(false)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	c := tx.Cursor()
-
-	count := 0
-	for next, _ := c.First(); next != nil; next, _ = c.Next() {
-		b := tx.Bucket(next)
-		if b == nil {
-			return fmt.Errorf("backend: cannot defrag bucket %s", next)
-		}
-
-		tmpb, berr := tmptx.CreateBucketIfNotExists(next)
-		if berr != nil {
-			return berr
-		}
-		tmpb.FillPercent = 0.9 // for bucket2seq write in for each
-
-		if err = b.ForEach(func(k, v []byte) error {
-			count++
-			if count > limit {
-				err = tmptx.Commit()
-				if err != nil {
-					return err
-				}
-				tmptx, err = tmpdb.Begin(true)
-				if err != nil {
-					return err
-				}
-				tmpb = tmptx.Bucket(next)
-				tmpb.FillPercent = 0.9 // for bucket2seq write in for each
-
-				count = 0
-			}
-			return tmpb.Put(k, v)
-		}); err != nil {
-			return err
-		}
-	}
-
-	return tmptx.Commit()
-}
-
-func (b *backend) begin(write bool) *bolt.Tx {
+func (b *backend) begin(write bool) EngineTx {
 	b.mu.RLock()
 	tx := b.unsafeBegin(write)
+	size, sizeInUse, openReadTxN := b.db.Stats(tx)
 	b.mu.RUnlock()
 
-	size := tx.Size()
-	db := tx.DB()
-	stats := db.Stats()
 	atomic.StoreInt64(&b.size, size)
-	atomic.StoreInt64(&b.sizeInUse, size-(int64(stats.FreePageN)*int64(db.Info().PageSize)))
-	atomic.StoreInt64(&b.openReadTxN, int64(stats.OpenTxN))
+	atomic.StoreInt64(&b.sizeInUse, sizeInUse)
+	atomic.StoreInt64(&b.openReadTxN, openReadTxN)
 
 	return tx
 }
 
-func (b *backend) unsafeBegin(write bool) *bolt.Tx {
+func (b *backend) unsafeBegin(write bool) EngineTx {
 	// gofail: var beforeStartDBTxn struct{}
 	tx, err := b.db.Begin(write)
 	// gofail: var afterStartDBTxn struct{}
@@ -695,7 +594,7 @@ func (b *backend) OpenReadTxN() int64 {
 }
 
 type snapshot struct {
-	*bolt.Tx
+	EngineSnapshot
 	stopc chan struct{}
 	donec chan struct{}
 }
@@ -703,22 +602,5 @@ type snapshot struct {
 func (s *snapshot) Close() error {
 	close(s.stopc)
 	<-s.donec
-	return s.Tx.Rollback()
-}
-
-func newBoltLoggerZap(bcfg BackendConfig) bolt.Logger {
-	lg := bcfg.Logger.Named("bbolt")
-	return &zapBoltLogger{lg.WithOptions(zap.AddCallerSkip(1)).Sugar()}
-}
-
-type zapBoltLogger struct {
-	*zap.SugaredLogger
-}
-
-func (zl *zapBoltLogger) Warning(args ...any) {
-	zl.SugaredLogger.Warn(args...)
-}
-
-func (zl *zapBoltLogger) Warningf(format string, args ...any) {
-	zl.SugaredLogger.Warnf(format, args...)
+	return s.EngineSnapshot.Close()
 }
@@ -23,329 +23,338 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 
-	bolt "go.etcd.io/bbolt"
 	"go.etcd.io/etcd/server/v3/storage/backend"
 	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
 	"go.etcd.io/etcd/server/v3/storage/schema"
 )
 
 func TestBatchTxPut(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
-
-	tx.Lock()
+		tx := b.BatchTx()
 
-	// create bucket
-	tx.UnsafeCreateBucket(schema.Test)
+		tx.Lock()
 
-	// put
-	v := []byte("bar")
-	tx.UnsafePut(schema.Test, []byte("foo"), v)
+		// create bucket
+		tx.UnsafeCreateBucket(schema.Test)
 
-	tx.Unlock()
+		// put
+		v := []byte("bar")
+		tx.UnsafePut(schema.Test, []byte("foo"), v)
 
-	// check put result before and after tx is committed
-	for k := 0; k < 2; k++ {
-		tx.Lock()
-		_, gv := tx.UnsafeRange(schema.Test, []byte("foo"), nil, 0)
 		tx.Unlock()
-		if !reflect.DeepEqual(gv[0], v) {
-			t.Errorf("v = %s, want %s", gv[0], v)
+
+		// check put result before and after tx is committed
+		for k := 0; k < 2; k++ {
+			tx.Lock()
+			_, gv := tx.UnsafeRange(schema.Test, []byte("foo"), nil, 0)
+			tx.Unlock()
+			if !reflect.DeepEqual(gv[0], v) {
+				t.Errorf("v = %s, want %s", gv[0], v)
+			}
+			tx.Commit()
 		}
-		tx.Commit()
-	}
+	})
 }
 
 func TestBatchTxRange(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
-
-	tx := b.BatchTx()
-	tx.Lock()
-	defer tx.Unlock()
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx.UnsafeCreateBucket(schema.Test)
-	// put keys
-	allKeys := [][]byte{[]byte("foo"), []byte("foo1"), []byte("foo2")}
-	allVals := [][]byte{[]byte("bar"), []byte("bar1"), []byte("bar2")}
-	for i := range allKeys {
-		tx.UnsafePut(schema.Test, allKeys[i], allVals[i])
-	}
+		tx := b.BatchTx()
+		tx.Lock()
+		defer tx.Unlock()
+
+		tx.UnsafeCreateBucket(schema.Test)
+		// put keys
+		allKeys := [][]byte{[]byte("foo"), []byte("foo1"), []byte("foo2")}
+		allVals := [][]byte{[]byte("bar"), []byte("bar1"), []byte("bar2")}
+		for i := range allKeys {
+			tx.UnsafePut(schema.Test, allKeys[i], allVals[i])
+		}
 
-	tests := []struct {
-		key    []byte
-		endKey []byte
-		limit  int64
-
-		wkeys [][]byte
-		wvals [][]byte
-	}{
-		// single key
-		{
-			[]byte("foo"), nil, 0,
-			allKeys[:1], allVals[:1],
-		},
-		// single key, bad
-		{
-			[]byte("doo"), nil, 0,
-			nil, nil,
-		},
-		// key range
-		{
-			[]byte("foo"), []byte("foo1"), 0,
-			allKeys[:1], allVals[:1],
-		},
-		// key range, get all keys
-		{
-			[]byte("foo"), []byte("foo3"), 0,
-			allKeys, allVals,
-		},
-		// key range, bad
-		{
-			[]byte("goo"), []byte("goo3"), 0,
-			nil, nil,
-		},
-		// key range with effective limit
-		{
-			[]byte("foo"), []byte("foo3"), 1,
-			allKeys[:1], allVals[:1],
-		},
-		// key range with limit
-		{
-			[]byte("foo"), []byte("foo3"), 4,
-			allKeys, allVals,
-		},
-	}
-	for i, tt := range tests {
-		keys, vals := tx.UnsafeRange(schema.Test, tt.key, tt.endKey, tt.limit)
-		if !reflect.DeepEqual(keys, tt.wkeys) {
-			t.Errorf("#%d: keys = %+v, want %+v", i, keys, tt.wkeys)
+		tests := []struct {
+			key    []byte
+			endKey []byte
+			limit  int64
+
+			wkeys [][]byte
+			wvals [][]byte
+		}{
+			// single key
+			{
+				[]byte("foo"), nil, 0,
+				allKeys[:1], allVals[:1],
+			},
+			// single key, bad
+			{
+				[]byte("doo"), nil, 0,
+				nil, nil,
+			},
+			// key range
+			{
+				[]byte("foo"), []byte("foo1"), 0,
+				allKeys[:1], allVals[:1],
+			},
+			// key range, get all keys
+			{
+				[]byte("foo"), []byte("foo3"), 0,
+				allKeys, allVals,
+			},
+			// key range, bad
+			{
+				[]byte("goo"), []byte("goo3"), 0,
+				nil, nil,
+			},
+			// key range with effective limit
+			{
+				[]byte("foo"), []byte("foo3"), 1,
+				allKeys[:1], allVals[:1],
+			},
+			// key range with limit
+			{
+				[]byte("foo"), []byte("foo3"), 4,
+				allKeys, allVals,
+			},
 		}
-		if !reflect.DeepEqual(vals, tt.wvals) {
-			t.Errorf("#%d: vals = %+v, want %+v", i, vals, tt.wvals)
+		for i, tt := range tests {
+			keys, vals := tx.UnsafeRange(schema.Test, tt.key, tt.endKey, tt.limit)
+			if !reflect.DeepEqual(keys, tt.wkeys) {
+				t.Errorf("#%d: keys = %+v, want %+v", i, keys, tt.wkeys)
+			}
+			if !reflect.DeepEqual(vals, tt.wvals) {
+				t.Errorf("#%d: vals = %+v, want %+v", i, vals, tt.wvals)
+			}
 		}
-	}
+	})
 }
 
 func TestBatchTxDelete(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
-
-	tx := b.BatchTx()
-	tx.Lock()
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx := b.BatchTx()
+		tx.Lock()
 
-	tx.UnsafeDelete(schema.Test, []byte("foo"))
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
 
-	tx.Unlock()
+		tx.UnsafeDelete(schema.Test, []byte("foo"))
 
-	// check put result before and after tx is committed
-	for k := 0; k < 2; k++ {
-		tx.Lock()
-		ks, _ := tx.UnsafeRange(schema.Test, []byte("foo"), nil, 0)
 		tx.Unlock()
-		if len(ks) != 0 {
-			t.Errorf("keys on foo = %v, want nil", ks)
+
+		// check put result before and after tx is committed
+		for k := 0; k < 2; k++ {
+			tx.Lock()
+			ks, _ := tx.UnsafeRange(schema.Test, []byte("foo"), nil, 0)
+			tx.Unlock()
+			if len(ks) != 0 {
+				t.Errorf("keys on foo = %v, want nil", ks)
+			}
+			tx.Commit()
 		}
-		tx.Commit()
-	}
+	})
 }
 
 func TestBatchTxCommit(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
-	tx.Unlock()
+		tx := b.BatchTx()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx.Unlock()
 
-	tx.Commit()
+		tx.Commit()
 
-	// check whether put happens via db view
-	backend.DbFromBackendForTest(b).View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(schema.Test.Name())
-		if bucket == nil {
+		// check whether put happens via db view
+		v, ok := backend.CommittedForTest(b, schema.Test, []byte("foo"))
+		if !ok {
 			t.Errorf("bucket test does not exit")
-			return nil
-		}
-		v := bucket.Get([]byte("foo"))
-		if v == nil {
+		} else if v == nil {
 			t.Errorf("foo key failed to written in backend")
 		}
-		return nil
 	})
 }
 
 func TestBatchTxBatchLimitCommit(t *testing.T) {
-	// start backend with batch limit 1 so one write can
-	// trigger a commit
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 1)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		// start backend with batch limit 1 so one write can
+		// trigger a commit
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 1, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
-	tx.Unlock()
+		tx := b.BatchTx()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx.Unlock()
 
-	// batch limit commit should have been triggered
-	// check whether put happens via db view
-	backend.DbFromBackendForTest(b).View(func(tx *bolt.Tx) error {
-		bucket := tx.Bucket(schema.Test.Name())
-		if bucket == nil {
+		// batch limit commit should have been triggered
+		// check whether put happens via db view
+		v, ok := backend.CommittedForTest(b, schema.Test, []byte("foo"))
+		if !ok {
 			t.Errorf("bucket test does not exit")
-			return nil
-		}
-		v := bucket.Get([]byte("foo"))
-		if v == nil {
+		} else if v == nil {
 			t.Errorf("foo key failed to written in backend")
 		}
-		return nil
 	})
 }
 
 func TestRangeAfterDeleteBucketMatch(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
+		tx := b.BatchTx()
 
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
-	tx.Unlock()
-	tx.Commit()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx.Unlock()
+		tx.Commit()
 
-	checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo")}, [][]byte{[]byte("bar")})
+		checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo")}, [][]byte{[]byte("bar")})
 
-	tx.Lock()
-	tx.UnsafeDeleteBucket(schema.Test)
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafeDeleteBucket(schema.Test)
+		tx.Unlock()
 
-	checkForEach(t, b.BatchTx(), b.ReadTx(), nil, nil)
+		checkForEach(t, b.BatchTx(), b.ReadTx(), nil, nil)
+	})
 }
 
 func TestRangeAfterDeleteMatch(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
+		tx := b.BatchTx()
 
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
-	tx.Unlock()
-	tx.Commit()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx.Unlock()
+		tx.Commit()
 
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
-	checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo")}, [][]byte{[]byte("bar")})
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
+		checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo")}, [][]byte{[]byte("bar")})
 
-	tx.Lock()
-	tx.UnsafeDelete(schema.Test, []byte("foo"))
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafeDelete(schema.Test, []byte("foo"))
+		tx.Unlock()
 
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
-	checkForEach(t, b.BatchTx(), b.ReadTx(), nil, nil)
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
+		checkForEach(t, b.BatchTx(), b.ReadTx(), nil, nil)
+	})
 }
 
 func TestRangeAfterUnorderedKeyWriteMatch(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
+		tx := b.BatchTx()
 
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo5"), []byte("bar5"))
-	tx.UnsafePut(schema.Test, []byte("foo2"), []byte("bar2"))
-	tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar1"))
-	tx.UnsafePut(schema.Test, []byte("foo3"), []byte("bar3"))
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
-	tx.UnsafePut(schema.Test, []byte("foo4"), []byte("bar4"))
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo5"), []byte("bar5"))
+		tx.UnsafePut(schema.Test, []byte("foo2"), []byte("bar2"))
+		tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar1"))
+		tx.UnsafePut(schema.Test, []byte("foo3"), []byte("bar3"))
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx.UnsafePut(schema.Test, []byte("foo4"), []byte("bar4"))
+		tx.Unlock()
 
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 1)
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 1)
+	})
 }
 
 func TestRangeAfterAlternatingBucketWriteMatch(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
+		tx := b.BatchTx()
 
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Key)
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafeSeqPut(schema.Key, []byte("key1"), []byte("val1"))
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Key)
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafeSeqPut(schema.Key, []byte("key1"), []byte("val1"))
+		tx.Unlock()
 
-	tx.Lock()
-	tx.UnsafeSeqPut(schema.Key, []byte("key2"), []byte("val2"))
-	tx.Unlock()
-	tx.Commit()
-	// only in the 2nd commit the schema.Key key is removed from the readBuffer.buckets.
-	// This makes sure to test the case when an empty writeBuffer.bucket
-	// is used to replace the read buffer bucket.
-	tx.Commit()
+		tx.Lock()
+		tx.UnsafeSeqPut(schema.Key, []byte("key2"), []byte("val2"))
+		tx.Unlock()
+		tx.Commit()
+		// only in the 2nd commit the schema.Key key is removed from the readBuffer.buckets.
+		// This makes sure to test the case when an empty writeBuffer.bucket
+		// is used to replace the read buffer bucket.
+		tx.Commit()
 
-	tx.Lock()
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
-	tx.Unlock()
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Key, []byte("key"), []byte("key5"), 100)
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), []byte("foo3"), 1)
+		tx.Lock()
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar"))
+		tx.Unlock()
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Key, []byte("key"), []byte("key5"), 100)
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), []byte("foo3"), 1)
+	})
 }
 
 func TestRangeAfterOverwriteMatch(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
+		tx := b.BatchTx()
 
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar2"))
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar0"))
-	tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar10"))
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar1"))
-	tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar11"))
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar2"))
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar0"))
+		tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar10"))
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar1"))
+		tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar11"))
+		tx.Unlock()
 
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), []byte("foo3"), 1)
-	checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo"), []byte("foo1")}, [][]byte{[]byte("bar1"), []byte("bar11")})
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), []byte("foo3"), 1)
+		checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo"), []byte("foo1")}, [][]byte{[]byte("bar1"), []byte("bar11")})
+	})
 }
 
 func TestRangeAfterOverwriteAndDeleteMatch(t *testing.T) {
-	b, _ := betesting.NewTmpBackend(t, time.Hour, 10000)
-	defer betesting.Close(t, b)
+	betesting.ForEachEngine(t, func(t *testing.T, engine backend.EngineType) {
+		b, _ := betesting.NewTmpBackend(t, time.Hour, 10000, backend.WithEngine(engine))
+		defer betesting.Close(t, b)
 
-	tx := b.BatchTx()
+		tx := b.BatchTx()
 
-	tx.Lock()
-	tx.UnsafeCreateBucket(schema.Test)
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar2"))
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar0"))
-	tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar10"))
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar1"))
-	tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar11"))
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafeCreateBucket(schema.Test)
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar2"))
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar0"))
+		tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar10"))
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar1"))
+		tx.UnsafePut(schema.Test, []byte("foo1"), []byte("bar11"))
+		tx.Unlock()
 
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
-	checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo"), []byte("foo1")}, [][]byte{[]byte("bar1"), []byte("bar11")})
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
+		checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo"), []byte("foo1")}, [][]byte{[]byte("bar1"), []byte("bar11")})
 
-	tx.Lock()
-	tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar3"))
-	tx.UnsafeDelete(schema.Test, []byte("foo1"))
-	tx.Unlock()
+		tx.Lock()
+		tx.UnsafePut(schema.Test, []byte("foo"), []byte("bar3"))
+		tx.UnsafeDelete(schema.Test, []byte("foo1"))
+		tx.Unlock()
 
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
-	checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo1"), nil, 0)
-	checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo")}, [][]byte{[]byte("bar3")})
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo"), nil, 0)
+		checkRangeResponseMatch(t, b.BatchTx(), b.ReadTx(), schema.Test, []byte("foo1"), nil, 0)
+		checkForEach(t, b.BatchTx(), b.ReadTx(), [][]byte{[]byte("foo")}, [][]byte{[]byte("bar3")})
+	})
 }
 
 func checkRangeResponseMatch(t *testing.T, tx backend.BatchTx, rtx backend.ReadTx, bucket backend.Bucket, key, endKey []byte, limit int64) {
@@ -0,0 +1,211 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+// EngineType is the type of storage engine the backend runs on.
+type EngineType string
+
+const (
+	// EngineBbolt stores the data in a single bbolt B+tree file.
+	EngineBbolt EngineType = "bbolt"
+	// EnginePebble stores the data in a Pebble log-structured merge tree,
+	// in a directory next to the path of the bbolt file. It is linked in by
+	// importing go.etcd.io/etcd/server/v3/storage/backend/pebble.
+	EnginePebble EngineType = "pebble"
+)
+
+// EngineDriver opens the backends of a storage engine. The engines other
+// than EngineBbolt are linked in by importing their package, which registers
+// their driver.
+type EngineDriver struct {
+	// Open opens the engine of the backend configured by bcfg.
+	Open func(bcfg BackendConfig) (Engine, error)
+	// Exist returns true if the engine holds a database for the backend at
+	// path.
+	Exist func(path string) bool
+}
+
+var (
+	enginesMu sync.RWMutex
+	engines   = make(map[EngineType]EngineDriver)
+)
+
+func init() {
+	RegisterEngine(EngineBbolt, EngineDriver{
+		Open:  func(bcfg BackendConfig) (Engine, error) { return openBoltEngine(bcfg) },
+		Exist: fileutil.Exist,
+	})
+}
+
+// RegisterEngine makes the engine t available to the backends. It panics if
+// the engine is registered twice.
+func RegisterEngine(t EngineType, d EngineDriver) {
+	enginesMu.Lock()
+	defer enginesMu.Unlock()
+	if _, ok := engines[t]; ok {
+		panic(fmt.Sprintf("backend: engine %q registered twice", t))
+	}
+	engines[t] = d
+}
+
+func engineDriver(t EngineType) (EngineDriver, bool) {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	d, ok := engines[t]
+	return d, ok
+}
+
+// Engines lists the registered storage engines, EngineBbolt first.
+func Engines() []EngineType {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	ts := make([]EngineType, 0, len(engines))
+	for t := range engines {
+		ts = append(ts, t)
+	}
+	sort.Slice(ts, func(i, j int) bool {
+		return ts[i] == EngineBbolt || (ts[j] != EngineBbolt && ts[i] < ts[j])
+	})
+	return ts
+}
+
+// ParseEngineType returns the engine type named s. The empty string
+// selects EngineBbolt.
+func ParseEngineType(s string) (EngineType, error) {
+	t := EngineType(s)
+	if t == "" {
+		return EngineBbolt, nil
+	}
+	if _, ok := engineDriver(t); ok {
+		return t, nil
+	}
+	if t == EnginePebble {
+		return "", fmt.Errorf("backend engine %q is not linked in (import %q)", s, "go.etcd.io/etcd/server/v3/storage/backend/pebble")
+	}
+	return "", fmt.Errorf("unknown backend engine %q (supported engines: %q)", s, Engines())
+}
+
+// EngineDir returns the directory in which the engine t keeps the database
+// of the backend at path, unless it is EngineBbolt which keeps it at path.
+func EngineDir(path string, t EngineType) string {
+	return path + "." + string(t)
+}
+
+// Exist returns true if a backend database exists at path, regardless of
+// the engine it was written by.
+func Exist(path string) bool {
+	return existOtherThan(path, "")
+}
+
+// existOtherThan returns true if an engine other than t holds a database
+// for the backend at path. The directory of EnginePebble is taken as holding
+// one when the engine is not linked in.
+func existOtherThan(path string, t EngineType) bool {
+	enginesMu.RLock()
+	defer enginesMu.RUnlock()
+	if _, ok := engines[EnginePebble]; !ok && t != EnginePebble && fileutil.Exist(EngineDir(path, EnginePebble)) {
+		return true
+	}
+	for et, d := range engines {
+		if et != t && d.Exist(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrBucketNotFound is returned by EngineTx.DeleteBucket for the buckets
+// that do not exist.
+var ErrBucketNotFound = errors.New("backend: bucket not found")
+
+// Engine is a transactional key-value store that organizes keys into
+// buckets. The backend batches writes and buffers reads on top of it.
+type Engine interface {
+	// Path returns the path the engine was opened at.
+	Path() string
+	// Begin starts a transaction. At most one writable transaction may be
+	// open at a time; read-only transactions see the data as of the last
+	// commit before they started.
+	Begin(writable bool) (EngineTx, error)
+	// Stats returns the physically allocated and logically used size of
+	// the engine, and the number of open read-only transactions. tx is the
+	// transaction that was just started.
+	Stats(tx EngineTx) (size, sizeInUse, openReadTxN int64)
+	// Snapshot returns a point-in-time copy of the data, in the format of the
+	// engine. The backends of the other engines must be able to open it (see
+	// NewStreamSnapshot).
+	Snapshot() (EngineSnapshot, error)
+	// Defrag reclaims unused space. It is called without any open
+	// transactions.
+	Defrag(lg *zap.Logger, limit int) error
+	Close() error
+}
+
+// EngineTx is a transaction of an Engine.
+type EngineTx interface {
+	// Bucket returns the named bucket, or nil if it does not exist.
+	Bucket(name []byte) EngineBucket
+	CreateBucketIfNotExists(name []byte) (EngineBucket, error)
+	// DeleteBucket deletes the named bucket and all of its keys. It returns
+	// ErrBucketNotFound if the bucket does not exist.
+	DeleteBucket(name []byte) error
+	// ForEachBucket calls fn for every bucket in ascending name order.
+	ForEachBucket(fn func(name []byte, b EngineBucket) error) error
+	Commit() error
+	Rollback() error
+}
+
+// EngineBucket is a bucket of keys, valid until its transaction ends.
+type EngineBucket interface {
+	// Get returns the value of key, or nil if it does not exist.
+	Get(key []byte) []byte
+	Put(key, value []byte) error
+	// SeqPut is a Put for keys that are mostly written in ascending order.
+	SeqPut(key, value []byte) error
+	Delete(key []byte) error
+	Cursor() EngineCursor
+	// ForEach calls fn for every key in the bucket in ascending order.
+	ForEach(fn func(k, v []byte) error) error
+}
+
+// EngineCursor iterates over the keys of a bucket.
+type EngineCursor interface {
+	// Seek moves the cursor to the first key that is greater than or equal
+	// to key, and returns it. It returns a nil key if there is none.
+	Seek(key []byte) (k, v []byte)
+	// Next moves the cursor to the next key, and returns it. It returns a
+	// nil key at the end of the bucket.
+	Next() (k, v []byte)
+	Close()
+}
+
+// EngineSnapshot is a point-in-time copy of the data of an Engine.
+type EngineSnapshot interface {
+	Size() int64
+	WriteTo(w io.Writer) (n int64, err error)
+	Close() error
+}
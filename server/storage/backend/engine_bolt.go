@@ -0,0 +1,313 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.uber.org/zap"
+
+	bolt "go.etcd.io/bbolt"
+	bolterrors "go.etcd.io/bbolt/errors"
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+)
+
+type boltEngine struct {
+	bopts *bolt.Options
+	db    *bolt.DB
+}
+
+func newBoltOptions(bcfg BackendConfig) *bolt.Options {
+	bopts := &bolt.Options{}
+	if boltOpenOptions != nil {
+		*bopts = *boltOpenOptions
+	}
+	bopts.InitialMmapSize = bcfg.mmapSize()
+	bopts.FreelistType = bcfg.BackendFreelistType
+	bopts.NoSync = bcfg.UnsafeNoFsync
+	bopts.NoGrowSync = bcfg.UnsafeNoFsync
+	bopts.Mlock = bcfg.Mlock
+	bopts.Logger = newBoltLoggerZap(bcfg)
+	return bopts
+}
+
+func openBoltEngine(bcfg BackendConfig) (*boltEngine, error) {
+	if !fileutil.Exist(bcfg.Path) && existOtherThan(bcfg.Path, EngineBbolt) {
+		return nil, fmt.Errorf("database at %q was written by another engine", bcfg.Path)
+	}
+	// a snapshot received from a member running another engine
+	if fileutil.Exist(bcfg.Path) {
+		stream, err := isStreamSnapshot(bcfg.Path)
+		if err != nil {
+			return nil, err
+		}
+		if stream {
+			if err = importSnapshotStream(bcfg.Path, defragLimit); err != nil {
+				return nil, err
+			}
+		}
+	}
+	bopts := newBoltOptions(bcfg)
+	db, err := bolt.Open(bcfg.Path, 0o600, bopts)
+	if err != nil {
+		return nil, err
+	}
+	return &boltEngine{bopts: bopts, db: db}, nil
+}
+
+func (e *boltEngine) Path() string { return e.db.Path() }
+
+func (e *boltEngine) Begin(writable bool) (EngineTx, error) {
+	tx, err := e.db.Begin(writable)
+	if err != nil {
+		return nil, err
+	}
+	return &boltTx{tx}, nil
+}
+
+func (e *boltEngine) Stats(tx EngineTx) (size, sizeInUse, openReadTxN int64) {
+	size = tx.(*boltTx).Size()
+	stats := e.db.Stats()
+	sizeInUse = size - (int64(stats.FreePageN) * int64(e.db.Info().PageSize))
+	return size, sizeInUse, int64(stats.OpenTxN)
+}
+
+func (e *boltEngine) Snapshot() (EngineSnapshot, error) {
+	tx, err := e.db.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return &boltSnapshot{tx}, nil
+}
+
+func (e *boltEngine) Defrag(lg *zap.Logger, limit int) error {
+	// Create a temporary file to ensure we start with a clean slate.
+	// Snapshotter.cleanupSnapdir cleans up any of these that are found during startup.
+	dir := filepath.Dir(e.db.Path())
+	temp, err := os.CreateTemp(dir, "db.tmp.*")
+	if err != nil {
+		return err
+	}
+
+	options := bolt.Options{}
+	if boltOpenOptions != nil {
+		options = *boltOpenOptions
+	}
+	options.OpenFile = func(_ string, _ int, _ os.FileMode) (file *os.File, err error) {
+		// gofail: var defragOpenFileError string
+		// return nil, fmt.Errorf(defragOpenFileError)
+		return temp, nil
+	}
+	// Don't load tmp db into memory regardless of opening options
+	options.Mlock = false
+	tdbp := temp.Name()
+	tmpdb, err := bolt.Open(tdbp, 0o600, &options)
+	if err != nil {
+		temp.Close()
+		if rmErr := os.Remove(temp.Name()); rmErr != nil {
+			lg.Error(
+				"failed to remove temporary file",
+				zap.String("path", temp.Name()),
+				zap.Error(rmErr),
+			)
+		}
+
+		return err
+	}
+
+	// gofail: var defragBeforeCopy struct{}
+	err = defragdb(e.db, tmpdb, limit)
+	if err != nil {
+		tmpdb.Close()
+		if rmErr := os.RemoveAll(tmpdb.Path()); rmErr != nil {
+			lg.Error("failed to remove db.tmp after defragmentation completed", zap.Error(rmErr))
+		}
+		return err
+	}
+
+	dbp := e.db.Path()
+	err = e.db.Close()
+	if err != nil {
+		lg.Fatal("failed to close database", zap.Error(err))
+	}
+	err = tmpdb.Close()
+	if err != nil {
+		lg.Fatal("failed to close tmp database", zap.Error(err))
+	}
+	// gofail: var defragBeforeRename struct{}
+	err = os.Rename(tdbp, dbp)
+	if err != nil {
+		lg.Fatal("failed to rename tmp database", zap.Error(err))
+	}
+
+	e.db, err = bolt.Open(dbp, 0o600, e.bopts)
+	if err != nil {
+		lg.Fatal("failed to open database", zap.String("path", dbp), zap.Error(err))
+	}
+	return nil
+}
+
+func defragdb(odb, tmpdb *bolt.DB, limit int) error {
+	// gofail: var defragdbFail string
+	// return fmt.Errorf(defragdbFail)
+
+	// open a tx on tmpdb for writes
+	tmptx, err := tmpdb.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tmptx.Rollback()
+		}
+	}()
+
+	// open a tx on old db for read
+	tx, err := odb.Begin(false)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	c := tx.Cursor()
+
+	count := 0
+	for next, _ := c.First(); next != nil; next, _ = c.Next() {
+		b := tx.Bucket(next)
+		if b == nil {
+			return fmt.Errorf("backend: cannot defrag bucket %s", next)
+		}
+
+		tmpb, berr := tmptx.CreateBucketIfNotExists(next)
+		if berr != nil {
+			return berr
+		}
+		tmpb.FillPercent = 0.9 // for bucket2seq write in for each
+
+		if err = b.ForEach(func(k, v []byte) error {
+			count++
+			if count > limit {
+				err = tmptx.Commit()
+				if err != nil {
+					return err
+				}
+				tmptx, err = tmpdb.Begin(true)
+				if err != nil {
+					return err
+				}
+				tmpb = tmptx.Bucket(next)
+				tmpb.FillPercent = 0.9 // for bucket2seq write in for each
+
+				count = 0
+			}
+			return tmpb.Put(k, v)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tmptx.Commit()
+}
+
+func (e *boltEngine) Close() error { return e.db.Close() }
+
+type boltTx struct {
+	*bolt.Tx
+}
+
+func (tx *boltTx) Bucket(name []byte) EngineBucket {
+	b := tx.Tx.Bucket(name)
+	if b == nil {
+		return nil
+	}
+	return &boltBucket{b}
+}
+
+func (tx *boltTx) CreateBucketIfNotExists(name []byte) (EngineBucket, error) {
+	b, err := tx.Tx.CreateBucketIfNotExists(name)
+	if err != nil {
+		return nil, err
+	}
+	return &boltBucket{b}, nil
+}
+
+func (tx *boltTx) DeleteBucket(name []byte) error {
+	err := tx.Tx.DeleteBucket(name)
+	if errors.Is(err, bolterrors.ErrBucketNotFound) {
+		return ErrBucketNotFound
+	}
+	return err
+}
+
+func (tx *boltTx) ForEachBucket(fn func(name []byte, b EngineBucket) error) error {
+	return tx.Tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+		return fn(name, &boltBucket{b})
+	})
+}
+
+func (tx *boltTx) Commit() error {
+	err := tx.Tx.Commit()
+	rebalanceSec.Observe(tx.Stats().RebalanceTime.Seconds())
+	spillSec.Observe(tx.Stats().SpillTime.Seconds())
+	writeSec.Observe(tx.Stats().WriteTime.Seconds())
+	return err
+}
+
+type boltBucket struct {
+	*bolt.Bucket
+}
+
+func (b *boltBucket) SeqPut(key, value []byte) error {
+	// it is useful to increase fill percent when the workloads are mostly append-only.
+	// this can delay the page split and reduce space usage.
+	b.FillPercent = 0.9
+	return b.Put(key, value)
+}
+
+func (b *boltBucket) Cursor() EngineCursor { return &boltCursor{b.Bucket.Cursor()} }
+
+type boltCursor struct {
+	*bolt.Cursor
+}
+
+func (c *boltCursor) Close() {}
+
+type boltSnapshot struct {
+	*bolt.Tx
+}
+
+func (s *boltSnapshot) Size() int64 { return s.Tx.Size() }
+
+func (s *boltSnapshot) Close() error { return s.Tx.Rollback() }
+
+func newBoltLoggerZap(bcfg BackendConfig) bolt.Logger {
+	lg := bcfg.Logger.Named("bbolt")
+	return &zapBoltLogger{lg.WithOptions(zap.AddCallerSkip(1)).Sugar()}
+}
+
+type zapBoltLogger struct {
+	*zap.SugaredLogger
+}
+
+func (zl *zapBoltLogger) Warning(args ...any) {
+	zl.SugaredLogger.Warn(args...)
+}
+
+func (zl *zapBoltLogger) Warningf(format string, args ...any) {
+	zl.SugaredLogger.Warnf(format, args...)
+}
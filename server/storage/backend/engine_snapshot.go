@@ -0,0 +1,396 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backend
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// The engines other than bbolt take their snapshots as a stream of the
+// buckets and keys, tagged with the engine that wrote it:
+//
+//	"etcd-backend-snapshot\n" <uvarint len> <engine>
+//	0x01 <uvarint len> <bucket name>          starts a bucket
+//	0x02 <uvarint len> <key> <uvarint len> <value>
+//	0x00                                      ends the stream
+//
+// The stream is written by reading the data, without copying it to a
+// temporary file first. A backend of any engine opened at a path holding
+// such a stream imports it, like a bbolt database file.
+const snapshotStreamMagic = "etcd-backend-snapshot\n"
+
+const (
+	streamRecordEnd byte = iota
+	streamRecordBucket
+	streamRecordKey
+)
+
+// NewStreamSnapshot returns a snapshot streaming the data seen by tx, a
+// read-only transaction of the engine t, which is rolled back when the
+// snapshot is closed. The data is read once more to measure its size.
+func NewStreamSnapshot(t EngineType, tx EngineTx) (EngineSnapshot, error) {
+	s := &streamSnapshot{engine: t, tx: tx}
+	n, err := s.WriteTo(io.Discard)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	s.size = n
+	return s, nil
+}
+
+type streamSnapshot struct {
+	engine EngineType
+	tx     EngineTx
+	size   int64
+}
+
+func (s *streamSnapshot) Size() int64 { return s.size }
+
+func (s *streamSnapshot) WriteTo(w io.Writer) (int64, error) {
+	sw := &streamWriter{w: bufio.NewWriter(w)}
+	sw.writeString(snapshotStreamMagic)
+	sw.writeBytes([]byte(s.engine))
+	err := s.tx.ForEachBucket(func(name []byte, b EngineBucket) error {
+		sw.writeByte(streamRecordBucket)
+		sw.writeBytes(name)
+		return b.ForEach(func(k, v []byte) error {
+			sw.writeByte(streamRecordKey)
+			sw.writeBytes(k)
+			sw.writeBytes(v)
+			return sw.err
+		})
+	})
+	if err != nil {
+		return sw.n, err
+	}
+	sw.writeByte(streamRecordEnd)
+	if sw.err == nil {
+		sw.err = sw.w.Flush()
+	}
+	return sw.n, sw.err
+}
+
+func (s *streamSnapshot) Close() error { return s.tx.Rollback() }
+
+// streamWriter counts the bytes written and keeps the first error.
+type streamWriter struct {
+	w   *bufio.Writer
+	n   int64
+	err error
+	buf [binary.MaxVarintLen64]byte
+}
+
+func (sw *streamWriter) write(p []byte) {
+	if sw.err != nil {
+		return
+	}
+	var n int
+	n, sw.err = sw.w.Write(p)
+	sw.n += int64(n)
+}
+
+func (sw *streamWriter) writeString(s string) { sw.write([]byte(s)) }
+
+func (sw *streamWriter) writeByte(c byte) { sw.write([]byte{c}) }
+
+func (sw *streamWriter) writeBytes(p []byte) {
+	sw.write(sw.buf[:binary.PutUvarint(sw.buf[:], uint64(len(p)))])
+	sw.write(p)
+}
+
+// isStreamSnapshot returns true if the file at path holds a snapshot
+// stream rather than a bbolt database.
+func isStreamSnapshot(path string) (bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+	magic := make([]byte, len(snapshotStreamMagic))
+	if _, err = io.ReadFull(f, magic); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return false, nil
+		}
+		return false, err
+	}
+	return string(magic) == snapshotStreamMagic, nil
+}
+
+// ReadSnapshotFile calls fn for every bucket of the snapshot file at path,
+// with nil k and v, then for every key of the bucket. The file holds either
+// a bbolt database or a snapshot stream. The slices passed to fn are only
+// valid until it returns.
+func ReadSnapshotFile(path string, fn func(bucket, k, v []byte) error) error {
+	stream, err := isStreamSnapshot(path)
+	if err != nil {
+		return err
+	}
+	if stream {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return readSnapshotStream(bufio.NewReader(f), fn)
+	}
+
+	db, err := bolt.Open(path, 0o600, &bolt.Options{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	return db.View(func(tx *bolt.Tx) error {
+		return tx.ForEach(func(name []byte, b *bolt.Bucket) error {
+			if err := fn(name, nil, nil); err != nil {
+				return err
+			}
+			return b.ForEach(func(k, v []byte) error {
+				return fn(name, k, v)
+			})
+		})
+	})
+}
+
+func readSnapshotStream(r *bufio.Reader, fn func(bucket, k, v []byte) error) error {
+	magic := make([]byte, len(snapshotStreamMagic))
+	if _, err := io.ReadFull(r, magic); err != nil {
+		return err
+	}
+	if _, err := readStreamBytes(r); err != nil {
+		return err
+	}
+	var bucket []byte
+	for {
+		kind, err := r.ReadByte()
+		if err != nil {
+			return fmt.Errorf("backend: truncated snapshot stream: %w", err)
+		}
+		switch kind {
+		case streamRecordEnd:
+			return nil
+		case streamRecordBucket:
+			if bucket, err = readStreamBytes(r); err != nil {
+				return err
+			}
+			if err = fn(bucket, nil, nil); err != nil {
+				return err
+			}
+		case streamRecordKey:
+			if bucket == nil {
+				return errors.New("backend: snapshot stream has a key outside of a bucket")
+			}
+			k, err := readStreamBytes(r)
+			if err != nil {
+				return err
+			}
+			v, err := readStreamBytes(r)
+			if err != nil {
+				return err
+			}
+			if err = fn(bucket, k, v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("backend: unknown snapshot stream record %d", kind)
+		}
+	}
+}
+
+func readStreamBytes(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("backend: truncated snapshot stream: %w", err)
+	}
+	p := make([]byte, n)
+	if _, err = io.ReadFull(r, p); err != nil {
+		return nil, fmt.Errorf("backend: truncated snapshot stream: %w", err)
+	}
+	return p, nil
+}
+
+// importSnapshotStream replaces the snapshot stream at path with a bbolt
+// database holding the same data.
+func importSnapshotStream(path string, limit int) error {
+	// Snapshotter.cleanupSnapdir cleans up any of these that are found during startup.
+	temp, err := os.CreateTemp(filepath.Dir(path), "db.tmp.*")
+	if err != nil {
+		return err
+	}
+	temp.Close()
+	defer os.Remove(temp.Name())
+
+	db, err := bolt.Open(temp.Name(), 0o600, &bolt.Options{NoSync: true})
+	if err != nil {
+		return err
+	}
+	tx, err := db.Begin(true)
+	if err != nil {
+		db.Close()
+		return err
+	}
+	var b *bolt.Bucket
+	count := 0
+	err = ReadSnapshotFile(path, func(bucket, k, v []byte) error {
+		if k == nil {
+			var berr error
+			b, berr = tx.CreateBucketIfNotExists(bucket)
+			return berr
+		}
+		count++
+		if count > limit {
+			if cerr := tx.Commit(); cerr != nil {
+				tx = nil
+				return cerr
+			}
+			var berr error
+			if tx, berr = db.Begin(true); berr != nil {
+				tx = nil
+				return berr
+			}
+			b = tx.Bucket(bucket)
+			count = 0
+		}
+		b.FillPercent = 0.9
+		return b.Put(k, v)
+	})
+	if err == nil {
+		err = tx.Commit()
+	} else if tx != nil {
+		tx.Rollback()
+	}
+	if err == nil {
+		err = db.Sync()
+	}
+	if cerr := db.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), path)
+}
+
+// exportBoltSnapshot exports the data of the engine to a temporary bbolt
+// file, which is removed when the returned snapshot is closed.
+func exportBoltSnapshot(e Engine, limit int) (EngineSnapshot, error) {
+	// Snapshotter.cleanupSnapdir cleans up any of these that are found during startup.
+	temp, err := os.CreateTemp(filepath.Dir(e.Path()), "db.tmp.*")
+	if err != nil {
+		return nil, err
+	}
+	temp.Close()
+	s := &boltFileSnapshot{path: temp.Name()}
+	if err = exportBolt(e, s.path, limit); err == nil {
+		s.f, err = os.Open(s.path)
+	}
+	if err == nil {
+		var fi os.FileInfo
+		if fi, err = s.f.Stat(); err == nil {
+			s.size = fi.Size()
+		}
+	}
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func exportBolt(e Engine, path string, limit int) error {
+	bdb, err := bolt.Open(path, 0o600, &bolt.Options{NoSync: true})
+	if err != nil {
+		return err
+	}
+	defer bdb.Close()
+
+	tx, err := e.Begin(false)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	btx, err := bdb.Begin(true)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if btx != nil {
+			btx.Rollback()
+		}
+	}()
+	count := 0
+	err = tx.ForEachBucket(func(name []byte, b EngineBucket) error {
+		bb, berr := btx.CreateBucketIfNotExists(name)
+		if berr != nil {
+			return berr
+		}
+		bb.FillPercent = 0.9
+		return b.ForEach(func(k, v []byte) error {
+			count++
+			if count > limit {
+				if cerr := btx.Commit(); cerr != nil {
+					btx = nil
+					return cerr
+				}
+				if btx, err = bdb.Begin(true); err != nil {
+					btx = nil
+					return err
+				}
+				bb = btx.Bucket(name)
+				bb.FillPercent = 0.9
+				count = 0
+			}
+			return bb.Put(k, v)
+		})
+	})
+	if err != nil {
+		return err
+	}
+	err = btx.Commit()
+	btx = nil
+	return err
+}
+
+type boltFileSnapshot struct {
+	path string
+	f    *os.File
+	size int64
+}
+
+func (s *boltFileSnapshot) Size() int64 { return s.size }
+
+func (s *boltFileSnapshot) WriteTo(w io.Writer) (int64, error) {
+	return io.Copy(w, s.f)
+}
+
+func (s *boltFileSnapshot) Close() error {
+	var err error
+	if s.f != nil {
+		err = s.f.Close()
+	}
+	if rerr := os.Remove(s.path); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -17,7 +17,7 @@ package backend
 import bolt "go.etcd.io/bbolt"
 
 func DbFromBackendForTest(b Backend) *bolt.DB {
-	return b.(*backend).db
+	return b.(*backend).db.(*boltEngine).db
 }
 
 func DefragLimitForTest() int {
@@ -27,3 +27,21 @@ func DefragLimitForTest() int {
 func CommitsForTest(b Backend) int64 {
 	return b.(*backend).Commits()
 }
+
+// CommittedForTest returns the value of key in the data committed to the
+// storage engine, and whether the bucket exists there.
+func CommittedForTest(b Backend, bucket Bucket, key []byte) (value []byte, bucketExists bool) {
+	be := b.(*backend)
+	be.mu.RLock()
+	defer be.mu.RUnlock()
+	tx, err := be.db.Begin(false)
+	if err != nil {
+		panic(err)
+	}
+	defer tx.Rollback()
+	bkt := tx.Bucket(bucket.Name())
+	if bkt == nil {
+		return nil, false
+	}
+	return bkt.Get(key), true
+}
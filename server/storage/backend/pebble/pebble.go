@@ -0,0 +1,523 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package pebble links the Pebble storage engine into the backend. Import it
+// for its side effect to make backend.EnginePebble available:
+//
+//	import _ "go.etcd.io/etcd/server/v3/storage/backend/pebble"
+package pebble
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	pebbledb "github.com/cockroachdb/pebble"
+	"go.uber.org/zap"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+// The Pebble engine keeps its data in the directory <path>.pebble, where
+// <path> is the path of the bbolt file. A snapshot found at <path> when
+// opening the engine, for example after receiving one from the leader, is
+// imported and then removed. It is either a bbolt file or a snapshot stream
+// of any engine (see backend.NewStreamSnapshot).
+//
+// Every import writes a new generation of the data to a subdirectory, and
+// the generation file names the current one. This lets a backend open the
+// imported data while the backend it replaces still has the previous
+// generation open.
+const generationFileName = "generation"
+
+// importLimit is the number of keys imported per batch.
+const importLimit = 10000
+
+// Buckets are mapped to the flat key space of Pebble as follows:
+//
+//	0x00 <bucket name>                      marks that the bucket exists
+//	0x01 <len(bucket name)> <bucket name> <key>  holds the value of key
+const (
+	bucketPrefix byte = 0x00
+	keyPrefix    byte = 0x01
+
+	maxBucketNameLen = 255
+)
+
+func init() {
+	backend.RegisterEngine(backend.EnginePebble, backend.EngineDriver{
+		Open: func(bcfg backend.BackendConfig) (backend.Engine, error) {
+			return openEngine(bcfg)
+		},
+		Exist: exist,
+	})
+}
+
+var (
+	// openDirs holds the generation directories opened by this
+	// process, which must not be cleaned up as stale.
+	openDirs   = make(map[string]struct{})
+	openDirsMu sync.Mutex
+)
+
+type engine struct {
+	lg   *zap.Logger
+	path string
+	// root is the directory holding all generations.
+	root string
+	// dir is the directory of the opened generation.
+	dir       string
+	db        *pebbledb.DB
+	writeOpts *pebbledb.WriteOptions
+
+	openReadTxN int64
+	// readTxWg lets Close wait for the open read-only transactions, whose
+	// snapshots must be released before closing the database.
+	readTxWg sync.WaitGroup
+}
+
+func exist(path string) bool {
+	gen, err := readGeneration(backend.EngineDir(path, backend.EnginePebble))
+	return err == nil && gen > 0
+}
+
+func openEngine(bcfg backend.BackendConfig) (*engine, error) {
+	e := &engine{
+		lg:        bcfg.Logger,
+		path:      bcfg.Path,
+		root:      backend.EngineDir(bcfg.Path, backend.EnginePebble),
+		writeOpts: pebbledb.Sync,
+	}
+	if bcfg.UnsafeNoFsync {
+		e.writeOpts = pebbledb.NoSync
+	}
+	if err := fileutil.TouchDirAll(e.lg, e.root); err != nil {
+		return nil, err
+	}
+
+	openDirsMu.Lock()
+	defer openDirsMu.Unlock()
+	gen, err := readGeneration(e.root)
+	if err != nil {
+		return nil, err
+	}
+	if err = e.removeStaleGenerations(gen); err != nil {
+		return nil, err
+	}
+	if gen == 0 || fileutil.Exist(e.path) {
+		gen++
+		if err = e.createGeneration(gen); err != nil {
+			return nil, err
+		}
+		if err = e.removeStaleGenerations(gen); err != nil {
+			return nil, err
+		}
+	}
+
+	e.dir = e.generationDir(gen)
+	if e.db, err = pebbledb.Open(e.dir, e.options()); err != nil {
+		return nil, err
+	}
+	openDirs[e.dir] = struct{}{}
+	return e, nil
+}
+
+func (e *engine) options() *pebbledb.Options {
+	return &pebbledb.Options{
+		Logger: e.lg.Named("pebble").WithOptions(zap.AddCallerSkip(1)).Sugar(),
+	}
+}
+
+func (e *engine) generationDir(gen uint64) string {
+	return filepath.Join(e.root, fmt.Sprintf("%016x", gen))
+}
+
+// removeStaleGenerations removes the generations other than gen that are
+// not open, which were left behind by a crash or a failed import.
+func (e *engine) removeStaleGenerations(gen uint64) error {
+	names, err := fileutil.ReadDir(e.root)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		p := filepath.Join(e.root, name)
+		if name == generationFileName || p == e.generationDir(gen) {
+			continue
+		}
+		if _, ok := openDirs[p]; ok {
+			continue
+		}
+		e.lg.Info("removing stale pebble data", zap.String("path", p))
+		if err := os.RemoveAll(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// createGeneration writes generation gen, importing the snapshot at the
+// engine path if there is one, and makes it the current generation.
+func (e *engine) createGeneration(gen uint64) error {
+	dir := e.generationDir(gen)
+	db, err := pebbledb.Open(dir, e.options())
+	if err != nil {
+		return err
+	}
+	imported := fileutil.Exist(e.path)
+	if imported {
+		e.lg.Info("importing snapshot", zap.String("path", e.path), zap.String("pebble-path", dir))
+		err = importSnapshot(e.path, db, importLimit)
+	}
+	if err == nil {
+		err = db.Flush()
+	}
+	if cerr := db.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = writeGeneration(e.root, gen); err != nil {
+		return err
+	}
+	if imported {
+		return os.Remove(e.path)
+	}
+	return nil
+}
+
+func importSnapshot(path string, db *pebbledb.DB, limit int) error {
+	batch := db.NewBatch()
+	defer func() { batch.Close() }()
+	var prefix []byte
+	count := 0
+	err := backend.ReadSnapshotFile(path, func(bucket, k, v []byte) error {
+		if k == nil {
+			if len(bucket) > maxBucketNameLen {
+				return fmt.Errorf("backend: bucket name %q is too long", bucket)
+			}
+			prefix = keyPrefixOf(bucket)
+			return batch.Set(bucketKey(bucket), nil, nil)
+		}
+		count++
+		if count > limit {
+			if err := batch.Commit(pebbledb.NoSync); err != nil {
+				return err
+			}
+			batch.Close()
+			batch = db.NewBatch()
+			count = 0
+		}
+		return batch.Set(append(prefix[:len(prefix):len(prefix)], k...), v, nil)
+	})
+	if err != nil {
+		return err
+	}
+	return batch.Commit(pebbledb.NoSync)
+}
+
+func readGeneration(root string) (uint64, error) {
+	data, err := os.ReadFile(filepath.Join(root, generationFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	gen, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("backend: corrupted pebble generation file: %w", err)
+	}
+	return gen, nil
+}
+
+func writeGeneration(root string, gen uint64) error {
+	p := filepath.Join(root, generationFileName)
+	tmp := p + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, fileutil.PrivateFileMode)
+	if err != nil {
+		return err
+	}
+	_, err = f.WriteString(strconv.FormatUint(gen, 10) + "\n")
+	if err == nil {
+		err = fileutil.Fsync(f)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if err = os.Rename(tmp, p); err != nil {
+		return err
+	}
+	d, err := fileutil.OpenDir(root)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return fileutil.Fsync(d)
+}
+
+func bucketKey(name []byte) []byte {
+	return append([]byte{bucketPrefix}, name...)
+}
+
+func keyPrefixOf(name []byte) []byte {
+	prefix := make([]byte, 0, 2+len(name))
+	prefix = append(prefix, keyPrefix, byte(len(name)))
+	return append(prefix, name...)
+}
+
+// prefixEnd returns the smallest key greater than all keys with the
+// given prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	// all buckets have a prefix starting with a byte below 0xff
+	panic("backend: no prefix end")
+}
+
+func (e *engine) Path() string { return e.path }
+
+func (e *engine) Begin(writable bool) (backend.EngineTx, error) {
+	if writable {
+		return &txn{e: e, r: e.db.NewIndexedBatch(), writable: true}, nil
+	}
+	atomic.AddInt64(&e.openReadTxN, 1)
+	e.readTxWg.Add(1)
+	return &txn{e: e, r: e.db.NewSnapshot()}, nil
+}
+
+func (e *engine) Stats(_ backend.EngineTx) (size, sizeInUse, openReadTxN int64) {
+	m := e.db.Metrics()
+	size = int64(m.DiskSpaceUsage())
+	sizeInUse = size - int64(m.Table.ObsoleteSize+m.Table.ZombieSize+m.WAL.ObsoletePhysicalSize)
+	return size, sizeInUse, atomic.LoadInt64(&e.openReadTxN)
+}
+
+// Snapshot streams the data seen by a read-only transaction, without
+// copying it first.
+func (e *engine) Snapshot() (backend.EngineSnapshot, error) {
+	tx, err := e.Begin(false)
+	if err != nil {
+		return nil, err
+	}
+	return backend.NewStreamSnapshot(backend.EnginePebble, tx)
+}
+
+func (e *engine) Defrag(_ *zap.Logger, _ int) error {
+	return e.db.Compact([]byte{bucketPrefix}, []byte{keyPrefix + 1}, true)
+}
+
+func (e *engine) Close() error {
+	e.readTxWg.Wait()
+	err := e.db.Close()
+
+	openDirsMu.Lock()
+	defer openDirsMu.Unlock()
+	delete(openDirs, e.dir)
+	// the generation was replaced by an import while it was open
+	if gen, gerr := readGeneration(e.root); gerr == nil && e.generationDir(gen) != e.dir {
+		if rerr := os.RemoveAll(e.dir); err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+type txn struct {
+	e *engine
+	// r is an indexed batch for writable transactions and a snapshot for
+	// read-only transactions.
+	r        pebbledb.Reader
+	writable bool
+}
+
+func (tx *txn) batch() *pebbledb.Batch { return tx.r.(*pebbledb.Batch) }
+
+func (tx *txn) Bucket(name []byte) backend.EngineBucket {
+	_, closer, err := tx.r.Get(bucketKey(name))
+	if err != nil {
+		if !errors.Is(err, pebbledb.ErrNotFound) {
+			tx.e.lg.Fatal("failed to read bucket", zap.ByteString("bucket-name", name), zap.Error(err))
+		}
+		return nil
+	}
+	closer.Close()
+	return tx.bucket(name)
+}
+
+func (tx *txn) bucket(name []byte) *bucket {
+	prefix := keyPrefixOf(name)
+	return &bucket{tx: tx, prefix: prefix, end: prefixEnd(prefix)}
+}
+
+func (tx *txn) CreateBucketIfNotExists(name []byte) (backend.EngineBucket, error) {
+	if len(name) == 0 || len(name) > maxBucketNameLen {
+		return nil, fmt.Errorf("backend: invalid bucket name %q", name)
+	}
+	if err := tx.batch().Set(bucketKey(name), nil, nil); err != nil {
+		return nil, err
+	}
+	return tx.bucket(name), nil
+}
+
+func (tx *txn) DeleteBucket(name []byte) error {
+	if tx.Bucket(name) == nil {
+		return backend.ErrBucketNotFound
+	}
+	b := tx.bucket(name)
+	if err := tx.batch().DeleteRange(b.prefix, b.end, nil); err != nil {
+		return err
+	}
+	return tx.batch().Delete(bucketKey(name), nil)
+}
+
+func (tx *txn) ForEachBucket(fn func(name []byte, b backend.EngineBucket) error) error {
+	it, err := tx.r.NewIter(&pebbledb.IterOptions{
+		LowerBound: []byte{bucketPrefix},
+		UpperBound: []byte{bucketPrefix + 1},
+	})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.First(); it.Valid(); it.Next() {
+		name := append([]byte(nil), it.Key()[1:]...)
+		if err := fn(name, tx.bucket(name)); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func (tx *txn) Commit() error {
+	if !tx.writable {
+		return errors.New("backend: commit of a read-only transaction")
+	}
+	err := tx.batch().Commit(tx.e.writeOpts)
+	if cerr := tx.batch().Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+func (tx *txn) Rollback() error {
+	if tx.writable {
+		return tx.r.Close()
+	}
+	err := tx.r.Close()
+	atomic.AddInt64(&tx.e.openReadTxN, -1)
+	tx.e.readTxWg.Done()
+	return err
+}
+
+type bucket struct {
+	tx *txn
+	// prefix and end bound the keys of the bucket.
+	prefix, end []byte
+}
+
+func (b *bucket) key(k []byte) []byte {
+	return append(b.prefix[:len(b.prefix):len(b.prefix)], k...)
+}
+
+func (b *bucket) Get(key []byte) []byte {
+	v, closer, err := b.tx.r.Get(b.key(key))
+	if err != nil {
+		if !errors.Is(err, pebbledb.ErrNotFound) {
+			b.tx.e.lg.Fatal("failed to read key", zap.Error(err))
+		}
+		return nil
+	}
+	defer closer.Close()
+	return append([]byte{}, v...)
+}
+
+func (b *bucket) Put(key, value []byte) error {
+	return b.tx.batch().Set(b.key(key), value, nil)
+}
+
+func (b *bucket) SeqPut(key, value []byte) error { return b.Put(key, value) }
+
+func (b *bucket) Delete(key []byte) error {
+	return b.tx.batch().Delete(b.key(key), nil)
+}
+
+func (b *bucket) Cursor() backend.EngineCursor {
+	it, err := b.tx.r.NewIter(&pebbledb.IterOptions{LowerBound: b.prefix, UpperBound: b.end})
+	if err != nil {
+		b.tx.e.lg.Fatal("failed to create iterator", zap.Error(err))
+	}
+	return &cursor{it: it, prefix: b.prefix}
+}
+
+func (b *bucket) ForEach(fn func(k, v []byte) error) error {
+	c := b.Cursor().(*cursor)
+	defer c.Close()
+	for k, v := c.first(); k != nil; k, v = c.Next() {
+		if err := fn(k, v); err != nil {
+			return err
+		}
+	}
+	return c.it.Error()
+}
+
+// cursor returns copies of the keys and values, since the ones
+// returned by the iterator are only valid until it is moved.
+type cursor struct {
+	it     *pebbledb.Iterator
+	prefix []byte
+}
+
+func (c *cursor) first() (k, v []byte) {
+	c.it.First()
+	return c.current()
+}
+
+func (c *cursor) Seek(key []byte) (k, v []byte) {
+	c.it.SeekGE(append(c.prefix[:len(c.prefix):len(c.prefix)], key...))
+	return c.current()
+}
+
+func (c *cursor) Next() (k, v []byte) {
+	c.it.Next()
+	return c.current()
+}
+
+func (c *cursor) current() (k, v []byte) {
+	if !c.it.Valid() {
+		return nil, nil
+	}
+	k = append([]byte{}, c.it.Key()[len(c.prefix):]...)
+	v = append([]byte{}, c.it.Value()...)
+	return k, v
+}
+
+func (c *cursor) Close() {
+	c.it.Close()
+}
@@ -0,0 +1,148 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pebble_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	_ "go.etcd.io/etcd/server/v3/storage/backend/pebble"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+func putForTest(b backend.Backend, key, value string) {
+	tx := b.BatchTx()
+	tx.Lock()
+	tx.UnsafeCreateBucket(schema.Test)
+	tx.UnsafePut(schema.Test, []byte(key), []byte(value))
+	tx.Unlock()
+	b.ForceCommit()
+}
+
+func rangeForTest(b backend.Backend, key string) []byte {
+	tx := b.ReadTx()
+	tx.RLock()
+	defer tx.RUnlock()
+	_, vs := tx.UnsafeRange(schema.Test, []byte(key), nil, 0)
+	if len(vs) == 0 {
+		return nil
+	}
+	return vs[0]
+}
+
+// TestPebbleReplaceWithSnapshot mirrors applying a snapshot from the leader:
+// the snapshot is moved into place and opened while the replaced backend is
+// still open.
+func TestPebbleReplaceWithSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "db")
+	newBackend := func() backend.Backend {
+		return backend.NewDefaultBackend(zaptest.NewLogger(t), path, backend.WithEngine(backend.EnginePebble))
+	}
+
+	assert.False(t, backend.Exist(path))
+	old := newBackend()
+	putForTest(old, "foo", "old")
+	assert.True(t, backend.Exist(path))
+	assert.False(t, fileutil.Exist(path), "pebble engine must not create a bbolt file")
+
+	// snapshots are written in bbolt format
+	src := backend.NewDefaultBackend(zaptest.NewLogger(t), filepath.Join(dir, "src"))
+	putForTest(src, "foo", "new")
+	snap := src.Snapshot()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	_, err = snap.WriteTo(f)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, snap.Close())
+	require.NoError(t, src.Close())
+
+	nb := newBackend()
+	assert.Equal(t, []byte("new"), rangeForTest(nb, "foo"))
+	assert.Equal(t, []byte("old"), rangeForTest(old, "foo"))
+	assert.False(t, fileutil.Exist(path), "imported bbolt file must be removed")
+	require.NoError(t, old.Close())
+	require.NoError(t, nb.Close())
+
+	gens, err := os.ReadDir(path + ".pebble")
+	require.NoError(t, err)
+	assert.Len(t, gens, 2, "expected the current generation and the generation file, got %v", gens)
+
+	nb = newBackend()
+	defer nb.Close()
+	assert.Equal(t, []byte("new"), rangeForTest(nb, "foo"))
+
+	// the data can be exported again to bootstrap a bbolt backend
+	dst := filepath.Join(dir, "dst")
+	writeSnapshotForTest(t, nb.Snapshot(), dst)
+	bb := backend.NewDefaultBackend(zaptest.NewLogger(t), dst)
+	defer bb.Close()
+	assert.Equal(t, []byte("new"), rangeForTest(bb, "foo"))
+}
+
+func writeSnapshotForTest(t *testing.T, snap backend.Snapshot, path string) {
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	n, err := snap.WriteTo(f)
+	require.NoError(t, err)
+	assert.Equal(t, snap.Size(), n)
+	require.NoError(t, f.Close())
+	require.NoError(t, snap.Close())
+}
+
+// TestPebbleNativeSnapshot checks that the snapshot stream of the pebble
+// engine is written without a temporary file, and restored by both engines.
+func TestPebbleNativeSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	src := backend.NewDefaultBackend(zaptest.NewLogger(t), filepath.Join(dir, "src"), backend.WithEngine(backend.EnginePebble))
+	defer src.Close()
+	putForTest(src, "foo", "bar")
+
+	snap := src.NativeSnapshot()
+	files, err := filepath.Glob(filepath.Join(dir, "db.tmp.*"))
+	require.NoError(t, err)
+	assert.Empty(t, files)
+
+	bboltPath := filepath.Join(dir, "bbolt")
+	writeSnapshotForTest(t, snap, bboltPath)
+	require.NoError(t, os.Link(bboltPath, filepath.Join(dir, "pebble")))
+
+	for _, engine := range []backend.EngineType{backend.EngineBbolt, backend.EnginePebble} {
+		t.Run(string(engine), func(t *testing.T) {
+			b := backend.NewDefaultBackend(zaptest.NewLogger(t), filepath.Join(dir, string(engine)), backend.WithEngine(engine))
+			defer b.Close()
+			assert.Equal(t, []byte("bar"), rangeForTest(b, "foo"))
+		})
+	}
+}
+
+func TestBboltRefusesPebbleData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "db")
+	b := backend.NewDefaultBackend(zaptest.NewLogger(t), path, backend.WithEngine(backend.EnginePebble))
+	require.NoError(t, b.Close())
+
+	assert.Panics(t, func() {
+		backend.NewDefaultBackend(zaptest.NewLogger(t), path, backend.WithEngine(backend.EngineBbolt))
+	})
+	assert.False(t, fileutil.Exist(path))
+}
@@ -38,14 +38,29 @@ func NewTmpBackendFromCfg(tb testing.TB, bcfg backend.BackendConfig) (backend.Ba
 }
 
 // NewTmpBackend creates a backend implementation for testing.
-func NewTmpBackend(tb testing.TB, batchInterval time.Duration, batchLimit int) (backend.Backend, string) {
+func NewTmpBackend(tb testing.TB, batchInterval time.Duration, batchLimit int, opts ...backend.BackendConfigOption) (backend.Backend, string) {
 	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(tb))
 	bcfg.BatchInterval, bcfg.BatchLimit = batchInterval, batchLimit
+	for _, opt := range opts {
+		opt(&bcfg)
+	}
 	return NewTmpBackendFromCfg(tb, bcfg)
 }
 
-func NewDefaultTmpBackend(tb testing.TB) (backend.Backend, string) {
-	return NewTmpBackendFromCfg(tb, backend.DefaultBackendConfig(zaptest.NewLogger(tb)))
+func NewDefaultTmpBackend(tb testing.TB, opts ...backend.BackendConfigOption) (backend.Backend, string) {
+	bcfg := backend.DefaultBackendConfig(zaptest.NewLogger(tb))
+	for _, opt := range opts {
+		opt(&bcfg)
+	}
+	return NewTmpBackendFromCfg(tb, bcfg)
+}
+
+// ForEachEngine runs f as a subtest for every storage engine linked into
+// the test binary.
+func ForEachEngine(t *testing.T, f func(t *testing.T, engine backend.EngineType)) {
+	for _, engine := range backend.Engines() {
+		t.Run(string(engine), func(t *testing.T) { f(t, engine) })
+	}
 }
 
 func Close(tb testing.TB, b backend.Backend) {
@@ -141,6 +141,22 @@ func TestCompactionHash(t *testing.T) {
 	testutil.TestCompactionHash(t.Context(), t, hashTestCase{s}, s.cfg.CompactionBatchLimit)
 }
 
+func TestCompactionHashWithRetentionPolicies(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	for _, p := range []RetentionPolicy{
+		{Prefix: []byte("a"), KeepRevisions: 2},
+		{Prefix: []byte("d"), KeepRevisions: 5},
+		{Prefix: []byte("xav"), KeepRevisions: 3},
+		{Prefix: []byte("z"), KeepRevisions: 1},
+	} {
+		require.NoError(t, s.PutRetentionPolicy(p))
+	}
+	testutil.TestCompactionHash(t.Context(), t, hashTestCase{s}, s.cfg.CompactionBatchLimit)
+}
+
 type hashTestCase struct {
 	*store
 }
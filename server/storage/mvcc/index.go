@@ -28,8 +28,8 @@ type index interface {
 	CountRevisions(key, end []byte, atRev int64) int
 	Put(key []byte, rev Revision)
 	Tombstone(key []byte, rev Revision) error
-	Compact(rev int64) map[Revision]struct{}
-	Keep(rev int64) map[Revision]struct{}
+	Compact(rev int64, rp retentionPolicies) map[Revision]struct{}
+	Keep(rev int64, rp retentionPolicies) map[Revision]struct{}
 	Equal(b index) bool
 
 	Insert(ki *keyIndex)
@@ -189,7 +189,7 @@ func (ti *treeIndex) Tombstone(key []byte, rev Revision) error {
 	return ki.tombstone(ti.lg, rev.Main, rev.Sub)
 }
 
-func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
+func (ti *treeIndex) Compact(rev int64, rp retentionPolicies) map[Revision]struct{} {
 	available := make(map[Revision]struct{})
 	ti.lg.Info("compact tree index", zap.Int64("revision", rev))
 	ti.Lock()
@@ -200,7 +200,11 @@ func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
 		// Lock is needed here to prevent modification to the keyIndex while
 		// compaction is going on or revision added to empty before deletion
 		ti.Lock()
-		keyi.compact(ti.lg, rev, available)
+		if p, ok := rp.match(keyi.key); ok && p.retains() {
+			keyi.compactRetain(ti.lg, rev, p, available)
+		} else {
+			keyi.compact(ti.lg, rev, available)
+		}
 		if keyi.isEmpty() {
 			_, ok := ti.tree.Delete(keyi)
 			if !ok {
@@ -214,11 +218,15 @@ func (ti *treeIndex) Compact(rev int64) map[Revision]struct{} {
 }
 
 // Keep finds all revisions to be kept for a Compaction at the given rev.
-func (ti *treeIndex) Keep(rev int64) map[Revision]struct{} {
+func (ti *treeIndex) Keep(rev int64, rp retentionPolicies) map[Revision]struct{} {
 	available := make(map[Revision]struct{})
 	ti.RLock()
 	defer ti.RUnlock()
 	ti.tree.Ascend(func(keyi *keyIndex) bool {
+		if p, ok := rp.match(keyi.key); ok && p.retains() {
+			keyi.keepRetain(rev, p, available)
+			return true
+		}
 		keyi.keep(rev, available)
 		return true
 	})
@@ -640,10 +640,10 @@ func TestIndexCompactAndKeep(t *testing.T) {
 			j = int64(len(afterCompacts)) - 1
 		}
 
-		am := ti.Compact(i)
+		am := ti.Compact(i, nil)
 		require.Equalf(t, afterCompacts[j].compacted, am, "#%d: compact(%d) != expected", i, i)
 
-		keep := ti.Keep(i)
+		keep := ti.Keep(i, nil)
 		require.Equalf(t, afterCompacts[j].keep, keep, "#%d: keep(%d) != expected", i, i)
 
 		nti := newTreeIndex(zaptest.NewLogger(t)).(*treeIndex)
@@ -663,10 +663,10 @@ func TestIndexCompactAndKeep(t *testing.T) {
 			j = int64(len(afterCompacts)) - 1
 		}
 
-		am := ti.Compact(i)
+		am := ti.Compact(i, nil)
 		require.Equalf(t, afterCompacts[j].compacted, am, "#%d: compact(%d) != expected", i, i)
 
-		keep := ti.Keep(i)
+		keep := ti.Keep(i, nil)
 		require.Equalf(t, afterCompacts[j].keep, keep, "#%d: keep(%d) != expected", i, i)
 
 		nti := newTreeIndex(zaptest.NewLogger(t)).(*treeIndex)
@@ -255,6 +255,77 @@ func (ki *keyIndex) keep(atRev int64, available map[Revision]struct{}) {
 	}
 }
 
+// retain returns the revision at which ki should be compacted so that the n
+// most recent revisions at or below atRev are kept. It returns 0 if ki has
+// fewer than n such revisions, and atRev if n is not greater than one.
+func (ki *keyIndex) retain(atRev, n int64) int64 {
+	if n <= 1 {
+		return atRev
+	}
+	for gi := len(ki.generations) - 1; gi >= 0; gi-- {
+		revs := ki.generations[gi].revs
+		for ri := len(revs) - 1; ri >= 0; ri-- {
+			if revs[ri].Main > atRev {
+				continue
+			}
+			if n--; n == 0 {
+				return revs[ri].Main
+			}
+		}
+	}
+	return 0
+}
+
+// retainRev returns the revision at which ki should be compacted so that the
+// history kept by p for a compaction at atRev is kept.
+func (ki *keyIndex) retainRev(atRev int64, p RetentionPolicy) int64 {
+	rev := ki.retain(atRev, p.KeepRevisions)
+	if p.KeepDuration > 0 {
+		rev = min(rev, p.sinceRev)
+	}
+	return rev
+}
+
+// compactRetain compacts ki at atRev while keeping the history kept by p at
+// or below atRev, all of which is added to available.
+func (ki *keyIndex) compactRetain(lg *zap.Logger, atRev int64, p RetentionPolicy, available map[Revision]struct{}) {
+	effRev := ki.retainRev(atRev, p)
+	if effRev == atRev {
+		ki.compact(lg, atRev, available)
+		return
+	}
+	ki.compact(lg, effRev, available)
+	ki.addRetained(effRev, atRev, available)
+}
+
+// keepRetain finds the revisions to be kept if compactRetain is called with
+// the given atRev and p.
+func (ki *keyIndex) keepRetain(atRev int64, p RetentionPolicy, available map[Revision]struct{}) {
+	if ki.isEmpty() {
+		return
+	}
+	effRev := ki.retainRev(atRev, p)
+	if effRev == atRev {
+		ki.keep(atRev, available)
+		return
+	}
+	// Unlike keep, a tombstone at effRev is kept since compactRetain keeps
+	// it and hashes it as a regular revision below the compaction revision.
+	ki.doCompact(effRev, available)
+	ki.addRetained(effRev, atRev, available)
+}
+
+// addRetained adds the revisions in (fromRev, toRev] to available.
+func (ki *keyIndex) addRetained(fromRev, toRev int64, available map[Revision]struct{}) {
+	for _, g := range ki.generations {
+		for _, r := range g.revs {
+			if r.Main > fromRev && r.Main <= toRev {
+				available[r] = struct{}{}
+			}
+		}
+	}
+}
+
 func (ki *keyIndex) doCompact(atRev int64, available map[Revision]struct{}) (genIdx int, revIndex int) {
 	// walk until reaching the first revision smaller or equal to "atRev",
 	// and add the revision to the available map
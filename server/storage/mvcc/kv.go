@@ -16,6 +16,7 @@ package mvcc
 
 import (
 	"context"
+	"time"
 
 	"go.etcd.io/etcd/api/v3/mvccpb"
 	"go.etcd.io/etcd/pkg/v3/traceutil"
@@ -78,7 +79,25 @@ type WriteView interface {
 	// id.
 	// A put also increases the rev of the store, and generates one event in the event history.
 	// The returned rev is the current revision of the KV when the operation is executed.
-	Put(key, value []byte, lease lease.LeaseID) (rev int64)
+	Put(key, value []byte, lease lease.LeaseID, opts ...PutOption) (rev int64)
+}
+
+// PutOption configures a Put.
+type PutOption func(*putOptions)
+
+type putOptions struct {
+	extraLeases []lease.LeaseID
+	leaseMode   mvccpb.LeaseMode
+}
+
+// WithExtraLeases attaches the key to the given leases in addition to the
+// lease passed to Put. Depending on the mode, the key is deleted when any or
+// all of the leases are revoked.
+func WithExtraLeases(mode mvccpb.LeaseMode, ids ...lease.LeaseID) PutOption {
+	return func(o *putOptions) {
+		o.extraLeases = ids
+		o.leaseMode = mode
+	}
 }
 
 // TxnWrite represents a transaction that can modify the store.
@@ -93,7 +112,7 @@ type TxnWrite interface {
 type txnReadWrite struct{ TxnRead }
 
 func (trw *txnReadWrite) DeleteRange(key, end []byte) (n, rev int64) { panic("unexpected DeleteRange") }
-func (trw *txnReadWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
+func (trw *txnReadWrite) Put(key, value []byte, lease lease.LeaseID, opts ...PutOption) (rev int64) {
 	panic("unexpected Put")
 }
 func (trw *txnReadWrite) Changes() []mvccpb.KeyValue { return nil }
@@ -125,6 +144,24 @@ type KV interface {
 	// Compact frees all superseded keys with revisions less than rev.
 	Compact(trace *traceutil.Trace, rev int64) (<-chan struct{}, error)
 
+	// PutRetentionPolicy adds or replaces the retention policy for a prefix,
+	// which Compact applies to the keys under the prefix.
+	PutRetentionPolicy(p RetentionPolicy) error
+
+	// DeleteRetentionPolicy removes the retention policy for a prefix.
+	DeleteRetentionPolicy(prefix []byte) error
+
+	// RetentionPolicies returns the retention policies sorted by prefix.
+	RetentionPolicies() []RetentionPolicy
+
+	// RecordRevisionTime records that the current revision was applied at
+	// or before t.
+	RecordRevisionTime(t time.Time)
+
+	// RevisionAt returns the latest revision known to have been applied at
+	// or before t, and the time it was sampled at.
+	RevisionAt(t time.Time) (rev int64, at time.Time, err error)
+
 	// Commit commits outstanding txns into the underlying backend.
 	Commit()
 
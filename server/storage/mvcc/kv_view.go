@@ -49,8 +49,8 @@ func (wv *writeView) DeleteRange(key, end []byte) (n, rev int64) {
 	return tw.DeleteRange(key, end)
 }
 
-func (wv *writeView) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
+func (wv *writeView) Put(key, value []byte, lease lease.LeaseID, opts ...PutOption) (rev int64) {
 	tw := wv.kv.Write(traceutil.TODO())
 	defer tw.End()
-	return tw.Put(key, value, lease)
+	return tw.Put(key, value, lease, opts...)
 }
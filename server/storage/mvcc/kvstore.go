@@ -61,6 +61,12 @@ type store struct {
 	b       backend.Backend
 	kvindex index
 
+	// retention holds the retention policies applied by compaction.
+	// It is protected by mu.
+	retention retentionPolicies
+
+	revTimes *revisionTimes
+
 	le lease.Lessor
 
 	// revMuLock protects currentRev and compactMainRev.
@@ -98,6 +104,8 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 		b:       b,
 		kvindex: newTreeIndex(lg),
 
+		revTimes: newRevisionTimes(),
+
 		le: le,
 
 		currentRev:     1,
@@ -120,6 +128,7 @@ func NewStore(lg *zap.Logger, b backend.Backend, le lease.Lessor, cfg StoreConfi
 	tx.LockOutsideApply()
 	tx.UnsafeCreateBucket(schema.Key)
 	schema.UnsafeCreateMetaBucket(tx)
+	schema.UnsafeCreateRevisionTimeBucket(tx)
 	tx.Unlock()
 	s.b.ForceCommit()
 
@@ -182,7 +191,7 @@ func (s *store) hashByRev(rev int64) (hash KeyValueHash, currentRev int64, err e
 	if rev == 0 {
 		rev = currentRev
 	}
-	keep := s.kvindex.Keep(rev)
+	keep := s.kvindex.Keep(rev, s.retention.resolve(s.revTimes, rev))
 
 	tx := s.b.ReadTx()
 	tx.RLock()
@@ -232,12 +241,15 @@ func (s *store) checkPrevCompactionCompleted() bool {
 
 func (s *store) compact(trace *traceutil.Trace, rev, prevCompactRev int64, prevCompactionCompleted bool) <-chan struct{} {
 	ch := make(chan struct{})
+	// Policies are captured when the compaction is applied rather than when
+	// it runs, so that every member compacts with the same policies.
+	rp := s.retention.resolve(s.revTimes, rev)
 	j := schedule.NewJob("kvstore_compact", func(ctx context.Context) {
 		if ctx.Err() != nil {
 			s.compactBarrier(ctx, ch)
 			return
 		}
-		hash, err := s.scheduleCompaction(rev, prevCompactRev)
+		hash, err := s.scheduleCompaction(rev, prevCompactRev, rp)
 		if err != nil {
 			s.lg.Warn("Failed compaction", zap.Error(err))
 			s.compactBarrier(context.TODO(), ch)
@@ -312,7 +324,6 @@ func (s *store) Restore(b backend.Backend) error {
 	return s.restore()
 }
 
-//nolint:unparam
 func (s *store) restore() error {
 	s.setupMetricsReporter()
 
@@ -321,6 +332,7 @@ func (s *store) restore() error {
 	max = RevToBytes(Revision{Main: math.MaxInt64, Sub: math.MaxInt64}, max)
 
 	keyToLease := make(map[string]lease.LeaseID)
+	keyToExtraLeases := make(map[string]extraLeases)
 
 	// restore index
 	tx := s.b.ReadTx()
@@ -339,6 +351,16 @@ func (s *store) restore() error {
 		s.revMu.Unlock()
 	}
 	scheduledCompact, _ := UnsafeReadScheduledCompact(tx)
+	rp, err := unsafeReadRetentionPolicies(tx)
+	if err != nil {
+		tx.RUnlock()
+		return err
+	}
+	s.retention = rp
+	if err = s.revTimes.unsafeRestore(tx); err != nil {
+		tx.RUnlock()
+		return err
+	}
 	// index keys concurrently as they're loaded in from tx
 	keysGauge.Set(0)
 	rkvc, revc := restoreIntoIndex(s.lg, s.kvindex)
@@ -349,7 +371,7 @@ func (s *store) restore() error {
 		}
 		// rkvc blocks if the total pending keys exceeds the restore
 		// chunk size to keep keys from consuming too much memory.
-		restoreChunk(s.lg, rkvc, keys, vals, keyToLease)
+		restoreChunk(s.lg, rkvc, keys, vals, keyToLease, keyToExtraLeases)
 		if len(keys) < restoreChunkKeys {
 			// partial set implies final set
 			break
@@ -394,7 +416,14 @@ func (s *store) restore() error {
 			tx.RUnlock()
 			panic("no lessor to attach lease")
 		}
-		err := s.le.Attach(lid, []lease.LeaseItem{{Key: key}})
+		var err error
+		if el, ok := keyToExtraLeases[key]; ok {
+			// some of the leases may have been revoked while the key was kept
+			// by the others, those are skipped
+			err = s.le.AttachLeases(append([]lease.LeaseID{lid}, el.ids...), el.mode, []lease.LeaseItem{{Key: key}})
+		} else {
+			err = s.le.Attach(lid, []lease.LeaseItem{{Key: key}})
+		}
 		if err != nil {
 			s.lg.Error(
 				"failed to attach a lease",
@@ -488,17 +517,31 @@ func restoreIntoIndex(lg *zap.Logger, idx index) (chan<- revKeyValue, <-chan int
 	return rkvc, revc
 }
 
-func restoreChunk(lg *zap.Logger, kvc chan<- revKeyValue, keys, vals [][]byte, keyToLease map[string]lease.LeaseID) {
+// extraLeases are the leases a key is attached to in addition to its primary lease.
+type extraLeases struct {
+	ids  []lease.LeaseID
+	mode mvccpb.LeaseMode
+}
+
+func restoreChunk(lg *zap.Logger, kvc chan<- revKeyValue, keys, vals [][]byte, keyToLease map[string]lease.LeaseID, keyToExtraLeases map[string]extraLeases) {
 	for i, key := range keys {
 		rkv := revKeyValue{key: key}
 		if err := rkv.kv.Unmarshal(vals[i]); err != nil {
 			lg.Fatal("failed to unmarshal mvccpb.KeyValue", zap.Error(err))
 		}
 		rkv.kstr = string(rkv.kv.Key)
+		delete(keyToExtraLeases, rkv.kstr)
 		if isTombstone(key) {
 			delete(keyToLease, rkv.kstr)
 		} else if lid := lease.LeaseID(rkv.kv.Lease); lid != lease.NoLease {
 			keyToLease[rkv.kstr] = lid
+			if len(rkv.kv.ExtraLeases) > 0 {
+				el := extraLeases{ids: make([]lease.LeaseID, len(rkv.kv.ExtraLeases)), mode: rkv.kv.LeaseMode}
+				for i, id := range rkv.kv.ExtraLeases {
+					el.ids[i] = lease.LeaseID(id)
+				}
+				keyToExtraLeases[rkv.kstr] = el
+			}
 		} else {
 			delete(keyToLease, rkv.kstr)
 		}
@@ -25,9 +25,9 @@ import (
 	"go.etcd.io/etcd/server/v3/storage/schema"
 )
 
-func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyValueHash, error) {
+func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64, rp retentionPolicies) (KeyValueHash, error) {
 	totalStart := time.Now()
-	keep := s.kvindex.Compact(compactMainRev)
+	keep := s.kvindex.Compact(compactMainRev, rp)
 	indexCompactionPauseMs.Observe(float64(time.Since(totalStart) / time.Millisecond))
 
 	totalStart = time.Now()
@@ -63,6 +63,8 @@ func (s *store) scheduleCompaction(compactMainRev, prevCompactRev int64) (KeyVal
 		if len(keys) < batchNum {
 			// gofail: var compactBeforeSetFinishedCompact struct{}
 			UnsafeSetFinishedCompact(tx, compactMainRev)
+			// keep the samples the policies resolved their duration with.
+			s.revTimes.unsafeCompact(tx, rp.oldestRetained(compactMainRev))
 			tx.Unlock()
 			dbCompactionPauseMs.Observe(float64(time.Since(start) / time.Millisecond))
 			// gofail: var compactAfterSetFinishedCompact struct{}
@@ -29,6 +29,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 
@@ -928,6 +929,7 @@ func newFakeStore(lg *zap.Logger) *store {
 		fifoSched:      schedule.NewFIFOScheduler(lg),
 		stopc:          make(chan struct{}),
 		lg:             lg,
+		revTimes:       newRevisionTimes(),
 	}
 	s.ReadView, s.WriteView = &readView{s}, &writeView{s}
 	s.hashes = NewHashStorage(lg, s)
@@ -998,6 +1000,7 @@ func (b *fakeBackend) Size() int64
 func (b *fakeBackend) SizeInUse() int64                                           { return 0 }
 func (b *fakeBackend) OpenReadTxN() int64                                         { return 0 }
 func (b *fakeBackend) Snapshot() backend.Snapshot                                 { return nil }
+func (b *fakeBackend) NativeSnapshot() backend.Snapshot                           { return nil }
 func (b *fakeBackend) ForceCommit()                                               {}
 func (b *fakeBackend) Defrag() error                                              { return nil }
 func (b *fakeBackend) Close() error                                               { return nil }
@@ -1067,12 +1070,12 @@ func (i *fakeIndex) RangeSince(key, end []byte, rev int64) []Revision {
 	return r.revs
 }
 
-func (i *fakeIndex) Compact(rev int64) map[Revision]struct{} {
+func (i *fakeIndex) Compact(rev int64, rp retentionPolicies) map[Revision]struct{} {
 	i.Recorder.Record(testutil.Action{Name: "compact", Params: []any{rev}})
 	return <-i.indexCompactRespc
 }
 
-func (i *fakeIndex) Keep(rev int64) map[Revision]struct{} {
+func (i *fakeIndex) Keep(rev int64, rp retentionPolicies) map[Revision]struct{} {
 	i.Recorder.Record(testutil.Action{Name: "keep", Params: []any{rev}})
 	return <-i.indexCompactRespc
 }
@@ -1098,3 +1101,40 @@ func createBytesSlice(bytesN, sliceN int) [][]byte {
 	}
 	return rs
 }
+
+// renewLockLessor checks that the leases are renewed on write without the
+// backend lock held, as the lessor takes its lock before the backend lock.
+type renewLockLessor struct {
+	lease.FakeLessor
+	b      backend.Backend
+	renews []lease.LeaseID
+	locked bool
+}
+
+func (le *renewLockLessor) RenewOnWrite(id lease.LeaseID) {
+	donec := make(chan struct{})
+	go func() {
+		tx := le.b.BatchTx()
+		tx.Lock()
+		tx.Unlock()
+		close(donec)
+	}()
+	select {
+	case <-donec:
+	case <-time.After(time.Second):
+		le.locked = true
+	}
+	le.renews = append(le.renews, id)
+}
+
+func TestStoreRenewOnWriteOutsideBackendLock(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	le := &renewLockLessor{b: b}
+	s := NewStore(zaptest.NewLogger(t), b, le, StoreConfig{})
+	defer s.Close()
+	defer b.Close()
+
+	s.Put([]byte("foo"), []byte("bar"), 1)
+	assert.Equal(t, []lease.LeaseID{1}, le.renews)
+	assert.Falsef(t, le.locked, "lease renewed on write with the backend lock held")
+}
@@ -77,7 +77,7 @@ func (tr *storeTxnCommon) rangeKeys(ctx context.Context, key, end []byte, curRev
 	if rev <= 0 {
 		rev = curRev
 	}
-	if rev < tr.s.compactMainRev {
+	if rev < tr.s.compactMainRev && !tr.s.retention.covers(key, end, rev) {
 		return &RangeResult{KVs: nil, Count: -1, Rev: 0}, ErrCompacted
 	}
 	if ro.Count {
@@ -142,6 +142,10 @@ type storeTxnWrite struct {
 	// beginRev is the revision where the txn begins; it will write to the next revision.
 	beginRev int64
 	changes  []mvccpb.KeyValue
+	// renews are the leases renewed on write by the puts of the txn. They
+	// are renewed once the backend lock is released, as the lessor takes its
+	// lock before the backend lock.
+	renews []lease.LeaseID
 }
 
 func (s *store) Write(trace *traceutil.Trace) TxnWrite {
@@ -174,8 +178,12 @@ func (tw *storeTxnWrite) DeleteRange(key, end []byte) (int64, int64) {
 	return 0, tw.beginRev
 }
 
-func (tw *storeTxnWrite) Put(key, value []byte, lease lease.LeaseID) int64 {
-	tw.put(key, value, lease)
+func (tw *storeTxnWrite) Put(key, value []byte, lease lease.LeaseID, opts ...PutOption) int64 {
+	var o putOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tw.put(key, value, lease, o)
 	return tw.beginRev + 1
 }
 
@@ -191,19 +199,22 @@ func (tw *storeTxnWrite) End() {
 		tw.s.revMu.Unlock()
 	}
 	tw.s.mu.RUnlock()
+	for _, id := range tw.renews {
+		tw.s.le.RenewOnWrite(id)
+	}
 }
 
-func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
+func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID, o putOptions) {
 	rev := tw.beginRev + 1
 	c := rev
-	oldLease := lease.NoLease
+	var oldLeases []lease.LeaseID
 
 	// if the key exists before, use its previous created and
 	// get its previous leaseID
 	_, created, ver, err := tw.s.kvindex.Get(key, rev)
 	if err == nil {
 		c = created.Main
-		oldLease = tw.s.le.GetLease(lease.LeaseItem{Key: string(key)})
+		oldLeases = tw.s.le.GetLeases(lease.LeaseItem{Key: string(key)})
 		tw.trace.Step("get key's previous created_revision and leaseID")
 	}
 	ibytes := NewRevBytes()
@@ -219,6 +230,13 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		Version:        ver,
 		Lease:          int64(leaseID),
 	}
+	if leaseID != lease.NoLease && len(o.extraLeases) > 0 {
+		kv.ExtraLeases = make([]int64, len(o.extraLeases))
+		for i, id := range o.extraLeases {
+			kv.ExtraLeases[i] = int64(id)
+		}
+		kv.LeaseMode = o.leaseMode
+	}
 
 	d, err := kv.Marshal()
 	if err != nil {
@@ -234,16 +252,23 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 	tw.changes = append(tw.changes, kv)
 	tw.trace.Step("store kv pair into bolt db")
 
-	if oldLease == leaseID {
+	if leaseID != lease.NoLease && tw.s.le != nil {
+		// a put to a key attached to a lease renews it if the lease asks for it
+		tw.renews = append(tw.renews, leaseID)
+	}
+
+	if len(kv.ExtraLeases) == 0 && len(oldLeases) == 1 && oldLeases[0] == leaseID ||
+		leaseID == lease.NoLease && len(oldLeases) == 0 {
 		tw.trace.Step("attach lease to kv pair")
 		return
 	}
 
-	if oldLease != lease.NoLease {
+	item := lease.LeaseItem{Key: string(key)}
+	for _, oldLease := range oldLeases {
 		if tw.s.le == nil {
 			panic("no lessor to detach lease")
 		}
-		err = tw.s.le.Detach(oldLease, []lease.LeaseItem{{Key: string(key)}})
+		err = tw.s.le.Detach(oldLease, []lease.LeaseItem{item})
 		if err != nil {
 			tw.storeTxnCommon.s.lg.Error(
 				"failed to detach old lease from a key",
@@ -255,7 +280,11 @@ func (tw *storeTxnWrite) put(key, value []byte, leaseID lease.LeaseID) {
 		if tw.s.le == nil {
 			panic("no lessor to attach lease")
 		}
-		err = tw.s.le.Attach(leaseID, []lease.LeaseItem{{Key: string(key)}})
+		if len(kv.ExtraLeases) == 0 {
+			err = tw.s.le.Attach(leaseID, []lease.LeaseItem{item})
+		} else {
+			err = tw.s.le.AttachLeases(append([]lease.LeaseID{leaseID}, o.extraLeases...), o.leaseMode, []lease.LeaseItem{item})
+		}
 		if err != nil {
 			panic("unexpected error from lease Attach")
 		}
@@ -302,12 +331,18 @@ func (tw *storeTxnWrite) delete(key []byte) {
 			zap.Error(err),
 		)
 	}
-	tw.changes = append(tw.changes, kv)
 
 	item := lease.LeaseItem{Key: string(key)}
-	leaseID := tw.s.le.GetLease(item)
+	leaseIDs := tw.s.le.GetLeases(item)
 
-	if leaseID != lease.NoLease {
+	// The tombstone is stored without a lease, but the change keeps the
+	// primary lease of the deleted key so that the delete event can report it.
+	if len(leaseIDs) > 0 {
+		kv.Lease = int64(leaseIDs[0])
+	}
+	tw.changes = append(tw.changes, kv)
+
+	for _, leaseID := range leaseIDs {
 		err = tw.s.le.Detach(leaseID, []lease.LeaseItem{item})
 		if err != nil {
 			tw.storeTxnCommon.s.lg.Error(
@@ -46,11 +46,11 @@ func (tw *metricsTxnWrite) DeleteRange(key, end []byte) (n, rev int64) {
 	return tw.TxnWrite.DeleteRange(key, end)
 }
 
-func (tw *metricsTxnWrite) Put(key, value []byte, lease lease.LeaseID) (rev int64) {
+func (tw *metricsTxnWrite) Put(key, value []byte, lease lease.LeaseID, opts ...PutOption) (rev int64) {
 	tw.puts++
 	size := int64(len(key) + len(value))
 	tw.putSize += size
-	return tw.TxnWrite.Put(key, value, lease)
+	return tw.TxnWrite.Put(key, value, lease, opts...)
 }
 
 func (tw *metricsTxnWrite) End() {
@@ -0,0 +1,260 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+	"time"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+var (
+	ErrInvalidRetentionPolicy  = errors.New("mvcc: retention policy requires a non-empty prefix and a positive number of revisions or keep duration")
+	ErrRetentionPolicyNotFound = errors.New("mvcc: retention policy not found")
+)
+
+// RetentionPolicy extends the history that compaction keeps for the keys
+// under a prefix. Compacting at revision rev keeps, for every key under
+// Prefix, the KeepRevisions most recent revisions at or below rev instead
+// of only the latest one, and the revisions applied within KeepDuration
+// before rev together with the one they replaced. When both are set, the
+// longer history is kept.
+//
+// KeepDuration is resolved to a revision with the revision time samples,
+// which are proposed by the leader and replicated through raft, so every
+// member keeps the same revisions. It is counted back from the latest sample
+// below rev; while no sample is old enough, the whole history since the
+// policy was put is kept.
+//
+// Revisions kept by a policy stay readable below the compaction revision
+// as long as the requested range lies entirely under the policy's Prefix
+// and the revision is not older than the policy's CompactRevision.
+// Revisions of such a key that are older than the kept history read as
+// if the key did not exist.
+type RetentionPolicy struct {
+	Prefix        []byte
+	KeepRevisions int64
+	// KeepDuration is kept in whole seconds.
+	KeepDuration time.Duration
+	// CompactRevision is the compaction revision when the policy was first
+	// put. The history compacted before it is not retained.
+	CompactRevision int64
+
+	// sinceRev is the revision KeepDuration resolved to for a compaction.
+	// The revisions after it are kept, as well as the one it was current at.
+	sinceRev int64
+}
+
+// retains reports whether the policy keeps more than the latest revision.
+func (p RetentionPolicy) retains() bool {
+	return p.KeepRevisions > 1 || p.KeepDuration > 0
+}
+
+// retentionPolicies is a list of retention policies sorted by prefix.
+type retentionPolicies []RetentionPolicy
+
+// match returns the policy applying to the given key. When several prefixes
+// match, the longest one wins.
+func (rp retentionPolicies) match(key []byte) (RetentionPolicy, bool) {
+	var (
+		policy  RetentionPolicy
+		longest = -1
+	)
+	for _, p := range rp {
+		if bytes.HasPrefix(key, p.Prefix) && len(p.Prefix) > longest {
+			policy, longest = p, len(p.Prefix)
+		}
+	}
+	return policy, longest >= 0
+}
+
+// resolve returns the policies with their KeepDuration resolved to revisions
+// for a compaction at rev.
+func (rp retentionPolicies) resolve(rt *revisionTimes, rev int64) retentionPolicies {
+	var resolved retentionPolicies
+	for i, p := range rp {
+		if p.KeepDuration <= 0 {
+			continue
+		}
+		if resolved == nil {
+			resolved = append(retentionPolicies{}, rp...)
+		}
+		resolved[i].sinceRev = rt.revisionBefore(rev, p.KeepDuration)
+	}
+	if resolved == nil {
+		return rp
+	}
+	return resolved
+}
+
+// oldestRetained returns the lowest revision the resolved policies keep the
+// history from for a compaction at rev, or 0 if a policy keeps all of it.
+func (rp retentionPolicies) oldestRetained(rev int64) int64 {
+	for _, p := range rp {
+		if p.KeepDuration > 0 {
+			rev = min(rev, p.sinceRev)
+		}
+	}
+	return rev
+}
+
+// covers reports whether the range [key, end) at rev lies entirely under
+// the prefix of a retention policy retaining rev.
+func (rp retentionPolicies) covers(key, end []byte, rev int64) bool {
+	for _, p := range rp {
+		if !bytes.HasPrefix(key, p.Prefix) || rev < p.CompactRevision {
+			continue
+		}
+		if end == nil {
+			return true
+		}
+		pend := prefixEnd(p.Prefix)
+		if pend == nil {
+			return true
+		}
+		if len(end) != 0 && bytes.Compare(end, pend) <= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// get returns the policy for the given prefix.
+func (rp retentionPolicies) get(prefix []byte) (RetentionPolicy, bool) {
+	i := sort.Search(len(rp), func(i int) bool { return bytes.Compare(rp[i].Prefix, prefix) >= 0 })
+	if i < len(rp) && bytes.Equal(rp[i].Prefix, prefix) {
+		return rp[i], true
+	}
+	return RetentionPolicy{}, false
+}
+
+func (rp retentionPolicies) put(p RetentionPolicy) retentionPolicies {
+	i := sort.Search(len(rp), func(i int) bool { return bytes.Compare(rp[i].Prefix, p.Prefix) >= 0 })
+	if i < len(rp) && bytes.Equal(rp[i].Prefix, p.Prefix) {
+		npolicies := append(retentionPolicies{}, rp...)
+		npolicies[i] = p
+		return npolicies
+	}
+	npolicies := make(retentionPolicies, 0, len(rp)+1)
+	npolicies = append(npolicies, rp[:i]...)
+	npolicies = append(npolicies, p)
+	return append(npolicies, rp[i:]...)
+}
+
+func (rp retentionPolicies) delete(prefix []byte) (retentionPolicies, bool) {
+	for i, p := range rp {
+		if bytes.Equal(p.Prefix, prefix) {
+			npolicies := make(retentionPolicies, 0, len(rp)-1)
+			npolicies = append(npolicies, rp[:i]...)
+			return append(npolicies, rp[i+1:]...), true
+		}
+	}
+	return rp, false
+}
+
+// prefixEnd returns the end of the range covering all keys with the given
+// prefix, or nil if the prefix is all 0xff bytes.
+func prefixEnd(prefix []byte) []byte {
+	end := make([]byte, len(prefix))
+	copy(end, prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// PutRetentionPolicy adds the policy, replacing any existing policy for the
+// same prefix. The policy takes effect from the next compaction. The
+// CompactRevision of p is ignored: it is set to the current compaction
+// revision, or kept from the replaced policy.
+func (s *store) PutRetentionPolicy(p RetentionPolicy) error {
+	p.KeepDuration = p.KeepDuration.Truncate(time.Second)
+	if len(p.Prefix) == 0 || p.KeepRevisions < 0 || p.KeepDuration < 0 || (p.KeepRevisions == 0 && p.KeepDuration == 0) {
+		return ErrInvalidRetentionPolicy
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if old, ok := s.retention.get(p.Prefix); ok {
+		p.CompactRevision = old.CompactRevision
+	} else {
+		s.revMu.RLock()
+		p.CompactRevision = max(s.compactMainRev, 0)
+		s.revMu.RUnlock()
+	}
+
+	tx := s.b.BatchTx()
+	tx.LockInsideApply()
+	schema.UnsafeCreateCompactionPolicyBucket(tx)
+	schema.MustUnsafePutCompactionPolicy(tx, &etcdserverpb.CompactionPolicy{
+		Prefix:          p.Prefix,
+		KeepRevisions:   p.KeepRevisions,
+		CompactRevision: p.CompactRevision,
+		KeepSeconds:     int64(p.KeepDuration / time.Second),
+	})
+	tx.Unlock()
+
+	s.retention = s.retention.put(p)
+	return nil
+}
+
+// DeleteRetentionPolicy removes the policy for the given prefix. It returns
+// ErrRetentionPolicyNotFound if there is no such policy.
+func (s *store) DeleteRetentionPolicy(prefix []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ok bool
+	if s.retention, ok = s.retention.delete(prefix); !ok {
+		return ErrRetentionPolicyNotFound
+	}
+	tx := s.b.BatchTx()
+	tx.LockInsideApply()
+	schema.UnsafeDeleteCompactionPolicy(tx, prefix)
+	tx.Unlock()
+	return nil
+}
+
+// RetentionPolicies returns the retention policies sorted by prefix.
+func (s *store) RetentionPolicies() []RetentionPolicy {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]RetentionPolicy(nil), s.retention...)
+}
+
+func unsafeReadRetentionPolicies(tx backend.UnsafeReader) (retentionPolicies, error) {
+	ps, err := schema.UnsafeGetAllCompactionPolicies(tx)
+	if err != nil {
+		return nil, err
+	}
+	rp := make(retentionPolicies, 0, len(ps))
+	for _, p := range ps {
+		rp = append(rp, RetentionPolicy{
+			Prefix:          p.Prefix,
+			KeepRevisions:   p.KeepRevisions,
+			KeepDuration:    time.Duration(p.KeepSeconds) * time.Second,
+			CompactRevision: p.CompactRevision,
+		})
+	}
+	return rp, nil
+}
@@ -0,0 +1,329 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestRetentionPoliciesMatch(t *testing.T) {
+	var rp retentionPolicies
+	rp = rp.put(RetentionPolicy{Prefix: []byte("/a/"), KeepRevisions: 3})
+	rp = rp.put(RetentionPolicy{Prefix: []byte("/a/b/"), KeepRevisions: 5})
+	rp = rp.put(RetentionPolicy{Prefix: []byte("/c"), KeepRevisions: 2})
+
+	tests := []struct {
+		key   string
+		wkeep int64
+	}{
+		{"/a/", 3},
+		{"/a/x", 3},
+		{"/a/b/", 5},
+		{"/a/b/x", 5},
+		{"/a/bx", 3},
+		{"/c", 2},
+		{"/cd", 2},
+		{"/b", 0},
+		{"/a", 0},
+	}
+	keepRevisions := func(key string) int64 {
+		p, ok := rp.match([]byte(key))
+		if !ok {
+			return 0
+		}
+		return p.KeepRevisions
+	}
+	for _, tt := range tests {
+		assert.Equalf(t, tt.wkeep, keepRevisions(tt.key), "key %q", tt.key)
+	}
+
+	rp = rp.put(RetentionPolicy{Prefix: []byte("/a/b/"), KeepRevisions: 1})
+	assert.Len(t, rp, 3)
+	assert.Equal(t, int64(1), keepRevisions("/a/b/x"))
+
+	rp, ok := rp.delete([]byte("/a/b/"))
+	require.True(t, ok)
+	assert.Equal(t, int64(3), keepRevisions("/a/b/x"))
+	_, ok = rp.delete([]byte("/a/b/"))
+	assert.False(t, ok)
+}
+
+func TestRetentionPoliciesCovers(t *testing.T) {
+	var rp retentionPolicies
+	rp = rp.put(RetentionPolicy{Prefix: []byte("/a/"), KeepRevisions: 3})
+	rp = rp.put(RetentionPolicy{Prefix: []byte{0xff, 0xff}, KeepRevisions: 3})
+	rp = rp.put(RetentionPolicy{Prefix: []byte("/c/"), KeepRevisions: 3, CompactRevision: 10})
+
+	tests := []struct {
+		key, end []byte
+		wcovers  bool
+	}{
+		{[]byte("/c/x"), nil, true},
+		{[]byte("/a/x"), nil, true},
+		{[]byte("/a/"), []byte("/a0"), true},
+		{[]byte("/a/x"), []byte("/a/y"), true},
+		{[]byte("/a/x"), []byte("/b"), false},
+		{[]byte("/a"), []byte("/a0"), false},
+		{[]byte("/a/"), []byte{}, false},
+		{[]byte("/b"), nil, false},
+		{[]byte{0xff, 0xff}, []byte{}, true},
+		{[]byte{0xff, 0xff, 0x01}, nil, true},
+	}
+	for i, tt := range tests {
+		assert.Equalf(t, tt.wcovers, rp.covers(tt.key, tt.end, 10), "#%d", i)
+	}
+	assert.False(t, rp.covers([]byte("/c/x"), nil, 9))
+}
+
+// TestKeyIndexCompactRetainKeep ensures that keepRetain finds the same
+// revisions that compactRetain keeps.
+func TestKeyIndexCompactRetainKeep(t *testing.T) {
+	newTestKeyIndex := func() *keyIndex {
+		// key: "foo"
+		// modified: 16
+		// generations:
+		//    {empty}
+		//    {{14, 0}[1], {15, 1}[2], {16, 0}(t)[3]}
+		//    {{8, 0}[1], {10, 0}[2], {12, 0}(t)[3]}
+		//    {{2, 0}[1], {4, 0}[2], {6, 0}(t)[3]}
+		ki := &keyIndex{key: []byte("foo")}
+		ki.put(zaptest.NewLogger(t), 2, 0)
+		ki.put(zaptest.NewLogger(t), 4, 0)
+		ki.tombstone(zaptest.NewLogger(t), 6, 0)
+		ki.put(zaptest.NewLogger(t), 8, 0)
+		ki.put(zaptest.NewLogger(t), 10, 0)
+		ki.tombstone(zaptest.NewLogger(t), 12, 0)
+		ki.put(zaptest.NewLogger(t), 14, 0)
+		ki.put(zaptest.NewLogger(t), 15, 1)
+		ki.tombstone(zaptest.NewLogger(t), 16, 0)
+		return ki
+	}
+
+	for atRev := int64(1); atRev <= 17; atRev++ {
+		for n := int64(2); n <= 10; n++ {
+			t.Run(fmt.Sprintf("rev=%d/n=%d", atRev, n), func(t *testing.T) {
+				ki := newTestKeyIndex()
+				p := RetentionPolicy{KeepRevisions: n}
+				kept := make(map[Revision]struct{})
+				ki.keepRetain(atRev, p, kept)
+
+				compacted := make(map[Revision]struct{})
+				ki.compactRetain(zaptest.NewLogger(t), atRev, p, compacted)
+				assert.Equal(t, kept, compacted)
+
+				var retained int64
+				for r := range compacted {
+					if r.Main <= atRev {
+						retained++
+					}
+				}
+				assert.LessOrEqual(t, retained, n)
+			})
+		}
+		for sinceRev := int64(0); sinceRev < atRev; sinceRev++ {
+			t.Run(fmt.Sprintf("rev=%d/since=%d", atRev, sinceRev), func(t *testing.T) {
+				ki := newTestKeyIndex()
+				p := RetentionPolicy{KeepDuration: time.Second, sinceRev: sinceRev}
+				kept := make(map[Revision]struct{})
+				ki.keepRetain(atRev, p, kept)
+
+				compacted := make(map[Revision]struct{})
+				ki.compactRetain(zaptest.NewLogger(t), atRev, p, compacted)
+				assert.Equal(t, kept, compacted)
+
+				for _, r := range []int64{2, 4, 6, 8, 10, 12, 14, 16} {
+					if r > sinceRev && r <= atRev {
+						assert.Containsf(t, compacted, Revision{Main: r}, "revision %d", r)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestStoreRetentionPolicy(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer b.Close()
+
+	require.ErrorIs(t, s.PutRetentionPolicy(RetentionPolicy{KeepRevisions: 3}), ErrInvalidRetentionPolicy)
+	require.ErrorIs(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/")}), ErrInvalidRetentionPolicy)
+	require.NoError(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/"), KeepRevisions: 3}))
+
+	// revisions 2-6 for /a/x and 7-11 for /b
+	for i := 0; i < 5; i++ {
+		s.Put([]byte("/a/x"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+	for i := 0; i < 5; i++ {
+		s.Put([]byte("/b"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+
+	done, err := s.Compact(traceutil.TODO(), 11)
+	require.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for compaction to finish")
+	}
+
+	for rev := int64(4); rev <= 6; rev++ {
+		r, err := s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: rev})
+		require.NoError(t, err)
+		require.Len(t, r.KVs, 1)
+		assert.Equal(t, rev, r.KVs[0].ModRevision)
+	}
+	r, err := s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 3})
+	require.NoError(t, err)
+	assert.Empty(t, r.KVs)
+
+	_, err = s.Range(t.Context(), []byte("/b"), nil, RangeOptions{Rev: 10})
+	require.ErrorIs(t, err, ErrCompacted)
+	_, err = s.Range(t.Context(), []byte("/a/x"), []byte("/b0"), RangeOptions{Rev: 5})
+	require.ErrorIs(t, err, ErrCompacted)
+
+	require.NoError(t, s.Close())
+
+	s = NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	assert.Equal(t, []RetentionPolicy{{Prefix: []byte("/a/"), KeepRevisions: 3}}, s.RetentionPolicies())
+	r, err = s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 4})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+	assert.Equal(t, int64(4), r.KVs[0].ModRevision)
+
+	require.NoError(t, s.DeleteRetentionPolicy([]byte("/a/")))
+	require.ErrorIs(t, s.DeleteRetentionPolicy([]byte("/a/")), ErrRetentionPolicyNotFound)
+	assert.Empty(t, s.RetentionPolicies())
+	_, err = s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 4})
+	require.ErrorIs(t, err, ErrCompacted)
+	require.NoError(t, s.Close())
+}
+
+// TestStoreRetentionPolicyKeepDuration ensures that a policy keeps the
+// revisions applied within its duration, counted back from the revision time
+// samples, and that the samples it resolves the duration with are kept.
+func TestStoreRetentionPolicyKeepDuration(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer b.Close()
+
+	compact := func(rev int64) {
+		done, err := s.Compact(traceutil.TODO(), rev)
+		require.NoError(t, err)
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for compaction to finish")
+		}
+	}
+
+	require.ErrorIs(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/"), KeepDuration: -time.Second}), ErrInvalidRetentionPolicy)
+	require.ErrorIs(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/"), KeepDuration: time.Millisecond}), ErrInvalidRetentionPolicy)
+	require.NoError(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/"), KeepDuration: 10 * time.Second}))
+
+	// revisions 2-11 for /a/x, one every 5 seconds
+	base := time.Unix(1000, 0)
+	for i := 0; i < 10; i++ {
+		s.Put([]byte("/a/x"), []byte(fmt.Sprint(i)), lease.NoLease)
+		s.RecordRevisionTime(base.Add(time.Duration(i) * 5 * time.Second))
+	}
+
+	// no sample is 10 seconds older than the one of revision 3, so the whole
+	// history is kept.
+	compact(4)
+	r, err := s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 2})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+
+	// the latest sample below revision 10 is the one of revision 9, at 35s,
+	// so the revisions applied after 25s, and revision 7 they replaced, are
+	// kept.
+	compact(10)
+	for rev := int64(7); rev <= 10; rev++ {
+		r, err = s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: rev})
+		require.NoError(t, err)
+		require.Len(t, r.KVs, 1)
+		assert.Equal(t, rev, r.KVs[0].ModRevision)
+	}
+	r, err = s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 6})
+	require.NoError(t, err)
+	assert.Empty(t, r.KVs)
+
+	// the samples from revision 7 are kept, but only the ones from the
+	// compaction revision are served.
+	assert.Equal(t, int64(7), s.revTimes.samples[0].rev)
+	_, _, err = s.RevisionAt(base.Add(30 * time.Second))
+	require.ErrorIs(t, err, ErrCompacted)
+
+	require.NoError(t, s.Close())
+	s = NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	assert.Equal(t, []RetentionPolicy{{Prefix: []byte("/a/"), KeepDuration: 10 * time.Second}}, s.RetentionPolicies())
+	r, err = s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 7})
+	require.NoError(t, err)
+	require.Len(t, r.KVs, 1)
+	require.NoError(t, s.Close())
+}
+
+// TestStoreRetentionPolicyCompactRevision ensures that a policy does not
+// make the history compacted before it was put readable.
+func TestStoreRetentionPolicyCompactRevision(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer b.Close()
+
+	compact := func(rev int64) {
+		done, err := s.Compact(traceutil.TODO(), rev)
+		require.NoError(t, err)
+		select {
+		case <-done:
+		case <-time.After(10 * time.Second):
+			t.Fatal("timeout waiting for compaction to finish")
+		}
+	}
+
+	// revisions 2-11 for /a/x
+	for i := 0; i < 10; i++ {
+		s.Put([]byte("/a/x"), []byte(fmt.Sprint(i)), lease.NoLease)
+	}
+	compact(5)
+	require.NoError(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/"), KeepRevisions: 10}))
+	require.NoError(t, s.PutRetentionPolicy(RetentionPolicy{Prefix: []byte("/a/"), KeepRevisions: 20, CompactRevision: 1}))
+	assert.Equal(t, []RetentionPolicy{{Prefix: []byte("/a/"), KeepRevisions: 20, CompactRevision: 5}}, s.RetentionPolicies())
+	compact(10)
+
+	_, err := s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 4})
+	require.ErrorIs(t, err, ErrCompacted)
+	for rev := int64(5); rev <= 10; rev++ {
+		r, err := s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: rev})
+		require.NoError(t, err)
+		require.Len(t, r.KVs, 1)
+		assert.Equal(t, rev, r.KVs[0].ModRevision)
+	}
+
+	require.NoError(t, s.Close())
+	s = NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	_, err = s.Range(t.Context(), []byte("/a/x"), nil, RangeOptions{Rev: 4})
+	require.ErrorIs(t, err, ErrCompacted)
+	require.NoError(t, s.Close())
+}
@@ -0,0 +1,158 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"go.etcd.io/etcd/server/v3/storage/backend"
+	"go.etcd.io/etcd/server/v3/storage/schema"
+)
+
+type revisionTime struct {
+	rev int64
+	// unixNano is the time the revision was known to be applied at.
+	unixNano int64
+}
+
+// revisionTimes is a sparse index from wall-clock time to revision.
+//
+// The samples are proposed by the leader through raft with its wall-clock
+// time, and recorded for the revision they are applied at, so every member
+// keeps the same samples. A lookup falling between two samples resolves to
+// the revision of the earlier one. Recorded times never decrease, even if the
+// clock of a new leader is behind the one of the previous leader.
+type revisionTimes struct {
+	mu sync.RWMutex
+	// samples holds the recorded samples in ascending revision order,
+	// which is also ascending time order.
+	samples []revisionTime
+}
+
+func newRevisionTimes() *revisionTimes {
+	return &revisionTimes{}
+}
+
+// unsafeRecord records that rev was applied at or before unixNano, holding
+// the lock on tx. Nothing is recorded if rev is already the latest sample.
+func (rt *revisionTimes) unsafeRecord(tx backend.UnsafeWriter, rev, unixNano int64) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	if n := len(rt.samples); n > 0 {
+		last := rt.samples[n-1]
+		if rev <= last.rev {
+			return
+		}
+		unixNano = max(unixNano, last.unixNano)
+	}
+	schema.UnsafePutRevisionTime(tx, rev, unixNano)
+	rt.samples = append(rt.samples, revisionTime{rev: rev, unixNano: unixNano})
+}
+
+// revisionAt returns the latest revision known to have been applied at or
+// before t, and the time it was sampled at. It returns ErrCompacted if no
+// such revision is known.
+func (rt *revisionTimes) revisionAt(t time.Time) (revisionTime, error) {
+	ts := t.UnixNano()
+
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	i := sort.Search(len(rt.samples), func(i int) bool { return rt.samples[i].unixNano > ts })
+	if i == 0 {
+		return revisionTime{}, ErrCompacted
+	}
+	return rt.samples[i-1], nil
+}
+
+// revisionBefore returns the latest sampled revision at least d older than
+// the latest sample below rev, or 0 if there is no such revision. Samples
+// below rev are all applied before rev is compacted, so every member resolves
+// rev alike whenever it compacts or hashes it.
+func (rt *revisionTimes) revisionBefore(rev int64, d time.Duration) int64 {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+	i := sort.Search(len(rt.samples), func(i int) bool { return rt.samples[i].rev >= rev })
+	if i == 0 {
+		return 0
+	}
+	ts := rt.samples[i-1].unixNano - int64(d)
+	j := sort.Search(i, func(j int) bool { return rt.samples[j].unixNano > ts })
+	if j == 0 {
+		return 0
+	}
+	return rt.samples[j-1].rev
+}
+
+// unsafeCompact removes the samples of revisions lower than compactRev,
+// holding the lock on tx.
+func (rt *revisionTimes) unsafeCompact(tx backend.UnsafeWriter, compactRev int64) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	i := sort.Search(len(rt.samples), func(i int) bool { return rt.samples[i].rev >= compactRev })
+	for _, sample := range rt.samples[:i] {
+		schema.UnsafeDeleteRevisionTime(tx, sample.rev)
+	}
+	rt.samples = append([]revisionTime(nil), rt.samples[i:]...)
+}
+
+// unsafeRestore reloads the samples from tx, holding the lock on tx.
+func (rt *revisionTimes) unsafeRestore(tx backend.UnsafeReader) error {
+	var samples []revisionTime
+	err := schema.UnsafeForEachRevisionTime(tx, func(rev, unixNano int64) error {
+		samples = append(samples, revisionTime{rev: rev, unixNano: unixNano})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.samples = samples
+	return nil
+}
+
+// RecordRevisionTime records that the current revision was applied at or
+// before t.
+func (s *store) RecordRevisionTime(t time.Time) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tx := s.b.BatchTx()
+	tx.LockInsideApply()
+	defer tx.Unlock()
+	s.revMu.RLock()
+	rev := s.currentRev
+	s.revMu.RUnlock()
+	s.revTimes.unsafeRecord(tx, rev, t.UnixNano())
+}
+
+// RevisionAt returns the latest revision known to have been applied at or
+// before t, and the time it was sampled at.
+func (s *store) RevisionAt(t time.Time) (int64, time.Time, error) {
+	sample, err := s.revTimes.revisionAt(t)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	s.revMu.RLock()
+	compactRev := s.compactMainRev
+	s.revMu.RUnlock()
+	if sample.rev < compactRev {
+		return 0, time.Time{}, ErrCompacted
+	}
+	return sample.rev, time.Unix(0, sample.unixNano), nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mvcc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/pkg/v3/traceutil"
+	"go.etcd.io/etcd/server/v3/lease"
+	betesting "go.etcd.io/etcd/server/v3/storage/backend/testing"
+)
+
+func TestRevisionAt(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer b.Close()
+
+	base := time.Unix(1000, 0)
+	sample := func(at time.Duration) {
+		s.RecordRevisionTime(base.Add(at))
+	}
+	put := func() {
+		s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	}
+	put()                    // rev 2
+	sample(0)                // rev 2 at 0s
+	put()                    // rev 3
+	put()                    // rev 4
+	sample(time.Second)      // rev 4 at 1s
+	sample(2 * time.Second)  // no write since the previous sample
+	put()                    // rev 5
+	sample(10 * time.Second) // rev 5 at 10s
+	put()                    // rev 6, not sampled yet
+
+	tests := []struct {
+		at   time.Duration
+		wrev int64
+		wat  time.Duration
+		werr error
+	}{
+		{-time.Second, 0, 0, ErrCompacted},
+		{0, 2, 0, nil},
+		{400 * time.Millisecond, 2, 0, nil},
+		{time.Second, 4, time.Second, nil},
+		{5 * time.Second, 4, time.Second, nil},
+		{10 * time.Second, 5, 10 * time.Second, nil},
+		{time.Hour, 5, 10 * time.Second, nil},
+	}
+	for i, tt := range tests {
+		rev, at, err := s.RevisionAt(base.Add(tt.at))
+		if tt.werr != nil {
+			assert.ErrorIsf(t, err, tt.werr, "#%d", i)
+			continue
+		}
+		require.NoErrorf(t, err, "#%d", i)
+		assert.Equalf(t, tt.wrev, rev, "#%d", i)
+		assert.Truef(t, base.Add(tt.wat).Equal(at), "#%d: at = %v, want %v", i, at, base.Add(tt.wat))
+	}
+
+	// the clock of a new leader is behind; recorded times must not decrease
+	sample(5 * time.Second) // rev 6 at 10s
+	rev, at, err := s.RevisionAt(base.Add(10 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), rev)
+	assert.True(t, base.Add(10*time.Second).Equal(at))
+
+	done, err := s.Compact(traceutil.TODO(), 5)
+	require.NoError(t, err)
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timeout waiting for compaction to finish")
+	}
+	_, _, err = s.RevisionAt(base.Add(5 * time.Second))
+	require.ErrorIs(t, err, ErrCompacted)
+	rev, _, err = s.RevisionAt(base.Add(10 * time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), rev)
+
+	require.NoError(t, s.Close())
+
+	// samples are persisted with the revisions they were applied at
+	s = NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	rev, _, err = s.RevisionAt(base.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), rev)
+	_, _, err = s.RevisionAt(base.Add(5 * time.Second))
+	require.ErrorIs(t, err, ErrCompacted)
+	require.NoError(t, s.Close())
+}
+
+func TestRevisionAtNotSampled(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	s := NewStore(zaptest.NewLogger(t), b, &lease.FakeLessor{}, StoreConfig{})
+	defer cleanup(s, b)
+
+	s.Put([]byte("foo"), []byte("bar"), lease.NoLease)
+	_, _, err := s.RevisionAt(time.Now())
+	require.ErrorIs(t, err, ErrCompacted)
+}
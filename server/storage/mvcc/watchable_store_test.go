@@ -967,3 +967,54 @@ func TestStressWatchCancelClose(t *testing.T) {
 
 	wg.Wait()
 }
+
+// TestWatchLeaseRevokeReason ensures that delete events of keys attached to a
+// lease carry the lease ID and tell why the key was deleted.
+func TestWatchLeaseRevokeReason(t *testing.T) {
+	b, _ := betesting.NewDefaultTmpBackend(t)
+	le := lease.NewLessor(zaptest.NewLogger(t), b, nil, lease.LessorConfig{MinLeaseTTL: 1})
+	s := New(zaptest.NewLogger(t), b, le, StoreConfig{})
+	defer cleanup(s, b)
+	defer le.Stop()
+	le.SetRangeDeleter(func() lease.TxnDelete { return s.Write(traceutil.TODO()) })
+
+	w := s.NewWatchStream()
+	defer w.Close()
+	_, err := w.Watch(0, []byte("foo"), []byte("fop"), 0)
+	require.NoError(t, err)
+
+	tests := []struct {
+		key    string
+		lease  lease.LeaseID
+		delete func(id lease.LeaseID)
+		reason mvccpb.Event_RevokeReason
+	}{
+		{"foo1", 1, func(id lease.LeaseID) { require.NoError(t, le.Expire(id)) }, mvccpb.EXPIRED},
+		{"foo2", 2, func(id lease.LeaseID) { require.NoError(t, le.Revoke(id)) }, mvccpb.REVOKED},
+		{"foo3", 3, func(id lease.LeaseID) { s.DeleteRange([]byte("foo3"), nil) }, mvccpb.DELETED},
+		{"foo4", lease.NoLease, func(id lease.LeaseID) { s.DeleteRange([]byte("foo4"), nil) }, mvccpb.NOT_REVOKED},
+	}
+	for _, tt := range tests {
+		if tt.lease != lease.NoLease {
+			_, err = le.Grant(tt.lease, 10)
+			require.NoError(t, err)
+		}
+		s.Put([]byte(tt.key), []byte("bar"), tt.lease)
+		tt.delete(tt.lease)
+
+		var evs []mvccpb.Event
+		for len(evs) < 2 {
+			select {
+			case resp := <-w.Chan():
+				evs = append(evs, resp.Events...)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("%s: timed out waiting for events", tt.key)
+			}
+		}
+		ev := evs[1]
+		assert.Equal(t, mvccpb.DELETE, ev.Type, tt.key)
+		assert.Equal(t, int64(tt.lease), ev.Lease, tt.key)
+		assert.Equal(t, tt.reason, ev.RevokeReason, tt.key)
+		assert.Equal(t, int64(0), ev.Kv.Lease, tt.key)
+	}
+}
@@ -33,6 +33,12 @@ func (tw *watchableStoreTxnWrite) End() {
 		if change.CreateRevision == 0 {
 			evs[i].Type = mvccpb.DELETE
 			evs[i].Kv.ModRevision = rev
+			if change.Lease != 0 {
+				evs[i].Lease = change.Lease
+				evs[i].RevokeReason = tw.revokeReason
+				// tombstones are stored without a lease
+				evs[i].Kv.Lease = 0
+			}
 		} else {
 			evs[i].Type = mvccpb.PUT
 		}
@@ -49,8 +55,16 @@ func (tw *watchableStoreTxnWrite) End() {
 type watchableStoreTxnWrite struct {
 	TxnWrite
 	s *watchableStore
+	// revokeReason is reported by the delete events of keys attached to a lease.
+	revokeReason mvccpb.Event_RevokeReason
 }
 
 func (s *watchableStore) Write(trace *traceutil.Trace) TxnWrite {
-	return &watchableStoreTxnWrite{s.store.Write(trace), s}
+	return &watchableStoreTxnWrite{TxnWrite: s.store.Write(trace), s: s, revokeReason: mvccpb.DELETED}
+}
+
+// SetRevokeReason sets the reason reported by the delete events of keys
+// attached to a lease, when the txn deletes them to revoke the lease.
+func (tw *watchableStoreTxnWrite) SetRevokeReason(reason mvccpb.Event_RevokeReason) {
+	tw.revokeReason = reason
 }
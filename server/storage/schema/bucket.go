@@ -27,6 +27,10 @@ var (
 	leaseBucketName = []byte("lease")
 	alarmBucketName = []byte("alarm")
 
+	compactionPolicyBucketName = []byte("compactionPolicy")
+	revisionTimeBucketName     = []byte("revisionTime")
+	leaseGroupBucketName       = []byte("leaseGroup")
+
 	clusterBucketName = []byte("cluster")
 
 	membersBucketName        = []byte("members")
@@ -46,6 +50,10 @@ var (
 	Alarm   = backend.Bucket(bucket{id: 4, name: alarmBucketName, safeRangeBucket: false})
 	Cluster = backend.Bucket(bucket{id: 5, name: clusterBucketName, safeRangeBucket: false})
 
+	CompactionPolicy = backend.Bucket(bucket{id: 6, name: compactionPolicyBucketName, safeRangeBucket: false})
+	RevisionTime     = backend.Bucket(bucket{id: 7, name: revisionTimeBucketName, safeRangeBucket: false})
+	LeaseGroup       = backend.Bucket(bucket{id: 8, name: leaseGroupBucketName, safeRangeBucket: false})
+
 	Members        = backend.Bucket(bucket{id: 10, name: membersBucketName, safeRangeBucket: false})
 	MembersRemoved = backend.Bucket(bucket{id: 11, name: membersRemovedBucketName, safeRangeBucket: false})
 
@@ -55,7 +63,7 @@ var (
 
 	Test = backend.Bucket(bucket{id: 100, name: testBucketName, safeRangeBucket: false})
 
-	AllBuckets = []backend.Bucket{Key, Meta, Lease, Alarm, Cluster, Members, MembersRemoved, Auth, AuthUsers, AuthRoles}
+	AllBuckets = []backend.Bucket{Key, Meta, Lease, Alarm, Cluster, CompactionPolicy, RevisionTime, LeaseGroup, Members, MembersRemoved, Auth, AuthUsers, AuthRoles}
 )
 
 type bucket struct {
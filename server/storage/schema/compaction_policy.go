@@ -0,0 +1,52 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"fmt"
+
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+func UnsafeCreateCompactionPolicyBucket(tx backend.UnsafeWriter) {
+	tx.UnsafeCreateBucket(CompactionPolicy)
+}
+
+// UnsafeGetAllCompactionPolicies returns the compaction policies sorted by prefix.
+func UnsafeGetAllCompactionPolicies(tx backend.UnsafeReader) ([]*etcdserverpb.CompactionPolicy, error) {
+	var ps []*etcdserverpb.CompactionPolicy
+	err := tx.UnsafeForEach(CompactionPolicy, func(k, v []byte) error {
+		var p etcdserverpb.CompactionPolicy
+		if err := p.Unmarshal(v); err != nil {
+			return fmt.Errorf("failed to unmarshal compaction policy for prefix %q: %w", k, err)
+		}
+		ps = append(ps, &p)
+		return nil
+	})
+	return ps, err
+}
+
+func MustUnsafePutCompactionPolicy(tx backend.UnsafeWriter, p *etcdserverpb.CompactionPolicy) {
+	v, err := p.Marshal()
+	if err != nil {
+		panic(fmt.Errorf("failed to marshal compaction policy: %w", err))
+	}
+	tx.UnsafePut(CompactionPolicy, p.Prefix, v)
+}
+
+func UnsafeDeleteCompactionPolicy(tx backend.UnsafeWriter, prefix []byte) {
+	tx.UnsafeDelete(CompactionPolicy, prefix)
+}
@@ -70,6 +70,39 @@ func MustUnsafeGetLease(tx backend.UnsafeReader, leaseID int64) *leasepb.Lease {
 	return &lpb
 }
 
+func UnsafeCreateLeaseGroupBucket(tx backend.UnsafeWriter) {
+	tx.UnsafeCreateBucket(LeaseGroup)
+}
+
+func MustUnsafeGetAllLeaseGroups(tx backend.UnsafeReader) []*leasepb.LeaseGroup {
+	gs := make([]*leasepb.LeaseGroup, 0)
+	err := tx.UnsafeForEach(LeaseGroup, func(k, v []byte) error {
+		var gpb leasepb.LeaseGroup
+		err := gpb.Unmarshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to Unmarshal lease group proto item; lease group ID=%016x", bytesToLeaseID(k))
+		}
+		gs = append(gs, &gpb)
+		return nil
+	})
+	if err != nil {
+		panic(err)
+	}
+	return gs
+}
+
+func MustUnsafePutLeaseGroup(tx backend.UnsafeWriter, gpb *leasepb.LeaseGroup) {
+	val, err := gpb.Marshal()
+	if err != nil {
+		panic("failed to marshal lease group proto item")
+	}
+	tx.UnsafePut(LeaseGroup, leaseIDToBytes(gpb.ID), val)
+}
+
+func UnsafeDeleteLeaseGroup(tx backend.UnsafeWriter, gpb *leasepb.LeaseGroup) {
+	tx.UnsafeDelete(LeaseGroup, leaseIDToBytes(gpb.ID))
+}
+
 func leaseIDToBytes(n int64) []byte {
 	bytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(bytes, uint64(n))
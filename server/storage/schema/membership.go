@@ -80,6 +80,37 @@ func (s *membershipBackend) MustDeleteMemberFromBackend(id types.ID) {
 	tx.UnsafePut(MembersRemoved, mkey, []byte("removed"))
 }
 
+// MustReplaceMembershipInBackend replaces all the members and removed members
+// in the backend and, if not nil, the cluster version, like when the
+// membership is recovered from a v2 snapshot.
+func (s *membershipBackend) MustReplaceMembershipInBackend(members map[types.ID]*membership.Member, removed map[types.ID]bool, ver *semver.Version) {
+	tx := s.be.BatchTx()
+	tx.LockOutsideApply()
+	defer tx.Unlock()
+	for _, bucket := range []backend.Bucket{Members, MembersRemoved} {
+		err := tx.UnsafeForEach(bucket, func(k, v []byte) error {
+			tx.UnsafeDelete(bucket, k)
+			return nil
+		})
+		if err != nil {
+			s.lg.Panic("failed to delete members from backend", zap.Error(err))
+		}
+	}
+	for id, m := range members {
+		mvalue, err := json.Marshal(m)
+		if err != nil {
+			s.lg.Panic("failed to marshal member", zap.Error(err))
+		}
+		tx.UnsafePut(Members, BackendMemberKey(id), mvalue)
+	}
+	for id := range removed {
+		tx.UnsafePut(MembersRemoved, BackendMemberKey(id), []byte("removed"))
+	}
+	if ver != nil {
+		tx.UnsafePut(Cluster, ClusterClusterVersionKeyName, []byte(ver.String()))
+	}
+}
+
 func (s *membershipBackend) MustReadMembersFromBackend() (map[types.ID]*membership.Member, map[types.ID]bool) {
 	members, removed, err := s.readMembersFromBackend()
 	if err != nil {
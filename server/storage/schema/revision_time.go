@@ -0,0 +1,55 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"go.etcd.io/etcd/server/v3/storage/backend"
+)
+
+const revisionTimeBytesLen = 8
+
+func UnsafeCreateRevisionTimeBucket(tx backend.UnsafeWriter) {
+	tx.UnsafeCreateBucket(RevisionTime)
+}
+
+// UnsafePutRevisionTime records that the given main revision was applied at
+// the given time, in nanoseconds since the Unix epoch.
+func UnsafePutRevisionTime(tx backend.UnsafeWriter, rev, unixNano int64) {
+	tx.UnsafePut(RevisionTime, revisionTimeKey(rev), revisionTimeKey(unixNano))
+}
+
+// UnsafeForEachRevisionTime calls visitor with every recorded revision and
+// its time in ascending revision order.
+func UnsafeForEachRevisionTime(tx backend.UnsafeReader, visitor func(rev, unixNano int64) error) error {
+	return tx.UnsafeForEach(RevisionTime, func(k, v []byte) error {
+		if len(k) != revisionTimeBytesLen || len(v) != revisionTimeBytesLen {
+			return fmt.Errorf("malformed revision time entry (key: %x, value: %x)", k, v)
+		}
+		return visitor(int64(binary.BigEndian.Uint64(k)), int64(binary.BigEndian.Uint64(v)))
+	})
+}
+
+func UnsafeDeleteRevisionTime(tx backend.UnsafeWriter, rev int64) {
+	tx.UnsafeDelete(RevisionTime, revisionTimeKey(rev))
+}
+
+func revisionTimeKey(n int64) []byte {
+	b := make([]byte, revisionTimeBytesLen)
+	binary.BigEndian.PutUint64(b, uint64(n))
+	return b
+}
@@ -115,6 +115,7 @@ func CreateConfigChangeEnts(lg *zap.Logger, ids []uint64, self uint64, term, ind
 // - ConfChangeAddNode, in which case the contained ID will Be added into the set.
 // - ConfChangeRemoveNode, in which case the contained ID will Be removed from the set.
 // - ConfChangeAddLearnerNode, in which the contained ID will Be added into the set.
+// The changes of a ConfChangeV2 entry are handled the same way, in order.
 func GetEffectiveNodeIDsFromWALEntries(lg *zap.Logger, snap *raftpb.Snapshot, ents []raftpb.Entry) []uint64 {
 	ids := make(map[uint64]bool)
 	if snap != nil {
@@ -123,22 +124,32 @@ func GetEffectiveNodeIDsFromWALEntries(lg *zap.Logger, snap *raftpb.Snapshot, en
 		}
 	}
 	for _, e := range ents {
-		if e.Type != raftpb.EntryConfChange {
+		var changes []raftpb.ConfChangeSingle
+		switch e.Type {
+		case raftpb.EntryConfChange:
+			var cc raftpb.ConfChange
+			pbutil.MustUnmarshal(&cc, e.Data)
+			changes = []raftpb.ConfChangeSingle{{Type: cc.Type, NodeID: cc.NodeID}}
+		case raftpb.EntryConfChangeV2:
+			var cc raftpb.ConfChangeV2
+			pbutil.MustUnmarshal(&cc, e.Data)
+			changes = cc.Changes
+		default:
 			continue
 		}
-		var cc raftpb.ConfChange
-		pbutil.MustUnmarshal(&cc, e.Data)
-		switch cc.Type {
-		case raftpb.ConfChangeAddLearnerNode:
-			ids[cc.NodeID] = true
-		case raftpb.ConfChangeAddNode:
-			ids[cc.NodeID] = true
-		case raftpb.ConfChangeRemoveNode:
-			delete(ids, cc.NodeID)
-		case raftpb.ConfChangeUpdateNode:
-			// do nothing
-		default:
-			lg.Panic("unknown ConfChange Type", zap.String("type", cc.Type.String()))
+		for _, cc := range changes {
+			switch cc.Type {
+			case raftpb.ConfChangeAddLearnerNode:
+				ids[cc.NodeID] = true
+			case raftpb.ConfChangeAddNode:
+				ids[cc.NodeID] = true
+			case raftpb.ConfChangeRemoveNode:
+				delete(ids, cc.NodeID)
+			case raftpb.ConfChangeUpdateNode:
+				// do nothing
+			default:
+				lg.Panic("unknown ConfChange Type", zap.String("type", cc.Type.String()))
+			}
 		}
 	}
 	sids := make(types.Uint64Slice, 0, len(ids))
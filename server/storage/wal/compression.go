@@ -0,0 +1,111 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the codec used to compress the data of new WAL records.
+type Compression string
+
+const (
+	CompressionNone   Compression = "none"
+	CompressionSnappy Compression = "snappy"
+	CompressionZstd   Compression = "zstd"
+)
+
+// A compressed record stores the codec in the bits of the record type above
+// recordTypeMask, so segments written with and without compression can be
+// mixed and read back by the same decoder. The crc of a record always covers
+// the uncompressed data.
+const (
+	recordTypeMask        int64 = 0xff
+	recordCodecShift            = 8
+	recordCodecSnappy     int64 = 1
+	recordCodecZstd       int64 = 2
+	minCompressRecordSize       = 256
+)
+
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+)
+
+// ParseCompression parses the name of a WAL compression codec. An empty
+// name means no compression.
+func ParseCompression(s string) (Compression, error) {
+	switch c := Compression(s); c {
+	case "", CompressionNone:
+		return CompressionNone, nil
+	case CompressionSnappy, CompressionZstd:
+		return c, nil
+	default:
+		return "", fmt.Errorf("unknown WAL compression %q (supported: %q, %q, %q)", s, CompressionNone, CompressionSnappy, CompressionZstd)
+	}
+}
+
+// compressRecordData compresses data with the given codec, appending to
+// dst. It returns the record type to store and false if the record should
+// be written uncompressed.
+func compressRecordData(c Compression, typ int64, dst, data []byte) (int64, []byte, bool) {
+	if len(data) < minCompressRecordSize {
+		return typ, nil, false
+	}
+	var (
+		codec int64
+		out   []byte
+	)
+	switch c {
+	case CompressionSnappy:
+		codec, out = recordCodecSnappy, snappy.Encode(dst[:cap(dst)], data)
+	case CompressionZstd:
+		codec, out = recordCodecZstd, zstdEncoder.EncodeAll(data, dst[:0])
+	default:
+		return typ, nil, false
+	}
+	if len(out) >= len(data) {
+		return typ, out, false
+	}
+	return typ | codec<<recordCodecShift, out, true
+}
+
+// decompressRecordData returns the base record type and the uncompressed
+// data of a record as stored on disk.
+func decompressRecordData(typ int64, data []byte) (int64, []byte, error) {
+	codec := typ >> recordCodecShift
+	if typ < 0 || codec == 0 {
+		return typ, data, nil
+	}
+	var (
+		out []byte
+		err error
+	)
+	switch codec {
+	case recordCodecSnappy:
+		out, err = snappy.Decode(nil, data)
+	case recordCodecZstd:
+		out, err = zstdDecoder.DecodeAll(data, nil)
+	default:
+		err = fmt.Errorf("unknown codec %d", codec)
+	}
+	if err != nil {
+		return typ, data, fmt.Errorf("wal: failed to decompress record of type %d: %w", typ, err)
+	}
+	return typ & recordTypeMask, out, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package wal
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zaptest"
+
+	"go.etcd.io/etcd/client/pkg/v3/fileutil"
+	"go.etcd.io/etcd/server/v3/storage/wal/walpb"
+	"go.etcd.io/raft/v3/raftpb"
+)
+
+func TestParseCompression(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Compression
+		werr bool
+	}{
+		{"", CompressionNone, false},
+		{"none", CompressionNone, false},
+		{"snappy", CompressionSnappy, false},
+		{"zstd", CompressionZstd, false},
+		{"gzip", "", true},
+	}
+	for _, tt := range tests {
+		c, err := ParseCompression(tt.in)
+		assert.Equal(t, tt.want, c, tt.in)
+		assert.Equal(t, tt.werr, err != nil, tt.in)
+	}
+}
+
+func TestWriteCompressedRecord(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible "), 100)
+	small := []byte("Hello world!")
+	for _, c := range []Compression{CompressionNone, CompressionSnappy, CompressionZstd} {
+		t.Run(string(c), func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			e := newEncoder(buf, 0, 0)
+			e.compression = c
+			require.NoError(t, e.encode(&walpb.Record{Type: EntryType, Data: data}))
+			require.NoError(t, e.encode(&walpb.Record{Type: StateType, Data: small}))
+			require.NoError(t, e.flush())
+			if c == CompressionNone {
+				assert.Greater(t, buf.Len(), len(data))
+			} else {
+				assert.Less(t, buf.Len(), len(data))
+			}
+
+			f, err := createFileWithData(t, buf)
+			require.NoError(t, err)
+			decoder := NewDecoder(fileutil.NewFileReader(f))
+			var rec walpb.Record
+			require.NoError(t, decoder.Decode(&rec))
+			assert.Equal(t, EntryType, rec.Type)
+			assert.Equal(t, data, rec.Data)
+			require.NoError(t, decoder.Decode(&rec))
+			assert.Equal(t, StateType, rec.Type)
+			assert.Equal(t, small, rec.Data)
+		})
+	}
+}
+
+func TestReadCorruptedCompressedRecord(t *testing.T) {
+	data := bytes.Repeat([]byte("compressible "), 100)
+	buf := new(bytes.Buffer)
+	e := newEncoder(buf, 0, 0)
+	e.compression = CompressionSnappy
+	require.NoError(t, e.encode(&walpb.Record{Type: EntryType, Data: data}))
+	require.NoError(t, e.flush())
+	b := buf.Bytes()
+	// corrupt the snappy preamble holding the uncompressed length
+	b[bytes.Index(b, []byte("compressible"))-3] ^= 0xff
+
+	f, err := createFileWithData(t, bytes.NewBuffer(b))
+	require.NoError(t, err)
+	decoder := NewDecoder(fileutil.NewFileReader(f))
+	var rec walpb.Record
+	err = decoder.Decode(&rec)
+	assert.Truef(t, errors.Is(err, walpb.ErrCRCMismatch), "err = %v, want ErrCRCMismatch", err)
+}
+
+// TestMixedCompression ensures that segments written with different
+// compression settings can be read back and verified together.
+func TestMixedCompression(t *testing.T) {
+	p := t.TempDir()
+	lg := zaptest.NewLogger(t)
+	data := bytes.Repeat([]byte("value"), 200)
+
+	w, err := Create(lg, p, []byte("metadata"), WithSegmentSizeBytes(8*1024))
+	require.NoError(t, err)
+	index := uint64(1)
+	save := func(w *WAL, n int) {
+		for i := 0; i < n; i++ {
+			require.NoError(t, w.Save(raftpb.HardState{Term: 1, Commit: index}, []raftpb.Entry{{Index: index, Term: 1, Data: data}}))
+			index++
+		}
+	}
+	save(w, 20)
+	require.NoError(t, w.Close())
+
+	for _, c := range []Compression{CompressionZstd, CompressionSnappy} {
+		w, err = Open(lg, p, walpb.Snapshot{}, WithCompression(c), WithSegmentSizeBytes(8*1024))
+		require.NoError(t, err)
+		_, _, ents, rerr := w.ReadAll()
+		require.NoError(t, rerr)
+		require.Len(t, ents, int(index-1))
+		save(w, 20)
+		require.NoError(t, w.Close())
+	}
+
+	names, err := readWALNames(lg, p)
+	require.NoError(t, err)
+	assert.Greater(t, len(names), 1, "expected the uncompressed records to be cut into several segments")
+
+	state, err := Verify(lg, p, walpb.Snapshot{})
+	require.NoError(t, err)
+	assert.Equal(t, index-1, state.Commit)
+
+	w, err = OpenForRead(lg, p, walpb.Snapshot{})
+	require.NoError(t, err)
+	defer w.Close()
+	metadata, _, ents, err := w.ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("metadata"), metadata)
+	require.Len(t, ents, int(index-1))
+	for i, e := range ents {
+		assert.Equal(t, uint64(i+1), e.Index)
+		assert.Equal(t, data, e.Data)
+	}
+}
+
+func TestSegmentSizeOption(t *testing.T) {
+	p := t.TempDir()
+	w, err := Create(zaptest.NewLogger(t), p, nil, WithSegmentSizeBytes(4*1024))
+	require.NoError(t, err)
+	defer w.Close()
+
+	first := w.tail().Name()
+	data := make([]byte, 1024)
+	for i := uint64(1); i <= 8; i++ {
+		require.NoError(t, w.Save(raftpb.HardState{Term: 1}, []raftpb.Entry{{Index: i, Term: 1, Data: data}}))
+	}
+	assert.NotEqual(t, filepath.Base(first), filepath.Base(w.tail().Name()))
+}
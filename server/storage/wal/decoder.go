@@ -126,6 +126,11 @@ func (d *decoder) decodeRecord(rec *walpb.Record) error {
 		}
 		return err
 	}
+	// a record that fails to decompress keeps its data and is reported
+	// as a crc mismatch below, so it is handled like any other corruption.
+	if typ, data, derr := decompressRecordData(rec.Type, rec.Data); derr == nil {
+		rec.Type, rec.Data = typ, data
+	}
 
 	// skip crc checking if the record type is CrcType
 	if rec.Type != CrcType {
@@ -41,6 +41,11 @@ protobuf. The record protobuf contains a CRC, a type, and a data payload. The le
 record is 8-byte aligned so that the length field is never torn. The CRC contains the CRC32
 value of all record protobufs preceding the current record.
 
+If the WAL is created or opened WithCompression, the data payload of large records is
+compressed and the codec is stored in the bits of the record type above the lowest byte.
+The CRC always covers the uncompressed data, and records written with and without
+compression can be mixed within and across WAL files.
+
 WAL files are placed inside the directory in the following format:
 $seq-$index.wal
 
@@ -48,7 +53,7 @@ The first WAL file to be created will be 0000000000000000-0000000000000000.wal
 indicating an initial sequence of 0 and an initial raft index of 0. The first
 entry written to WAL MUST have raft index 0.
 
-WAL will cut its current tail wal file if its size exceeds 64 MB. This will increment an internal
+WAL will cut its current tail wal file if its size exceeds 64 MB, or the size set WithSegmentSizeBytes. This will increment an internal
 sequence number and cause a new file to be created. If the last raft index saved
 was 0x20 and this is the first time cut has been called on this WAL then the sequence will
 increment from 0x0 to 0x1. The new file will be: 0000000000000001-0000000000000021.wal.
@@ -39,6 +39,10 @@ type encoder struct {
 	crc       hash.Hash32
 	buf       []byte
 	uint64buf []byte
+
+	compression Compression
+	// zbuf is reused to hold compressed record data
+	zbuf []byte
 }
 
 func newEncoder(w io.Writer, prevCrc uint32, pageOffset int) *encoder {
@@ -46,18 +50,21 @@ func newEncoder(w io.Writer, prevCrc uint32, pageOffset int) *encoder {
 		bw:  ioutil.NewPageWriter(w, walPageBytes, pageOffset),
 		crc: crc.New(prevCrc, crcTable),
 		// 1MB buffer
-		buf:       make([]byte, 1024*1024),
-		uint64buf: make([]byte, 8),
+		buf:         make([]byte, 1024*1024),
+		uint64buf:   make([]byte, 8),
+		compression: CompressionNone,
 	}
 }
 
 // newFileEncoder creates a new encoder with current file offset for the page writer.
-func newFileEncoder(f *os.File, prevCrc uint32) (*encoder, error) {
+func newFileEncoder(f *os.File, prevCrc uint32, compression Compression) (*encoder, error) {
 	offset, err := f.Seek(0, io.SeekCurrent)
 	if err != nil {
 		return nil, err
 	}
-	return newEncoder(f, prevCrc, int(offset)), nil
+	e := newEncoder(f, prevCrc, int(offset))
+	e.compression = compression
+	return e, nil
 }
 
 func (e *encoder) encode(rec *walpb.Record) error {
@@ -66,6 +73,11 @@ func (e *encoder) encode(rec *walpb.Record) error {
 
 	e.crc.Write(rec.Data)
 	rec.Crc = e.crc.Sum32()
+	if typ, zdata, ok := compressRecordData(e.compression, rec.Type, e.zbuf, rec.Data); ok {
+		// the caller's record keeps its uncompressed data
+		rec = &walpb.Record{Type: typ, Crc: rec.Crc, Data: zdata}
+		e.zbuf = zdata[:0]
+	}
 	var (
 		data []byte
 		err  error
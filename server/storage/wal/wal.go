@@ -48,10 +48,10 @@ const (
 )
 
 var (
-	// SegmentSizeBytes is the preallocated size of each wal segment file.
-	// The actual size might be larger than this. In general, the default
-	// value should be used, but this is defined as an exported variable
-	// so that tests can set a different segment size.
+	// SegmentSizeBytes is the default preallocated size of each wal segment
+	// file. The actual size might be larger than this. It can be overridden
+	// per WAL with WithSegmentSizeBytes; it is defined as an exported
+	// variable so that tests can set a different default segment size.
 	SegmentSizeBytes int64 = 64 * 1000 * 1000 // 64MB
 
 	ErrMetadataConflict = errors.New("wal: conflicting metadata found")
@@ -85,6 +85,7 @@ type WAL struct {
 	readClose func() error   // closer for Decode reader
 
 	unsafeNoSync bool // if set, do not fsync
+	opts         options
 
 	mu      sync.Mutex
 	enti    uint64   // index of the last entry saved to the wal
@@ -94,13 +95,54 @@ type WAL struct {
 	fp    *filePipeline
 }
 
+type options struct {
+	segmentSizeBytes int64
+	compression      Compression
+}
+
+func (o options) segmentSize() int64 {
+	if o.segmentSizeBytes > 0 {
+		return o.segmentSizeBytes
+	}
+	return SegmentSizeBytes
+}
+
+// Option configures how a WAL writes new segments and records. Options
+// only affect writing; records are always read back regardless of the
+// options they were written with.
+type Option func(*options)
+
+// WithSegmentSizeBytes sets the preallocated size of new WAL segment files.
+// A non-positive size selects SegmentSizeBytes.
+func WithSegmentSizeBytes(size int64) Option {
+	return func(o *options) { o.segmentSizeBytes = size }
+}
+
+// WithCompression sets the codec used to compress the data of new records.
+func WithCompression(c Compression) Option {
+	return func(o *options) { o.compression = c }
+}
+
+func withOptions(opts options) Option {
+	return func(o *options) { *o = opts }
+}
+
+func newOptions(opts []Option) options {
+	o := options{compression: CompressionNone}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
 // Create creates a WAL ready for appending records. The given metadata is
 // recorded at the head of each WAL file, and can be retrieved with ReadAll
 // after the file is Open.
-func Create(lg *zap.Logger, dirpath string, metadata []byte) (*WAL, error) {
+func Create(lg *zap.Logger, dirpath string, metadata []byte, opts ...Option) (*WAL, error) {
 	if Exist(dirpath) {
 		return nil, os.ErrExist
 	}
+	o := newOptions(opts)
 
 	if lg == nil {
 		lg = zap.NewNop()
@@ -143,11 +185,11 @@ func Create(lg *zap.Logger, dirpath string, metadata []byte) (*WAL, error) {
 		)
 		return nil, err
 	}
-	if err = fileutil.Preallocate(f.File, SegmentSizeBytes, true); err != nil {
+	if err = fileutil.Preallocate(f.File, o.segmentSize(), true); err != nil {
 		lg.Warn(
 			"failed to preallocate an initial WAL file",
 			zap.String("path", p),
-			zap.Int64("segment-bytes", SegmentSizeBytes),
+			zap.Int64("segment-bytes", o.segmentSize()),
 			zap.Error(err),
 		)
 		return nil, err
@@ -157,8 +199,9 @@ func Create(lg *zap.Logger, dirpath string, metadata []byte) (*WAL, error) {
 		lg:       lg,
 		dir:      dirpath,
 		metadata: metadata,
+		opts:     o,
 	}
-	w.encoder, err = newFileEncoder(f.File, 0)
+	w.encoder, err = newFileEncoder(f.File, 0, o.compression)
 	if err != nil {
 		return nil, err
 	}
@@ -263,7 +306,7 @@ func (w *WAL) Reopen(lg *zap.Logger, snap walpb.Snapshot) (*WAL, error) {
 	if err != nil {
 		lg.Panic("failed to close WAL during reopen", zap.Error(err))
 	}
-	return Open(lg, w.dir, snap)
+	return Open(lg, w.dir, snap, withOptions(w.opts))
 }
 
 func (w *WAL) SetUnsafeNoFsync() {
@@ -303,7 +346,7 @@ func (w *WAL) renameWAL(tmpdirpath string) (*WAL, error) {
 		}
 		return nil, err
 	}
-	w.fp = newFilePipeline(w.lg, w.dir, SegmentSizeBytes)
+	w.fp = newFilePipeline(w.lg, w.dir, w.opts.segmentSize())
 	df, err := fileutil.OpenDir(w.dir)
 	w.dirFile = df
 	return w, err
@@ -324,7 +367,7 @@ func (w *WAL) renameWALUnlock(tmpdirpath string) (*WAL, error) {
 	}
 
 	// reopen and relock
-	newWAL, oerr := Open(w.lg, w.dir, walpb.Snapshot{})
+	newWAL, oerr := Open(w.lg, w.dir, walpb.Snapshot{}, withOptions(w.opts))
 	if oerr != nil {
 		return nil, oerr
 	}
@@ -341,8 +384,8 @@ func (w *WAL) renameWALUnlock(tmpdirpath string) (*WAL, error) {
 // The returned WAL is ready to read and the first record will be the one after
 // the given snap. The WAL cannot be appended to before reading out all of its
 // previous records.
-func Open(lg *zap.Logger, dirpath string, snap walpb.Snapshot) (*WAL, error) {
-	w, err := openAtIndex(lg, dirpath, snap, true)
+func Open(lg *zap.Logger, dirpath string, snap walpb.Snapshot, opts ...Option) (*WAL, error) {
+	w, err := openAtIndex(lg, dirpath, snap, true, newOptions(opts))
 	if err != nil {
 		return nil, fmt.Errorf("openAtIndex failed: %w", err)
 	}
@@ -355,10 +398,10 @@ func Open(lg *zap.Logger, dirpath string, snap walpb.Snapshot) (*WAL, error) {
 // OpenForRead only opens the wal files for read.
 // Write on a read only wal panics.
 func OpenForRead(lg *zap.Logger, dirpath string, snap walpb.Snapshot) (*WAL, error) {
-	return openAtIndex(lg, dirpath, snap, false)
+	return openAtIndex(lg, dirpath, snap, false, newOptions(nil))
 }
 
-func openAtIndex(lg *zap.Logger, dirpath string, snap walpb.Snapshot, write bool) (*WAL, error) {
+func openAtIndex(lg *zap.Logger, dirpath string, snap walpb.Snapshot, write bool, opts options) (*WAL, error) {
 	if lg == nil {
 		lg = zap.NewNop()
 	}
@@ -380,6 +423,7 @@ func openAtIndex(lg *zap.Logger, dirpath string, snap walpb.Snapshot, write bool
 		decoder:   NewDecoder(rs...),
 		readClose: closer,
 		locks:     ls,
+		opts:      opts,
 	}
 
 	if write {
@@ -390,7 +434,7 @@ func openAtIndex(lg *zap.Logger, dirpath string, snap walpb.Snapshot, write bool
 			closer()
 			return nil, fmt.Errorf("[openAtIndex] parseWALName failed: %w", err)
 		}
-		w.fp = newFilePipeline(lg, w.dir, SegmentSizeBytes)
+		w.fp = newFilePipeline(lg, w.dir, w.opts.segmentSize())
 	}
 
 	return w, nil
@@ -580,7 +624,7 @@ func (w *WAL) ReadAll() (metadata []byte, state raftpb.HardState, ents []raftpb.
 
 	if w.tail() != nil {
 		// create encoder (chain crc with the decoder), enable appending
-		w.encoder, err = newFileEncoder(w.tail().File, w.decoder.LastCRC())
+		w.encoder, err = newFileEncoder(w.tail().File, w.decoder.LastCRC(), w.opts.compression)
 		if err != nil {
 			return nil, state, nil, err
 		}
@@ -768,7 +812,7 @@ func (w *WAL) cut() error {
 	// update writer and save the previous crc
 	w.locks = append(w.locks, newTail)
 	prevCrc := w.encoder.crc.Sum32()
-	w.encoder, err = newFileEncoder(w.tail().File, prevCrc)
+	w.encoder, err = newFileEncoder(w.tail().File, prevCrc, w.opts.compression)
 	if err != nil {
 		return err
 	}
@@ -817,7 +861,7 @@ func (w *WAL) cut() error {
 	w.locks[len(w.locks)-1] = newTail
 
 	prevCrc = w.encoder.crc.Sum32()
-	w.encoder, err = newFileEncoder(w.tail().File, prevCrc)
+	w.encoder, err = newFileEncoder(w.tail().File, prevCrc, w.opts.compression)
 	if err != nil {
 		return err
 	}
@@ -977,7 +1021,7 @@ func (w *WAL) Save(st raftpb.HardState, ents []raftpb.Entry) error {
 	if err != nil {
 		return err
 	}
-	if curOff < SegmentSizeBytes {
+	if curOff < w.opts.segmentSize() {
 		if mustSync {
 			// gofail: var walBeforeSync struct{}
 			err = w.sync()
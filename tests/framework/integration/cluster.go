@@ -172,9 +172,16 @@ type ClusterConfig struct {
 
 	WatchProgressNotifyInterval time.Duration
 	MaxLearners                 int
+	LearnerAutoPromoteWait      time.Duration
+	MemberLabels                map[string]string
+	PreferredLeaderLabels       map[string]string
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+	PeerTransport               string
+	PeerCompression             string
+	LeaseRead                   bool
+	RevisionTimeSampleInterval  time.Duration
 }
 
 type Cluster struct {
@@ -285,9 +292,16 @@ func (c *Cluster) MustNewMember(t testutil.TB) *Member {
 			LeaseCheckpointPersist:      c.Cfg.LeaseCheckpointPersist,
 			WatchProgressNotifyInterval: c.Cfg.WatchProgressNotifyInterval,
 			MaxLearners:                 c.Cfg.MaxLearners,
+			LearnerAutoPromoteWait:      c.Cfg.LearnerAutoPromoteWait,
+			MemberLabels:                c.Cfg.MemberLabels,
+			PreferredLeaderLabels:       c.Cfg.PreferredLeaderLabels,
 			DisableStrictReconfigCheck:  c.Cfg.DisableStrictReconfigCheck,
 			CorruptCheckTime:            c.Cfg.CorruptCheckTime,
 			Metrics:                     c.Cfg.Metrics,
+			PeerTransport:               c.Cfg.PeerTransport,
+			PeerCompression:             c.Cfg.PeerCompression,
+			LeaseRead:                   c.Cfg.LeaseRead,
+			RevisionTimeSampleInterval:  c.Cfg.RevisionTimeSampleInterval,
 		})
 	return m
 }
@@ -610,9 +624,16 @@ type MemberConfig struct {
 	LeaseCheckpointPersist      bool
 	WatchProgressNotifyInterval time.Duration
 	MaxLearners                 int
+	LearnerAutoPromoteWait      time.Duration
+	MemberLabels                map[string]string
+	PreferredLeaderLabels       map[string]string
 	DisableStrictReconfigCheck  bool
 	CorruptCheckTime            time.Duration
 	Metrics                     string
+	PeerTransport               string
+	PeerCompression             string
+	LeaseRead                   bool
+	RevisionTimeSampleInterval  time.Duration
 }
 
 // MustNewMember return an inited member with the given name. If peerTLS is
@@ -725,7 +746,16 @@ func MustNewMember(t testutil.TB, mcfg MemberConfig) *Member {
 	if mcfg.MaxLearners != 0 {
 		m.MaxLearners = mcfg.MaxLearners
 	}
+	m.LearnerAutoPromoteWait = mcfg.LearnerAutoPromoteWait
+	m.MemberLabels = mcfg.MemberLabels
+	m.PreferredLeaderLabels = mcfg.PreferredLeaderLabels
 	m.Metrics = mcfg.Metrics
+	m.PeerTransport = mcfg.PeerTransport
+	m.PeerCompression = mcfg.PeerCompression
+	m.LeaseRead = mcfg.LeaseRead
+	m.RevisionTimeSampleInterval = mcfg.RevisionTimeSampleInterval
+	// the default drift is as long as the election timeout of test members.
+	m.LeaseReadMaxClockDrift = time.Duration(ElectionTicks) * framecfg.TickDuration / 10
 	m.V2Deprecation = config.V2_DEPR_DEFAULT
 	m.GRPCServerRecorder = &grpctesting.GRPCRecorder{}
 
@@ -985,6 +1015,16 @@ func (m *Member) Launch() error {
 		lockpb.RegisterLockServer(m.GRPCServer, v3lock.NewLockServer(m.ServerClient))
 		epb.RegisterElectionServer(m.GRPCServer, v3election.NewElectionServer(m.ServerClient))
 		go m.GRPCServer.Serve(m.GRPCListener)
+
+		// like embed, stop serving clients once the member starts draining
+		srv, gs := m.Server, m.GRPCServer
+		srv.GoAttach(func() {
+			select {
+			case <-srv.StoppingNotify():
+			case <-srv.DrainNotify():
+				stopGRPCServer(gs)
+			}
+		})
 	}
 
 	m.RaftHandler = &testutil.PauseableHandler{Next: etcdhttp.NewPeerHandler(m.Logger, m.Server)}
@@ -1001,6 +1041,14 @@ func (m *Member) Launch() error {
 		// don't hang on matcher after closing listener
 		cm.SetReadTimeout(time.Second)
 
+		// serve raft traffic of the gRPC peer transport, which is matched
+		// before the TLS handshake, so only without peer TLS.
+		if m.PeerTransport == rafthttp.PeerTransportGRPC && peerTLScfg == nil {
+			gs := rafthttp.NewGRPCServer(m.Logger, m.RaftHandler)
+			go gs.Serve(cm.Match(cmux.HTTP2()))
+			m.ServerClosers = append(m.ServerClosers, gs.Stop)
+		}
+
 		// serve http1/http2 rafthttp/grpc
 		ll := cm.Match(cmux.Any())
 		if peerTLScfg != nil {
@@ -1170,6 +1218,25 @@ func (m *Member) Resume() {
 	m.Server.ResumeSending()
 }
 
+func stopGRPCServer(gs *grpc.Server) {
+	ch := make(chan struct{})
+	go func() {
+		defer close(ch)
+		// close listeners to stop accepting new connections,
+		// will block on any existing transports
+		gs.GracefulStop()
+	}()
+	// wait until all pending RPCs are finished
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		// took too long, manually close open transports
+		// e.g. watch streams
+		gs.Stop()
+		<-ch
+	}
+}
+
 // Close stops the member'Server etcdserver and closes its connections
 func (m *Member) Close() {
 	if m.GRPCBridge != nil {
@@ -1181,22 +1248,7 @@ func (m *Member) Close() {
 		m.ServerClient = nil
 	}
 	if m.GRPCServer != nil {
-		ch := make(chan struct{})
-		go func() {
-			defer close(ch)
-			// close listeners to stop accepting new connections,
-			// will block on any existing transports
-			m.GRPCServer.GracefulStop()
-		}()
-		// wait until all pending RPCs are finished
-		select {
-		case <-ch:
-		case <-time.After(2 * time.Second):
-			// took too long, manually close open transports
-			// e.g. watch streams
-			m.GRPCServer.Stop()
-			<-ch
-		}
+		stopGRPCServer(m.GRPCServer)
 		m.GRPCServer = nil
 	}
 	if m.Server != nil {
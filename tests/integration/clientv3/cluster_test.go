@@ -25,7 +25,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/client/pkg/v3/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/storage/mvcc"
 	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
 )
 
@@ -378,6 +381,222 @@ func TestMemberPromoteMemberNotExist(t *testing.T) {
 	}
 }
 
+// TestMemberReplace ensures that a voting member is replaced by a learner in a
+// single membership change once the learner has caught up.
+func TestMemberReplace(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	// send the request to a follower to include the server-side forwarding
+	// to the leader, and replace the remaining follower.
+	leaderIdx := clus.WaitLeader(t)
+	capi := clus.Client((leaderIdx + 1) % 3)
+	replaced := clus.Members[(leaderIdx+2)%3]
+	replacedID := uint64(replaced.Server.MemberID())
+
+	learnerMember := clus.MustNewMember(t)
+	urls := learnerMember.PeerURLs.StringSlice()
+	memberAddResp, err := capi.MemberAddAsLearner(t.Context(), urls)
+	require.NoError(t, err)
+	learnerID := memberAddResp.Member.ID
+
+	// replacing a learner is not allowed.
+	_, err = capi.MemberReplace(t.Context(), learnerID, []string{"http://127.0.0.1:1"})
+	require.ErrorContains(t, err, "can only replace a voting member")
+
+	// the learner is not started yet, so it cannot catch up.
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	_, err = capi.MemberReplace(ctx, replacedID, urls)
+	cancel()
+	require.Error(t, err)
+
+	clus.InitializeMemberWithResponse(t, learnerMember, memberAddResp)
+	require.NoError(t, learnerMember.Launch())
+
+	ctx, cancel = context.WithTimeout(t.Context(), 10*time.Second)
+	resp, err := capi.MemberReplace(ctx, replacedID, urls)
+	cancel()
+	require.NoError(t, err)
+	require.Equal(t, learnerID, resp.Member.ID)
+	require.False(t, resp.Member.IsLearner)
+	require.Len(t, resp.Members, 3)
+	for _, m := range resp.Members {
+		require.NotEqual(t, replacedID, m.ID)
+		require.False(t, m.IsLearner)
+	}
+
+	// the replaced member stops itself once it applies its removal.
+	select {
+	case <-replaced.Server.StopNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatalf("replaced member %x did not stop", replacedID)
+	}
+}
+
+func TestMemberAddAutoPromoteLearner(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3, LearnerAutoPromoteWait: time.Second, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	capi := clus.RandClient()
+
+	// auto promote can only be requested for a learner.
+	_, err := pb.NewClusterClient(capi.ActiveConnection()).MemberAdd(t.Context(), &pb.MemberAddRequest{
+		PeerURLs:    []string{"http://127.0.0.1:1"},
+		AutoPromote: true,
+	})
+	require.ErrorContains(t, err, "auto promote can only be set for a learner member")
+
+	learnerMember := clus.MustNewMember(t)
+	memberAddResp, err := capi.MemberAddAsAutoPromoteLearner(t.Context(), learnerMember.PeerURLs.StringSlice())
+	require.NoError(t, err)
+	require.True(t, memberAddResp.Member.IsLearner)
+	require.True(t, memberAddResp.Member.AutoPromote)
+	learnerID := memberAddResp.Member.ID
+
+	// the learner is not started yet, so it cannot catch up and stays a learner.
+	time.Sleep(2 * time.Second)
+	learner, err := findMember(t.Context(), capi, learnerID)
+	require.NoError(t, err)
+	require.True(t, learner.IsLearner)
+	require.True(t, learner.AutoPromote)
+
+	clus.InitializeMemberWithResponse(t, learnerMember, memberAddResp)
+	require.NoError(t, learnerMember.Launch())
+
+	require.Eventually(t, func() bool {
+		learner, err := findMember(t.Context(), capi, learnerID)
+		return err == nil && !learner.IsLearner && !learner.AutoPromote
+	}, 15*time.Second, 200*time.Millisecond)
+}
+
+func TestMemberAddWitness(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 2, SnapshotCount: 10, SnapshotCatchUpEntries: 5, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	capi := clus.Client(0)
+
+	// a witness cannot be a learner.
+	_, err := pb.NewClusterClient(capi.ActiveConnection()).MemberAdd(t.Context(), &pb.MemberAddRequest{
+		PeerURLs:  []string{"http://127.0.0.1:1"},
+		IsLearner: true,
+		IsWitness: true,
+	})
+	require.ErrorContains(t, err, "a witness member cannot be a learner")
+
+	// compact the log, so that the witness catches up from a snapshot.
+	for i := 0; i < 20; i++ {
+		_, err = capi.Put(t.Context(), "foo", "bar")
+		require.NoError(t, err)
+	}
+
+	witnessMember := clus.MustNewMember(t)
+	memberAddResp, err := capi.MemberAddAsWitness(t.Context(), witnessMember.PeerURLs.StringSlice())
+	require.NoError(t, err)
+	require.True(t, memberAddResp.Member.IsWitness)
+	require.False(t, memberAddResp.Member.IsLearner)
+	witnessID := memberAddResp.Member.ID
+
+	clus.InitializeMemberWithResponse(t, witnessMember, memberAddResp)
+	require.NoError(t, witnessMember.Launch())
+	defer witnessMember.Terminate(t)
+
+	witness, err := findMember(t.Context(), capi, witnessID)
+	require.NoError(t, err)
+	require.True(t, witness.IsWitness)
+
+	// the witness restarts from the snapshot without a key-value backend.
+	witnessMember.Stop(t)
+	require.NoError(t, witnessMember.Restart(t))
+
+	// the witness does not serve clients.
+	wcli, err := integration2.NewClientV3(witnessMember)
+	require.NoError(t, err)
+	defer wcli.Close()
+	_, err = wcli.Put(t.Context(), "foo", "baz")
+	require.ErrorContains(t, err, "rpc not supported for witness")
+
+	// the leadership cannot be moved to the witness.
+	leaderIdx := clus.WaitLeader(t)
+	_, err = clus.Client(leaderIdx).MoveLeader(t.Context(), witnessID)
+	require.ErrorContains(t, err, "bad leader transferee")
+
+	// the witness votes, so the cluster keeps its quorum without one of the
+	// full members, while the witness keeps no key-value data.
+	clus.Members[1-leaderIdx].Stop(t)
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	_, err = clus.Client(leaderIdx).Put(ctx, "foo", "qux")
+	cancel()
+	require.NoError(t, err)
+	rr, err := witnessMember.Server.KV().Range(t.Context(), []byte("foo"), nil, mvcc.RangeOptions{})
+	require.NoError(t, err)
+	require.Empty(t, rr.KVs)
+}
+
+// TestWitnessLostMember ensures that the cluster recovers when a member keeping
+// data is lost and only the witness has the latest log: the witness is elected,
+// replicates its log to the other member and hands the leadership over to it.
+func TestWitnessLostMember(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 2, DisableStrictReconfigCheck: true})
+	defer clus.Terminate(t)
+
+	witnessMember := clus.MustNewMember(t)
+	memberAddResp, err := clus.Client(0).MemberAddAsWitness(t.Context(), witnessMember.PeerURLs.StringSlice())
+	require.NoError(t, err)
+	clus.InitializeMemberWithResponse(t, witnessMember, memberAddResp)
+	require.NoError(t, witnessMember.Launch())
+	defer witnessMember.Terminate(t)
+
+	leaderIdx := clus.WaitLeader(t)
+	leader, follower := clus.Members[leaderIdx], clus.Members[1-leaderIdx]
+
+	// the follower misses the writes committed by the leader and the witness.
+	follower.Stop(t)
+	for i := 0; i < 20; i++ {
+		ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+		_, err = clus.Client(leaderIdx).Put(ctx, "foo", fmt.Sprint(i))
+		cancel()
+		require.NoError(t, err)
+	}
+
+	// the leader is lost, so only the witness has the latest log.
+	leader.Stop(t)
+	require.NoError(t, follower.Restart(t))
+	followerID := uint64(follower.Server.MemberID())
+	require.Eventually(t, func() bool {
+		return follower.Server.Lead() == followerID && witnessMember.Server.Lead() == followerID
+	}, 10*time.Second, 100*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Second)
+	defer cancel()
+	resp, err := clus.Client(1-leaderIdx).Get(ctx, "foo")
+	require.NoError(t, err)
+	require.Len(t, resp.Kvs, 1)
+	require.Equal(t, "19", string(resp.Kvs[0].Value))
+	_, err = clus.Client(1-leaderIdx).Put(ctx, "foo", "bar")
+	require.NoError(t, err)
+}
+
+func findMember(ctx context.Context, capi *clientv3.Client, id uint64) (*pb.Member, error) {
+	resp, err := capi.MemberList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range resp.Members {
+		if m.ID == id {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("member %x not found", id)
+}
+
 // TestMaxLearnerInCluster verifies that the maximum number of learners allowed in a cluster
 func TestMaxLearnerInCluster(t *testing.T) {
 	integration2.BeforeTest(t, integration2.WithFailpoint("raftBeforeAdvance", `sleep(100)`))
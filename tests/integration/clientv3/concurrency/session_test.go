@@ -95,3 +95,65 @@ func TestSessionCtx(t *testing.T) {
 	}
 	assert.Equal(t, childCtx.Err(), context.Canceled)
 }
+
+func TestSessionLeaseGroup(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+	group, err := cli.GroupGrant(t.Context())
+	require.NoError(t, err)
+	defer cli.GroupRevoke(context.Background(), group.ID)
+
+	var sessions []*concurrency.Session
+	for i := 0; i < 3; i++ {
+		s, serr := concurrency.NewSession(cli, concurrency.WithTTL(30), concurrency.WithLeaseGroup(group.ID))
+		require.NoError(t, serr)
+		defer s.Close()
+		sessions = append(sessions, s)
+	}
+
+	resp, err := cli.GroupKeepAliveOnce(t.Context(), group.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(sessions)), resp.Renewed)
+
+	sessions[0].Orphan()
+	select {
+	case <-sessions[0].Done():
+	case <-time.After(time.Millisecond * 100):
+		t.Fatal("session did not get orphaned as expected")
+	}
+}
+
+// TestSessionLeaseGroupExpire ensures that a session attached to a lease
+// group is done once the group is no longer kept alive.
+func TestSessionLeaseGroupExpire(t *testing.T) {
+	cli, err := integration2.NewClient(t, clientv3.Config{Endpoints: exampleEndpoints()})
+	require.NoError(t, err)
+	defer cli.Close()
+	group, err := cli.GroupGrant(t.Context())
+	require.NoError(t, err)
+	defer cli.GroupRevoke(context.Background(), group.ID)
+
+	s, err := concurrency.NewSession(cli, concurrency.WithTTL(2), concurrency.WithLeaseGroup(group.ID))
+	require.NoError(t, err)
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(t.Context())
+	_, err = cli.GroupKeepAlive(ctx, group.ID)
+	require.NoError(t, err)
+
+	// the session outlives its TTL while the group is kept alive
+	select {
+	case <-s.Done():
+		t.Fatal("session is done while its lease group is kept alive")
+	case <-time.After(4 * time.Second):
+	}
+
+	cancel()
+	select {
+	case <-s.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("session is not done after its lease group stopped being kept alive")
+	}
+	assert.Equal(t, context.Canceled, s.Ctx().Err())
+}
@@ -0,0 +1,198 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package recipes_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	recipe "go.etcd.io/etcd/client/v3/experimental/recipes"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestWorkQueueAck ensures items are delivered in FIFO order and removed once
+// acknowledged.
+func TestWorkQueueAck(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	q := recipe.NewWorkQueue(clus.RandClient(), "testwq", recipe.WorkQueueConfig{})
+	for i := 0; i < 3; i++ {
+		_, err := q.Enqueue(t.Context(), fmt.Sprint(i))
+		require.NoError(t, err)
+	}
+	st, err := q.Stats(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 3, st.Ready)
+	require.False(t, st.Oldest.IsZero())
+
+	for i := 0; i < 3; i++ {
+		item, err := q.Dequeue(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprint(i), item.Value)
+		require.Equal(t, 1, item.Attempts)
+
+		st, err = q.Stats(t.Context())
+		require.NoError(t, err)
+		require.Equal(t, 1, st.InFlight)
+		require.Equal(t, 2-i, st.Ready)
+		require.NoError(t, item.Ack(t.Context()))
+	}
+
+	st, err = q.Stats(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, recipe.WorkQueueStats{}, *st)
+
+	// an empty queue blocks until an item is enqueued
+	itemc := make(chan *recipe.WorkItem, 1)
+	go func() {
+		item, derr := q.Dequeue(t.Context())
+		if derr != nil {
+			t.Error(derr)
+		}
+		itemc <- item
+	}()
+	select {
+	case <-itemc:
+		t.Fatal("dequeued from an empty queue")
+	case <-time.After(200 * time.Millisecond):
+	}
+	_, err = q.Enqueue(t.Context(), "late")
+	require.NoError(t, err)
+	select {
+	case item := <-itemc:
+		require.Equal(t, "late", item.Value)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out dequeueing")
+	}
+}
+
+// TestWorkQueueDeadLetter ensures nacked items are delivered again until they
+// exceed the maximum attempts, and are then dead-lettered.
+func TestWorkQueueDeadLetter(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	q := recipe.NewWorkQueue(clus.RandClient(), "testwq", recipe.WorkQueueConfig{MaxAttempts: 2})
+	id, err := q.Enqueue(t.Context(), "poison")
+	require.NoError(t, err)
+	_, err = q.Enqueue(t.Context(), "good")
+	require.NoError(t, err)
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		item, derr := q.Dequeue(t.Context())
+		require.NoError(t, derr)
+		require.Equal(t, id, item.ID)
+		require.Equal(t, attempt, item.Attempts)
+		require.NoError(t, item.Nack(t.Context()))
+	}
+
+	item, err := q.Dequeue(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "good", item.Value)
+	require.NoError(t, item.Ack(t.Context()))
+
+	dead, err := q.DeadLetters(t.Context())
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.Equal(t, id, dead[0].ID)
+	require.Equal(t, "poison", dead[0].Value)
+	require.Equal(t, 2, dead[0].Attempts)
+
+	st, err := q.Stats(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, recipe.WorkQueueStats{DeadLettered: 1}, *st)
+}
+
+// TestWorkQueueRedeliver ensures an item is delivered again once the claim of
+// a consumer that did not acknowledge it expires.
+func TestWorkQueueRedeliver(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	q := recipe.NewWorkQueue(clus.RandClient(), "testwq", recipe.WorkQueueConfig{VisibilityTimeout: time.Second})
+	_, err := q.Enqueue(t.Context(), "job")
+	require.NoError(t, err)
+
+	crashed, err := q.Dequeue(t.Context())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 15*time.Second)
+	defer cancel()
+	item, err := q.Dequeue(ctx)
+	require.NoError(t, err)
+	require.Equal(t, crashed.ID, item.ID)
+	require.Equal(t, 2, item.Attempts)
+
+	if err = crashed.Ack(t.Context()); !errors.Is(err, recipe.ErrClaimExpired) {
+		t.Fatalf("err = %v, want %v", err, recipe.ErrClaimExpired)
+	}
+	require.NoError(t, item.Ack(t.Context()))
+}
+
+// TestWorkQueuePages ensures the items enqueued concurrently get distinct IDs,
+// and that items past the first page of claimed ones are delivered.
+func TestWorkQueuePages(t *testing.T) {
+	integration2.BeforeTest(t)
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	q := recipe.NewWorkQueue(clus.RandClient(), "testwq", recipe.WorkQueueConfig{})
+	const producers, items = 4, 25
+	idc := make(chan string, producers*items)
+	errc := make(chan error, producers)
+	for p := 0; p < producers; p++ {
+		go func() {
+			for i := 0; i < items; i++ {
+				id, err := q.Enqueue(t.Context(), "job")
+				if err != nil {
+					errc <- err
+					return
+				}
+				idc <- id
+			}
+			errc <- nil
+		}()
+	}
+	for p := 0; p < producers; p++ {
+		require.NoError(t, <-errc)
+	}
+	close(idc)
+	ids := make(map[string]struct{})
+	for id := range idc {
+		ids[id] = struct{}{}
+	}
+	require.Len(t, ids, producers*items)
+
+	// claim all the items without acknowledging them.
+	for i := 0; i < producers*items; i++ {
+		item, err := q.Dequeue(t.Context())
+		require.NoError(t, err)
+		require.Contains(t, ids, item.ID)
+		delete(ids, item.ID)
+	}
+	require.Empty(t, ids)
+	st, err := q.Stats(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, producers*items, st.InFlight)
+}
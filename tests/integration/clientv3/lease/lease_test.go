@@ -179,6 +179,142 @@ func TestLeaseKeepAlive(t *testing.T) {
 	}
 }
 
+// TestLeaseGrantMaxLifetime ensures a lease with a max lifetime is revoked
+// once it reaches it, even though it is kept alive.
+func TestLeaseGrantMaxLifetime(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	resp, err := cli.Grant(t.Context(), 2, clientv3.WithMaxLifetime(3))
+	require.NoError(t, err)
+	_, err = cli.Put(t.Context(), "foo", "bar", clientv3.WithLease(resp.ID))
+	require.NoError(t, err)
+
+	rc, err := cli.KeepAlive(t.Context(), resp.ID)
+	require.NoError(t, err)
+	timer := time.NewTimer(10 * time.Second)
+	defer timer.Stop()
+	for done := false; !done; {
+		select {
+		case _, ok := <-rc:
+			done = !ok
+		case <-timer.C:
+			t.Fatal("keepalive channel was not closed after the max lifetime")
+		}
+	}
+
+	tresp, err := cli.TimeToLive(t.Context(), resp.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), tresp.TTL)
+	gresp, err := cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Empty(t, gresp.Kvs)
+}
+
+// TestLeaseGrantMaxLifetimeLeaderChange ensures the max lifetime of a lease
+// runs from its grant rather than restarting on leader changes.
+func TestLeaseGrantMaxLifetimeLeaderChange(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	cli := clus.Client((lead + 1) % 3)
+	start := time.Now()
+	resp, err := cli.Grant(t.Context(), 2, clientv3.WithMaxLifetime(4))
+	require.NoError(t, err)
+	_, err = cli.KeepAlive(t.Context(), resp.ID)
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Second)
+	clus.Members[lead].Stop(t)
+	defer clus.Members[lead].Restart(t)
+	clus.WaitLeader(t)
+
+	// a restarted max lifetime would keep the lease alive past 6 seconds
+	time.Sleep(time.Until(start.Add(5500 * time.Millisecond)))
+	tresp, err := cli.TimeToLive(t.Context(), resp.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), tresp.TTL)
+}
+
+// TestLeaseGrantRenewOnWrite ensures puts to a key attached to a lease granted
+// with renew on write keep the lease alive.
+func TestLeaseGrantRenewOnWrite(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	resp, err := cli.Grant(t.Context(), 2, clientv3.WithRenewOnWrite())
+	require.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		_, err = cli.Put(t.Context(), "foo", fmt.Sprint(i), clientv3.WithLease(resp.ID))
+		require.NoError(t, err)
+		time.Sleep(time.Second)
+	}
+	tresp, err := cli.TimeToLive(t.Context(), resp.ID)
+	require.NoError(t, err)
+	require.NotEqualf(t, int64(-1), tresp.TTL, "lease expired although its key was written")
+
+	// without writes the lease expires
+	time.Sleep(3 * time.Second)
+	tresp, err = cli.TimeToLive(t.Context(), resp.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), tresp.TTL)
+}
+
+// TestLeaseGroupKeepAlive ensures the group keepalive renews the leases of
+// the group and stops once the group is revoked.
+func TestLeaseGroupKeepAlive(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	gresp, err := cli.GroupGrant(t.Context())
+	require.NoError(t, err)
+	_, err = cli.GroupKeepAliveOnce(t.Context(), gresp.ID)
+	require.ErrorIs(t, err, rpctypes.ErrLeaseGroupEmpty)
+	var ids []clientv3.LeaseID
+	for i := 0; i < 2; i++ {
+		resp, gerr := cli.Grant(t.Context(), 3)
+		require.NoError(t, gerr)
+		ids = append(ids, resp.ID)
+	}
+	_, err = cli.GroupAttach(t.Context(), gresp.ID, ids...)
+	require.NoError(t, err)
+
+	rc, err := cli.GroupKeepAlive(t.Context(), gresp.ID)
+	require.NoError(t, err)
+	kresp, ok := <-rc
+	require.Truef(t, ok, "chan is closed, want not closed")
+	require.Equal(t, gresp.ID, kresp.ID)
+	require.Equal(t, int64(2), kresp.Renewed)
+	require.Equal(t, int64(3), kresp.TTL)
+
+	// the leases outlive their TTL while the group is kept alive
+	time.Sleep(4 * time.Second)
+	for _, id := range ids {
+		tresp, terr := cli.TimeToLive(t.Context(), id)
+		require.NoError(t, terr)
+		require.Positivef(t, tresp.TTL, "lease %x expired", id)
+	}
+
+	_, err = cli.GroupRevoke(t.Context(), gresp.ID)
+	require.NoError(t, err)
+	for range rc {
+	}
+	_, err = cli.GroupKeepAliveOnce(t.Context(), gresp.ID)
+	require.ErrorIs(t, err, rpctypes.ErrLeaseGroupNotFound)
+}
+
 func TestLeaseKeepAliveSeconds(t *testing.T) {
 	integration2.BeforeTest(t)
 
@@ -601,6 +737,65 @@ func TestLeaseLeases(t *testing.T) {
 	}
 }
 
+// TestLeaseLeasesFilter ensures leases can be listed by label and remaining
+// TTL, one page at a time.
+func TestLeaseLeasesFilter(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+
+	var svcA []clientv3.LeaseID
+	for i := 0; i < 5; i++ {
+		labels := map[string]string{"owner": "svc-a"}
+		ttl := int64(100)
+		if i%2 == 1 {
+			labels["owner"] = "svc-b"
+			ttl = 1000
+		}
+		resp, err := cli.Grant(t.Context(), ttl, clientv3.WithLabels(labels))
+		require.NoError(t, err)
+		if labels["owner"] == "svc-a" {
+			svcA = append(svcA, resp.ID)
+		}
+	}
+
+	resp, err := cli.Leases(t.Context(), clientv3.WithLabels(map[string]string{"owner": "svc-a"}))
+	require.NoError(t, err)
+	require.Len(t, resp.Leases, len(svcA))
+	for i, ls := range resp.Leases {
+		require.Equal(t, svcA[i], ls.ID)
+		require.Equal(t, map[string]string{"owner": "svc-a"}, ls.Labels)
+		require.LessOrEqual(t, ls.TTL, int64(100))
+	}
+	require.False(t, resp.More)
+
+	resp, err = cli.Leases(t.Context(), clientv3.WithRemainingTTL(500, 0))
+	require.NoError(t, err)
+	require.Len(t, resp.Leases, 2)
+	for _, ls := range resp.Leases {
+		require.Equal(t, "svc-b", ls.Labels["owner"])
+	}
+
+	var paged []clientv3.LeaseID
+	after := clientv3.LeaseID(0)
+	for {
+		resp, err = cli.Leases(t.Context(), clientv3.WithLabels(map[string]string{"owner": "svc-a"}), clientv3.WithLeasesLimit(2), clientv3.WithLeasesAfter(after))
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(resp.Leases), 2)
+		for _, ls := range resp.Leases {
+			paged = append(paged, ls.ID)
+		}
+		if !resp.More {
+			break
+		}
+		after = resp.Leases[len(resp.Leases)-1].ID
+	}
+	require.Equal(t, svcA, paged)
+}
+
 // TestLeaseRenewLostQuorum ensures keepalives work after losing quorum
 // for a while.
 func TestLeaseRenewLostQuorum(t *testing.T) {
@@ -34,6 +34,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
 	"go.etcd.io/etcd/api/v3/version"
 	clientv3 "go.etcd.io/etcd/client/v3"
@@ -147,6 +148,73 @@ func TestMaintenanceMoveLeader(t *testing.T) {
 	}
 }
 
+// TestMaintenanceDrain ensures that a drained member gives up its leadership,
+// cancels its watch streams and stops serving clients until it restarts, and
+// that draining is refused when the remaining members would not form a quorum.
+func TestMaintenanceDrain(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	clus := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	leadIdx := clus.WaitLeader(t)
+	lead := clus.Members[leadIdx]
+	cli := clus.Client((leadIdx + 1) % 3)
+
+	// a watch stream opened before the drain is canceled by it
+	oldStream, err := integration2.ToGRPC(clus.Client(leadIdx)).Watch.Watch(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, oldStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")},
+	}}))
+	wresp, err := oldStream.Recv()
+	require.NoError(t, err)
+	require.True(t, wresp.Created)
+
+	_, err = cli.Drain(t.Context(), lead.GRPCURL)
+	require.NoError(t, err)
+	require.True(t, lead.Server.IsDraining())
+	require.NotEqual(t, leadIdx, clus.WaitLeader(t))
+
+	_, err = oldStream.Recv()
+	require.ErrorIs(t, err, rpctypes.ErrGRPCMemberDraining)
+
+	members, err := cli.MemberList(t.Context())
+	require.NoError(t, err)
+	for _, m := range members.Members {
+		require.Equal(t, m.ID == uint64(lead.ID()), m.Draining)
+	}
+
+	// the drained member no longer serves clients
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	_, err = clus.Client(leadIdx).Get(ctx, "foo")
+	cancel()
+	require.Error(t, err)
+
+	other := clus.Members[(leadIdx+2)%3]
+	_, err = cli.Drain(t.Context(), other.GRPCURL)
+	require.ErrorIs(t, err, rpctypes.ErrDrainUnsafe)
+	require.False(t, other.Server.IsDraining())
+
+	// restarting the drained member clears its mark
+	lead.Stop(t)
+	require.NoError(t, lead.Restart(t))
+	clus.WaitLeader(t)
+	require.Eventually(t, func() bool { return !lead.Server.IsDraining() }, 10*time.Second, 100*time.Millisecond)
+
+	leadCli, err := integration2.NewClientV3(lead)
+	require.NoError(t, err)
+	defer leadCli.Close()
+	wStream, err := integration2.ToGRPC(leadCli).Watch.Watch(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, wStream.Send(&pb.WatchRequest{RequestUnion: &pb.WatchRequest_CreateRequest{
+		CreateRequest: &pb.WatchCreateRequest{Key: []byte("foo")},
+	}}))
+	wresp, err = wStream.Recv()
+	require.NoError(t, err)
+	require.True(t, wresp.Created)
+}
+
 // TestMaintenanceSnapshotCancel ensures that context cancel
 // before snapshot reading returns corresponding context errors.
 func TestMaintenanceSnapshotCancel(t *testing.T) {
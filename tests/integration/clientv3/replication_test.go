@@ -0,0 +1,391 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clientv3test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/replication"
+	integration2 "go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// newReplicationClusters returns the source and destination clusters of a
+// replication, listening on TCP so that their members do not share sockets.
+func newReplicationClusters(t *testing.T) (*clientv3.Client, *clientv3.Client) {
+	integration2.BeforeTest(t)
+	src := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, UseTCP: true})
+	t.Cleanup(func() { src.Terminate(t) })
+	dest := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, UseTCP: true})
+	t.Cleanup(func() { dest.Terminate(t) })
+	return src.Client(0), dest.Client(0)
+}
+
+// startReplication runs the replication until the test ends, and returns the
+// error it stopped with.
+func startReplication(t *testing.T, r *replication.Replicator) <-chan error {
+	ctx, cancel := context.WithCancel(t.Context())
+	errc := make(chan error, 1)
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		errc <- r.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-donec
+	})
+	return errc
+}
+
+// waitReplicated waits for the replication to apply the current source
+// revision to the destination.
+func waitReplicated(t *testing.T, src, dest *clientv3.Client, name string) replication.Position {
+	t.Helper()
+	resp, err := src.Get(t.Context(), "foo", clientv3.WithCountOnly())
+	require.NoError(t, err)
+	var pos replication.Position
+	require.Eventuallyf(t, func() bool {
+		pos, err = replication.GetPosition(t.Context(), dest, name)
+		require.NoError(t, err)
+		return pos.SourceRevision >= resp.Header.Revision
+	}, 10*time.Second, 10*time.Millisecond, "replication did not reach source revision %d", resp.Header.Revision)
+	return pos
+}
+
+func mustGetKVs(t *testing.T, c *clientv3.Client, prefix string) map[string]string {
+	t.Helper()
+	resp, err := c.Get(t.Context(), prefix, clientv3.WithPrefix())
+	require.NoError(t, err)
+	kvs := make(map[string]string)
+	for _, kv := range resp.Kvs {
+		kvs[string(kv.Key)] = string(kv.Value)
+	}
+	return kvs
+}
+
+func TestReplication(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+
+	_, err := src.Put(ctx, "foo/a", "1")
+	require.NoError(t, err)
+	_, err = src.Put(ctx, "other", "1")
+	require.NoError(t, err)
+	// deleted from the destination by the initial sync.
+	_, err = dest.Put(ctx, "bar/stale", "1")
+	require.NoError(t, err)
+	_, err = src.RoleAdd(ctx, "reader")
+	require.NoError(t, err)
+	_, err = src.RoleGrantPermission(ctx, "reader", "foo/", clientv3.GetPrefixRangeEnd("foo/"), clientv3.PermissionType(clientv3.PermRead))
+	require.NoError(t, err)
+	_, err = src.UserAdd(ctx, "alice", "secret")
+	require.NoError(t, err)
+	_, err = src.UserGrantRole(ctx, "alice", "reader")
+	require.NoError(t, err)
+
+	r := replication.New(src, dest, replication.Config{
+		Prefix:           "foo/",
+		DestPrefix:       "bar/",
+		Leases:           true,
+		Auth:             true,
+		MaxTxnOps:        4,
+		ProgressInterval: 10 * time.Millisecond,
+		LeaseInterval:    10 * time.Millisecond,
+	})
+	startReplication(t, r)
+	waitReplicated(t, src, dest, replication.DefaultName)
+	assert.Equal(t, map[string]string{"bar/a": "1"}, mustGetKVs(t, dest, "bar/"))
+
+	lresp, err := src.Grant(ctx, 60)
+	require.NoError(t, err)
+	_, err = src.Put(ctx, "foo/leased", "1", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	// a source revision of more operations than a destination transaction.
+	var ops []clientv3.Op
+	for i := 0; i < 5; i++ {
+		ops = append(ops, clientv3.OpPut(fmt.Sprintf("foo/txn%d", i), "1"))
+	}
+	_, err = src.Txn(ctx).Then(ops...).Commit()
+	require.NoError(t, err)
+	_, err = src.Delete(ctx, "foo/a")
+	require.NoError(t, err)
+	_, err = src.Put(ctx, "other", "2")
+	require.NoError(t, err)
+
+	pos := waitReplicated(t, src, dest, replication.DefaultName)
+	assert.Equal(t, map[string]string{
+		"bar/leased": "1",
+		"bar/txn0":   "1", "bar/txn1": "1", "bar/txn2": "1", "bar/txn3": "1", "bar/txn4": "1",
+	}, mustGetKVs(t, dest, "bar/"))
+	assert.Empty(t, mustGetKVs(t, dest, "other"))
+
+	// the history of the position maps the destination revisions to the
+	// source ones.
+	srcRev, err := replication.SourceRevision(ctx, dest, replication.DefaultName, pos.DestRevision)
+	require.NoError(t, err)
+	assert.Equal(t, pos.SourceRevision, srcRev)
+	st := r.Status()
+	assert.Equal(t, pos.SourceRevision, st.AppliedRevision)
+	assert.Equal(t, pos.DestRevision, st.DestRevision)
+
+	// the key is attached to the same lease in the destination.
+	gresp, err := dest.Get(ctx, "bar/leased")
+	require.NoError(t, err)
+	require.Len(t, gresp.Kvs, 1)
+	assert.Equal(t, int64(lresp.ID), gresp.Kvs[0].Lease)
+	_, err = src.Revoke(ctx, lresp.ID)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		ttl, err := dest.TimeToLive(ctx, lresp.ID)
+		require.NoError(t, err)
+		return ttl.TTL == -1
+	}, 10*time.Second, 10*time.Millisecond, "lease was not revoked in the destination")
+	waitReplicated(t, src, dest, replication.DefaultName)
+	assert.NotContains(t, mustGetKVs(t, dest, "bar/"), "bar/leased")
+
+	role, err := dest.RoleGet(ctx, "reader")
+	require.NoError(t, err)
+	require.Len(t, role.Perm, 1)
+	assert.Equal(t, "foo/", string(role.Perm[0].Key))
+	user, err := dest.UserGet(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"reader"}, user.Roles)
+}
+
+func TestReplicationResume(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+	cfg := replication.Config{Name: "dr", Prefix: "foo/", ProgressInterval: 10 * time.Millisecond}
+
+	_, err := src.Put(ctx, "foo/a", "1")
+	require.NoError(t, err)
+	rctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() { errc <- replication.New(src, dest, cfg).Run(rctx) }()
+	pos := waitReplicated(t, src, dest, "dr")
+	cancel()
+	require.ErrorIs(t, <-errc, context.Canceled)
+
+	_, err = src.Put(ctx, "foo/b", "1")
+	require.NoError(t, err)
+	_, err = src.Delete(ctx, "foo/a")
+	require.NoError(t, err)
+	startReplication(t, replication.New(src, dest, cfg))
+	resumed := waitReplicated(t, src, dest, "dr")
+	assert.Greater(t, resumed.SourceRevision, pos.SourceRevision)
+	assert.Equal(t, map[string]string{"foo/b": "1"}, mustGetKVs(t, dest, "foo/"))
+}
+
+// TestReplicationCompacted tests that the destination is synced with the
+// source again once the source compacted the position of the replication.
+func TestReplicationCompacted(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+	cfg := replication.Config{Prefix: "foo/", ProgressInterval: 10 * time.Millisecond}
+
+	_, err := src.Put(ctx, "foo/a", "1")
+	require.NoError(t, err)
+	rctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() { errc <- replication.New(src, dest, cfg).Run(rctx) }()
+	waitReplicated(t, src, dest, replication.DefaultName)
+	cancel()
+	<-errc
+
+	_, err = src.Delete(ctx, "foo/a")
+	require.NoError(t, err)
+	resp, err := src.Put(ctx, "foo/b", "1")
+	require.NoError(t, err)
+	_, err = src.Compact(ctx, resp.Header.Revision)
+	require.NoError(t, err)
+
+	startReplication(t, replication.New(src, dest, cfg))
+	waitReplicated(t, src, dest, replication.DefaultName)
+	assert.Equal(t, map[string]string{"foo/b": "1"}, mustGetKVs(t, dest, "foo/"))
+}
+
+func TestReplicationPromote(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+
+	errc := startReplication(t, replication.New(src, dest, replication.Config{ProgressInterval: 10 * time.Millisecond}))
+	for i := 0; i < 10; i++ {
+		_, err := src.Put(ctx, "foo", fmt.Sprint(i))
+		require.NoError(t, err)
+	}
+
+	// the writes to the source stopped, the cutover applies all of them.
+	pos, err := replication.Promote(ctx, src, dest, "")
+	require.NoError(t, err)
+	assert.True(t, pos.Promoted)
+	resp, err := src.Get(ctx, "foo")
+	require.NoError(t, err)
+	assert.Equal(t, resp.Header.Revision, pos.SourceRevision)
+	assert.Equal(t, map[string]string{"foo": "9"}, mustGetKVs(t, dest, "foo"))
+	select {
+	case err = <-errc:
+		require.ErrorIs(t, err, replication.ErrPromoted)
+	case <-time.After(10 * time.Second):
+		t.Fatal("replication did not stop on promotion")
+	}
+
+	// the promoted destination takes writes, the source ones are not
+	// replicated anymore.
+	_, err = dest.Put(ctx, "foo", "dest")
+	require.NoError(t, err)
+	_, err = src.Put(ctx, "foo", "src")
+	require.NoError(t, err)
+	require.ErrorIs(t, replication.New(src, dest, replication.Config{}).Run(ctx), replication.ErrPromoted)
+	assert.Equal(t, map[string]string{"foo": "dest"}, mustGetKVs(t, dest, "foo"))
+	_, err = replication.Promote(ctx, src, dest, "")
+	require.ErrorIs(t, err, replication.ErrPromoted)
+}
+
+// TestReplicationForcePromote tests the promotion of a destination when its
+// source is lost.
+func TestReplicationForcePromote(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+
+	errc := startReplication(t, replication.New(src, dest, replication.Config{ProgressInterval: 10 * time.Millisecond}))
+	_, err := src.Put(ctx, "foo", "1")
+	require.NoError(t, err)
+	want := waitReplicated(t, src, dest, replication.DefaultName)
+
+	pos, err := replication.Promote(ctx, nil, dest, "")
+	require.NoError(t, err)
+	assert.Equal(t, want.SourceRevision, pos.SourceRevision)
+	require.ErrorIs(t, <-errc, replication.ErrPromoted)
+}
+
+// TestReplicationPromoteSyncing tests that a destination is not promoted
+// while it is being synced with the source, and that an interrupted sync is
+// done again when the replication resumes.
+func TestReplicationPromoteSyncing(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+	cfg := replication.Config{Prefix: "foo/", ProgressInterval: 10 * time.Millisecond}
+
+	rctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() { errc <- replication.New(src, dest, cfg).Run(rctx) }()
+	waitReplicated(t, src, dest, replication.DefaultName)
+	cancel()
+	<-errc
+
+	// a sync interrupted after writing some of the source keys.
+	_, err := src.Put(ctx, "foo/a", "1")
+	require.NoError(t, err)
+	_, err = dest.Put(ctx, replication.MetaPrefix+replication.DefaultName+"/syncing", "0")
+	require.NoError(t, err)
+	pos, err := replication.GetPosition(ctx, dest, "")
+	require.NoError(t, err)
+	assert.True(t, pos.Syncing)
+	_, err = replication.Promote(ctx, nil, dest, "")
+	require.ErrorIs(t, err, replication.ErrSyncInProgress)
+
+	startReplication(t, replication.New(src, dest, cfg))
+	require.Eventually(t, func() bool {
+		pos, err = replication.GetPosition(ctx, dest, "")
+		require.NoError(t, err)
+		return !pos.Syncing
+	}, 10*time.Second, 10*time.Millisecond, "sync was not completed")
+	assert.Equal(t, map[string]string{"foo/a": "1"}, mustGetKVs(t, dest, "foo/"))
+	pos, err = replication.Promote(ctx, nil, dest, "")
+	require.NoError(t, err)
+	assert.True(t, pos.Promoted)
+}
+
+func TestReplicationSourceMismatch(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+
+	rctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() { errc <- replication.New(src, dest, replication.Config{}).Run(rctx) }()
+	waitReplicated(t, src, dest, replication.DefaultName)
+	cancel()
+	<-errc
+
+	other := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1, UseTCP: true})
+	defer other.Terminate(t)
+	err := replication.New(other.Client(0), dest, replication.Config{}).Run(ctx)
+	require.ErrorIs(t, err, replication.ErrSourceMismatch)
+}
+
+// TestReplicationLeaseExpired tests that the keys attached to a replicated
+// lease that expired in the destination are replicated again, whether it
+// expired while the replication was stopped or running.
+func TestReplicationLeaseExpired(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+	cfg := replication.Config{Prefix: "foo/", Leases: true, ProgressInterval: 10 * time.Millisecond, LeaseInterval: 10 * time.Millisecond}
+
+	lresp, err := src.Grant(ctx, 60)
+	require.NoError(t, err)
+	_, err = src.Put(ctx, "foo/leased", "1", clientv3.WithLease(lresp.ID))
+	require.NoError(t, err)
+	rctx, cancel := context.WithCancel(ctx)
+	errc := make(chan error, 1)
+	go func() { errc <- replication.New(src, dest, cfg).Run(rctx) }()
+	waitReplicated(t, src, dest, replication.DefaultName)
+	require.Equal(t, map[string]string{"foo/leased": "1"}, mustGetKVs(t, dest, "foo/"))
+	cancel()
+	<-errc
+
+	waitLeasedKey := func() {
+		t.Helper()
+		require.Eventuallyf(t, func() bool {
+			resp, err := dest.Get(ctx, "foo/leased")
+			require.NoError(t, err)
+			return len(resp.Kvs) == 1 && resp.Kvs[0].Lease == int64(lresp.ID)
+		}, 10*time.Second, 10*time.Millisecond, "key of the expired lease was not replicated again")
+	}
+	// revoking the lease deletes its keys as if it expired.
+	_, err = dest.Revoke(ctx, lresp.ID)
+	require.NoError(t, err)
+	startReplication(t, replication.New(src, dest, cfg))
+	waitLeasedKey()
+
+	_, err = dest.Revoke(ctx, lresp.ID)
+	require.NoError(t, err)
+	waitLeasedKey()
+}
+
+// TestReplicationLeaseConflict tests that the replication fails when the
+// destination has a lease of the ID of a replicated lease that it did not
+// grant.
+func TestReplicationLeaseConflict(t *testing.T) {
+	src, dest := newReplicationClusters(t)
+	ctx := t.Context()
+
+	_, err := dest.Grant(ctx, 60, clientv3.WithLeaseID(0x1234))
+	require.NoError(t, err)
+	_, err = src.Grant(ctx, 60, clientv3.WithLeaseID(0x1234))
+	require.NoError(t, err)
+	_, err = src.Put(ctx, "foo/leased", "1", clientv3.WithLease(0x1234))
+	require.NoError(t, err)
+
+	err = replication.New(src, dest, replication.Config{Prefix: "foo/", Leases: true}).Run(ctx)
+	require.ErrorIs(t, err, replication.ErrLeaseConflict)
+	assert.Empty(t, mustGetKVs(t, dest, "foo/"))
+}
@@ -861,6 +861,90 @@ func TestWatchWithFilter(t *testing.T) {
 	}
 }
 
+// TestWatchLeaseRevokeReason checks that delete events of keys attached to a
+// lease tell an expired lease from a revoked one.
+func TestWatchLeaseRevokeReason(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	cluster := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := t.Context()
+
+	wc := client.Watch(ctx, "a", clientv3.WithPrefix(), clientv3.WithFilterPut())
+
+	revoked, err := client.Grant(ctx, 60)
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "a1", "abc", clientv3.WithLease(revoked.ID))
+	require.NoError(t, err)
+	expired, err := client.Grant(ctx, 1)
+	require.NoError(t, err)
+	_, err = client.Put(ctx, "a2", "abc", clientv3.WithLease(expired.ID))
+	require.NoError(t, err)
+
+	_, err = client.Revoke(ctx, revoked.ID)
+	require.NoError(t, err)
+
+	var evs []*clientv3.Event
+	for len(evs) < 2 {
+		select {
+		case resp := <-wc:
+			require.NoError(t, resp.Err())
+			evs = append(evs, resp.Events...)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for delete events, got %+v", evs)
+		}
+	}
+
+	require.Equal(t, "a1", string(evs[0].Kv.Key))
+	require.Equal(t, int64(revoked.ID), evs[0].Lease)
+	require.True(t, evs[0].IsLeaseRevoked())
+	require.Equal(t, "a2", string(evs[1].Kv.Key))
+	require.Equal(t, int64(expired.ID), evs[1].Lease)
+	require.True(t, evs[1].IsLeaseExpired())
+}
+
+// TestWatchLease checks that watching a lease sends a single event once the
+// lease is revoked and then closes the watch channel.
+func TestWatchLease(t *testing.T) {
+	integration2.BeforeTest(t)
+
+	cluster := integration2.NewCluster(t, &integration2.ClusterConfig{Size: 1})
+	defer cluster.Terminate(t)
+
+	client := cluster.RandClient()
+	ctx := t.Context()
+
+	resp, err := client.Grant(ctx, 1)
+	require.NoError(t, err)
+
+	wc := client.Watch(ctx, "a", clientv3.WithLease(resp.ID))
+
+	select {
+	case wresp := <-wc:
+		require.NoError(t, wresp.Err())
+		require.Lenf(t, wresp.Events, 1, "%+v", wresp)
+		require.Equal(t, clientv3.EventTypeDelete, wresp.Events[0].Type)
+		require.Equal(t, int64(resp.ID), wresp.Events[0].Lease)
+		require.True(t, wresp.Events[0].IsLeaseExpired())
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the lease to expire")
+	}
+
+	select {
+	case wresp, ok := <-wc:
+		require.Falsef(t, ok, "unexpected watch response %+v", wresp)
+	case <-time.After(5 * time.Second):
+		t.Fatal("watch channel is not closed")
+	}
+
+	wc = client.Watch(ctx, "a", clientv3.WithLease(resp.ID))
+	wresp := <-wc
+	require.True(t, wresp.Canceled)
+	require.ErrorContains(t, wresp.Err(), rpctypes.ErrGRPCLeaseNotFound.Error())
+}
+
 // TestWatchWithCreatedNotification checks that WithCreatedNotify returns a
 // Created watch response.
 func TestWatchWithCreatedNotification(t *testing.T) {
@@ -29,6 +29,7 @@ import (
 
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.etcd.io/etcd/server/v3/etcdserver"
+	"go.etcd.io/etcd/server/v3/etcdserver/api/rafthttp"
 	"go.etcd.io/etcd/tests/v3/framework/config"
 	"go.etcd.io/etcd/tests/v3/framework/integration"
 )
@@ -70,6 +71,82 @@ func TestTLSClusterOf3WithSpecificUsage(t *testing.T) {
 	clusterMustProgress(t, c.Members)
 }
 
+func TestGRPCPeerTransportClusterOf3(t *testing.T) {
+	integration.BeforeTest(t)
+	c := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, PeerTransport: rafthttp.PeerTransportGRPC})
+	defer c.Terminate(t)
+	clusterMustProgress(t, c.Members)
+}
+
+// TestGRPCPeerTransportSnapshot tests that a member added to a cluster using
+// the gRPC peer transport catches up from a snapshot.
+func TestGRPCPeerTransportSnapshot(t *testing.T) {
+	integration.BeforeTest(t)
+	c := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:                       1,
+		SnapshotCount:              10,
+		SnapshotCatchUpEntries:     5,
+		PeerTransport:              rafthttp.PeerTransportGRPC,
+		DisableStrictReconfigCheck: true,
+	})
+	defer c.Terminate(t)
+
+	for i := 0; i < 20; i++ {
+		_, err := c.Members[0].Client.Put(t.Context(), "foo", strconv.Itoa(i))
+		require.NoError(t, err)
+	}
+	c.AddMember(t)
+	clusterMustProgress(t, c.Members)
+}
+
+// TestGRPCPeerTransportFallback tests that a cluster using the gRPC peer
+// transport progresses with a member that only serves HTTP.
+func TestGRPCPeerTransportFallback(t *testing.T) {
+	integration.BeforeTest(t)
+	c := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, PeerTransport: rafthttp.PeerTransportGRPC})
+	defer c.Terminate(t)
+
+	m := c.Members[2]
+	m.Stop(t)
+	m.PeerTransport = rafthttp.PeerTransportHTTP
+	require.NoError(t, m.Restart(t))
+	c.WaitMembersForLeader(t, c.Members)
+	clusterMustProgress(t, c.Members)
+}
+
+// TestPeerCompression tests that a cluster compressing its raft traffic
+// progresses, catches up a new member from a snapshot, and keeps working with
+// a member that does not compress its traffic.
+func TestPeerCompression(t *testing.T) {
+	for _, codec := range []string{rafthttp.CompressionSnappy, rafthttp.CompressionZstd} {
+		t.Run(codec, func(t *testing.T) {
+			integration.BeforeTest(t)
+			c := integration.NewCluster(t, &integration.ClusterConfig{
+				Size:                       3,
+				SnapshotCount:              10,
+				SnapshotCatchUpEntries:     5,
+				PeerCompression:            codec,
+				DisableStrictReconfigCheck: true,
+			})
+			defer c.Terminate(t)
+
+			for i := 0; i < 20; i++ {
+				_, err := c.Members[0].Client.Put(t.Context(), "foo", strconv.Itoa(i))
+				require.NoError(t, err)
+			}
+			c.AddMember(t)
+			clusterMustProgress(t, c.Members)
+
+			m := c.Members[2]
+			m.Stop(t)
+			m.PeerCompression = rafthttp.CompressionNone
+			require.NoError(t, m.Restart(t))
+			c.WaitMembersForLeader(t, c.Members)
+			clusterMustProgress(t, c.Members)
+		})
+	}
+}
+
 func TestDoubleClusterSizeOf1(t *testing.T) { testDoubleClusterSize(t, 1) }
 func TestDoubleClusterSizeOf3(t *testing.T) { testDoubleClusterSize(t, 3) }
 
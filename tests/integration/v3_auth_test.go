@@ -47,6 +47,25 @@ func TestV3AuthEmptyUserGet(t *testing.T) {
 	require.Truef(t, eqErrGRPC(err, rpctypes.ErrUserEmpty), "got %v, expected %v", err, rpctypes.ErrUserEmpty)
 }
 
+// TestV3AuthEmptyUserRevisionAt ensures that a revision lookup with an empty
+// user will return an empty user error.
+func TestV3AuthEmptyUserRevisionAt(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	ctx, cancel := context.WithTimeout(t.Context(), 30*time.Second)
+	defer cancel()
+
+	api := integration.ToGRPC(clus.Client(0))
+	authSetupRoot(t, api.Auth)
+
+	_, err := api.KV.RevisionAt(ctx, &pb.RevisionAtRequest{Timestamp: time.Now().UnixNano()})
+	require.Truef(t, eqErrGRPC(err, rpctypes.ErrUserEmpty), "got %v, expected %v", err, rpctypes.ErrUserEmpty)
+	_, err = api.KV.RevisionAt(ctx, &pb.RevisionAtRequest{Timestamp: time.Now().UnixNano(), Serializable: true})
+	require.Truef(t, eqErrGRPC(err, rpctypes.ErrUserEmpty), "got %v, expected %v", err, rpctypes.ErrUserEmpty)
+}
+
 // TestV3AuthEmptyUserPut ensures that a put with an empty user will return an empty user error,
 // and the consistent_index should be moved forward even the apply-->Put fails.
 func TestV3AuthEmptyUserPut(t *testing.T) {
@@ -224,6 +243,48 @@ func TestV3AuthWithLeaseRevoke(t *testing.T) {
 	}
 }
 
+// TestV3AuthLeaseOwner ensures a lease is owned by the user who granted it,
+// whatever the client claims.
+func TestV3AuthLeaseOwner(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	users := []user{
+		{
+			name:     "user1",
+			password: "user1-123",
+			role:     "role1",
+			key:      "k1",
+			end:      "k2",
+		},
+	}
+	authSetupUsers(t, integration.ToGRPC(clus.Client(0)).Auth, users)
+	authSetupRoot(t, integration.ToGRPC(clus.Client(0)).Auth)
+
+	userc, cerr := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "user1", Password: "user1-123"})
+	require.NoError(t, cerr)
+	defer userc.Close()
+
+	_, err := integration.ToGRPC(userc).Lease.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: 90, Owner: "root"})
+	require.NoError(t, err)
+
+	rootc, cerr := integration.NewClient(t, clientv3.Config{Endpoints: clus.Client(0).Endpoints(), Username: "root", Password: "123"})
+	require.NoError(t, cerr)
+	defer rootc.Close()
+	_, err = rootc.Grant(t.Context(), 90)
+	require.NoError(t, err)
+
+	resp, err := rootc.Leases(t.Context(), clientv3.WithOwner("user1"))
+	require.NoError(t, err)
+	require.Len(t, resp.Leases, 1)
+	require.Equal(t, "user1", resp.Leases[0].Owner)
+
+	resp, err = rootc.Leases(t.Context(), clientv3.WithOwner("root"))
+	require.NoError(t, err)
+	require.Len(t, resp.Leases, 1)
+}
+
 func TestV3AuthWithLeaseAttach(t *testing.T) {
 	integration.BeforeTest(t)
 	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
@@ -0,0 +1,271 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/client/v3/concurrency"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestRWMutexFair ensures readers share the lock, and that readers asking for
+// the lock after a waiting writer wait for it.
+func TestRWMutexFair(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	var clients []*clientv3.Client
+	newClient := integration.MakeMultiNodeClients(t, clus, &clients)
+	defer func() {
+		integration.CloseClients(t, clients)
+	}()
+
+	var sessions []*concurrency.Session
+	defer func() {
+		for _, s := range sessions {
+			s.Close()
+		}
+	}()
+	newRWMutex := func() *concurrency.RWMutex {
+		s, err := concurrency.NewSession(newClient())
+		require.NoError(t, err)
+		sessions = append(sessions, s)
+		return concurrency.NewRWMutex(s, "test-rwmutex")
+	}
+
+	r1, r2 := newRWMutex(), newRWMutex()
+	require.NoError(t, r1.RLock(t.Context()))
+	require.NoError(t, r2.RLock(t.Context()))
+
+	w := newRWMutex()
+	wc := make(chan error, 1)
+	go func() { wc <- w.Lock(t.Context()) }()
+	waitPrefixCount(t, clients[0], "test-rwmutex/write/", 1)
+
+	r3 := newRWMutex()
+	r3c := make(chan error, 1)
+	go func() { r3c <- r3.RLock(t.Context()) }()
+	waitPrefixCount(t, clients[0], "test-rwmutex/read/", 3)
+
+	mustBlock(t, wc, "writer acquired the lock held by readers")
+	require.NoError(t, r1.RUnlock(t.Context()))
+	require.NoError(t, r2.RUnlock(t.Context()))
+	mustUnblock(t, wc)
+
+	mustBlock(t, r3c, "reader acquired the lock held by a writer")
+	require.NoError(t, w.Unlock(t.Context()))
+	mustUnblock(t, r3c)
+	require.NoError(t, r3.RUnlock(t.Context()))
+}
+
+// TestSemaphorePermits ensures no more sessions than the number of permits
+// hold the semaphore at the same time.
+func TestSemaphorePermits(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	var clients []*clientv3.Client
+	newClient := integration.MakeMultiNodeClients(t, clus, &clients)
+	defer func() {
+		integration.CloseClients(t, clients)
+	}()
+
+	var sessions []*concurrency.Session
+	defer func() {
+		for _, s := range sessions {
+			s.Close()
+		}
+	}()
+	newSemaphore := func() *concurrency.Semaphore {
+		s, err := concurrency.NewSession(newClient())
+		require.NoError(t, err)
+		sessions = append(sessions, s)
+		sm, err := concurrency.NewSemaphore(s, "test-semaphore", 2)
+		require.NoError(t, err)
+		return sm
+	}
+
+	s1, s2, s3 := newSemaphore(), newSemaphore(), newSemaphore()
+	if _, err := concurrency.NewSemaphore(sessions[0], "test-semaphore", 0); !errors.Is(err, concurrency.ErrInvalidPermits) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrInvalidPermits)
+	}
+	require.NoError(t, s1.Acquire(t.Context()))
+	require.NoError(t, s2.TryAcquire(t.Context()))
+	if err := s3.TryAcquire(t.Context()); !errors.Is(err, concurrency.ErrNoPermit) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrNoPermit)
+	}
+
+	s3c := make(chan error, 1)
+	go func() { s3c <- s3.Acquire(t.Context()) }()
+	mustBlock(t, s3c, "acquired a permit while all permits are held")
+	require.NoError(t, s1.Release(t.Context()))
+	mustUnblock(t, s3c)
+
+	require.NoError(t, s2.Release(t.Context()))
+	require.NoError(t, s3.Release(t.Context()))
+	if err := s3.Release(t.Context()); !errors.Is(err, concurrency.ErrLockReleased) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrLockReleased)
+	}
+}
+
+// TestFencedMutexToken ensures fencing tokens increase with each holder and
+// that guarded txns only apply while the lock is held.
+func TestFencedMutexToken(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	cli := clus.Client(0)
+	s1, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s1.Close()
+	s2, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	defer s2.Close()
+
+	m1 := concurrency.NewFencedMutex(s1, "test-fenced")
+	token1, err := m1.Lock(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, token1, m1.Token())
+
+	resp, err := m1.Txn(t.Context()).
+		If(clientv3.Compare(clientv3.Version("foo"), "=", 0)).
+		Then(clientv3.OpPut("foo", "bar")).
+		Commit()
+	require.NoError(t, err)
+	require.True(t, resp.Succeeded)
+
+	m2 := concurrency.NewFencedMutex(s2, "test-fenced")
+	if _, err = m2.TryLock(t.Context()); !errors.Is(err, concurrency.ErrLocked) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrLocked)
+	}
+	guard := m1.IsOwner()
+	require.NoError(t, m1.Unlock(t.Context()))
+	require.Zero(t, m1.Token())
+
+	token2, err := m2.Lock(t.Context())
+	require.NoError(t, err)
+	require.Greater(t, token2, token1)
+
+	_, err = concurrency.NewGuardedTxn(t.Context(), cli, guard).Then(clientv3.OpPut("foo", "baz")).Commit()
+	if !errors.Is(err, concurrency.ErrNotOwner) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrNotOwner)
+	}
+	gresp, err := cli.Get(t.Context(), "foo")
+	require.NoError(t, err)
+	require.Equal(t, "bar", string(gresp.Kvs[0].Value))
+}
+
+func TestFencedMutexPartition(t *testing.T) {
+	testPrimitivePartition(t, func(ctx context.Context, s *concurrency.Session) (clientv3.Cmp, error) {
+		m := concurrency.NewFencedMutex(s, "test-fenced-partition")
+		_, err := m.Lock(ctx)
+		return m.IsOwner(), err
+	})
+}
+
+func TestRWMutexPartition(t *testing.T) {
+	testPrimitivePartition(t, func(ctx context.Context, s *concurrency.Session) (clientv3.Cmp, error) {
+		m := concurrency.NewRWMutex(s, "test-rwmutex-partition")
+		err := m.Lock(ctx)
+		return m.IsOwner(), err
+	})
+}
+
+func TestSemaphorePartition(t *testing.T) {
+	testPrimitivePartition(t, func(ctx context.Context, s *concurrency.Session) (clientv3.Cmp, error) {
+		sm, err := concurrency.NewSemaphore(s, "test-semaphore-partition", 1)
+		if err != nil {
+			return clientv3.Cmp{}, err
+		}
+		err = sm.Acquire(ctx)
+		return sm.IsOwner(), err
+	})
+}
+
+// testPrimitivePartition ensures that once the holder of a primitive is cut off
+// from the majority of the cluster, its session expires, another session gets
+// hold of the primitive and txns guarded by the former holder fail.
+func testPrimitivePartition(t *testing.T, acquire func(context.Context, *concurrency.Session) (clientv3.Cmp, error)) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+	clus.WaitLeader(t)
+
+	s0, err := concurrency.NewSession(clus.Client(0), concurrency.WithTTL(2))
+	require.NoError(t, err)
+	defer s0.Orphan()
+	guard0, err := acquire(t.Context(), s0)
+	require.NoError(t, err)
+
+	minority, majority := clus.Members[:1], clus.Members[1:]
+	injectPartition(t, minority, majority)
+
+	s1, err := concurrency.NewSession(clus.Client(1), concurrency.WithTTL(2))
+	require.NoError(t, err)
+	defer s1.Close()
+	ctx, cancel := context.WithTimeout(t.Context(), 15*time.Second)
+	defer cancel()
+	guard1, err := acquire(ctx, s1)
+	require.NoError(t, err)
+
+	recoverPartition(t, minority, majority)
+
+	_, err = concurrency.NewGuardedTxn(ctx, clus.Client(1), guard0).Then(clientv3.OpPut("fenced", "0")).Commit()
+	if !errors.Is(err, concurrency.ErrNotOwner) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrNotOwner)
+	}
+	_, err = concurrency.NewGuardedTxn(ctx, clus.Client(1), guard1).Then(clientv3.OpPut("fenced", "1")).Commit()
+	require.NoError(t, err)
+}
+
+// waitPrefixCount waits for the given number of keys to exist under the prefix.
+func waitPrefixCount(t *testing.T, cli *clientv3.Client, pfx string, count int64) {
+	for i := 0; i < 100; i++ {
+		resp, err := cli.Get(t.Context(), pfx, clientv3.WithPrefix(), clientv3.WithCountOnly())
+		require.NoError(t, err)
+		if resp.Count == count {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d keys under %q", count, pfx)
+}
+
+func mustBlock(t *testing.T, errc <-chan error, msg string) {
+	select {
+	case err := <-errc:
+		t.Fatalf("%s (err: %v)", msg, err)
+	case <-time.After(500 * time.Millisecond):
+	}
+}
+
+func mustUnblock(t *testing.T, errc <-chan error) {
+	select {
+	case err := <-errc:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for the lock")
+	}
+}
@@ -16,8 +16,10 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -390,3 +392,174 @@ func TestElectionWithAuthEnabled(t *testing.T) {
 		t.Fatal("Timed out")
 	}
 }
+
+// TestElectionTransfer ensures a leader can hand leadership over to a given
+// campaigner ahead of the others.
+func TestElectionTransfer(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	var sessions []*concurrency.Session
+	defer func() {
+		for _, s := range sessions {
+			s.Close()
+		}
+	}()
+	newElection := func() (*concurrency.Election, string) {
+		s, err := concurrency.NewSession(cli)
+		require.NoError(t, err)
+		sessions = append(sessions, s)
+		return concurrency.NewElection(s, "test-elect"), fmt.Sprintf("test-elect/%x", s.Lease())
+	}
+
+	e1, key1 := newElection()
+	require.NoError(t, e1.Campaign(t.Context(), "1"))
+	e2, _ := newElection()
+	e3, key3 := newElection()
+	e2c, e3c := make(chan error, 1), make(chan error, 1)
+	go func() { e2c <- e2.Campaign(t.Context(), "2") }()
+	waitPrefixCount(t, cli, "test-elect/", 2)
+	go func() { e3c <- e3.Campaign(t.Context(), "3") }()
+	waitPrefixCount(t, cli, "test-elect/", 3)
+
+	if err := e1.Transfer(t.Context(), "test-elect/missing"); !errors.Is(err, concurrency.ErrElectionNoCandidate) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrElectionNoCandidate)
+	}
+	require.NoError(t, e1.Transfer(t.Context(), key3))
+	mustUnblock(t, e3c)
+	mustBlock(t, e2c, "campaigner ahead of the candidate got leadership")
+
+	resp, err := e3.Leader(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "3", string(resp.Kvs[0].Value))
+	if err = e1.Transfer(t.Context(), key1); !errors.Is(err, concurrency.ErrElectionNotLeader) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrElectionNotLeader)
+	}
+
+	require.NoError(t, e3.Resign(t.Context()))
+	mustUnblock(t, e2c)
+}
+
+// TestElectorHealthCheck ensures an unhealthy elector resigns leadership and
+// does not campaign again until it is healthy.
+func TestElectorHealthCheck(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	var healthy atomic.Bool
+	healthy.Store(true)
+	startedc, stoppedc, leaderc := make(chan struct{}, 2), make(chan struct{}, 2), make(chan string, 8)
+	el1, stop1 := runElector(t, cli, concurrency.ElectorConfig{
+		Value: "1",
+		HealthCheck: func(context.Context) error {
+			if !healthy.Load() {
+				return errors.New("unhealthy")
+			}
+			return nil
+		},
+		OnStartedLeading: func(context.Context) { startedc <- struct{}{} },
+		OnStoppedLeading: func() { stoppedc <- struct{}{} },
+		OnNewLeader:      func(_, value string) { leaderc <- value },
+	})
+	defer stop1()
+	waitSignal(t, startedc)
+	require.Equal(t, "1", waitValue(t, leaderc))
+
+	_, stop2 := runElector(t, cli, concurrency.ElectorConfig{Value: "2"})
+	defer stop2()
+	healthy.Store(false)
+	waitSignal(t, stoppedc)
+	require.Equal(t, "2", waitValue(t, leaderc))
+
+	// el1 campaigns again once healthy, behind el2
+	healthy.Store(true)
+	waitPrefixCount(t, cli, "test-elector/", 2)
+	if err := el1.Transfer(t.Context(), "test-elector/missing"); !errors.Is(err, concurrency.ErrElectionNotLeader) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrElectionNotLeader)
+	}
+}
+
+// TestElectorPriority ensures the leader hands leadership over to a campaigner
+// with a higher priority, and to a campaigner it transfers leadership to.
+func TestElectorPriority(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+	cli := clus.RandClient()
+
+	started1, started2, started3 := make(chan struct{}, 2), make(chan struct{}, 2), make(chan struct{}, 2)
+	el1, stop1 := runElector(t, cli, concurrency.ElectorConfig{
+		Value:            "1",
+		Priority:         1,
+		OnStartedLeading: func(context.Context) { started1 <- struct{}{} },
+	})
+	defer stop1()
+	waitSignal(t, started1)
+
+	el2, stop2 := runElector(t, cli, concurrency.ElectorConfig{
+		Value:            "2",
+		Priority:         2,
+		OnStartedLeading: func(context.Context) { started2 <- struct{}{} },
+	})
+	defer stop2()
+	waitSignal(t, started2)
+
+	el3, stop3 := runElector(t, cli, concurrency.ElectorConfig{
+		Value:            "3",
+		Priority:         2,
+		OnStartedLeading: func(context.Context) { started3 <- struct{}{} },
+	})
+	defer stop3()
+	waitPrefixCount(t, cli, "test-elector/", 3)
+	require.NoError(t, el2.Transfer(t.Context(), el3.Key()))
+	waitSignal(t, started3)
+
+	resp, err := cli.Get(t.Context(), "test-elector/", clientv3.WithFirstCreate()...)
+	require.NoError(t, err)
+	require.Equal(t, "3", string(resp.Kvs[0].Value))
+	if err = el1.Transfer(t.Context(), el2.Key()); !errors.Is(err, concurrency.ErrElectionNotLeader) {
+		t.Fatalf("err = %v, want %v", err, concurrency.ErrElectionNotLeader)
+	}
+}
+
+// runElector runs an elector on its own session until the returned function
+// is called.
+func runElector(t *testing.T, cli *clientv3.Client, cfg concurrency.ElectorConfig) (*concurrency.Elector, func()) {
+	s, err := concurrency.NewSession(cli)
+	require.NoError(t, err)
+	cfg.CheckInterval = 100 * time.Millisecond
+	el := concurrency.NewElector(s, "test-elector", cfg)
+	ctx, cancel := context.WithCancel(t.Context())
+	donec := make(chan struct{})
+	go func() {
+		defer close(donec)
+		el.Run(ctx)
+	}()
+	return el, func() {
+		cancel()
+		<-donec
+		s.Close()
+	}
+}
+
+func waitSignal(t *testing.T, c <-chan struct{}) {
+	select {
+	case <-c:
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for leadership change")
+	}
+}
+
+func waitValue(t *testing.T, c <-chan string) string {
+	select {
+	case v := <-c:
+		return v
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a new leader")
+	}
+	return ""
+}
@@ -135,6 +135,62 @@ func TestV3CompactCurrentRev(t *testing.T) {
 	require.NoErrorf(t, err, "couldn't get serialized key after compaction")
 }
 
+// TestV3CompactionPolicyInvalidAction ensures that a compaction policy request
+// with an unknown action is rejected.
+func TestV3CompactionPolicyInvalidAction(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	mvc := integration.ToGRPC(clus.RandClient()).Maintenance
+	_, err := mvc.CompactionPolicy(t.Context(), &pb.CompactionPolicyRequest{Action: 42})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCInvalidCompactionPolicy)
+}
+
+// TestV3CompactionPolicyKeepDuration ensures that every member keeps the
+// revisions a keep duration policy resolves to with the revision time samples
+// of the leader.
+func TestV3CompactionPolicyKeepDuration(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, RevisionTimeSampleInterval: 50 * time.Millisecond})
+	defer clus.Terminate(t)
+
+	cli := clus.RandClient()
+	_, err := cli.CompactionPolicyPut(t.Context(), "/a/", 0, time.Second)
+	require.NoError(t, err)
+
+	put := func(val string) int64 {
+		resp, perr := cli.Put(t.Context(), "/a/x", val)
+		require.NoError(t, perr)
+		return resp.Header.Revision
+	}
+	// "old" is replaced before the duration kept at the compaction, "v0"
+	// is the value at its start.
+	oldRev := put("old")
+	v0Rev := put("v0")
+	time.Sleep(1500 * time.Millisecond)
+	v1Rev := put("v1")
+	time.Sleep(1500 * time.Millisecond)
+	v2Rev := put("v2")
+	time.Sleep(300 * time.Millisecond)
+	_, err = cli.Compact(t.Context(), v2Rev, clientv3.WithCompactPhysical())
+	require.NoError(t, err)
+
+	for i := range clus.Members {
+		c := clus.Client(i)
+		require.Eventuallyf(t, func() bool {
+			resp, gerr := c.Get(t.Context(), "/a/x", clientv3.WithRev(oldRev), clientv3.WithSerializable())
+			return gerr == nil && len(resp.Kvs) == 0
+		}, 10*time.Second, 100*time.Millisecond, "member %d", i)
+		for rev, val := range map[int64]string{v0Rev: "v0", v1Rev: "v1", v2Rev: "v2"} {
+			resp, gerr := c.Get(t.Context(), "/a/x", clientv3.WithRev(rev), clientv3.WithSerializable())
+			require.NoError(t, gerr)
+			require.Lenf(t, resp.Kvs, 1, "member %d, revision %d", i, rev)
+			require.Equal(t, val, string(resp.Kvs[0].Value))
+		}
+	}
+}
+
 // TestV3HashKV ensures that multiple calls of HashKV on same node return same hash and compact rev.
 func TestV3HashKV(t *testing.T) {
 	integration.BeforeTest(t)
@@ -181,6 +181,45 @@ func TestTransferLeadershipWithLearner(t *testing.T) {
 	}
 }
 
+// TestLeaderPlacementPreferredLabels ensures the leader transfers its leadership
+// to a voting member carrying the preferred leader labels, and that member labels
+// are listed in the membership.
+func TestLeaderPlacementPreferredLabels(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{
+		Size:                       3,
+		MemberLabels:               map[string]string{"zone": "a"},
+		PreferredLeaderLabels:      map[string]string{"zone": "b"},
+		DisableStrictReconfigCheck: true,
+	})
+	defer clus.Terminate(t)
+
+	leaderIdx := clus.WaitLeader(t)
+	cli := clus.Client(leaderIdx)
+
+	newMember := clus.MustNewMember(t)
+	newMember.MemberLabels = map[string]string{"zone": "b"}
+	resp, err := cli.MemberAdd(t.Context(), newMember.PeerURLs.StringSlice())
+	require.NoError(t, err)
+	clus.InitializeMemberWithResponse(t, newMember, resp)
+	require.NoError(t, newMember.Launch())
+
+	require.Eventually(t, func() bool {
+		return clus.Members[leaderIdx].Server.Leader() == newMember.Server.MemberID()
+	}, 20*time.Second, 100*time.Millisecond)
+
+	members, err := cli.MemberList(t.Context())
+	require.NoError(t, err)
+	for _, m := range members.Members {
+		zone := "a"
+		if m.ID == resp.Member.ID {
+			zone = "b"
+		}
+		require.Equal(t, map[string]string{"zone": zone}, m.Labels)
+	}
+}
+
 func TestFirstCommitNotification(t *testing.T) {
 	integration.BeforeTest(t)
 	ctx := t.Context()
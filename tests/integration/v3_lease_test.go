@@ -472,6 +472,48 @@ func TestV3LeaseLeases(t *testing.T) {
 	}
 }
 
+// TestV3LeaseGroupKeepAlive ensures a single lease group keepalive, sent to
+// any member, renews all the leases attached to the group.
+func TestV3LeaseGroupKeepAlive(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3})
+	defer clus.Terminate(t)
+
+	ctx := t.Context()
+	gresp, err := integration.ToGRPC(clus.RandClient()).Lease.LeaseGroupGrant(ctx, &pb.LeaseGroupGrantRequest{})
+	require.NoError(t, err)
+	groupID := gresp.ID
+
+	var ids []int64
+	for i := 0; i < 3; i++ {
+		lresp, lerr := integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(ctx, &pb.LeaseGrantRequest{TTL: 2})
+		require.NoError(t, lerr)
+		ids = append(ids, lresp.ID)
+	}
+	_, err = integration.ToGRPC(clus.RandClient()).Lease.LeaseGroupAttach(ctx, &pb.LeaseGroupAttachRequest{ID: groupID, Leases: ids[:2]})
+	require.NoError(t, err)
+
+	// renew through every member, long enough for the leases to expire otherwise
+	for i := 0; i < 6; i++ {
+		lc := integration.ToGRPC(clus.Client(i % 3)).Lease
+		kresp, kerr := lc.LeaseGroupKeepAlive(ctx, &pb.LeaseGroupKeepAliveRequest{ID: groupID})
+		require.NoError(t, kerr)
+		require.Equal(t, groupID, kresp.ID)
+		require.Equal(t, int64(2), kresp.Renewed)
+		time.Sleep(time.Second)
+	}
+
+	for _, id := range ids[:2] {
+		require.Truef(t, leaseExist(t, clus, id), "lease %x of the group expired", id)
+	}
+	require.Falsef(t, leaseExist(t, clus, ids[2]), "lease %x outside the group was renewed", ids[2])
+
+	_, err = integration.ToGRPC(clus.RandClient()).Lease.LeaseGroupRevoke(ctx, &pb.LeaseGroupRevokeRequest{ID: groupID})
+	require.NoError(t, err)
+	_, err = integration.ToGRPC(clus.RandClient()).Lease.LeaseGroupKeepAlive(ctx, &pb.LeaseGroupKeepAliveRequest{ID: groupID})
+	require.ErrorIs(t, err, rpctypes.ErrGRPCLeaseGroupNotFound)
+}
+
 // TestV3LeaseRenewStress keeps creating lease and renewing it immediately to ensure the renewal goes through.
 // it was oberserved that the immediate lease renewal after granting a lease from follower resulted lease not found.
 // related issue https://github.com/etcd-io/etcd/issues/6978
@@ -949,6 +991,126 @@ func TestV3LeaseRecoverKeyWithMutipleLease(t *testing.T) {
 	}
 }
 
+// TestV3LeaseMultipleLeases ensures a key attached to several leases is
+// deleted when any or all of them are revoked, depending on its lease mode.
+func TestV3LeaseMultipleLeases(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.Client(0)).KV
+	lsc := integration.ToGRPC(clus.Client(0)).Lease
+
+	tests := []struct {
+		mode mvccpb.LeaseMode
+		// whether the key is kept after the first lease is revoked
+		kept bool
+	}{
+		{mvccpb.ANY, false},
+		{mvccpb.ALL, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode.String(), func(t *testing.T) {
+			var leaseIDs []int64
+			for i := 0; i < 2; i++ {
+				lresp, err := lsc.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: fiveMinTTL})
+				require.NoError(t, err)
+				leaseIDs = append(leaseIDs, lresp.ID)
+			}
+			key := []byte("foo-" + tt.mode.String())
+			_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: key, Value: []byte("bar"), Lease: leaseIDs[0], ExtraLeases: leaseIDs[1:], LeaseMode: tt.mode})
+			require.NoError(t, err)
+
+			rresp, err := kvc.Range(t.Context(), &pb.RangeRequest{Key: key})
+			require.NoError(t, err)
+			require.Len(t, rresp.Kvs, 1)
+			require.Equal(t, leaseIDs[0], rresp.Kvs[0].Lease)
+			require.Equal(t, leaseIDs[1:], rresp.Kvs[0].ExtraLeases)
+			require.Equal(t, tt.mode, rresp.Kvs[0].LeaseMode)
+
+			_, err = lsc.LeaseRevoke(t.Context(), &pb.LeaseRevokeRequest{ID: leaseIDs[0]})
+			require.NoError(t, err)
+			rresp, err = kvc.Range(t.Context(), &pb.RangeRequest{Key: key})
+			require.NoError(t, err)
+			require.Equal(t, tt.kept, len(rresp.Kvs) == 1)
+
+			_, err = lsc.LeaseRevoke(t.Context(), &pb.LeaseRevokeRequest{ID: leaseIDs[1]})
+			require.NoError(t, err)
+			rresp, err = kvc.Range(t.Context(), &pb.RangeRequest{Key: key})
+			require.NoError(t, err)
+			require.Empty(t, rresp.Kvs)
+		})
+	}
+}
+
+// TestV3LeaseMultipleLeasesInvalid ensures puts with extra leases are rejected
+// when the leases are not given properly.
+func TestV3LeaseMultipleLeasesInvalid(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.Client(0)).KV
+	lsc := integration.ToGRPC(clus.Client(0)).Lease
+
+	lresp, err := lsc.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: fiveMinTTL})
+	require.NoError(t, err)
+
+	_, err = kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), ExtraLeases: []int64{lresp.ID}})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCLeaseNotProvided) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrGRPCLeaseNotProvided)
+	}
+	_, err = kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Lease: lresp.ID, ExtraLeases: []int64{lresp.ID + 1}})
+	if !eqErrGRPC(err, rpctypes.ErrGRPCLeaseNotFound) {
+		t.Errorf("err = %v, want %v", err, rpctypes.ErrGRPCLeaseNotFound)
+	}
+}
+
+// TestV3LeaseRecoverKeyWithSharedLeases ensures a key kept by the remaining
+// leases of an ALL mode key is attached to them after a restart.
+func TestV3LeaseRecoverKeyWithSharedLeases(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1, UseBridge: true})
+	defer clus.Terminate(t)
+
+	kvc := integration.ToGRPC(clus.Client(0)).KV
+	lsc := integration.ToGRPC(clus.Client(0)).Lease
+
+	var leaseIDs []int64
+	for i := 0; i < 2; i++ {
+		lresp, err := lsc.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: fiveMinTTL})
+		require.NoError(t, err)
+		leaseIDs = append(leaseIDs, lresp.ID)
+	}
+	_, err := kvc.Put(t.Context(), &pb.PutRequest{Key: []byte("foo"), Value: []byte("bar"), Lease: leaseIDs[0], ExtraLeases: leaseIDs[1:], LeaseMode: mvccpb.ALL})
+	require.NoError(t, err)
+	_, err = lsc.LeaseRevoke(t.Context(), &pb.LeaseRevokeRequest{ID: leaseIDs[0]})
+	require.NoError(t, err)
+
+	clus.Members[0].Stop(t)
+	clus.Members[0].Restart(t)
+	clus.WaitMembersForLeader(t, clus.Members)
+
+	nc, err := integration.NewClientV3(clus.Members[0])
+	require.NoError(t, err)
+	defer nc.Close()
+	kvc = integration.ToGRPC(nc).KV
+	lsc = integration.ToGRPC(nc).Lease
+
+	rresp, err := kvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo")})
+	require.NoError(t, err)
+	require.Len(t, rresp.Kvs, 1)
+
+	_, err = lsc.LeaseRevoke(t.Context(), &pb.LeaseRevokeRequest{ID: leaseIDs[1]})
+	require.NoError(t, err)
+	rresp, err = kvc.Range(t.Context(), &pb.RangeRequest{Key: []byte("foo")})
+	require.NoError(t, err)
+	require.Empty(t, rresp.Kvs)
+}
+
 func TestV3LeaseTimeToLiveWithLeaderChanged(t *testing.T) {
 	t.Run("normal", func(subT *testing.T) {
 		testV3LeaseTimeToLiveWithLeaderChanged(subT, "beforeLookupWhenLeaseTimeToLive")
@@ -0,0 +1,160 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+	gofail "go.etcd.io/gofail/runtime"
+)
+
+// TestLeaseRead tests that linearizable reads served by the leader within the
+// read lease observe the writes acknowledged before them, and that followers
+// never serve reads within a lease.
+func TestLeaseRead(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, LeaseRead: true})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	var leaseReads int
+	for i := 0; i < 20; i++ {
+		v := strconv.Itoa(i)
+		_, err := clus.Client(lead).Put(t.Context(), "foo", v)
+		require.NoError(t, err)
+		for j := range clus.Members {
+			reads, _ := leaseReadMetrics(t, clus.Members[j])
+			resp, err := clus.Client(j).Get(t.Context(), "foo")
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			assert.Equalf(t, v, string(resp.Kvs[0].Value), "member %d", j)
+
+			wreads, _ := leaseReadMetrics(t, clus.Members[j])
+			if j == lead {
+				leaseReads += wreads - reads
+			} else {
+				assert.Equalf(t, reads, wreads, "follower %d served a read within a read lease", j)
+			}
+		}
+	}
+	assert.Positivef(t, leaseReads, "no linearizable read was served within the read lease")
+}
+
+// TestLeaseReadLeaderChange tests that linearizable reads fall back to
+// ReadIndex once the leader the read lease was confirmed by is gone.
+func TestLeaseReadLeaderChange(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, LeaseRead: true})
+	defer clus.Terminate(t)
+
+	lead := clus.WaitLeader(t)
+	follower := (lead + 1) % 3
+	_, err := clus.Client(follower).Get(t.Context(), "foo")
+	require.NoError(t, err)
+
+	clus.Members[lead].Stop(t)
+	var membs []*integration.Member
+	for i, m := range clus.Members {
+		if i != lead {
+			membs = append(membs, m)
+		}
+	}
+	clus.WaitMembersForLeader(t, membs)
+
+	_, err = clus.Client(follower).Put(t.Context(), "foo", "bar")
+	require.NoError(t, err)
+	for i := range clus.Members {
+		if i == lead {
+			continue
+		}
+		resp, err := clus.Client(i).Get(t.Context(), "foo")
+		require.NoError(t, err)
+		require.Len(t, resp.Kvs, 1)
+		assert.Equal(t, "bar", string(resp.Kvs[0].Value))
+	}
+}
+
+// TestLeaseReadClockSkew tests that a leader whose clock jumps after its read
+// lease was renewed falls back to ReadIndex, as it can no longer tell whether
+// the lease holds.
+func TestLeaseReadClockSkew(t *testing.T) {
+	tests := []struct {
+		name string
+		skew string
+	}{
+		{"clock jumped past lease", "1h"},
+		{"clock jumped backwards", "-1h"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(gofail.List()) == 0 {
+				t.Skip("please run 'make gofail-enable' before running the test")
+			}
+			integration.BeforeTest(t)
+			clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, LeaseRead: true})
+			defer clus.Terminate(t)
+
+			lead := clus.WaitLeader(t)
+			_, err := clus.Client(lead).Put(t.Context(), "foo", "bar")
+			require.NoError(t, err)
+			// renew the read lease of the leader.
+			_, err = clus.Client(lead).Get(t.Context(), "foo")
+			require.NoError(t, err)
+
+			require.NoError(t, gofail.Enable("leaseReadClockSkew", `return("`+tt.skew+`")`))
+			t.Cleanup(func() {
+				terr := gofail.Disable("leaseReadClockSkew")
+				if terr != nil && !errors.Is(terr, gofail.ErrDisabled) {
+					t.Fatalf("failed to disable leaseReadClockSkew: %v", terr)
+				}
+			})
+
+			reads, fallbacks := leaseReadMetrics(t, clus.Members[lead])
+			resp, err := clus.Client(lead).Get(t.Context(), "foo")
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 1)
+			assert.Equal(t, "bar", string(resp.Kvs[0].Value))
+
+			wreads, wfallbacks := leaseReadMetrics(t, clus.Members[lead])
+			assert.Equalf(t, reads, wreads, "linearizable read served within the read lease after the clock jumped")
+			assert.Greaterf(t, wfallbacks, fallbacks, "linearizable read did not fall back to ReadIndex")
+		})
+	}
+}
+
+// leaseReadMetrics returns the number of linearizable reads served within
+// the read lease, and of those falling back to ReadIndex.
+func leaseReadMetrics(t *testing.T, m *integration.Member) (reads int, fallbacks int) {
+	t.Helper()
+	for _, metric := range []struct {
+		name string
+		n    *int
+	}{
+		{"etcd_server_lease_reads_total", &reads},
+		{"etcd_server_lease_read_fallbacks_total", &fallbacks},
+	} {
+		v, err := m.Metric(metric.name)
+		require.NoError(t, err)
+		*metric.n, err = strconv.Atoi(v)
+		require.NoError(t, err)
+	}
+	return reads, fallbacks
+}
@@ -0,0 +1,67 @@
+// Copyright 2026 The etcd Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package integration
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/tests/v3/framework/integration"
+)
+
+// TestRevisionAtMembers ensures that every member resolves a time to the same
+// revision, as the samples are proposed by the leader through raft.
+func TestRevisionAtMembers(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 3, RevisionTimeSampleInterval: 50 * time.Millisecond})
+	defer clus.Terminate(t)
+
+	var times []time.Time
+	for i := 0; i < 5; i++ {
+		_, err := clus.Client(0).Put(t.Context(), "foo", fmt.Sprint(i))
+		require.NoError(t, err)
+		time.Sleep(200 * time.Millisecond)
+		times = append(times, time.Now())
+	}
+
+	for _, at := range times {
+		var want *clientv3.RevisionAtResponse
+		for i := range clus.Members {
+			resp, err := clus.Client(i).RevisionAt(t.Context(), at)
+			require.NoError(t, err)
+			require.False(t, time.Unix(0, resp.Timestamp).After(at))
+			if want == nil {
+				want = resp
+				continue
+			}
+			require.Equalf(t, want.Revision, resp.Revision, "member %d", i)
+			require.Equalf(t, want.Timestamp, resp.Timestamp, "member %d", i)
+		}
+	}
+
+	// the time after each write resolves to the revision of the write.
+	for i, at := range times {
+		resp, err := clus.Client(0).RevisionAt(t.Context(), at)
+		require.NoError(t, err)
+		gresp, err := clus.Client(0).Get(t.Context(), "foo", clientv3.WithRev(resp.Revision))
+		require.NoError(t, err)
+		require.Len(t, gresp.Kvs, 1)
+		require.Equal(t, fmt.Sprint(i), string(gresp.Kvs[0].Value))
+	}
+}
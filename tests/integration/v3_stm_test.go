@@ -20,6 +20,7 @@ import (
 	"math/rand"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -305,3 +306,112 @@ func TestSTMSerializableSnapshotPut(t *testing.T) {
 		t.Fatalf("bad version. got %+v, expected version 2", resp)
 	}
 }
+
+// TestSTMRange ensures range reads see the txn's own writes and that a write
+// to a read range fails the txn, for each isolation level tracking reads.
+func TestSTMRange(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	etcdc := clus.RandClient()
+	isos := []concurrency.Isolation{
+		concurrency.SerializableSnapshot,
+		concurrency.Serializable,
+		concurrency.RepeatableReads,
+	}
+	for _, iso := range isos {
+		t.Run(fmt.Sprint(iso), func(t *testing.T) {
+			pfx := fmt.Sprintf("range-%d/", iso)
+			for _, k := range []string{"a", "b", "c"} {
+				_, err := etcdc.Put(t.Context(), pfx+k, k)
+				require.NoError(t, err)
+			}
+
+			var conflicts [][]string
+			tries := 0
+			applyf := func(stm concurrency.STM) error {
+				tries++
+				stm.Put(pfx+"d", "d")
+				stm.Del(pfx + "a")
+				var keys []string
+				for _, kv := range stm.Range(pfx, v3.GetPrefixRangeEnd(pfx)) {
+					keys = append(keys, string(kv.Key))
+				}
+				if tries == 1 {
+					assert.Equal(t, []string{pfx + "b", pfx + "c", pfx + "d"}, keys)
+					// conflicting write to the range
+					_, err := etcdc.Put(t.Context(), pfx+"c", "cc")
+					require.NoError(t, err)
+				}
+				return nil
+			}
+			onConflict := func(_ int, keys []string) { conflicts = append(conflicts, keys) }
+			_, err := concurrency.NewSTM(etcdc, applyf, concurrency.WithIsolation(iso), concurrency.WithConflictCallback(onConflict))
+			require.NoError(t, err)
+			require.Equal(t, 2, tries)
+			require.Equal(t, [][]string{{pfx + "c"}}, conflicts)
+
+			resp, err := etcdc.Get(t.Context(), pfx, v3.WithPrefix(), v3.WithKeysOnly())
+			require.NoError(t, err)
+			require.Len(t, resp.Kvs, 3)
+		})
+	}
+}
+
+// TestSTMRangeTooLarge ensures a txn reading more keys than its compares may
+// guard aborts instead of failing to commit.
+func TestSTMRangeTooLarge(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	etcdc := clus.RandClient()
+	for _, k := range []string{"a", "b", "c", "d"} {
+		_, err := etcdc.Put(t.Context(), "large/"+k, k)
+		require.NoError(t, err)
+	}
+	applyf := func(stm concurrency.STM) error {
+		stm.Range("large/", v3.GetPrefixRangeEnd("large/"))
+		return nil
+	}
+	_, err := concurrency.NewSTM(etcdc, applyf, concurrency.WithMaxTxnOps(4))
+	require.ErrorIs(t, err, concurrency.ErrRangeTooLarge)
+	_, err = concurrency.NewSTM(etcdc, applyf, concurrency.WithMaxTxnOps(5))
+	require.NoError(t, err)
+	_, err = concurrency.NewSTM(etcdc, applyf, concurrency.WithIsolation(concurrency.ReadCommitted), concurrency.WithMaxTxnOps(1))
+	require.NoError(t, err)
+}
+
+// TestSTMRetryPolicy ensures a txn gives up after the maximum number of
+// attempts with an error naming the conflicting keys.
+func TestSTMRetryPolicy(t *testing.T) {
+	integration.BeforeTest(t)
+
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	etcdc := clus.RandClient()
+	tries := 0
+	applyf := func(stm concurrency.STM) error {
+		tries++
+		stm.Put("foo", stm.Get("foo")+"a")
+		// conflicting write on every attempt
+		_, err := etcdc.Put(t.Context(), "foo", "bar")
+		require.NoError(t, err)
+		return nil
+	}
+	conflicts := 0
+	_, err := concurrency.NewSTM(etcdc, applyf,
+		concurrency.WithRetryPolicy(concurrency.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}),
+		concurrency.WithConflictCallback(func(int, []string) { conflicts++ }),
+	)
+	var cerr *concurrency.ConflictError
+	require.ErrorAs(t, err, &cerr)
+	require.Equal(t, []string{"foo"}, cerr.Keys)
+	require.Equal(t, 3, cerr.Attempts)
+	require.Equal(t, 3, tries)
+	require.Equal(t, 3, conflicts)
+}
@@ -15,6 +15,7 @@
 package integration
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -162,3 +163,98 @@ func TestV3ElectionObserve(t *testing.T) {
 
 	<-leader2c
 }
+
+// TestV3ElectionTransfer checks that Transfer hands leadership over to the
+// given campaigner ahead of the others.
+func TestV3ElectionTransfer(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := integration.ToGRPC(clus.Client(0)).Election
+	campaign := func(val string) (int64, chan *epb.CampaignResponse) {
+		lease, err := integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: 30})
+		require.NoError(t, err)
+		campaignc := make(chan *epb.CampaignResponse, 1)
+		go func() {
+			resp, err := lc.Campaign(t.Context(), &epb.CampaignRequest{Name: []byte("foo"), Lease: lease.ID, Value: []byte(val)})
+			if err != nil {
+				t.Error(err)
+			}
+			campaignc <- resp
+		}()
+		return lease.ID, campaignc
+	}
+
+	_, c1 := campaign("abc")
+	l1 := <-c1
+	_, c2 := campaign("def")
+	time.Sleep(200 * time.Millisecond)
+	lease3, c3 := campaign("ghi")
+	time.Sleep(200 * time.Millisecond)
+
+	_, err := lc.Transfer(t.Context(), &epb.TransferRequest{Leader: l1.Leader, Candidate: []byte(fmt.Sprintf("foo/%x", lease3))})
+	require.NoError(t, err)
+
+	var l3 *epb.CampaignResponse
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("candidate unelected after transfer")
+	case l3 = <-c3:
+	}
+	select {
+	case <-c2:
+		t.Fatalf("campaigner ahead of the candidate got leadership")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	lval, err := lc.Leader(t.Context(), &epb.LeaderRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	if string(lval.Kv.Value) != "ghi" {
+		t.Fatalf("got election value %q, expected %q", string(lval.Kv.Value), "ghi")
+	}
+
+	_, err = lc.Resign(t.Context(), &epb.ResignRequest{Leader: l3.Leader})
+	require.NoError(t, err)
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("campaigner unelected after resign")
+	case <-c2:
+	}
+}
+
+// TestV3ElectionCandidates checks that Candidates lists the campaigners in
+// queue order, starting with the leader.
+func TestV3ElectionCandidates(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := integration.ToGRPC(clus.Client(0)).Election
+	var leases []int64
+	for i := 0; i < 3; i++ {
+		lease, err := integration.ToGRPC(clus.RandClient()).Lease.LeaseGrant(t.Context(), &pb.LeaseGrantRequest{TTL: 30})
+		require.NoError(t, err)
+		leases = append(leases, lease.ID)
+	}
+
+	l1, err := lc.Campaign(t.Context(), &epb.CampaignRequest{Name: []byte("foo"), Lease: leases[0], Value: []byte("0")})
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	for i, lease := range leases[1:] {
+		go lc.Campaign(ctx, &epb.CampaignRequest{Name: []byte("foo"), Lease: lease, Value: []byte(fmt.Sprint(i + 1))})
+		require.Eventually(t, func() bool {
+			resp, cerr := lc.Candidates(t.Context(), &epb.CandidatesRequest{Name: []byte("foo")})
+			return cerr == nil && len(resp.Kvs) == i+2
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	resp, err := lc.Candidates(t.Context(), &epb.CandidatesRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	require.Equal(t, l1.Leader.Key, resp.Kvs[0].Key)
+	for i, kv := range resp.Kvs {
+		require.Equal(t, fmt.Sprint(i), string(kv.Value))
+		require.Equal(t, leases[i], kv.Lease)
+	}
+}
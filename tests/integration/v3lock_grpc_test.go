@@ -19,6 +19,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pb "go.etcd.io/etcd/api/v3/etcdserverpb"
 	lockpb "go.etcd.io/etcd/server/v3/etcdserver/api/v3lock/v3lockpb"
@@ -68,3 +70,96 @@ func TestV3LockLockWaiter(t *testing.T) {
 	case <-lockc:
 	}
 }
+
+// TestV3LockTryLock tests that TryLock fails instead of waiting for a held lock,
+// and that locks on a TTL get a lease of their own.
+func TestV3LockTryLock(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := integration.ToGRPC(clus.Client(0)).Lock
+	l1, err := lc.Lock(t.Context(), &lockpb.LockRequest{Name: []byte("foo"), Ttl: 30})
+	require.NoError(t, err)
+	require.NotZero(t, l1.Lease)
+
+	for _, timeout := range []int64{0, 100} {
+		_, err = lc.TryLock(t.Context(), &lockpb.TryLockRequest{Name: []byte("foo"), Ttl: 30, Timeout: timeout})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("timeout %d: err = %v, want %v", timeout, err, codes.FailedPrecondition)
+		}
+	}
+
+	lockc := make(chan *lockpb.LockResponse, 1)
+	go func() {
+		l2, lerr := lc.TryLock(t.Context(), &lockpb.TryLockRequest{Name: []byte("foo"), Ttl: 30, Timeout: 10000})
+		if lerr != nil {
+			t.Error(lerr)
+		}
+		lockc <- l2
+	}()
+	time.Sleep(200 * time.Millisecond)
+	_, err = lc.Unlock(t.Context(), &lockpb.UnlockRequest{Key: l1.Key})
+	require.NoError(t, err)
+
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("waiter did not lock after unlock")
+	case l2 := <-lockc:
+		require.NotEqual(t, l1.Lease, l2.Lease)
+	}
+}
+
+// TestV3LockInfoForceUnlock tests that LockInfo reports the holder and waiters
+// of a lock in queue order, and that ForceUnlock hands the lock to the next waiter.
+func TestV3LockInfoForceUnlock(t *testing.T) {
+	integration.BeforeTest(t)
+	clus := integration.NewCluster(t, &integration.ClusterConfig{Size: 1})
+	defer clus.Terminate(t)
+
+	lc := integration.ToGRPC(clus.Client(0)).Lock
+	info, err := lc.LockInfo(t.Context(), &lockpb.LockInfoRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	require.Nil(t, info.Holder)
+
+	l1, err := lc.Lock(t.Context(), &lockpb.LockRequest{Name: []byte("foo"), Ttl: 30})
+	require.NoError(t, err)
+	lockc := make(chan *lockpb.LockResponse, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			l, lerr := lc.Lock(t.Context(), &lockpb.LockRequest{Name: []byte("foo"), Ttl: 30})
+			if lerr != nil {
+				t.Error(lerr)
+			}
+			lockc <- l
+		}()
+		// keep the waiters in order
+		require.Eventually(t, func() bool {
+			info, err = lc.LockInfo(t.Context(), &lockpb.LockInfoRequest{Name: []byte("foo")})
+			return err == nil && len(info.Waiters) == i+1
+		}, 5*time.Second, 10*time.Millisecond)
+	}
+
+	require.Equal(t, l1.Key, info.Holder.Key)
+	require.Equal(t, l1.Lease, info.Holder.Lease)
+	require.Positive(t, info.Holder.Ttl)
+	require.Less(t, info.Waiters[0].CreateRevision, info.Waiters[1].CreateRevision)
+
+	fresp, err := lc.ForceUnlock(t.Context(), &lockpb.ForceUnlockRequest{Name: []byte("foo")})
+	require.NoError(t, err)
+	require.Equal(t, l1.Key, fresp.Key)
+
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("waiter did not lock after force unlock")
+	case l2 := <-lockc:
+		require.Equal(t, info.Waiters[0].Key, l2.Key)
+		_, err = lc.Unlock(t.Context(), &lockpb.UnlockRequest{Key: l2.Key})
+		require.NoError(t, err)
+	}
+	select {
+	case <-time.After(5 * time.Second):
+		t.Fatalf("waiter did not lock after unlock")
+	case <-lockc:
+	}
+}
@@ -226,6 +226,13 @@ func (c *RecordingClient) Compact(ctx context.Context, rev int64, _ ...clientv3.
 	return resp, err
 }
 
+func (c *RecordingClient) RevisionAt(ctx context.Context, t time.Time, opts ...clientv3.OpOption) (*clientv3.RevisionAtResponse, error) {
+	c.kvMux.Lock()
+	defer c.kvMux.Unlock()
+	resp, err := c.client.RevisionAt(ctx, t, opts...)
+	return resp, err
+}
+
 func (c *RecordingClient) MemberList(ctx context.Context, opts ...clientv3.OpOption) (*clientv3.MemberListResponse, error) {
 	c.kvMux.Lock()
 	defer c.kvMux.Unlock()
@@ -56,12 +56,13 @@ type decoder func(k, v []byte)
 
 // key is the bucket name, and value is the function to decode K/V in the bucket.
 var decoders = map[string]decoder{
-	"key":       keyDecoder,
-	"lease":     leaseDecoder,
-	"auth":      authDecoder,
-	"authRoles": authRolesDecoder,
-	"authUsers": authUsersDecoder,
-	"meta":      metaDecoder,
+	"key":        keyDecoder,
+	"lease":      leaseDecoder,
+	"leaseGroup": leaseGroupDecoder,
+	"auth":       authDecoder,
+	"authRoles":  authRolesDecoder,
+	"authUsers":  authUsersDecoder,
+	"meta":       metaDecoder,
 }
 
 func defaultDecoder(k, v []byte) {
@@ -90,7 +91,21 @@ func leaseDecoder(k, v []byte) {
 	if err := lpb.Unmarshal(v); err != nil {
 		panic(err)
 	}
-	fmt.Printf("lease ID=%016x, TTL=%ds, remaining TTL=%ds\n", leaseID, lpb.TTL, lpb.RemainingTTL)
+	labels := make([]string, len(lpb.Labels))
+	for i, l := range lpb.Labels {
+		labels[i] = l.Key + "=" + l.Value
+	}
+	fmt.Printf("lease ID=%016x, TTL=%ds, remaining TTL=%ds, group ID=%016x, max lifetime=%ds, remaining lifetime=%ds, renew on write=%v, owner=%q, labels=%v\n",
+		leaseID, lpb.TTL, lpb.RemainingTTL, lpb.GroupID, lpb.MaxLifetime, lpb.RemainingLifetime, lpb.RenewOnWrite, lpb.Owner, labels)
+}
+
+func leaseGroupDecoder(k, v []byte) {
+	groupID := bytesToLeaseID(k)
+	var gpb leasepb.LeaseGroup
+	if err := gpb.Unmarshal(v); err != nil {
+		panic(err)
+	}
+	fmt.Printf("lease group ID=%016x\n", groupID)
 }
 
 func authDecoder(k, v []byte) {
@@ -133,16 +133,19 @@ func readPage(dbPath string, pageSize uint64, pageID uint64) (*page, []byte, err
 
 func exceptionCheck(key []byte) bool {
 	whiteKeyList := map[string]struct{}{
-		"alarm":           {},
-		"auth":            {},
-		"authRoles":       {},
-		"authUsers":       {},
-		"cluster":         {},
-		"key":             {},
-		"lease":           {},
-		"members":         {},
-		"members_removed": {},
-		"meta":            {},
+		"alarm":            {},
+		"auth":             {},
+		"authRoles":        {},
+		"authUsers":        {},
+		"cluster":          {},
+		"compactionPolicy": {},
+		"key":              {},
+		"lease":            {},
+		"leaseGroup":       {},
+		"members":          {},
+		"members_removed":  {},
+		"meta":             {},
+		"revisionTime":     {},
 	}
 
 	_, ok := whiteKeyList[string(key)]
@@ -90,14 +90,14 @@ func TestEtcdDumpLogEntryType(t *testing.T) {
 	}
 }
 
-func mustCreateWALLog(t *testing.T, path string) {
+func mustCreateWALLog(t *testing.T, path string, opts ...wal.Option) {
 	memberdir := filepath.Join(path, "member")
 	err := os.Mkdir(memberdir, 0o744)
 	require.NoError(t, err)
 	waldir := walDir(path)
 	snapdir := snapDir(path)
 
-	w, err := wal.Create(zaptest.NewLogger(t), waldir, nil)
+	w, err := wal.Create(zaptest.NewLogger(t), waldir, nil, opts...)
 	require.NoError(t, err)
 
 	err = os.Mkdir(snapdir, 0o744)
@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"go.etcd.io/etcd/server/v3/storage/wal"
 )
 
 func Test_readRaw(t *testing.T) {
@@ -66,3 +68,17 @@ Entry: Term:27 Index:34 Data:"?"
 EOF: All entries were processed.
 `, out.String())
 }
+
+func Test_readRawCompressed(t *testing.T) {
+	for _, c := range []wal.Compression{wal.CompressionSnappy, wal.CompressionZstd} {
+		t.Run(string(c), func(t *testing.T) {
+			plain, compressed := t.TempDir(), t.TempDir()
+			mustCreateWALLog(t, plain)
+			mustCreateWALLog(t, compressed, wal.WithCompression(c))
+			var want, got bytes.Buffer
+			readRaw(nil, walDir(plain), &want)
+			readRaw(nil, walDir(compressed), &got)
+			assert.Equal(t, want.String(), got.String())
+		})
+	}
+}